@@ -12,6 +12,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -82,6 +83,42 @@ func TestCalcHash(t *testing.T) {
 	})
 }
 
+func TestGenerateWrapper(t *testing.T) {
+	e := newExecutor(t, false)
+
+	manifestPath := "./testdata/verify.manifest.json"
+	h := random.Uint160()
+	cmd := []string{"neo-go", "contract", "generate-wrapper"}
+	t.Run("no hash", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--manifest", manifestPath)...)
+	})
+	t.Run("no manifest file", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--hash", h.StringLE())...)
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--hash", h.StringLE(),
+			"--manifest", "./testdata/verify.manifest.json123")...)
+	})
+	t.Run("invalid package", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--hash", h.StringLE(),
+			"--manifest", manifestPath, "--package", "func")...)
+	})
+	t.Run("valid", func(t *testing.T) {
+		p := path.Join(os.TempDir(), "neogo.generate.verify.go")
+		t.Cleanup(func() {
+			os.Remove(p)
+		})
+		e.Run(t, append(cmd, "--hash", h.StringLE(), "--manifest", manifestPath,
+			"--package", "verify", "--out", p)...)
+		src, err := ioutil.ReadFile(p)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(src), "// Code generated by neo-go contract generate-wrapper."))
+		require.Contains(t, string(src), "package verify\n")
+		require.Contains(t, string(src), "func (c *Contract) VerifyTransaction(acc *wallet.Account) (*transaction.Transaction, error)")
+		require.Contains(t, string(src), "func (c *Contract) OnNEP17Payment(acc *wallet.Account, from []byte, amount *big.Int, data interface{}) (util.Uint256, error)")
+	})
+}
+
 func TestContractInitAndCompile(t *testing.T) {
 	tmpDir := path.Join(os.TempDir(), "neogo.inittest")
 	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
@@ -187,6 +224,16 @@ func TestContractDeployWithData(t *testing.T) {
 		"--config", "testdata/deploy/neo-go.yml",
 		"--out", nefName, "--manifest", manifestName)
 
+	t.Run("dry run", func(t *testing.T) {
+		e.Run(t, "neo-go", "contract", "deploy",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--in", nefName, "--manifest", manifestName, "--dry-run",
+			"[", "key1", "12", "key2", "take_me_to_church", "]")
+		e.checkNextLine(t, `^Deployment fee: 10 GAS \(\d+ bytes, storage price 0.001, minimum 10\)$`)
+		e.checkNextLine(t, `^System fee: 10\.\d+ GAS$`)
+		e.checkEOF(t) // Nothing is sent.
+	})
+
 	e.In.WriteString("one\r")
 	e.Run(t, "neo-go", "contract", "deploy",
 		"--rpc-endpoint", "http://"+e.RPC.Addr,
@@ -543,6 +590,14 @@ func TestContractInspect(t *testing.T) {
 		e.Run(t, append(cmd, "--in", nefName)...)
 		require.True(t, strings.Contains(e.Out.String(), "SYSCALL"))
 	})
+	t.Run("analyze", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", nefName, "--analyze")...)
+		out := e.Out.String()
+		require.True(t, strings.Contains(out, "SYSCALLS:"))
+		require.True(t, strings.Contains(out, interopnames.SystemStoragePut))
+		require.True(t, strings.Contains(out, "JUMP TARGETS:"))
+		require.False(t, strings.Contains(out, "INDEX"))
+	})
 }
 
 func TestCompileExamples(t *testing.T) {
@@ -0,0 +1,228 @@
+// Package contracttest implements 'contract test' CLI command running
+// contract tests in an in-memory chain.
+package contracttest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	nio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+// testContractName is the name of the contract deployed for tests.
+const testContractName = "ContractUnderTest"
+
+// testSender is the sender (and the only signer) of test transactions.
+var testSender = util.Uint160{1, 2, 3}
+
+// NewCommand returns 'test' subcommand of 'contract' command.
+func NewCommand() cli.Command {
+	return cli.Command{
+		Name:      "test",
+		Usage:     "run contract tests",
+		UsageText: "neo-go contract test -i path [--run regexp] [--verbose]",
+		Description: `Compiles contract package from the given directory along with its
+   _test.go files and runs every exported function without parameters whose
+   name starts with 'Test' (like TestTransfer) in an in-memory chain. Every
+   test is executed in a separate invocation deploying the contract first, so
+   each test starts with an empty contract storage. Test fails if it panics
+   (making VM FAULT) or returns false. Test transactions are signed by a
+   single account with Global scope, so runtime.CheckWitness succeeds for it.
+`,
+		Action: contractTest,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "in, i",
+				Usage: "directory with contract package and tests",
+			},
+			cli.StringFlag{
+				Name:  "run",
+				Usage: "run only tests matching the regular expression",
+			},
+			cli.BoolFlag{
+				Name:  "verbose, v",
+				Usage: "print names of passed tests too",
+			},
+		},
+	}
+}
+
+// contractTest compiles contract along with its tests and runs them.
+func contractTest(ctx *cli.Context) error {
+	src := ctx.String("in")
+	if len(src) == 0 {
+		return cli.NewExitError(errors.New("no input directory was found, specify it with the '--in or -i' flag"), 1)
+	}
+	var filter *regexp.Regexp
+	if r := ctx.String("run"); r != "" {
+		var err error
+		filter, err = regexp.Compile(r)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid test filter: %w", err), 1)
+		}
+	}
+	ok, err := runContractTests(ctx.App.Writer, src, filter, ctx.Bool("verbose"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if !ok {
+		fmt.Fprintln(ctx.App.Writer, "FAIL")
+		return cli.NewExitError("", 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, "PASS")
+	return nil
+}
+
+// runContractTests compiles contract package from the src directory with its
+// `_test.go` files and runs every test function (exported function without
+// parameters having `Test` prefix) matching the filter. Every test is executed
+// in a separate transaction deploying the contract first to an in-memory
+// chain. It returns false if some test has failed.
+func runContractTests(out io.Writer, src string, filter *regexp.Regexp, verbose bool) (bool, error) {
+	b, di, err := compiler.CompileWithOptions(src, nil, &compiler.Options{})
+	if err != nil {
+		return false, fmt.Errorf("failed to compile: %w", err)
+	}
+	m, err := compiler.CreateManifest(di, &compiler.Options{
+		Name:            testContractName,
+		NoEventsCheck:   true,
+		NoStandardCheck: true,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to create manifest: %w", err)
+	}
+	f, err := nef.NewFile(b)
+	if err != nil {
+		return false, err
+	}
+	rawNef, err := f.Bytes()
+	if err != nil {
+		return false, err
+	}
+	rawManifest, err := json.Marshal(m)
+	if err != nil {
+		return false, err
+	}
+
+	var tests []string
+	for _, method := range di.Methods {
+		if isTestFunc(method) && (filter == nil || filter.MatchString(method.ID)) {
+			tests = append(tests, method.ID)
+		}
+	}
+	if len(tests) == 0 {
+		return false, errors.New("no tests to run")
+	}
+
+	chain, err := newTestChain()
+	if err != nil {
+		return false, fmt.Errorf("failed to create test chain: %w", err)
+	}
+	defer chain.Close()
+
+	h := state.CreateContractHash(testSender, f.Checksum, m.Name)
+	passed := true
+	for _, name := range tests {
+		start := time.Now()
+		err := runContractTest(chain, rawNef, rawManifest, h, name)
+		dur := time.Since(start).Seconds()
+		if err != nil {
+			passed = false
+			fmt.Fprintf(out, "--- FAIL: %s (%.2fs)\n    %s\n", name, dur, err)
+			continue
+		}
+		if verbose {
+			fmt.Fprintf(out, "--- PASS: %s (%.2fs)\n", name, dur)
+		}
+	}
+	return passed, nil
+}
+
+// isTestFunc checks whether the method is a test function.
+func isTestFunc(m compiler.MethodDebugInfo) bool {
+	if !m.IsExported || !m.IsFunction || len(m.Parameters) != 0 || !strings.HasPrefix(m.ID, "Test") {
+		return false
+	}
+	// Like Go, don't treat `Testing` as a test function.
+	rest := []rune(m.ID[len("Test"):])
+	return len(rest) == 0 || !unicode.IsLower(rest[0])
+}
+
+// newTestChain creates in-memory chain with a single validator.
+func newTestChain() (*core.Blockchain, error) {
+	priv, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	cfg := config.ProtocolConfiguration{
+		Magic:            netmode.UnitTestNet,
+		StandbyCommittee: []string{hex.EncodeToString(priv.PublicKey().Bytes())},
+		ValidatorsCount:  1,
+	}
+	chain, err := core.NewBlockchain(storage.NewMemoryStore(), cfg, zap.NewNop())
+	if err != nil {
+		return nil, err
+	}
+	go chain.Run()
+	return chain, nil
+}
+
+// runContractTest deploys the contract and invokes the test method in the same
+// script, so that every test starts with a clean contract storage. Test fails
+// if the execution faults or if the test method returns false.
+func runContractTest(chain *core.Blockchain, rawNef, rawManifest []byte, h util.Uint160, name string) error {
+	w := nio.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, chain.ManagementContractHash(), "deploy", callflag.All, rawNef, rawManifest)
+	emit.Opcodes(w.BinWriter, opcode.DROP)
+	emit.AppCall(w.BinWriter, h, strings.ToLower(name[:1])+name[1:], callflag.All)
+	if w.Err != nil {
+		return w.Err
+	}
+
+	tx := transaction.New(w.Bytes(), 0)
+	tx.Signers = []transaction.Signer{{Account: testSender, Scopes: transaction.Global}}
+	b := &block.Block{
+		Header: block.Header{
+			Index:     chain.BlockHeight() + 1,
+			Timestamp: uint64(time.Now().UnixNano() / int64(time.Millisecond)),
+		},
+	}
+	v := chain.GetTestVM(trigger.Application, tx, b)
+	v.GasLimit = -1
+	v.LoadScriptWithFlags(tx.Script, callflag.All)
+	if err := v.Run(); err != nil {
+		return err
+	}
+	res := v.Estack().Pop().Item()
+	if res.Type() == stackitem.BooleanT {
+		if ok, _ := res.TryBool(); !ok {
+			return errors.New("test returned false")
+		}
+	}
+	return nil
+}
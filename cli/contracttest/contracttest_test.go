@@ -0,0 +1,44 @@
+package contracttest
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunContractTests(t *testing.T) {
+	const src = "./testdata/counter"
+
+	t.Run("pass", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		ok, err := runContractTests(out, src, regexp.MustCompile("^Test(Increment|Clean)$"), true)
+		require.NoError(t, err)
+		require.True(t, ok, out.String())
+		require.Contains(t, out.String(), "--- PASS: TestIncrement")
+		require.Contains(t, out.String(), "--- PASS: TestClean")
+	})
+	t.Run("fail", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		ok, err := runContractTests(out, src, nil, false)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.NotContains(t, out.String(), "--- PASS")
+		require.Contains(t, out.String(), "--- FAIL: TestFalse")
+		require.Contains(t, out.String(), "--- FAIL: TestPanic")
+		require.NotContains(t, out.String(), "Testing")
+	})
+	t.Run("no tests", func(t *testing.T) {
+		_, err := runContractTests(new(bytes.Buffer), src, regexp.MustCompile("Unknown"), false)
+		require.Error(t, err)
+	})
+	t.Run("tests are not compiled with NoTests", func(t *testing.T) {
+		_, di, err := compiler.CompileWithOptions(src, nil, &compiler.Options{NoTests: true})
+		require.NoError(t, err)
+		for _, m := range di.Methods {
+			require.False(t, isTestFunc(m), m.ID)
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package counter
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+var key = []byte("counter")
+
+// Increment increments counter and returns its new value.
+func Increment() int {
+	ctx := storage.GetContext()
+	var n int
+	if v := storage.Get(ctx, key); v != nil {
+		n = v.(int)
+	}
+	n++
+	storage.Put(ctx, key, n)
+	runtime.Notify("Incremented", n)
+	return n
+}
@@ -0,0 +1,25 @@
+package counter
+
+func TestIncrement() bool {
+	return Increment() == 1 && Increment() == 2
+}
+
+// TestClean checks that every test starts with an empty storage.
+func TestClean() {
+	if Increment() != 1 {
+		panic("storage is not clean")
+	}
+}
+
+func TestFalse() bool {
+	return Increment() == 2
+}
+
+func TestPanic() {
+	panic("expected failure")
+}
+
+// Testing is not a test.
+func Testing() {
+	panic("not a test")
+}
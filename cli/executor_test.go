@@ -80,11 +80,12 @@ func newTestChain(t *testing.T, f func(*config.Config)) (*core.Blockchain, *serv
 	netSrv, err := network.NewServer(serverConfig, chain, zap.NewNop())
 	require.NoError(t, err)
 	go netSrv.Start(make(chan error, 1))
-	rpcServer := server.New(chain, cfg.ApplicationConfiguration.RPC, netSrv, nil, logger)
+	rpcServer, err := server.New(chain, cfg.ApplicationConfiguration.RPC, netSrv, nil, logger)
+	require.NoError(t, err)
 	errCh := make(chan error, 2)
 	rpcServer.Start(errCh)
 
-	return chain, &rpcServer, netSrv
+	return chain, rpcServer, netSrv
 }
 
 func newExecutor(t *testing.T, needChain bool) *executor {
@@ -182,6 +183,16 @@ func (e *executor) RunWithError(t *testing.T, args ...string) {
 	checkExit(t, ch, 1)
 }
 
+// RunWithErrorCheck runs command and checks that it exits with error
+// containing the given message.
+func (e *executor) RunWithErrorCheck(t *testing.T, msg string, args ...string) {
+	ch := setExitFunc()
+	err := e.run(args...)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), msg)
+	checkExit(t, ch, 1)
+}
+
 // Run runs command and checks that there were no errors.
 func (e *executor) Run(t *testing.T, args ...string) {
 	ch := setExitFunc()
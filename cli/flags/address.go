@@ -92,11 +92,5 @@ func (f AddressFlag) Apply(set *flag.FlagSet) {
 
 // ParseAddress parses Uint160 form either LE string or address.
 func ParseAddress(s string) (util.Uint160, error) {
-	const uint160size = 2 * util.Uint160Size
-	switch len(s) {
-	case uint160size, uint160size + 2:
-		return util.Uint160DecodeStringLE(strings.TrimPrefix(s, "0x"))
-	default:
-		return address.StringToUint160(s)
-	}
+	return address.ParseUint160(s)
 }
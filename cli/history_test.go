@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletHistory(t *testing.T) {
+	w, err := wallet.NewWalletFromFile("testdata/testwallet.json")
+	require.NoError(t, err)
+	defer w.Close()
+
+	e := newExecutor(t, true)
+	addr := w.Accounts[0].Address
+	args := []string{"neo-go", "wallet", "history",
+		"--rpc-endpoint", "http://" + e.RPC.Addr,
+		"--wallet", "testdata/testwallet.json",
+		"--address", addr}
+
+	t.Run("no transfers", func(t *testing.T) {
+		e.Run(t, args...)
+		e.checkNextLine(t, "^Account "+addr+"$")
+		e.checkEOF(t)
+	})
+	t.Run("unknown address", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "history",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", "testdata/testwallet.json",
+			"--address", validatorAddr)
+	})
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--wallet", validatorWallet,
+		"--from", validatorAddr,
+		"--to", addr,
+		"--token", "NEO",
+		"--amount", "1")
+	tx, _ := e.checkTxPersisted(t)
+
+	e.Run(t, args...)
+	e.checkNextLine(t, "^Account "+addr+"$")
+	e.checkNextLine(t, "#\\d+  "+tx.Hash().StringLE()+"  HALT, GAS consumed: ")
+	e.checkNextLine(t, "^\\s*\\+1 NEO from "+validatorAddr+"$")
+	e.checkEOF(t)
+}
@@ -5,12 +5,19 @@ import (
 	"math/big"
 	"os"
 	"path"
+	"strconv"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
 
@@ -156,3 +163,211 @@ func TestSignMultisigTx(t *testing.T) {
 		require.Equal(t, big.NewInt(2), b)
 	})
 }
+
+func TestMultisigNew(t *testing.T) {
+	e := newExecutor(t, true)
+
+	privs, pubs := generateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+	multisigHash := hash.Hash160(script)
+	multisigAddr := address.Uint160ToString(multisigHash)
+
+	walletPath := path.Join(os.TempDir(), "multisigNewWallet.json")
+	t.Cleanup(func() {
+		os.Remove(walletPath)
+	})
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath)
+
+	var keyArgs []string
+	for i := range pubs {
+		keyArgs = append(keyArgs, hex.EncodeToString(pubs[i].Bytes()))
+	}
+	args := func(flags ...string) []string {
+		res := append([]string{"neo-go", "wallet", "multisig", "new", "--wallet", walletPath}, flags...)
+		return append(res, keyArgs...)
+	}
+	t.Run("no own key", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "wallet doesn't contain any of the given keys", args("--min", "2")...)
+	})
+
+	e.In.WriteString("acc\rpass\rpass\r")
+	e.Run(t, "neo-go", "wallet", "import", "--wallet", walletPath, "--wif", privs[1].WIF())
+
+	t.Run("insufficient number of keys", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "insufficient number of public keys", args("--min", "4")...)
+	})
+	t.Run("bad password", func(t *testing.T) {
+		e.In.WriteString("bad\r")
+		e.RunWithErrorCheck(t, "password mismatch", args("--min", "2")...)
+	})
+
+	e.In.WriteString("pass\r")
+	e.Run(t, args("--min", "2", "--name", "multi")...)
+	e.checkNextLine(t, "^Using key of "+privs[1].Address()+" account.")
+	e.checkNextLine(t, "^"+multisigAddr+"$")
+	e.checkEOF(t)
+
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	defer w.Close()
+	acc := w.GetAccount(multisigHash)
+	require.NotNil(t, acc)
+	require.Equal(t, "multi", acc.Label)
+	require.Equal(t, script, acc.Contract.Script)
+	require.NoError(t, acc.Decrypt("pass"))
+	require.Equal(t, privs[1].Bytes(), acc.PrivateKey().Bytes())
+
+	t.Run("already exists", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.RunWithErrorCheck(t, "is already in wallet", args("--min", "2")...)
+	})
+
+	t.Run("sign via notary", func(t *testing.T) {
+		e.In.WriteString("one\r")
+		e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", validatorWallet, "--from", validatorAddr,
+			"NEO:"+multisigAddr+":1",
+			"GAS:"+multisigAddr+":1")
+		e.checkTxPersisted(t)
+
+		txPath := path.Join(os.TempDir(), "multisignotarytx.json")
+		t.Cleanup(func() {
+			os.Remove(txPath)
+		})
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", walletPath, "--from", multisigAddr,
+			"--to", privs[0].Address(), "--token", "NEO", "--amount", "1",
+			"--out", txPath)
+
+		t.Run("no endpoint", func(t *testing.T) {
+			e.RunWithErrorCheck(t, "RPC endpoint is required", "neo-go", "wallet", "multisig", "sign",
+				"--wallet", walletPath, "--address", multisigAddr,
+				"--in", txPath, "--notary")
+		})
+		t.Run("no Notary signer", func(t *testing.T) {
+			e.In.WriteString("pass\r")
+			e.RunWithErrorCheck(t, "transaction can't be signed via notary", "neo-go", "wallet", "multisig", "sign",
+				"--rpc-endpoint", "http://"+e.RPC.Addr,
+				"--wallet", walletPath, "--address", multisigAddr,
+				"--in", txPath, "--notary")
+		})
+		t.Run("without notary", func(t *testing.T) {
+			// The transaction is already signed with the key from
+			// walletPath, so it's signed with another one.
+			wallet2Path := path.Join(os.TempDir(), "multisigNewWallet2.json")
+			t.Cleanup(func() {
+				os.Remove(wallet2Path)
+			})
+			e.Run(t, "neo-go", "wallet", "init", "--wallet", wallet2Path)
+			e.In.WriteString("acc\rpass\rpass\r")
+			e.Run(t, "neo-go", "wallet", "import", "--wallet", wallet2Path, "--wif", privs[0].WIF())
+			e.In.WriteString("pass\r")
+			e.Run(t, append([]string{"neo-go", "wallet", "multisig", "new", "--wallet", wallet2Path, "--min", "2"}, keyArgs...)...)
+
+			e.In.WriteString("pass\r")
+			e.RunWithErrorCheck(t, "signature is already added", "neo-go", "wallet", "multisig", "sign",
+				"--wallet", walletPath, "--address", multisigAddr,
+				"--in", txPath, "--out", txPath)
+
+			e.In.WriteString("pass\r")
+			e.Run(t, "neo-go", "wallet", "multisig", "sign",
+				"--wallet", wallet2Path, "--address", multisigAddr,
+				"--in", txPath, "--out", txPath)
+
+			pc, err := paramcontext.Read(txPath)
+			require.NoError(t, err)
+			item := pc.Items[multisigHash]
+			require.NotNil(t, item)
+			require.Equal(t, 2, len(item.Signatures))
+		})
+		t.Run("good", func(t *testing.T) {
+			notaryHash := e.Chain.GetNotaryContractScriptHash()
+
+			// Fallback transaction is paid from the deposit of the
+			// standard account with the same key.
+			e.In.WriteString("one\r")
+			e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+				"--rpc-endpoint", "http://"+e.RPC.Addr,
+				"--wallet", validatorWallet, "--from", validatorAddr,
+				"--to", address.Uint160ToString(notaryHash),
+				"--token", "GAS", "--amount", "2",
+				"[", privs[1].Address(), strconv.Itoa(int(e.Chain.BlockHeight()+100)), "]")
+			e.checkTxPersisted(t)
+
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+			tx.ValidUntilBlock = e.Chain.BlockHeight() + 10
+			tx.Signers = []transaction.Signer{
+				{Account: multisigHash, Scopes: transaction.CalledByEntry},
+				{Account: notaryHash, Scopes: transaction.None},
+			}
+			tx.Attributes = []transaction.Attribute{{
+				Type:  transaction.NotaryAssistedT,
+				Value: &transaction.NotaryAssisted{NKeys: uint8(len(pubs))},
+			}}
+			notaryTxPath := path.Join(os.TempDir(), "multisignotarygoodtx.json")
+			t.Cleanup(func() {
+				os.Remove(notaryTxPath)
+			})
+			pc := context.NewParameterContext("Neo.Core.ContractTransaction", netmode.UnitTestNet, tx)
+			require.NoError(t, paramcontext.Save(pc, notaryTxPath))
+
+			e.In.WriteString("pass\r")
+			e.Run(t, "neo-go", "wallet", "multisig", "sign",
+				"--rpc-endpoint", "http://"+e.RPC.Addr,
+				"--wallet", walletPath, "--address", multisigAddr,
+				"--in", notaryTxPath, "--out", notaryTxPath,
+				"--notary", "--fallback-valid-for", "5")
+			e.checkNextLine(t, "^Main transaction: "+tx.Hash().StringLE()+"$")
+			e.checkNextLine(t, "^Fallback transaction: ")
+			e.checkEOF(t)
+
+			// Own signature is saved to the context.
+			pc, err := paramcontext.Read(notaryTxPath)
+			require.NoError(t, err)
+			item := pc.Items[multisigHash]
+			require.NotNil(t, item)
+			require.Equal(t, 1, len(item.Signatures))
+		})
+	})
+}
+
+func TestMultisigNewCommittee(t *testing.T) {
+	e := newExecutor(t, true)
+
+	walletPath := path.Join(os.TempDir(), "multisigCommitteeWallet.json")
+	t.Cleanup(func() {
+		os.Remove(walletPath)
+	})
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath)
+	e.In.WriteString("acc\rpass\rpass\r")
+	e.Run(t, "neo-go", "wallet", "import", "--wallet", walletPath, "--wif", validatorWIF)
+
+	args := []string{"neo-go", "wallet", "multisig", "new", "--wallet", walletPath, "--committee"}
+	t.Run("no endpoint", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "RPC endpoint is required", args...)
+	})
+	args = append(args, "--rpc-endpoint", "http://"+e.RPC.Addr)
+	t.Run("keys specified", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "neither public keys nor minimum number", append(args, hex.EncodeToString(validatorPriv.PublicKey().Bytes()))...)
+	})
+	t.Run("min specified", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "neither public keys nor minimum number", append(args, "--min", "1")...)
+	})
+
+	e.In.WriteString("pass\r")
+	e.Run(t, append(args, "--name", "committee")...)
+	e.checkNextLine(t, "^Using key of "+validatorPriv.Address()+" account.")
+	e.checkNextLine(t, "^"+validatorAddr+"$")
+	e.checkEOF(t)
+
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	defer w.Close()
+	acc := w.GetAccount(validatorHash)
+	require.NotNil(t, acc)
+	require.Equal(t, "committee", acc.Label)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+func TestPolicyBlockAccount(t *testing.T) {
+	e := newExecutor(t, true)
+
+	addr := address.Uint160ToString(util.Uint160{1, 2, 3})
+	isBlocked := []string{"neo-go", "wallet", "policy", "isblocked",
+		"--rpc-endpoint", "http://" + e.RPC.Addr}
+	t.Run("no account", func(t *testing.T) {
+		e.RunWithError(t, isBlocked...)
+	})
+	t.Run("invalid account", func(t *testing.T) {
+		e.RunWithError(t, append(isBlocked, "not-an-address")...)
+	})
+
+	e.Run(t, append(isBlocked, addr)...)
+	e.checkNextLine(t, "^false$")
+
+	t.Run("no committee address", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "policy", "block",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", validatorWallet, addr)
+	})
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "policy", "block",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--wallet", validatorWallet,
+		"--address", validatorAddr, addr)
+	e.checkTxPersisted(t)
+
+	e.Run(t, append(isBlocked, addr)...)
+	e.checkNextLine(t, "^true$")
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "policy", "unblock",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--wallet", validatorWallet,
+		"--address", validatorAddr, addr)
+	e.checkTxPersisted(t)
+
+	e.Run(t, append(isBlocked, addr)...)
+	e.checkNextLine(t, "^false$")
+}
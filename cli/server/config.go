@@ -0,0 +1,269 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/urfave/cli"
+)
+
+// networkDefaults contains network-specific parameters of generated config.
+type networkDefaults struct {
+	Magic              netmode.Magic
+	MaxTraceableBlocks uint32
+	StandbyCommittee   []string
+	ValidatorsCount    int
+	SeedList           []string
+	VerifyTransactions bool
+	NodePort           uint16
+	RPCPort            uint16
+	TLSPort            uint16
+	MaxPeers           int
+	AttemptConnPeers   int
+	MinPeers           int
+}
+
+var knownNetworks = map[netmode.Magic]networkDefaults{
+	netmode.MainNet: {
+		Magic:              netmode.MainNet,
+		MaxTraceableBlocks: 2102400,
+		StandbyCommittee: []string{
+			"03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c",
+			"02df48f60e8f3e01c48ff40b9b7f1310d7a8b2a193188befe1c2e3df740e895093",
+			"03b8d9d5771d8f513aa0869b9cc8d50986403b78c6da36890638c3d46a5adce04a",
+			"02ca0e27697b9c248f6f16e085fd0061e26f44da85b58ee835c110caa5ec3ba554",
+			"024c7b7fb6c310fccf1ba33b082519d82964ea93868d676662d4a59ad548df0e7d",
+			"02aaec38470f6aad0042c6e877cfd8087d2676b0f516fddd362801b9bd3936399e",
+			"02486fd15702c4490a26703112a5cc1d0923fd697a33406bd5a1c00e0013b09a70",
+		},
+		ValidatorsCount: 7,
+		SeedList: []string{
+			"seed1.neo.org:10333",
+			"seed2.neo.org:10333",
+			"seed3.neo.org:10333",
+			"seed4.neo.org:10333",
+			"seed5.neo.org:10333",
+		},
+		NodePort:         10333,
+		RPCPort:          10332,
+		TLSPort:          10331,
+		MaxPeers:         100,
+		AttemptConnPeers: 20,
+		MinPeers:         5,
+	},
+	netmode.TestNet: {
+		Magic:              netmode.TestNet,
+		MaxTraceableBlocks: 2102400,
+		StandbyCommittee: []string{
+			"023e9b32ea89b94d066e649b124fd50e396ee91369e8e2a6ae1b11c170d022256d",
+			"03009b7540e10f2562e5fd8fac9eaec25166a58b26e412348ff5a86927bfac22a2",
+			"02ba2c70f5996f357a43198705859fae2cfea13e1172962800772b3d588a9d4abd",
+			"03408dcd416396f64783ac587ea1e1593c57d9fea880c8a6a1920e92a259477806",
+			"02a7834be9b32e2981d157cb5bbd3acb42cfd11ea5c3b10224d7a44e98c5910f1b",
+			"0214baf0ceea3a66f17e7e1e839ea25fd8bed6cd82e6bb6e68250189065f44ff01",
+			"030205e9cefaea5a1dfc580af20c8d5aa2468bb0148f1a5e4605fc622c80e604ba",
+		},
+		ValidatorsCount: 7,
+		SeedList: []string{
+			"seed1t.neo.org:20333",
+			"seed2t.neo.org:20333",
+			"seed3t.neo.org:20333",
+			"seed4t.neo.org:20333",
+			"seed5t.neo.org:20333",
+		},
+		NodePort:         20333,
+		RPCPort:          20332,
+		TLSPort:          20331,
+		MaxPeers:         100,
+		AttemptConnPeers: 20,
+		MinPeers:         5,
+	},
+	netmode.PrivNet: {
+		Magic:              netmode.PrivNet,
+		MaxTraceableBlocks: 200000,
+		StandbyCommittee: []string{
+			"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+			"02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e",
+			"03d90c07df63e690ce77912e10ab51acc944b66860237b608c4f8f8309e71ee699",
+			"02a7bc55fe8684e0119768d104ba30795bdcc86619e864add26156723ed185cd62",
+		},
+		ValidatorsCount: 4,
+		SeedList: []string{
+			"127.0.0.1:20333",
+			"127.0.0.1:20334",
+			"127.0.0.1:20335",
+			"127.0.0.1:20336",
+		},
+		VerifyTransactions: true,
+		NodePort:           20332,
+		RPCPort:            20331,
+		TLSPort:            20330,
+		MaxPeers:           10,
+		AttemptConnPeers:   5,
+		MinPeers:           3,
+	},
+}
+
+var configTemplate = template.Must(template.New("config").Parse(`ProtocolConfiguration:
+  # Network magic number, it must be the same for all nodes of the network.
+  Magic: {{.Magic | printf "%d"}}
+  # Number of blocks accessible to smart contracts.
+  MaxTraceableBlocks: {{.MaxTraceableBlocks}}
+  SecondsPerBlock: 15
+  MemPoolSize: 50000
+  # Initial committee, first ValidatorsCount of them are validators.
+  StandbyCommittee:
+{{- range .StandbyCommittee}}
+  - {{.}}
+{{- end}}
+  ValidatorsCount: {{.ValidatorsCount}}
+  # Nodes to connect to at startup.
+  SeedList:
+{{- range .SeedList}}
+  - {{.}}
+{{- end}}
+  VerifyBlocks: true
+  VerifyTransactions: {{.VerifyTransactions}}
+  P2PSigExtensions: false
+  NativeActivations:
+    ContractManagement: [0]
+    StdLib: [0]
+    CryptoLib: [0]
+    LedgerContract: [0]
+    NeoToken: [0]
+    GasToken: [0]
+    PolicyContract: [0]
+    RoleManagement: [0]
+    OracleContract: [0]
+    NameService: [0]
+
+ApplicationConfiguration:
+  # LogPath could be set up in case you need stdout logs to some proper file.
+  # LogPath: "./log/neogo.log"
+  # Logger:
+  #   Level: "info" # debug, info, warn or error.
+  #   Levels: # Overrides the default level for core, network, consensus or rpc.
+  #     consensus: "debug"
+  #   Encoding: "console" # or json.
+  #   MaxSize: 100 # Log file rotation size in megabytes (requires LogPath), 0 disables rotation.
+  #   MaxBackups: 5
+  DBConfiguration:
+    Type: "leveldb" #other options: 'inmemory','redis','boltdb', 'badgerdb'.
+    # DB type options. Uncomment those you need in case you want to switch DB type.
+    LevelDBOptions:
+      DataDirectoryPath: "./chains/{{.Magic}}"
+  #    RedisDBOptions:
+  #      Addr: "localhost:6379"
+  #      Password: ""
+  #      DB: 0
+  #    BoltDBOptions:
+  #      FilePath: "./chains/{{.Magic}}.bolt"
+  #    BadgerDBOptions:
+  #      BadgerDir: "./chains/{{.Magic}}.badger"
+  #  Uncomment in order to set up custom address for node.
+  #  Address: 127.0.0.1
+  # P2P port.
+  NodePort: {{.NodePort}}
+  Relay: true
+  DialTimeout: 3
+  ProtoTickInterval: 2
+  PingInterval: 30
+  PingTimeout: 90
+  MaxPeers: {{.MaxPeers}}
+  AttemptConnPeers: {{.AttemptConnPeers}}
+  MinPeers: {{.MinPeers}}
+  # Uncomment in order to run consensus node with the given wallet.
+  # UnlockWallet:
+  #   Path: "/cn_wallet.json"
+  #   Password: "pass"
+  Oracle:
+    Enabled: false
+  P2PNotary:
+    Enabled: false
+  StateRoot:
+    Enabled: false
+  RPC:
+    Enabled: true
+    MaxGasInvoke: 15
+    EnableCORSWorkaround: false
+    Port: {{.RPCPort}}
+    TLSConfig:
+      Enabled: false
+      Port: {{.TLSPort}}
+      CertFile: serv.crt
+      KeyFile: serv.key
+  Prometheus:
+    Enabled: true
+    Port: 2112
+  Pprof:
+    Enabled: false
+    Port: 2113
+`))
+
+func newConfigCommand(cfgFlags []cli.Flag) cli.Command {
+	initFlags := append([]cli.Flag{}, options.Network...)
+	initFlags = append(initFlags, cli.StringFlag{
+		Name:  "out, o",
+		Usage: "Output file (stdout if not given)",
+	})
+	return cli.Command{
+		Name:  "config",
+		Usage: "node configuration management",
+		Subcommands: []cli.Command{
+			{
+				Name:   "init",
+				Usage:  "generate documented configuration for the given network",
+				Action: initConfig,
+				Flags:  initFlags,
+			},
+			{
+				Name:   "validate",
+				Usage:  "check configuration for unknown keys, port conflicts and missing wallets",
+				Action: validateConfig,
+				Flags:  cfgFlags,
+			},
+		},
+	}
+}
+
+func initConfig(ctx *cli.Context) error {
+	net := options.GetNetwork(ctx)
+	defaults, ok := knownNetworks[net]
+	if !ok {
+		return cli.NewExitError(fmt.Errorf("can't generate config for %s network", net), 1)
+	}
+	var out io.Writer = ctx.App.Writer
+	if name := ctx.String("out"); name != "" {
+		f, err := os.Create(name)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := configTemplate.Execute(out, defaults); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
+func validateConfig(ctx *cli.Context) error {
+	configFile, network, err := getConfigFile(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	errs := config.ValidateFile(configFile, network)
+	if len(errs) == 0 {
+		fmt.Fprintf(ctx.App.Writer, "%s: OK\n", configFile)
+		return nil
+	}
+	for _, err := range errs {
+		fmt.Fprintf(ctx.App.Writer, "%s: %v\n", configFile, err)
+	}
+	return cli.NewExitError(fmt.Errorf("%d problem(s) found", len(errs)), 1)
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger names of node subsystems.
+const (
+	logCore      = "core"
+	logNetwork   = "network"
+	logConsensus = "consensus"
+	logRPC       = "rpc"
+)
+
+// handleLoggingParams reads logging parameters and creates node logger
+// according to them. If user selected debug level -- function enables it.
+// If logPath is configured -- function creates dir and file for logging.
+// Subsystem loggers are to be obtained via Named with one of the subsystem
+// names, their levels can differ from the default one.
+func handleLoggingParams(ctx *cli.Context, cfg config.ApplicationConfiguration) (*zap.Logger, error) {
+	lc := cfg.Logger
+	levels, err := newLogLevels(ctx, lc)
+	if err != nil {
+		return nil, err
+	}
+
+	ec := zap.NewProductionEncoderConfig()
+	ec.EncodeDuration = zapcore.StringDurationEncoder
+	ec.EncodeLevel = zapcore.CapitalLevelEncoder
+	ec.EncodeTime = zapcore.ISO8601TimeEncoder
+	var enc zapcore.Encoder
+	switch lc.Encoding {
+	case "", "console":
+		enc = zapcore.NewConsoleEncoder(ec)
+	case "json":
+		enc = zapcore.NewJSONEncoder(ec)
+	default:
+		return nil, fmt.Errorf("unknown logging encoding: %s", lc.Encoding)
+	}
+
+	var out zapcore.WriteSyncer = zapcore.Lock(os.Stderr)
+	if logPath := cfg.LogPath; logPath != "" {
+		if err := io.MakeDirForFile(logPath, "logger"); err != nil {
+			return nil, err
+		}
+		if lc.MaxSize > 0 {
+			out, err = newRotatingFile(logPath, int64(lc.MaxSize)*1024*1024, lc.MaxBackups)
+		} else {
+			out, _, err = zap.Open(logPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else if lc.MaxSize > 0 {
+		return nil, errors.New("log rotation requires LogPath to be set")
+	}
+
+	c := &subsystemCore{
+		Core:   zapcore.NewCore(enc, out, levels.min),
+		levels: levels,
+	}
+	return zap.New(c, zap.ErrorOutput(zapcore.Lock(os.Stderr))), nil
+}
+
+// reloadLogLevels makes the logger created by handleLoggingParams use levels
+// from the given configuration, other logging settings can't be changed
+// without restart.
+func reloadLogLevels(ctx *cli.Context, log *zap.Logger, cfg config.ApplicationConfiguration) error {
+	c, ok := log.Core().(*subsystemCore)
+	if !ok {
+		return errors.New("logger doesn't support level changes")
+	}
+	levels, err := newLogLevels(ctx, cfg.Logger)
+	if err != nil {
+		return err
+	}
+	c.levels.update(levels)
+	return nil
+}
+
+// logLevels contains the default logging level and levels of subsystems, it's
+// shared by all loggers derived from the node logger, so they can be changed
+// at runtime.
+type logLevels struct {
+	lock   sync.RWMutex
+	level  zapcore.Level
+	levels map[string]zapcore.Level
+	// min is the minimal level of all, it's used by the underlying core.
+	min zap.AtomicLevel
+}
+
+// newLogLevels parses levels from the given configuration.
+func newLogLevels(ctx *cli.Context, lc config.Logger) (*logLevels, error) {
+	var (
+		levels = make(map[string]zapcore.Level, len(lc.Levels))
+		level  = zapcore.InfoLevel
+	)
+	if lc.Level != "" {
+		if err := level.UnmarshalText([]byte(lc.Level)); err != nil {
+			return nil, fmt.Errorf("invalid logging level: %w", err)
+		}
+	}
+	if ctx.Bool("debug") {
+		level = zapcore.DebugLevel
+	}
+	minLevel := level
+	for name, l := range lc.Levels {
+		switch name {
+		case logCore, logNetwork, logConsensus, logRPC:
+		default:
+			return nil, fmt.Errorf("unknown logging subsystem: %s", name)
+		}
+		var sl zapcore.Level
+		if err := sl.UnmarshalText([]byte(l)); err != nil {
+			return nil, fmt.Errorf("invalid logging level for %s: %w", name, err)
+		}
+		levels[name] = sl
+		if sl < minLevel {
+			minLevel = sl
+		}
+	}
+	return &logLevels{
+		level:  level,
+		levels: levels,
+		min:    zap.NewAtomicLevelAt(minLevel),
+	}, nil
+}
+
+// update replaces levels with the given ones.
+func (l *logLevels) update(n *logLevels) {
+	l.lock.Lock()
+	l.level = n.level
+	l.levels = n.levels
+	l.lock.Unlock()
+	l.min.SetLevel(n.min.Level())
+}
+
+// levelOf returns the level for the logger with the given name, the most
+// specific subsystem (e.g. "consensus" for "network.consensus") is used.
+func (l *logLevels) levelOf(name string) zapcore.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if len(l.levels) != 0 && name != "" {
+		parts := strings.Split(name, ".")
+		for i := len(parts) - 1; i >= 0; i-- {
+			if lvl, ok := l.levels[parts[i]]; ok {
+				return lvl
+			}
+		}
+	}
+	return l.level
+}
+
+// subsystemCore filters log entries using the level of logger's subsystem.
+type subsystemCore struct {
+	zapcore.Core
+	levels *logLevels
+}
+
+// With implements zapcore.Core interface.
+func (c *subsystemCore) With(fields []zapcore.Field) zapcore.Core {
+	return &subsystemCore{
+		Core:   c.Core.With(fields),
+		levels: c.levels,
+	}
+}
+
+// Check implements zapcore.Core interface.
+func (c *subsystemCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.levelOf(ent.LoggerName).Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// rotatingFile is a log file that is moved to a backup (with ".1" suffix,
+// older backups are shifted) once it exceeds the size limit.
+type rotatingFile struct {
+	lock       sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	size       int64
+	file       *os.File
+}
+
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	r := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	return r, r.open()
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.file = f
+	r.size = fi.Size()
+	return nil
+}
+
+// Write implements io.Writer interface.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Sync implements zapcore.WriteSyncer interface.
+func (r *rotatingFile) Sync() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.file.Sync()
+}
+
+func (r *rotatingFile) backupName(i int) string {
+	return r.path + "." + strconv.Itoa(i)
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	if r.maxBackups == 0 {
+		if err := os.Remove(r.path); err != nil {
+			return err
+		}
+		return r.open()
+	}
+	if err := os.Remove(r.backupName(r.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := r.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(r.backupName(i), r.backupName(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(r.path, r.backupName(1)); err != nil {
+		return err
+	}
+	return r.open()
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/urfave/cli"
+)
+
+const (
+	// defaultMonitorInterval is the default status refresh interval.
+	defaultMonitorInterval = 2 * time.Second
+	// maxLogTail is the maximum number of bytes read from the end of the log
+	// file to get recent lines.
+	maxLogTail = 64 * 1024
+	// clearScreen moves cursor to the top left corner and clears the screen.
+	clearScreen = "\033[H\033[2J"
+)
+
+// nodeStatus is a snapshot of node state shown by monitor.
+type nodeStatus struct {
+	Endpoint      string
+	UserAgent     string
+	BlockHeight   uint32
+	HeaderHeight  uint32
+	LastBlockTime time.Time
+	Connected     int
+	Unconnected   int
+	MempoolSize   int
+	LogPath       string
+	LogLines      []string
+	// Err is the error occurred while polling the node, other fields are
+	// not valid if it's set.
+	Err error
+	// LogErr is the error occurred while reading the log file.
+	LogErr error
+}
+
+func newMonitorCommand() cli.Command {
+	monitorFlags := append([]cli.Flag{
+		cli.DurationFlag{
+			Name:  "interval, i",
+			Usage: "Status refresh interval (2 seconds by default)",
+		},
+		cli.StringFlag{
+			Name:  "log, l",
+			Usage: "Node log file to show recent lines from",
+		},
+		cli.IntFlag{
+			Name:  "lines, n",
+			Value: 10,
+			Usage: "Number of log lines to show",
+		},
+		cli.BoolFlag{
+			Name:  "once",
+			Usage: "Print status once and exit",
+		},
+	}, options.RPC...)
+	return cli.Command{
+		Name:      "monitor",
+		Usage:     "show live node status",
+		UsageText: "neo-go node monitor -r endpoint [-i interval] [-l node.log [-n lines]] [--once]",
+		Description: `Polls the node via RPC and shows its block and header heights, last block
+   time, number of peers and mempool size refreshing the screen every interval.
+   Recent lines of the node's log file are shown if it's specified. Timeout
+   flag sets the timeout of every RPC request. Press Ctrl+C to exit.
+`,
+		Action: monitorNode,
+		Flags:  monitorFlags,
+	}
+}
+
+func monitorNode(ctx *cli.Context) error {
+	endpoint := ctx.String(options.RPCEndpointFlag)
+	if endpoint == "" {
+		return cli.NewExitError("no RPC endpoint specified", 1)
+	}
+	interval := ctx.Duration("interval")
+	if interval <= 0 {
+		interval = defaultMonitorInterval
+	}
+	grace := newGraceContext()
+	c, err := client.New(grace, endpoint, client.Options{
+		DialTimeout:    ctx.Duration("timeout"),
+		RequestTimeout: ctx.Duration("timeout"),
+	})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	logPath := ctx.String("log")
+	lines := ctx.Int("lines")
+	if ctx.Bool("once") {
+		st := getNodeStatus(c, endpoint, logPath, lines)
+		printNodeStatus(ctx.App.Writer, st, time.Now())
+		if st.Err != nil {
+			return cli.NewExitError(st.Err, 1)
+		}
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		st := getNodeStatus(c, endpoint, logPath, lines)
+		fmt.Fprint(ctx.App.Writer, clearScreen)
+		printNodeStatus(ctx.App.Writer, st, time.Now())
+		select {
+		case <-grace.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// getNodeStatus polls the node and reads the log file.
+func getNodeStatus(c *client.Client, endpoint string, logPath string, lines int) *nodeStatus {
+	st := &nodeStatus{
+		Endpoint: endpoint,
+		LogPath:  logPath,
+	}
+	if logPath != "" {
+		st.LogLines, st.LogErr = tailFile(logPath, lines)
+	}
+	st.Err = func() error {
+		v, err := c.GetVersion()
+		if err != nil {
+			return fmt.Errorf("failed to get version: %w", err)
+		}
+		st.UserAgent = v.UserAgent
+		count, err := c.GetBlockCount()
+		if err != nil {
+			return fmt.Errorf("failed to get block count: %w", err)
+		}
+		st.BlockHeight = count - 1
+		hCount, err := c.GetBlockHeaderCount()
+		if err != nil {
+			return fmt.Errorf("failed to get header count: %w", err)
+		}
+		st.HeaderHeight = hCount - 1
+		h, err := c.GetBlockHeaderByIndex(st.BlockHeight)
+		if err != nil {
+			return fmt.Errorf("failed to get block header: %w", err)
+		}
+		st.LastBlockTime = time.Unix(0, int64(h.Timestamp)*int64(time.Millisecond))
+		peers, err := c.GetPeers()
+		if err != nil {
+			return fmt.Errorf("failed to get peers: %w", err)
+		}
+		st.Connected = len(peers.Connected)
+		st.Unconnected = len(peers.Unconnected)
+		mp, err := c.GetRawMemPool()
+		if err != nil {
+			return fmt.Errorf("failed to get mempool: %w", err)
+		}
+		st.MempoolSize = len(mp)
+		return nil
+	}()
+	return st
+}
+
+// printNodeStatus writes human-readable node status to w, now is used to
+// show the time passed since the last block.
+func printNodeStatus(w io.Writer, st *nodeStatus, now time.Time) {
+	fmt.Fprintf(w, "Node:          %s %s\n", st.Endpoint, st.UserAgent)
+	fmt.Fprintf(w, "Updated:       %s\n", now.Format("15:04:05"))
+	if st.Err != nil {
+		fmt.Fprintf(w, "Error:         %v\n", st.Err)
+	} else {
+		fmt.Fprintf(w, "Block height:  %d\n", st.BlockHeight)
+		fmt.Fprintf(w, "Header height: %d\n", st.HeaderHeight)
+		fmt.Fprintf(w, "Last block:    %s (%s ago)\n", st.LastBlockTime.Format(time.RFC3339),
+			now.Sub(st.LastBlockTime).Truncate(time.Second))
+		fmt.Fprintf(w, "Peers:         %d connected, %d unconnected\n", st.Connected, st.Unconnected)
+		fmt.Fprintf(w, "Mempool:       %d transactions\n", st.MempoolSize)
+	}
+	if st.LogPath == "" {
+		return
+	}
+	fmt.Fprintf(w, "\nRecent log lines (%s):\n", st.LogPath)
+	if st.LogErr != nil {
+		fmt.Fprintf(w, "Error: %v\n", st.LogErr)
+		return
+	}
+	for _, l := range st.LogLines {
+		fmt.Fprintln(w, l)
+	}
+}
+
+// tailFile returns at most n last lines of the file at the given path.
+func tailFile(path string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := fi.Size() - maxLogTail
+	if offset < 0 {
+		offset = 0
+	}
+	buf := make([]byte, fi.Size()-offset)
+	if _, err := f.ReadAt(buf, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	buf = bytes.TrimRight(buf, "\n")
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	lines := bytes.Split(buf, []byte{'\n'})
+	if offset > 0 {
+		lines = lines[1:] // The first line is likely to be incomplete.
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	res := make([]string, len(lines))
+	for i := range lines {
+		res[i] = string(lines[i])
+	}
+	return res, nil
+}
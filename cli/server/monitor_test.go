@@ -0,0 +1,105 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailFile(t *testing.T) {
+	d, err := ioutil.TempDir("", "neogo-monitor")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+
+	logPath := filepath.Join(d, "node.log")
+	_, err = tailFile(logPath, 10)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(logPath, []byte("one\ntwo\nthree\n"), os.ModePerm))
+	lines, err := tailFile(logPath, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"one", "two", "three"}, lines)
+
+	lines, err = tailFile(logPath, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"two", "three"}, lines)
+
+	lines, err = tailFile(logPath, 0)
+	require.NoError(t, err)
+	require.Nil(t, lines)
+
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(logPath, nil, os.ModePerm))
+		lines, err := tailFile(logPath, 2)
+		require.NoError(t, err)
+		require.Nil(t, lines)
+	})
+	t.Run("big", func(t *testing.T) {
+		var buf bytes.Buffer
+		for i := 0; buf.Len() < 2*maxLogTail; i++ {
+			fmt.Fprintf(&buf, "line %d\n", i)
+		}
+		require.NoError(t, ioutil.WriteFile(logPath, buf.Bytes(), os.ModePerm))
+		expected := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+		lines, err := tailFile(logPath, 3)
+		require.NoError(t, err)
+		require.Equal(t, expected[len(expected)-3:], lines)
+
+		// Only complete lines from the tail are returned.
+		lines, err = tailFile(logPath, len(expected))
+		require.NoError(t, err)
+		require.True(t, len(lines) < len(expected))
+		require.Equal(t, expected[len(expected)-len(lines):], lines)
+	})
+}
+
+func TestPrintNodeStatus(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	st := &nodeStatus{
+		Endpoint:      "http://localhost:20332",
+		UserAgent:     "/NEO-GO:0.93.0/",
+		BlockHeight:   10,
+		HeaderHeight:  12,
+		LastBlockTime: now.Add(-15 * time.Second),
+		Connected:     3,
+		Unconnected:   5,
+		MempoolSize:   7,
+	}
+	buf := bytes.NewBuffer(nil)
+	printNodeStatus(buf, st, now)
+	require.Equal(t, `Node:          http://localhost:20332 /NEO-GO:0.93.0/
+Updated:       12:00:00
+Block height:  10
+Header height: 12
+Last block:    2021-03-01T11:59:45Z (15s ago)
+Peers:         3 connected, 5 unconnected
+Mempool:       7 transactions
+`, buf.String())
+
+	st.LogPath = "node.log"
+	st.LogLines = []string{"first", "second"}
+	buf.Reset()
+	printNodeStatus(buf, st, now)
+	require.True(t, strings.HasSuffix(buf.String(), "\nRecent log lines (node.log):\nfirst\nsecond\n"))
+
+	st.Err = errors.New("connection refused")
+	st.LogErr = errors.New("no such file")
+	buf.Reset()
+	printNodeStatus(buf, st, now)
+	require.Equal(t, `Node:          http://localhost:20332 /NEO-GO:0.93.0/
+Updated:       12:00:00
+Error:         connection refused
+
+Recent log lines (node.log):
+Error: no such file
+`, buf.String())
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -19,13 +20,20 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/server"
 	"github.com/urfave/cli"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 )
 
 // NewCommands returns 'node' command.
 func NewCommands() []cli.Command {
 	var cfgFlags = []cli.Flag{
 		cli.StringFlag{Name: "config-path"},
+		cli.StringFlag{
+			Name:  "config-file",
+			Usage: "Single configuration file with named networks (overrides config-path and network mode flags)",
+		},
+		cli.StringFlag{
+			Name:  "network",
+			Usage: "Network to use from the config-file (top-level configuration if not given)",
+		},
 		cli.BoolFlag{Name: "debug, d"},
 	}
 	cfgFlags = append(cfgFlags, options.Network...)
@@ -71,6 +79,10 @@ func NewCommands() []cli.Command {
 			Usage:  "start a NEO node",
 			Action: startServer,
 			Flags:  cfgFlags,
+			Subcommands: []cli.Command{
+				newConfigCommand(cfgFlags),
+				newMonitorCommand(),
+			},
 		},
 		{
 			Name:  "db",
@@ -107,43 +119,31 @@ func newGraceContext() context.Context {
 // getConfigFromContext looks at path and mode flags in the given config and
 // returns appropriate config.
 func getConfigFromContext(ctx *cli.Context) (config.Config, error) {
-	configPath := "./config"
-	if argCp := ctx.String("config-path"); argCp != "" {
-		configPath = argCp
+	configFile, network, err := getConfigFile(ctx)
+	if err != nil {
+		return config.Config{}, err
 	}
-	return config.Load(configPath, options.GetNetwork(ctx))
+	return config.LoadNetwork(configFile, network)
 }
 
-// handleLoggingParams reads logging parameters.
-// If user selected debug level -- function enables it.
-// If logPath is configured -- function creates dir and file for logging.
-func handleLoggingParams(ctx *cli.Context, cfg config.ApplicationConfiguration) (*zap.Logger, error) {
-	level := zapcore.InfoLevel
-	if ctx.Bool("debug") {
-		level = zapcore.DebugLevel
-	}
-
-	cc := zap.NewProductionConfig()
-	cc.DisableCaller = true
-	cc.DisableStacktrace = true
-	cc.EncoderConfig.EncodeDuration = zapcore.StringDurationEncoder
-	cc.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
-	cc.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	cc.Encoding = "console"
-	cc.Level = zap.NewAtomicLevelAt(level)
-	cc.Sampling = nil
-
-	if logPath := cfg.LogPath; logPath != "" {
-		if err := io.MakeDirForFile(logPath, "logger"); err != nil {
-			return nil, err
-		}
-
-		cc.OutputPaths = []string{logPath}
+// getConfigFile returns configuration file path and network name to load from
+// it depending on path, file and mode flags in the given context.
+func getConfigFile(ctx *cli.Context) (string, string, error) {
+	if configFile := ctx.String("config-file"); configFile != "" {
+		return configFile, ctx.String("network"), nil
 	}
-
-	return cc.Build()
+	if ctx.String("network") != "" {
+		return "", "", errors.New("--network can only be used with --config-file")
+	}
+	configPath := "./config"
+	if argCp := ctx.String("config-path"); argCp != "" {
+		configPath = argCp
+	}
+	return fmt.Sprintf("%s/protocol.%s.yml", configPath, options.GetNetwork(ctx)), "", nil
 }
 
+// initBCWithMetrics creates the blockchain along with Prometheus and pprof
+// services and starts all of them.
 func initBCWithMetrics(cfg config.Config, log *zap.Logger) (*core.Blockchain, *metrics.Service, *metrics.Service, error) {
 	chain, err := initBlockChain(cfg, log)
 	if err != nil {
@@ -316,11 +316,14 @@ func startServer(ctx *cli.Context) error {
 		return err
 	}
 
-	serv, err := network.NewServer(serverConfig, chain, log)
+	serv, err := network.NewServer(serverConfig, chain, log.Named(logNetwork))
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("failed to create network server: %w", err), 1)
 	}
-	rpcServer := server.New(chain, cfg.ApplicationConfiguration.RPC, serv, serv.GetOracle(), log)
+	rpcServer, err := server.New(chain, cfg.ApplicationConfiguration.RPC, serv, serv.GetOracle(), log.Named(logRPC))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create RPC server: %w", err), 1)
+	}
 	errChan := make(chan error)
 
 	go serv.Start(errChan)
@@ -343,13 +346,29 @@ Main:
 		case sig := <-sighupCh:
 			switch sig {
 			case syscall.SIGHUP:
-				log.Info("SIGHUP received, restarting rpc-server")
+				log.Info("SIGHUP received, reloading configuration")
+				newCfg, err := getConfigFromContext(ctx)
+				if err != nil {
+					log.Error("failed to reload configuration, using the old one", zap.Error(err))
+				} else {
+					reloadConfig(ctx, newCfg, serv, log)
+					cfg.ApplicationConfiguration.RPC = newCfg.ApplicationConfiguration.RPC
+					if serverConfig.Wallet != nil {
+						reloadConsensusWallet(newCfg, serv, log)
+					}
+				}
+				newRPCServer, rpcErr := server.New(chain, cfg.ApplicationConfiguration.RPC, serv, serv.GetOracle(), log.Named(logRPC))
+				if rpcErr != nil {
+					log.Error("invalid RPC configuration, keeping the old rpc-server", zap.Error(rpcErr))
+					break
+				}
+				log.Info("restarting rpc-server")
 				serverErr := rpcServer.Shutdown()
 				if serverErr != nil {
 					errChan <- fmt.Errorf("error while restarting rpc-server: %w", serverErr)
 					break
 				}
-				rpcServer = server.New(chain, cfg.ApplicationConfiguration.RPC, serv, serv.GetOracle(), log)
+				rpcServer = newRPCServer
 				rpcServer.Start(errChan)
 			}
 		case <-grace.Done():
@@ -372,6 +391,31 @@ Main:
 	return nil
 }
 
+// reloadConfig applies runtime-tunable settings (logging levels, P2P peer
+// limits and quotas, relaying) from the given configuration without
+// restarting the node or dropping P2P connections.
+func reloadConfig(ctx *cli.Context, cfg config.Config, serv *network.Server, log *zap.Logger) {
+	if err := reloadLogLevels(ctx, log, cfg.ApplicationConfiguration); err != nil {
+		log.Error("failed to reload logging levels", zap.Error(err))
+	}
+	serv.ReloadConfig(network.NewServerConfig(cfg))
+}
+
+// reloadConsensusWallet uses consensus wallet from the given configuration
+// starting with the next block. It allows to rotate validator keys (or
+// replace the wallet file) without restarting the node.
+func reloadConsensusWallet(cfg config.Config, serv *network.Server, log *zap.Logger) {
+	w := cfg.ApplicationConfiguration.UnlockWallet
+	if w.Path == "" {
+		log.Error("consensus wallet can't be disabled without restart")
+		return
+	}
+	log.Info("reloading consensus wallet", zap.String("path", w.Path))
+	if err := serv.UpdateConsensusWallet(&w); err != nil {
+		log.Error("failed to reload consensus wallet", zap.Error(err))
+	}
+}
+
 // configureAddresses sets up addresses for RPC, Prometheus and Pprof depending from the provided config.
 // In case RPC or Prometheus or Pprof Address provided each of them will use it.
 // In case global Address (of the node) provided and RPC/Prometheus/Pprof don't have configured addresses they will
@@ -397,6 +441,9 @@ func initBlockChain(cfg config.Config, log *zap.Logger) (*core.Blockchain, error
 		return nil, cli.NewExitError(fmt.Errorf("could not initialize storage: %w", err), 1)
 	}
 
+	if log != nil {
+		log = log.Named(logCore)
+	}
 	chain, err := core.NewBlockchain(store, cfg.ProtocolConfiguration, log)
 	if err != nil {
 		return nil, cli.NewExitError(fmt.Errorf("could not initialize blockchain: %w", err), 1)
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestGetConfigFromContext(t *testing.T) {
@@ -26,6 +28,43 @@ func TestGetConfigFromContext(t *testing.T) {
 	require.Equal(t, netmode.TestNet, cfg.ProtocolConfiguration.Magic)
 }
 
+func TestGetConfigFromContextNetwork(t *testing.T) {
+	d, err := ioutil.TempDir("", "neogo-config")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+
+	cfgFile := filepath.Join(d, "protocol.yml")
+	require.NoError(t, ioutil.WriteFile(cfgFile, []byte(`ProtocolConfiguration:
+  Magic: 1
+ApplicationConfiguration:
+  NodePort: 20333
+Networks:
+  custom:
+    ProtocolConfiguration:
+      Magic: 42
+    ApplicationConfiguration:
+      NodePort: 30333
+`), 0644))
+
+	set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+	set.String("config-file", cfgFile, "")
+	set.String("network", "custom", "")
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+	cfg, err := getConfigFromContext(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, cfg.ProtocolConfiguration.Magic)
+	require.EqualValues(t, 30333, cfg.ApplicationConfiguration.NodePort)
+	require.NoError(t, validateConfig(ctx))
+
+	t.Run("network without config-file", func(t *testing.T) {
+		set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+		set.String("network", "custom", "")
+		ctx := cli.NewContext(cli.NewApp(), set, nil)
+		_, err := getConfigFromContext(ctx)
+		require.Error(t, err)
+	})
+}
+
 func TestHandleLoggingParams(t *testing.T) {
 	testLog, err := ioutil.TempFile("./", "*.log")
 	require.NoError(t, err)
@@ -57,6 +96,108 @@ func TestHandleLoggingParams(t *testing.T) {
 		require.True(t, logger.Core().Enabled(zap.InfoLevel))
 		require.True(t, logger.Core().Enabled(zap.DebugLevel))
 	})
+
+	t.Run("subsystems", func(t *testing.T) {
+		set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+		ctx := cli.NewContext(cli.NewApp(), set, nil)
+		cfg := config.ApplicationConfiguration{
+			LogPath: testLog.Name(),
+			Logger: config.Logger{
+				Level: "warn",
+				Levels: map[string]string{
+					logConsensus: "debug",
+					logRPC:       "error",
+				},
+				Encoding: "json",
+			},
+		}
+		logger, err := handleLoggingParams(ctx, cfg)
+		require.NoError(t, err)
+		check := func(log *zap.Logger, lvl zapcore.Level) {
+			require.NotNil(t, log.Check(lvl, "msg"))
+			if lvl > zapcore.DebugLevel {
+				require.Nil(t, log.Check(lvl-1, "msg"))
+			}
+		}
+		check(logger, zapcore.WarnLevel)
+		check(logger.Named(logCore), zapcore.WarnLevel)
+		check(logger.Named(logRPC), zapcore.ErrorLevel)
+		check(logger.Named(logNetwork), zapcore.WarnLevel)
+		check(logger.Named(logNetwork).Named(logConsensus), zapcore.DebugLevel)
+		check(logger.Named(logNetwork).Named(logConsensus).With(zap.Int("view", 1)), zapcore.DebugLevel)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+		ctx := cli.NewContext(cli.NewApp(), set, nil)
+		cfg := config.ApplicationConfiguration{
+			LogPath: testLog.Name(),
+		}
+		logger, err := handleLoggingParams(ctx, cfg)
+		require.NoError(t, err)
+		rpcLog := logger.Named(logRPC).With(zap.Int("id", 1))
+		require.Nil(t, rpcLog.Check(zapcore.DebugLevel, "msg"))
+
+		cfg.Logger.Levels = map[string]string{logRPC: "debug"}
+		require.NoError(t, reloadLogLevels(ctx, logger, cfg))
+		require.NotNil(t, rpcLog.Check(zapcore.DebugLevel, "msg"))
+		require.Nil(t, logger.Named(logCore).Check(zapcore.DebugLevel, "msg"))
+
+		cfg.Logger.Level = "verbose"
+		require.Error(t, reloadLogLevels(ctx, logger, cfg))
+		require.NotNil(t, rpcLog.Check(zapcore.DebugLevel, "msg"))
+	})
+
+	t.Run("bad config", func(t *testing.T) {
+		set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+		ctx := cli.NewContext(cli.NewApp(), set, nil)
+		for name, lc := range map[string]config.Logger{
+			"level":            {Level: "verbose"},
+			"subsystem":        {Levels: map[string]string{"vm": "info"}},
+			"subsystem level":  {Levels: map[string]string{logCore: "verbose"}},
+			"encoding":         {Encoding: "xml"},
+			"rotation no path": {MaxSize: 1},
+		} {
+			_, err := handleLoggingParams(ctx, config.ApplicationConfiguration{Logger: lc})
+			require.Error(t, err, name)
+		}
+	})
+}
+
+func TestRotatingFile(t *testing.T) {
+	d, err := ioutil.TempDir("", "neogo-log")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+
+	logPath := filepath.Join(d, "node.log")
+	f, err := newRotatingFile(logPath, 10, 2)
+	require.NoError(t, err)
+	check := func(path string, expected string) {
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data))
+	}
+	for _, s := range []string{"1234", "5678", "90", "abcdef", "ghijklmnopq", "rs"} {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Sync())
+	check(logPath, "rs")
+	check(logPath+".1", "ghijklmnopq")
+	check(logPath+".2", "abcdef")
+	_, err = os.Stat(logPath + ".3")
+	require.True(t, os.IsNotExist(err))
+	require.NoError(t, f.file.Close())
+
+	t.Run("existing file, no backups", func(t *testing.T) {
+		f, err := newRotatingFile(logPath, 5, 0)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("tuvw"))
+		require.NoError(t, err)
+		check(logPath, "tuvw")
+		check(logPath+".1", "ghijklmnopq")
+		require.NoError(t, f.file.Close())
+	})
 }
 
 func TestInitBCWithMetrics(t *testing.T) {
@@ -240,3 +381,26 @@ func TestInitBlockChain(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestConfigInit(t *testing.T) {
+	d, err := ioutil.TempDir("", "neogo-config")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+
+	for _, net := range []netmode.Magic{netmode.MainNet, netmode.TestNet, netmode.PrivNet} {
+		set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+		set.Bool(net.String(), true, "")
+		set.String("out", filepath.Join(d, "protocol."+net.String()+".yml"), "")
+		ctx := cli.NewContext(cli.NewApp(), set, nil)
+		require.NoError(t, initConfig(ctx))
+
+		set = flag.NewFlagSet("flagSet", flag.ExitOnError)
+		set.Bool(net.String(), true, "")
+		set.String("config-path", d, "")
+		ctx = cli.NewContext(cli.NewApp(), set, nil)
+		require.NoError(t, validateConfig(ctx))
+		cfg, err := getConfigFromContext(ctx)
+		require.NoError(t, err)
+		require.Equal(t, net, cfg.ProtocolConfiguration.Magic)
+	}
+}
@@ -0,0 +1,55 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/urfave/cli"
+)
+
+// generateWrapper writes Go wrapper for the contract with the given manifest.
+func generateWrapper(ctx *cli.Context) error {
+	h := ctx.Generic("hash").(*flags.Address)
+	if !h.IsSet {
+		return cli.NewExitError("contract hash is not set", 1)
+	}
+	mpath := ctx.String("manifest")
+	if mpath == "" {
+		return cli.NewExitError(errors.New("no manifest file provided"), 1)
+	}
+	manifestBytes, err := ioutil.ReadFile(mpath)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+	}
+	m := &manifest.Manifest{}
+	err = json.Unmarshal(manifestBytes, m)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+	}
+
+	var out io.Writer = ctx.App.Writer
+	if name := ctx.String("out"); name != "" {
+		f, err := os.Create(name)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer f.Close()
+		out = f
+	}
+	err = binding.Generate(binding.Config{
+		Manifest: m,
+		Hash:     h.Uint160(),
+		Package:  ctx.String("package"),
+	}, out)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to generate wrapper: %w", err), 1)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package smartcontract
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// permission is a YAML representation of manifest.Permission. Contract is
+// specified either with `hash` or with `group` key (wildcard is used if
+// there are none) and methods are either a list of names or a "*" wildcard
+// (which is also used if methods aren't specified).
+type permission manifest.Permission
+
+type permissionAux struct {
+	Hash    string      `yaml:"hash,omitempty"`
+	Group   string      `yaml:"group,omitempty"`
+	Methods interface{} `yaml:"methods,omitempty"`
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (p permission) MarshalYAML() (interface{}, error) {
+	aux := permissionAux{}
+	switch p.Contract.Type {
+	case manifest.PermissionHash:
+		aux.Hash = "0x" + p.Contract.Hash().StringLE()
+	case manifest.PermissionGroup:
+		aux.Group = hex.EncodeToString(p.Contract.Group().Bytes())
+	}
+	if p.Methods.IsWildcard() {
+		aux.Methods = "*"
+	} else {
+		aux.Methods = p.Methods.Value
+	}
+	return aux, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (p *permission) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	aux := permissionAux{}
+	if err := unmarshal(&aux); err != nil {
+		return err
+	}
+	switch {
+	case aux.Hash != "" && aux.Group != "":
+		return errors.New("permission can't have both hash and group")
+	case aux.Hash != "":
+		d, err := parsePermissionDesc(aux.Hash)
+		if err != nil || d.Type != manifest.PermissionHash {
+			return fmt.Errorf("invalid permission hash: %s", aux.Hash)
+		}
+		p.Contract = d
+	case aux.Group != "":
+		d, err := parsePermissionDesc(aux.Group)
+		if err != nil || d.Type != manifest.PermissionGroup {
+			return fmt.Errorf("invalid permission group: %s", aux.Group)
+		}
+		p.Contract = d
+	default:
+		p.Contract = manifest.PermissionDesc{Type: manifest.PermissionWildcard}
+	}
+
+	p.Methods = manifest.WildStrings{}
+	switch ms := aux.Methods.(type) {
+	case nil:
+	case string:
+		if ms != "*" {
+			return fmt.Errorf("invalid permission methods: %s", ms)
+		}
+	case []interface{}:
+		p.Methods.Restrict()
+		for i := range ms {
+			s, ok := ms[i].(string)
+			if !ok {
+				return errors.New("permission method name must be a string")
+			}
+			p.Methods.Add(s)
+		}
+	default:
+		return errors.New("invalid permission methods")
+	}
+	return nil
+}
+
+// parsePermissionDesc parses hash, public key or wildcard string into
+// manifest.PermissionDesc.
+func parsePermissionDesc(s string) (manifest.PermissionDesc, error) {
+	var d manifest.PermissionDesc
+	data, err := json.Marshal(s)
+	if err != nil {
+		return d, err
+	}
+	err = d.UnmarshalJSON(data)
+	return d, err
+}
+
+// permissionDesc is a YAML representation of manifest.PermissionDesc, it's
+// a hash, a public key or a "*" wildcard string.
+type permissionDesc manifest.PermissionDesc
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (d permissionDesc) MarshalYAML() (interface{}, error) {
+	data, err := (*manifest.PermissionDesc)(&d).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var s string
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (d *permissionDesc) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	pd, err := parsePermissionDesc(s)
+	if err != nil {
+		return fmt.Errorf("invalid contract descriptor %s: %w", s, err)
+	}
+	*d = permissionDesc(pd)
+	return nil
+}
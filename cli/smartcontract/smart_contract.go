@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/cli/flags"
@@ -67,6 +70,10 @@ var (
 )
 
 const (
+	// defaultConfigFile is the name of contract configuration file used by
+	// `compile` command if it's not specified explicitly.
+	defaultConfigFile = "contract.yml"
+
 	// smartContractTmpl is written to a file when used with `init` command.
 	// %s is parsed to be the smartContractName.
 	smartContractTmpl = `package %s
@@ -86,8 +93,9 @@ func RuntimeNotify(args []interface{}) {
 }`
 )
 
-// NewCommands returns 'contract' command.
-func NewCommands() []cli.Command {
+// NewCommands returns 'contract' command, extra commands are added to its
+// subcommands.
+func NewCommands(extra ...cli.Command) []cli.Command {
 	testInvokeScriptFlags := []cli.Flag{
 		cli.StringFlag{
 			Name:  "in, i",
@@ -112,8 +120,12 @@ func NewCommands() []cli.Command {
 			Name:  "manifest, m",
 			Usage: "Manifest input file (*.manifest.json)",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Only calculate deployment fee, don't send the transaction",
+		},
 	}...)
-	return []cli.Command{{
+	cmd := cli.Command{
 		Name:  "contract",
 		Usage: "compile - debug - deploy smart contracts",
 		Subcommands: []cli.Command{
@@ -144,7 +156,7 @@ func NewCommands() []cli.Command {
 					},
 					cli.StringFlag{
 						Name:  "config, c",
-						Usage: "Configuration input file (*.yml)",
+						Usage: "Configuration input file (*.yml), " + defaultConfigFile + " from the source directory is used by default",
 					},
 					cli.BoolFlag{
 						Name:  "no-standards",
@@ -154,15 +166,29 @@ func NewCommands() []cli.Command {
 						Name:  "no-events",
 						Usage: "do not check emitted events with the manifest",
 					},
+					cli.BoolFlag{
+						Name:  "no-optimize",
+						Usage: "do not optimize the resulting bytecode",
+					},
+					cli.BoolFlag{
+						Name:  "no-tests",
+						Usage: "do not compile _test.go files of the contract package",
+					},
 				},
 			},
 			{
 				Name:      "deploy",
 				Usage:     "deploy a smart contract (.nef with description)",
-				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [-g gas] --in contract.nef --manifest contract.manifest.json [--out file] [--force] [data]",
+				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [-g gas] --in contract.nef --manifest contract.manifest.json [--out file] [--force] [--dry-run] [data]",
 				Description: `Deploys given contract into the chain. The gas parameter is for additional
    gas to be added as a network fee to prioritize the transaction. The data 
    parameter is an optional parameter to be passed to '_deploy' method.
+   Deployment fee is the storage price (set by the Policy contract) multiplied
+   by NEF and manifest size, but not less than the minimum deployment fee of
+   the ContractManagement contract. It's a part of the transaction system fee
+   and is burned like any other system fee. With --dry-run flag the fee and
+   the total system fee are printed without sending the transaction (wallet
+   is not required in this case).
 `,
 				Action: contractDeploy,
 				Flags:  deployFlags,
@@ -331,8 +357,23 @@ func NewCommands() []cli.Command {
 						Name:  "in, i",
 						Usage: "input file of the program (either .go or .nef)",
 					},
+					cli.BoolFlag{
+						Name:  "analyze, a",
+						Usage: "print syscalls, contract calls and jump targets used by the program instead of instructions",
+					},
 				},
 			},
+			{
+				Name:      "interops",
+				Usage:     "list syscalls supported by the node along with their prices",
+				UsageText: "neo-go contract interops -r endpoint",
+				Description: `Prints IDs, names, prices and required call flags of all syscalls
+   supported by the given RPC node. Prices are given both in execution fee
+   units and in GAS (with the current execution fee factor applied).
+`,
+				Action: listInterops,
+				Flags:  options.RPC,
+			},
 			{
 				Name:   "calc-hash",
 				Usage:  "calculates hash of a contract after deployment",
@@ -352,8 +393,40 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "generate-wrapper",
+				Usage:     "generate Go RPC wrapper for the contract",
+				UsageText: "neo-go contract generate-wrapper --manifest <file.json> --hash <hash> [--package <name>] [--out <file.go>]",
+				Description: `Generates Go package with typed methods for all public methods of the
+   contract described by the given manifest. Safe methods are invoked in test
+   mode and return converted results, for every other method two functions
+   are generated: one creating a transaction (Transaction suffix) and one
+   creating, signing and sending it. Hash of the deployed contract is required.
+`,
+				Action: generateWrapper,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "manifest, m",
+						Usage: "path to manifest file",
+					},
+					flags.AddressFlag{
+						Name:  "hash",
+						Usage: "script hash or address of the deployed contract",
+					},
+					cli.StringFlag{
+						Name:  "package",
+						Usage: "name of generated package (derived from contract name by default)",
+					},
+					cli.StringFlag{
+						Name:  "out, o",
+						Usage: "output file (stdout if not given)",
+					},
+				},
+			},
 		},
-	}}
+	}
+	cmd.Subcommands = append(cmd.Subcommands, extra...)
+	return []cli.Command{cmd}
 }
 
 // initSmartContract initializes a given directory with some boiler plate code.
@@ -418,6 +491,9 @@ func contractCompile(ctx *cli.Context) error {
 	}
 	manifestFile := ctx.String("manifest")
 	confFile := ctx.String("config")
+	if len(confFile) == 0 {
+		confFile = findContractConfig(src)
+	}
 	debugFile := ctx.String("debug")
 	if len(confFile) == 0 && (len(manifestFile) != 0 || len(debugFile) != 0) {
 		return cli.NewExitError(errNoConfFile, 1)
@@ -431,6 +507,8 @@ func contractCompile(ctx *cli.Context) error {
 
 		NoStandardCheck: ctx.Bool("no-standards"),
 		NoEventsCheck:   ctx.Bool("no-events"),
+		NoOptimize:      ctx.Bool("no-optimize"),
+		NoTests:         ctx.Bool("no-tests"),
 	}
 
 	if len(confFile) != 0 {
@@ -438,10 +516,7 @@ func contractCompile(ctx *cli.Context) error {
 		if err != nil {
 			return err
 		}
-		o.Name = conf.Name
-		o.ContractEvents = conf.Events
-		o.ContractSupportedStandards = conf.SupportedStandards
-		o.SafeMethods = conf.SafeMethods
+		conf.Apply(o)
 	}
 
 	result, err := compiler.CompileAndSave(src, o)
@@ -455,6 +530,20 @@ func contractCompile(ctx *cli.Context) error {
 	return nil
 }
 
+// findContractConfig returns the path to the default configuration file
+// located next to the contract sources or an empty string if there is none.
+func findContractConfig(src string) string {
+	dir := src
+	if fi, err := os.Stat(src); err == nil && !fi.IsDir() {
+		dir = filepath.Dir(src)
+	}
+	p := filepath.Join(dir, defaultConfigFile)
+	if fi, err := os.Stat(p); err == nil && !fi.IsDir() {
+		return p
+	}
+	return ""
+}
+
 func calcHash(ctx *cli.Context) error {
 	sender := ctx.Generic("sender").(*flags.Address)
 	if !sender.IsSet {
@@ -665,6 +754,25 @@ type ProjectConfig struct {
 	SafeMethods        []string
 	SupportedStandards []string
 	Events             []manifest.Event
+	Permissions        []permission     `yaml:",omitempty"`
+	Trusts             []permissionDesc `yaml:",omitempty"`
+}
+
+// Apply sets manifest-related compiler options according to the
+// configuration.
+func (c *ProjectConfig) Apply(o *compiler.Options) {
+	o.Name = c.Name
+	o.ContractEvents = c.Events
+	o.ContractSupportedStandards = c.SupportedStandards
+	o.SafeMethods = c.SafeMethods
+	o.Permissions = make([]manifest.Permission, len(c.Permissions))
+	for i := range c.Permissions {
+		o.Permissions[i] = manifest.Permission(c.Permissions[i])
+	}
+	o.Trusts = make([]manifest.PermissionDesc, len(c.Trusts))
+	for i := range c.Trusts {
+		o.Trusts[i] = manifest.PermissionDesc(c.Trusts[i])
+	}
 }
 
 func inspect(ctx *cli.Context) error {
@@ -674,8 +782,9 @@ func inspect(ctx *cli.Context) error {
 		return cli.NewExitError(errNoInput, 1)
 	}
 	var (
-		b   []byte
-		err error
+		b      []byte
+		tokens []nef.MethodToken
+		err    error
 	)
 	if compile {
 		b, err = compiler.Compile(in, nil)
@@ -692,6 +801,15 @@ func inspect(ctx *cli.Context) error {
 			return cli.NewExitError(fmt.Errorf("failed to restore .nef file: %w", err), 1)
 		}
 		b = nefFile.Script
+		tokens = nefFile.Tokens
+	}
+	if ctx.Bool("analyze") {
+		info, err := vm.Parse(b)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to parse the program: %w", err), 1)
+		}
+		printScriptInfo(ctx.App.Writer, info, tokens)
+		return nil
 	}
 	v := vm.New()
 	v.LoadScript(b)
@@ -700,6 +818,68 @@ func inspect(ctx *cli.Context) error {
 	return nil
 }
 
+func listInterops(ctx *cli.Context) error {
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	fs, err := c.GetInterops()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPRICE\tGAS\tFLAGS")
+	for _, f := range fs {
+		fmt.Fprintf(w, "%08x\t%s\t%d\t%s\t%s\n", f.ID, f.Name, f.Price,
+			fixedn.Fixed8(f.ExecutionFee).String(), f.RequiredFlags)
+	}
+	return w.Flush()
+}
+
+// printScriptInfo prints the results of static program analysis, tokens are
+// used to describe CALLT instructions if available.
+func printScriptInfo(out io.Writer, info *vm.ScriptInfo, tokens []nef.MethodToken) {
+	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "SYSCALLS:")
+	for _, s := range info.Syscalls {
+		name := s.Name
+		if name == "" {
+			name = "unknown"
+		}
+		fmt.Fprintf(w, "\t%s (%08x)\t%s\n", name, s.ID, joinOffsets(s.Offsets))
+	}
+	fmt.Fprintln(w, "CONTRACT CALLS:")
+	for _, c := range info.ContractCalls {
+		method := c.Method
+		if method == "" {
+			method = "<unknown>"
+		}
+		fmt.Fprintf(w, "\t%s\t%s\t%d\n", c.Hash.StringLE(), method, c.Offset)
+	}
+	fmt.Fprintln(w, "METHOD TOKENS:")
+	for _, id := range info.Tokens {
+		if int(id) < len(tokens) {
+			fmt.Fprintf(w, "\t%d\t%s\t%s\n", id, tokens[id].Hash.StringLE(), tokens[id].Method)
+		} else {
+			fmt.Fprintf(w, "\t%d\t<unknown>\t\n", id)
+		}
+	}
+	fmt.Fprintf(w, "METHODS:\t%s\n", joinOffsets(info.Methods))
+	fmt.Fprintf(w, "JUMP TARGETS:\t%s\n", joinOffsets(info.JumpTargets))
+	w.Flush()
+}
+
+func joinOffsets(offsets []int) string {
+	ss := make([]string, len(offsets))
+	for i := range offsets {
+		ss[i] = strconv.Itoa(offsets[i])
+	}
+	return strings.Join(ss, ", ")
+}
+
 func getAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error) {
 	var addr util.Uint160
 
@@ -800,6 +980,9 @@ func contractDeploy(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("failed to get management contract's hash: %w", err), 1)
 	}
+	if ctx.Bool("dry-run") {
+		return printDeploymentFee(ctx, c, mgmtHash, appCallParams, len(f)+len(manifestBytes))
+	}
 	sender, extErr := invokeWithArgs(ctx, true, mgmtHash, "deploy", appCallParams, nil)
 	if extErr != nil {
 		return extErr
@@ -810,6 +993,35 @@ func contractDeploy(ctx *cli.Context) error {
 	return nil
 }
 
+// printDeploymentFee calculates the fee ContractManagement charges for the
+// deployment of a contract with the given NEF and manifest size and test
+// invokes deployment to get the resulting system fee.
+func printDeploymentFee(ctx *cli.Context, c *client.Client, mgmtHash util.Uint160, params []smartcontract.Parameter, size int) error {
+	storagePrice, err := c.GetStoragePrice()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get storage price: %w", err), 1)
+	}
+	minFee, err := c.GetMinimumDeploymentFee()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get minimum deployment fee: %w", err), 1)
+	}
+	fee := storagePrice * int64(size)
+	if fee < minFee {
+		fee = minFee
+	}
+	resp, err := c.InvokeFunction(mgmtHash, "deploy", params, nil)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if resp.State != "HALT" {
+		return cli.NewExitError(fmt.Errorf("deployment failed: %s VM state returned from the RPC node: %s", resp.State, resp.FaultException), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Deployment fee: %s GAS (%d bytes, storage price %s, minimum %s)\n",
+		fixedn.Fixed8(fee), size, fixedn.Fixed8(storagePrice), fixedn.Fixed8(minFee))
+	fmt.Fprintf(ctx.App.Writer, "System fee: %s GAS\n", fixedn.Fixed8(resp.GasConsumed))
+	return nil
+}
+
 // ParseContractConfig reads contract configuration file (.yaml) and returns unmarshalled ProjectConfig.
 func ParseContractConfig(confFile string) (ProjectConfig, error) {
 	conf := ProjectConfig{}
@@ -4,10 +4,15 @@ import (
 	"flag"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
 )
 
 func TestInitSmartContract(t *testing.T) {
@@ -66,3 +71,68 @@ events:
     type: Array
 `, string(manifest))
 }
+
+const testProjectConfig = `name: Test
+permissions:
+  - hash: 0x0000000000000000000000000000000000030201
+    methods: ["transfer"]
+  - group: 02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2
+    methods: '*'
+  - methods: ["onNEP17Payment"]
+trusts:
+  - 0x0000000000000000000000000000000000030201
+`
+
+func TestProjectConfig(t *testing.T) {
+	d, err := ioutil.TempDir("", "neogo-contract-config")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+
+	require.Equal(t, "", findContractConfig(d))
+	cfgPath := filepath.Join(d, defaultConfigFile)
+	require.NoError(t, ioutil.WriteFile(cfgPath, []byte(testProjectConfig), 0644))
+	require.Equal(t, cfgPath, findContractConfig(d))
+	mainPath := filepath.Join(d, "main.go")
+	require.NoError(t, ioutil.WriteFile(mainPath, []byte("package main"), 0644))
+	require.Equal(t, cfgPath, findContractConfig(mainPath))
+
+	conf, err := ParseContractConfig(cfgPath)
+	require.NoError(t, err)
+	o := new(compiler.Options)
+	conf.Apply(o)
+	require.Equal(t, "Test", o.Name)
+	require.Equal(t, 3, len(o.Permissions))
+
+	h := util.Uint160{1, 2, 3}
+	require.Equal(t, manifest.PermissionHash, o.Permissions[0].Contract.Type)
+	require.Equal(t, h, o.Permissions[0].Contract.Hash())
+	require.Equal(t, []string{"transfer"}, o.Permissions[0].Methods.Value)
+	require.Equal(t, manifest.PermissionGroup, o.Permissions[1].Contract.Type)
+	require.True(t, o.Permissions[1].Methods.IsWildcard())
+	require.Equal(t, manifest.PermissionWildcard, o.Permissions[2].Contract.Type)
+	require.Equal(t, []string{"onNEP17Payment"}, o.Permissions[2].Methods.Value)
+	require.Equal(t, []manifest.PermissionDesc{o.Permissions[0].Contract}, o.Trusts)
+
+	t.Run("marshal", func(t *testing.T) {
+		b, err := yaml.Marshal(conf)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(cfgPath, b, 0644))
+		actual, err := ParseContractConfig(cfgPath)
+		require.NoError(t, err)
+		require.Equal(t, conf.Permissions, actual.Permissions)
+		require.Equal(t, conf.Trusts, actual.Trusts)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, c := range []string{
+			"permissions:\n  - hash: 0x01\n",
+			"permissions:\n  - group: 0x0000000000000000000000000000000000030201\n",
+			"permissions:\n  - hash: 0x0000000000000000000000000000000000030201\n    group: 02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2\n",
+			"permissions:\n  - methods: transfer\n",
+			"trusts:\n  - bad\n",
+		} {
+			require.NoError(t, ioutil.WriteFile(cfgPath, []byte(c), 0644))
+			_, err := ParseContractConfig(cfgPath)
+			require.Error(t, err, c)
+		}
+	})
+}
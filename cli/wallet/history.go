@@ -0,0 +1,227 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+// historyPageSize is the number of transfers requested at once, it's the
+// maximum allowed by neo-go RPC server.
+const historyPageSize = 1000
+
+// historyMaxTimestamp is the upper bound of transfers time frame, it's not
+// limited by the local clock that can be behind the node's one. It's the
+// maximum integer that is passed via JSON number without precision loss.
+const historyMaxTimestamp = 1 << 53
+
+// historyEntry is a set of account transfers made by a single transaction
+// (or block, for transfers made by OnPersist/PostPersist).
+type historyEntry struct {
+	timestamp uint64
+	index     uint32
+	hash      util.Uint256
+	transfers []historyTransfer
+}
+
+type historyTransfer struct {
+	result.NEP17Transfer
+	received bool
+}
+
+func historyCommand() cli.Command {
+	flgs := []cli.Flag{
+		walletPathFlag,
+		flags.AddressFlag{
+			Name:  "address, a",
+			Usage: "Address to print history for (all wallet accounts by default)",
+		},
+	}
+	flgs = append(flgs, options.RPC...)
+	return cli.Command{
+		Name:      "history",
+		Usage:     "print NEP-17 transfers history of wallet accounts",
+		UsageText: "history -w <path> -r <rpc> [-a <address>]",
+		Description: `Retrieves all NEP-17 transfers of wallet accounts (or the given one)
+   from the RPC node and prints them in chronological order grouped by
+   transaction (or block for transfers made by native contracts when
+   persisting it) along with execution state and GAS consumed taken from
+   the application log (if it's available on the node). Token symbols and
+   decimals are taken from the wallet or requested from the node.
+`,
+		Action: printHistory,
+		Flags:  flgs,
+	}
+}
+
+func printHistory(ctx *cli.Context) error {
+	wall, err := openWallet(ctx.String("wallet"))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("bad wallet: %w", err), 1)
+	}
+	defer wall.Close()
+
+	var accounts []*wallet.Account
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if addrFlag.IsSet {
+		acc := wall.GetAccount(addrFlag.Uint160())
+		if acc == nil {
+			return cli.NewExitError(fmt.Errorf("can't find account for the address: %s", address.Uint160ToString(addrFlag.Uint160())), 1)
+		}
+		accounts = append(accounts, acc)
+	} else {
+		if len(wall.Accounts) == 0 {
+			return cli.NewExitError(errors.New("no accounts in the wallet"), 1)
+		}
+		accounts = wall.Accounts
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	tokens := make(map[util.Uint160]*wallet.Token)
+	for k, acc := range accounts {
+		entries, err := getHistory(c, acc.Address)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if k != 0 {
+			fmt.Fprintln(ctx.App.Writer)
+		}
+		fmt.Fprintf(ctx.App.Writer, "Account %s\n", acc.Address)
+		for _, e := range entries {
+			fmt.Fprintf(ctx.App.Writer, "%s  #%d  %s%s\n",
+				time.Unix(0, int64(e.timestamp)*int64(time.Millisecond)).UTC().Format(time.RFC3339),
+				e.index, e.hash.StringLE(), describeExecution(c, e.hash))
+			for _, tr := range e.transfers {
+				tok := getHistoryToken(ctx, c, wall, tokens, tr.Asset)
+				fmt.Fprintf(ctx.App.Writer, "\t%s\n", formatTransfer(tok, tr))
+			}
+		}
+	}
+	return nil
+}
+
+// getHistory retrieves all NEP-17 transfers of the account and groups them
+// by transaction (block) in chronological order.
+func getHistory(c *client.Client, addr string) ([]*historyEntry, error) {
+	var (
+		start   uint64
+		stop    = uint64(historyMaxTimestamp)
+		limit   = historyPageSize
+		entries []*historyEntry
+		byHash  = make(map[util.Uint256]*historyEntry)
+	)
+	add := func(trs []result.NEP17Transfer, received bool) {
+		for _, tr := range trs {
+			e, ok := byHash[tr.TxHash]
+			if !ok {
+				e = &historyEntry{timestamp: tr.Timestamp, index: tr.Index, hash: tr.TxHash}
+				byHash[tr.TxHash] = e
+				entries = append(entries, e)
+			}
+			e.transfers = append(e.transfers, historyTransfer{NEP17Transfer: tr, received: received})
+		}
+	}
+	for page := 0; ; page++ {
+		p := page
+		trs, err := c.GetNEP17TransfersMs(addr, &start, &stop, &limit, &p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transfers of %s: %w", addr, err)
+		}
+		add(trs.Sent, false)
+		add(trs.Received, true)
+		if len(trs.Sent)+len(trs.Received) < limit {
+			break
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].index != entries[j].index {
+			return entries[i].index < entries[j].index
+		}
+		return entries[i].timestamp < entries[j].timestamp
+	})
+	return entries, nil
+}
+
+// describeExecution returns execution details from the application log of
+// the given container or empty string if it's not available.
+func describeExecution(c *client.Client, h util.Uint256) string {
+	log, err := c.GetApplicationLog(h, nil)
+	if err != nil {
+		return ""
+	}
+	// Transaction log only has Application trigger execution, block one
+	// has OnPersist and PostPersist ones.
+	for _, ex := range log.Executions {
+		if ex.Trigger == trigger.Application {
+			return fmt.Sprintf("  %s, GAS consumed: %s", ex.VMState, fixedn.Fixed8(ex.GasConsumed))
+		}
+	}
+	if len(log.Executions) != 0 {
+		triggers := make([]string, len(log.Executions))
+		for i := range log.Executions {
+			triggers[i] = log.Executions[i].Trigger.String()
+		}
+		return "  (block, " + strings.Join(triggers, ", ") + ")"
+	}
+	return ""
+}
+
+// getHistoryToken returns token info from the wallet or from the node (nil
+// if it can't be retrieved) caching the result.
+func getHistoryToken(ctx *cli.Context, c *client.Client, w *wallet.Wallet, cache map[util.Uint160]*wallet.Token, h util.Uint160) *wallet.Token {
+	if tok, ok := cache[h]; ok {
+		return tok
+	}
+	tok, err := getMatchingToken(ctx, w, h.StringLE(), manifest.NEP17StandardName)
+	if err != nil {
+		tok, err = c.NEP17TokenInfo(h)
+		if err != nil {
+			tok = nil
+		}
+	}
+	cache[h] = tok
+	return tok
+}
+
+// formatTransfer returns human-readable transfer description.
+func formatTransfer(tok *wallet.Token, tr historyTransfer) string {
+	amount, symbol := tr.Amount, "UNKNOWN ("+tr.Asset.StringLE()+")"
+	if tok != nil {
+		symbol = tok.Symbol
+		if b, ok := new(big.Int).SetString(tr.Amount, 10); ok {
+			amount = fixedn.ToString(b, int(tok.Decimals))
+		}
+	}
+	var sign, dir, other string
+	if tr.received {
+		sign, dir, other = "+", "from", "(mint)"
+	} else {
+		sign, dir, other = "-", "to", "(burn)"
+	}
+	if tr.Address != "" {
+		other = tr.Address
+	}
+	return fmt.Sprintf("%s%s %s %s %s", sign, amount, symbol, dir, other)
+}
@@ -1,16 +1,335 @@
 package wallet
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/urfave/cli"
 )
 
+func newMultisigCommands() []cli.Command {
+	signFlags := []cli.Flag{
+		walletPathFlag,
+		outFlag,
+		inFlag,
+		flags.AddressFlag{
+			Name:  "address, a",
+			Usage: "Multisignature account address to sign with",
+		},
+		cli.BoolFlag{
+			Name:  "notary",
+			Usage: "Send signature to the network via P2P notary request (requires RPC endpoint)",
+		},
+		cli.UintFlag{
+			Name:  "fallback-valid-for",
+			Usage: "Number of blocks fallback transaction is valid for (maximum allowed by Notary contract if not set)",
+		},
+	}
+	signFlags = append(signFlags, options.RPC...)
+	newFlags := []cli.Flag{
+		walletPathFlag,
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "Optional account name",
+		},
+		cli.IntFlag{
+			Name:  "min, m",
+			Usage: "Minimal number of signatures",
+		},
+		cli.BoolFlag{
+			Name:  "committee",
+			Usage: "Use the current committee keys retrieved via RPC",
+		},
+	}
+	newFlags = append(newFlags, options.RPC...)
+	return []cli.Command{
+		{
+			Name:  "new",
+			Usage: "create new multisignature account using one of the wallet keys",
+			UsageText: "new --wallet <path> [--name <account_name>]" +
+				" {--min <n> <pubkey1> [<pubkey2> [...]] | --committee -r <endpoint>}",
+			Description: `Creates multisignature account with the given keys, one of them must
+   belong to some standard account of the wallet. With --committee the keys
+   of the current committee are retrieved from the RPC node and the account
+   is created with the majority of them required to sign, that's the account
+   native contracts check witness of for committee-only operations (like
+   Policy contract settings changes or role designation).
+`,
+			Action: newMultisig,
+			Flags:  newFlags,
+		},
+		{
+			Name:  "sign",
+			Usage: "sign transaction with multisignature account",
+			UsageText: "sign --wallet <path> --address <address> --in <file.in> [--out <file.out>]" +
+				" [-r <endpoint> [--notary [--fallback-valid-for <blocks>]]]",
+			Description: `Signs transaction from the given context file with the key of
+   multisignature account. Without --notary it works the same way as
+   'wallet sign' does. With --notary the transaction with a single signature
+   is sent to the node via P2P notary request, so the notary service collects
+   signatures from all cosignatories and sends complete transaction to the
+   network. The transaction must have native Notary contract as a signer with
+   None scope and NotaryAssisted attribute with the number of multisignature
+   account keys. Fallback transaction is paid for by the standard account of
+   the same key, it must have enough GAS deposited to the Notary contract.
+`,
+			Action: signMultisig,
+			Flags:  signFlags,
+		},
+	}
+}
+
+func newMultisig(ctx *cli.Context) error {
+	wall, err := openWallet(ctx.String("wallet"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	var (
+		pubs keys.PublicKeys
+		min  = ctx.Int("min")
+	)
+	if ctx.Bool("committee") {
+		pubs, err = getCommitteeKeys(ctx)
+		if err == nil {
+			min = smartcontract.GetMajorityHonestNodeCount(len(pubs))
+		}
+	} else {
+		pubs, err = getMultisigKeys(ctx)
+	}
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	own := findMultisigMember(wall, pubs)
+	if own == nil {
+		return cli.NewExitError(errors.New("wallet doesn't contain any of the given keys"), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Using key of %s account.\n", own.Address)
+	own, err = getDecryptedAccount(ctx, wall, own.Contract.ScriptHash())
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	acc := wallet.NewAccountFromPrivateKey(own.PrivateKey())
+	acc.EncryptedWIF = own.EncryptedWIF
+	if err := acc.ConvertMultisig(min, pubs); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc.Label = ctx.String("name")
+	if err := addAccountAndSave(wall, acc); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, acc.Address)
+	return nil
+}
+
+// getMultisigKeys returns public keys passed as command arguments checking
+// that there are at least `min` of them.
+func getMultisigKeys(ctx *cli.Context) (keys.PublicKeys, error) {
+	if ctx.NArg() < ctx.Int("min") {
+		return nil, errors.New("insufficient number of public keys")
+	}
+	args := []string(ctx.Args())
+	pubs := make(keys.PublicKeys, len(args))
+	for i := range args {
+		var err error
+		pubs[i], err = keys.NewPublicKeyFromString(args[i])
+		if err != nil {
+			return nil, fmt.Errorf("can't decode public key %d: %w", i, err)
+		}
+	}
+	return pubs, nil
+}
+
+// getCommitteeKeys returns the current committee keys retrieved via RPC.
+func getCommitteeKeys(ctx *cli.Context) (keys.PublicKeys, error) {
+	if ctx.NArg() != 0 || ctx.IsSet("min") {
+		return nil, errors.New("neither public keys nor minimum number of signatures can be specified for committee account")
+	}
+	if len(ctx.String(options.RPCEndpointFlag)) == 0 {
+		return nil, errors.New("RPC endpoint is required to get committee keys")
+	}
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return nil, exitErr
+	}
+	pubs, err := c.GetCommittee()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get committee: %w", err)
+	}
+	return pubs, nil
+}
+
+// findMultisigMember returns standard wallet account with a key from the
+// given list.
+func findMultisigMember(wall *wallet.Wallet, pubs keys.PublicKeys) *wallet.Account {
+	for _, acc := range wall.Accounts {
+		if acc.Contract == nil || acc.EncryptedWIF == "" {
+			continue
+		}
+		pub, ok := vm.ParseSignatureContract(acc.Contract.Script)
+		if !ok {
+			continue
+		}
+		for i := range pubs {
+			if bytes.Equal(pub, pubs[i].Bytes()) {
+				return acc
+			}
+		}
+	}
+	return nil
+}
+
+func signMultisig(ctx *cli.Context) error {
+	if !ctx.Bool("notary") {
+		return signStoredTransaction(ctx)
+	}
+	if len(ctx.String(options.RPCEndpointFlag)) == 0 {
+		return cli.NewExitError("RPC endpoint is required to send notary request", 1)
+	}
+	wall, err := openWallet(ctx.String("wallet"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return cli.NewExitError("verifiable item is not a transaction", 1)
+	}
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if !addrFlag.IsSet {
+		return cli.NewExitError("address was not provided", 1)
+	}
+	acc, err := getDecryptedAccount(ctx, wall, addrFlag.Uint160())
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	_, pubs, ok := vm.ParseMultiSigContract(acc.Contract.Script)
+	if !ok {
+		return cli.NewExitError(fmt.Errorf("%s is not a multisignature account", acc.Address), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if c.GetNetwork() != pc.Network {
+		return cli.NewExitError(fmt.Errorf("transaction is for network %d, but RPC node is from %d", pc.Network, c.GetNetwork()), 1)
+	}
+	notaryHash, err := c.GetNativeContractHash(nativenames.Notary)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get native Notary hash: %w", err), 1)
+	}
+	ch := acc.Contract.ScriptHash()
+	if err := checkNotaryTx(tx, notaryHash, ch, len(pubs)); err != nil {
+		return cli.NewExitError(fmt.Errorf("transaction can't be signed via notary: %w", err), 1)
+	}
+
+	priv := acc.PrivateKey()
+	sign := priv.SignHashable(uint32(pc.Network), tx)
+	if err := pc.AddSignature(ch, acc.Contract, priv.PublicKey(), sign); err != nil {
+		return cli.NewExitError(fmt.Errorf("can't add signature: %w", err), 1)
+	}
+	if out := ctx.String("out"); out != "" {
+		if err := paramcontext.Save(pc, out); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+
+	tx.Scripts = make([]transaction.Witness, len(tx.Signers))
+	for i := range tx.Signers {
+		switch tx.Signers[i].Account {
+		case notaryHash:
+			// Dummy witness to be replaced by notary service.
+			tx.Scripts[i] = transaction.Witness{
+				InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), 64}, make([]byte, 64)...),
+				VerificationScript: []byte{},
+			}
+		case ch:
+			tx.Scripts[i] = transaction.Witness{
+				InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), 64}, sign...),
+				VerificationScript: acc.Contract.Script,
+			}
+		default:
+			w, err := pc.GetWitness(tx.Signers[i].Account)
+			if err != nil {
+				return cli.NewExitError(fmt.Errorf("no witness for signer #%d: %w", i, err), 1)
+			}
+			tx.Scripts[i] = *w
+		}
+	}
+
+	validFor := uint32(ctx.Uint("fallback-valid-for"))
+	if validFor == 0 {
+		delta, err := c.GetMaxNotValidBeforeDelta()
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to get MaxNotValidBeforeDelta: %w", err), 1)
+		}
+		validFor = uint32(delta)
+	}
+	payer := wallet.NewAccountFromPrivateKey(priv)
+	req, err := c.SignAndPushP2PNotaryRequest(tx, []byte{byte(opcode.RET)}, -1, 0, validFor, payer)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Main transaction: %s\n", tx.Hash().StringLE())
+	fmt.Fprintf(ctx.App.Writer, "Fallback transaction: %s\n", req.FallbackTransaction.Hash().StringLE())
+	return nil
+}
+
+// checkNotaryTx checks that tx can be completed by the notary service
+// collecting signatures for multisignature signer with the given hash.
+func checkNotaryTx(tx *transaction.Transaction, notaryHash, signer util.Uint160, nKeys int) error {
+	var notaryFound bool
+	for i := range tx.Signers {
+		if tx.Signers[i].Account == notaryHash {
+			if tx.Signers[i].Scopes != transaction.None {
+				return errors.New("native Notary signer should have None scope")
+			}
+			notaryFound = true
+		}
+	}
+	if !notaryFound {
+		return errors.New("native Notary contract is not a signer")
+	}
+	if !tx.HasSigner(signer) {
+		return errors.New("tx signers don't contain provided account")
+	}
+	attrs := tx.GetAttributes(transaction.NotaryAssistedT)
+	if len(attrs) == 0 {
+		return errors.New("no NotaryAssisted attribute")
+	}
+	if n := attrs[0].Value.(*transaction.NotaryAssisted).NKeys; int(n) != nKeys {
+		return fmt.Errorf("NotaryAssisted attribute has %d keys, while multisignature account has %d", n, nKeys)
+	}
+	return nil
+}
+
 func signStoredTransaction(ctx *cli.Context) error {
 	wall, err := openWallet(ctx.String("wallet"))
 	if err != nil {
@@ -0,0 +1,170 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/urfave/cli"
+)
+
+func newPolicyCommands() []cli.Command {
+	blockFlags := []cli.Flag{
+		walletPathFlag,
+		gasFlag,
+		outFlag,
+		flags.AddressFlag{
+			Name:  "address, a",
+			Usage: "Committee multisignature account address",
+		},
+	}
+	blockFlags = append(blockFlags, options.RPC...)
+	return []cli.Command{
+		{
+			Name:      "block",
+			Usage:     "add account to the list of blocked accounts",
+			UsageText: "block -w <path> -r <rpc> -a <committee_address> [-g gas] [--out file] <account>",
+			Description: `Creates transaction invoking blockAccount method of the native Policy
+   contract with the given account (address or LE script hash). Transactions
+   signed by blocked accounts are rejected by the network. The transaction
+   must be witnessed by the committee, so the committee account (see
+   'wallet multisig new --committee') must be specified. If --out is given,
+   transaction is signed with the wallet key and saved to the file to be
+   signed by other committee members with 'wallet multisig sign', otherwise
+   it's sent to the network (which only works if the wallet key is enough to
+   sign for the committee).
+`,
+			Action: blockAccount,
+			Flags:  blockFlags,
+		},
+		{
+			Name:      "unblock",
+			Usage:     "remove account from the list of blocked accounts",
+			UsageText: "unblock -w <path> -r <rpc> -a <committee_address> [-g gas] [--out file] <account>",
+			Description: `Creates transaction invoking unblockAccount method of the native
+   Policy contract with the given account, see 'block' command for details.
+`,
+			Action: unblockAccount,
+			Flags:  blockFlags,
+		},
+		{
+			Name:      "isblocked",
+			Usage:     "check whether account is blocked",
+			UsageText: "isblocked -r <rpc> <account>",
+			Action:    isBlockedAccount,
+			Flags:     options.RPC,
+		},
+	}
+}
+
+func blockAccount(ctx *cli.Context) error {
+	return handlePolicyBlock(ctx, "blockAccount")
+}
+
+func unblockAccount(ctx *cli.Context) error {
+	return handlePolicyBlock(ctx, "unblockAccount")
+}
+
+// parsePolicyAccount returns account hash passed as the only command argument.
+func parsePolicyAccount(ctx *cli.Context) (util.Uint160, error) {
+	if ctx.NArg() != 1 {
+		return util.Uint160{}, fmt.Errorf("exactly one account is expected, got %d arguments", ctx.NArg())
+	}
+	h, err := flags.ParseAddress(ctx.Args().First())
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("invalid account: %w", err)
+	}
+	return h, nil
+}
+
+func handlePolicyBlock(ctx *cli.Context, method string) error {
+	h, err := parsePolicyAccount(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	wall, err := openWallet(ctx.String("wallet"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if !addrFlag.IsSet {
+		return cli.NewExitError("committee address was not provided", 1)
+	}
+	acc, err := getDecryptedAccount(ctx, wall, addrFlag.Uint160())
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	policyHash, err := c.GetNativeContractHash(nativenames.Policy)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, policyHash, method, callflag.States, h.BytesBE())
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+
+	gas := flags.Fixed8FromContext(ctx, "gas")
+	signers := []client.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: acc.Contract.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}}
+	if out := ctx.String("out"); out != "" {
+		tx, err := c.CreateTxFromScript(w.Bytes(), acc, -1, int64(gas), signers)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to create tx: %w", err), 1)
+		}
+		if err := paramcontext.InitAndSave(c.GetNetwork(), tx, acc, out); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, tx.Hash().StringLE())
+		return nil
+	}
+	res, err := c.SignAndPushInvocationTx(w.Bytes(), acc, -1, gas, signers)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to push transaction: %w", err), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, res.StringLE())
+	return nil
+}
+
+func isBlockedAccount(ctx *cli.Context) error {
+	h, err := parsePolicyAccount(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	blocked, err := c.IsBlocked(h)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, blocked)
+	return nil
+}
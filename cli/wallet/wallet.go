@@ -143,6 +143,7 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			historyCommand(),
 			{
 				Name:      "export",
 				Usage:     "export keys for address",
@@ -229,6 +230,11 @@ func NewCommands() []cli.Command {
 				Action:    signStoredTransaction,
 				Flags:     signFlags,
 			},
+			{
+				Name:        "multisig",
+				Usage:       "work with multisignature accounts",
+				Subcommands: newMultisigCommands(),
+			},
 			{
 				Name:        "nep17",
 				Usage:       "work with NEP17 contracts",
@@ -244,6 +250,11 @@ func NewCommands() []cli.Command {
 				Usage:       "work with candidates",
 				Subcommands: newValidatorCommands(),
 			},
+			{
+				Name:        "policy",
+				Usage:       "manage Policy contract blocked accounts (committee only)",
+				Subcommands: newPolicyCommands(),
+			},
 		},
 	}}
 }
@@ -273,10 +284,20 @@ func claimGas(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
+	unclaimed, err := c.GetUnclaimedGas(address.Uint160ToString(scriptHash))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get unclaimed GAS: %w", err), 1)
+	}
+	if unclaimed.Unclaimed.Sign() == 0 {
+		return cli.NewExitError("there is no GAS to claim", 1)
+	}
+
 	neoContractHash, err := c.GetNativeContractHash(nativenames.Neo)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
+	// GAS is claimed by transferring NEO, zero amount transfer to self is
+	// enough for that.
 	hash, err := c.TransferNEP17(acc, scriptHash, neoContractHash, 0, 0, nil, nil)
 	if err != nil {
 		return cli.NewExitError(err, 1)
@@ -397,18 +418,9 @@ func importMultisig(ctx *cli.Context) error {
 	defer wall.Close()
 
 	m := ctx.Int("min")
-	if ctx.NArg() < m {
-		return cli.NewExitError(errors.New("insufficient number of public keys"), 1)
-	}
-
-	args := []string(ctx.Args())
-	pubs := make([]*keys.PublicKey, len(args))
-
-	for i := range args {
-		pubs[i], err = keys.NewPublicKeyFromString(args[i])
-		if err != nil {
-			return cli.NewExitError(fmt.Errorf("can't decode public key %d: %w", i, err), 1)
-		}
+	pubs, err := getMultisigKeys(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
 	}
 
 	acc, err := newAccountFromWIF(ctx.App.Writer, ctx.String("wif"))
@@ -200,6 +200,25 @@ func TestClaimGas(t *testing.T) {
 	require.NoError(t, err)
 	t.Cleanup(w.Close)
 
+	t.Run("nothing to claim", func(t *testing.T) {
+		tmpPath := path.Join(os.TempDir(), "wallet_claim.json")
+		t.Cleanup(func() { os.Remove(tmpPath) })
+		tmp, err := wallet.NewWallet(tmpPath)
+		require.NoError(t, err)
+		acc, err := wallet.NewAccount()
+		require.NoError(t, err)
+		require.NoError(t, acc.Encrypt("pass"))
+		tmp.AddAccount(acc)
+		require.NoError(t, tmp.Save())
+		tmp.Close()
+
+		e.In.WriteString("pass\r")
+		e.RunWithError(t, "neo-go", "wallet", "claim",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", tmpPath,
+			"--address", acc.Address)
+	})
+
 	args := []string{
 		"neo-go", "wallet", "nep17", "multitransfer",
 		"--rpc-endpoint", "http://" + e.RPC.Addr,
@@ -0,0 +1,193 @@
+/*
+Package bench contains reproducible performance workloads for the node: block
+persisting, mempool operations and VM execution of token transfers. They're
+available both as standard Go benchmarks (see `make bench`) and via Run that
+returns results suitable for WriteReport.
+*/
+package bench
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// ConfigPath is the path to the configuration directory used for chain
+// creation. It's relative to the package directory by default, so it needs
+// to be changed if workloads are run from some other place.
+var ConfigPath = "../../config"
+
+// Workload is a named benchmark processing Items items (blocks, transactions,
+// etc.) per iteration.
+type Workload struct {
+	Name  string
+	Items int
+	Func  func(b *testing.B)
+}
+
+// Result is a result of a single workload run.
+type Result struct {
+	Name  string
+	Items int
+	testing.BenchmarkResult
+}
+
+// ItemsPerSecond returns the number of items processed per second.
+func (r Result) ItemsPerSecond() float64 {
+	if r.T <= 0 {
+		return 0
+	}
+	return float64(r.N) * float64(r.Items) / r.T.Seconds()
+}
+
+// Run runs all given workloads one by one and returns their results.
+func Run(ws ...Workload) []Result {
+	res := make([]Result, len(ws))
+	for i, w := range ws {
+		res[i] = Result{
+			Name:            w.Name,
+			Items:           w.Items,
+			BenchmarkResult: testing.Benchmark(w.Func),
+		}
+	}
+	return res
+}
+
+// WriteReport writes results as a table with a line per workload.
+func WriteReport(w io.Writer, rs []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Workload\tIterations\tns/op\titems/op\titems/s\tB/op\tallocs/op\t")
+	for _, r := range rs {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2f\t%d\t%d\t\n", r.Name, r.N, r.NsPerOp(),
+			r.Items, r.ItemsPerSecond(), r.AllocedBytesPerOp(), r.AllocsPerOp())
+	}
+	return tw.Flush()
+}
+
+// Standard returns the standard set of workloads.
+func Standard() []Workload {
+	return []Workload{
+		BlockPersist(1),
+		BlockPersist(100),
+		MempoolAddRemove(10000),
+		NEP17Transfer(),
+	}
+}
+
+// newChain creates and starts in-memory unit test chain.
+func newChain(b *testing.B) *core.Blockchain {
+	cfg, err := config.Load(ConfigPath, testchain.Network())
+	require.NoError(b, err)
+	bc, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, zap.NewNop())
+	require.NoError(b, err)
+	go bc.Run()
+	b.Cleanup(bc.Close)
+	return bc
+}
+
+// newTransfers returns n signed GAS transfers from the validators multisig
+// account valid till the next block.
+func newTransfers(b *testing.B, bc *core.Blockchain, n int) []*transaction.Transaction {
+	txs := make([]*transaction.Transaction, n)
+	for i := range txs {
+		tx, err := testchain.NewTransferFromOwner(bc, bc.UtilityTokenHash(), random.Uint160(), 1,
+			uint32(i), bc.BlockHeight()+1)
+		require.NoError(b, err)
+		txs[i] = tx
+	}
+	return txs
+}
+
+// BlockPersist measures the time needed to verify and store a block with txs
+// GAS transfers.
+func BlockPersist(txs int) Workload {
+	return Workload{
+		Name:  fmt.Sprintf("BlockPersist/%dtx", txs),
+		Items: txs,
+		Func: func(b *testing.B) {
+			bc := newChain(b)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				blk := testchain.NewBlock(b, bc, 1, 0, newTransfers(b, bc, txs)...)
+				b.StartTimer()
+				require.NoError(b, bc.AddBlock(blk))
+			}
+		},
+	}
+}
+
+// feer is a mempool.Feer with fixed fee and balance.
+type feer struct{}
+
+func (feer) FeePerByte() int64                            { return 0 }
+func (feer) GetUtilityTokenBalance(util.Uint160) *big.Int { return big.NewInt(1 << 62) }
+func (feer) BlockHeight() uint32                          { return 0 }
+func (feer) P2PSigExtensionsEnabled() bool                { return false }
+
+// MempoolAddRemove measures the time needed to add n transactions with
+// different fees to the mempool and then to remove all of them.
+func MempoolAddRemove(n int) Workload {
+	return Workload{
+		Name:  fmt.Sprintf("MempoolAddRemove/%d", n),
+		Items: n,
+		Func: func(b *testing.B) {
+			txs := make([]*transaction.Transaction, n)
+			for i := range txs {
+				tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+				tx.Nonce = uint32(i)
+				tx.NetworkFee = int64(random.Int(0, 1000))
+				tx.Signers = []transaction.Signer{{Account: random.Uint160()}}
+				txs[i] = tx
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				mp := mempool.New(n, 0, false)
+				for _, tx := range txs {
+					require.NoError(b, mp.Add(tx, feer{}))
+				}
+				for _, tx := range txs {
+					mp.Remove(tx.Hash(), feer{})
+				}
+			}
+		},
+	}
+}
+
+// NEP17Transfer measures the time needed to execute a single GAS transfer
+// script in the VM (without storing its results).
+func NEP17Transfer() Workload {
+	return Workload{
+		Name:  "NEP17Transfer",
+		Items: 1,
+		Func: func(b *testing.B) {
+			bc := newChain(b)
+			tx := newTransfers(b, bc, 1)[0]
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				v := bc.GetTestVM(trigger.Application, tx, nil)
+				v.LoadScriptWithFlags(tx.Script, callflag.All)
+				require.NoError(b, v.Run())
+			}
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkBlockPersist(b *testing.B) {
+	for _, n := range []int{1, 100} {
+		b.Run(fmt.Sprintf("%dtx", n), BlockPersist(n).Func)
+	}
+}
+
+func BenchmarkMempoolAddRemove(b *testing.B) {
+	MempoolAddRemove(10000).Func(b)
+}
+
+func BenchmarkNEP17Transfer(b *testing.B) {
+	NEP17Transfer().Func(b)
+}
+
+func TestWriteReport(t *testing.T) {
+	rs := []Result{
+		{Name: "first", Items: 10, BenchmarkResult: testing.BenchmarkResult{N: 100, T: time.Second}},
+		{Name: "second", Items: 1, BenchmarkResult: testing.BenchmarkResult{N: 5, T: 0}},
+	}
+	require.Equal(t, 1000.0, rs[0].ItemsPerSecond())
+	require.Equal(t, 0.0, rs[1].ItemsPerSecond())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, WriteReport(buf, rs))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 3, len(lines))
+	require.Equal(t, []string{"Workload", "Iterations", "ns/op", "items/op", "items/s", "B/op", "allocs/op"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"first", "100", "10000000", "10", "1000.00", "0", "0"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"second", "5", "0", "1", "0.00", "0", "0"}, strings.Fields(lines[2]))
+}
+
+func TestStandard(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs all workloads")
+	}
+	for _, w := range Standard() {
+		t.Run(w.Name, func(t *testing.T) {
+			r := testing.Benchmark(w.Func)
+			require.True(t, r.N > 0)
+		})
+	}
+}
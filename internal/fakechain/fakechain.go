@@ -172,8 +172,14 @@ func (chain *FakeChain) P2PSigExtensionsEnabled() bool {
 }
 
 // AddHeaders implements Blockchainer interface.
-func (chain *FakeChain) AddHeaders(...*block.Header) error {
-	panic("TODO")
+func (chain *FakeChain) AddHeaders(hdrs ...*block.Header) error {
+	for _, h := range hdrs {
+		if h.Index == atomic.LoadUint32(&chain.Blockheight)+1 {
+			chain.hdrHashes[h.Index] = h.Hash()
+			atomic.StoreUint32(&chain.Blockheight, h.Index)
+		}
+	}
+	return nil
 }
 
 // AddBlock implements Blockchainer interface.
@@ -255,7 +261,7 @@ func (chain *FakeChain) GetNextBlockValidators() ([]*keys.PublicKey, error) {
 }
 
 // ForEachNEP17Transfer implements Blockchainer interface.
-func (chain *FakeChain) ForEachNEP17Transfer(util.Uint160, func(*state.NEP17Transfer) (bool, error)) error {
+func (chain *FakeChain) ForEachNEP17Transfer(util.Uint160, uint64, int, func(*state.NEP17Transfer) (bool, error)) error {
 	panic("TODO")
 }
 
@@ -284,6 +290,16 @@ func (chain *FakeChain) GetEnrollments() ([]state.Validator, error) {
 	panic("TODO")
 }
 
+// GetAllCandidates implements Blockchainer interface.
+func (chain *FakeChain) GetAllCandidates() ([]state.Validator, error) {
+	panic("TODO")
+}
+
+// GetStateDiff implements Blockchainer interface.
+func (chain *FakeChain) GetStateDiff(index uint32) (*state.StateDiff, error) {
+	panic("TODO")
+}
+
 // GetStateModule implements Blockchainer interface.
 func (chain *FakeChain) GetStateModule() blockchainer.StateRoot {
 	return nil
@@ -299,11 +315,6 @@ func (chain *FakeChain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b
 	panic("TODO")
 }
 
-// GetStorageItems implements Blockchainer interface.
-func (chain *FakeChain) GetStorageItems(id int32) (map[string]state.StorageItem, error) {
-	panic("TODO")
-}
-
 // CurrentHeaderHash implements Blockchainer interface.
 func (chain *FakeChain) CurrentHeaderHash() util.Uint256 {
 	return util.Uint256{}
@@ -334,6 +345,11 @@ func (chain *FakeChain) GetTransaction(h util.Uint256) (*transaction.Transaction
 	return nil, 0, errors.New("not found")
 }
 
+// GetTransactionConflict implements Blockchainer interface.
+func (chain *FakeChain) GetTransactionConflict(h util.Uint256) (util.Uint256, uint32, error) {
+	return util.Uint256{}, 0, errors.New("not found")
+}
+
 // GetMemPool implements Blockchainer interface.
 func (chain *FakeChain) GetMemPool() *mempool.Pool {
 	return chain.Pool
@@ -1,7 +1,6 @@
 package testchain
 
 import (
-	"testing"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -156,7 +155,7 @@ func SignCommittee(h hash.Hashable) []byte {
 
 // NewBlock creates new block for the given blockchain with the given offset
 // (usually, 1), primary node index and transactions.
-func NewBlock(t *testing.T, bc blockchainer.Blockchainer, offset uint32, primary uint32, txs ...*transaction.Transaction) *block.Block {
+func NewBlock(t require.TestingT, bc blockchainer.Blockchainer, offset uint32, primary uint32, txs ...*transaction.Transaction) *block.Block {
 	witness := transaction.Witness{VerificationScript: MultisigVerificationScript()}
 	height := bc.BlockHeight()
 	h := bc.GetHeaderHash(int(height))
@@ -75,10 +75,7 @@ func NewDeployTx(bc blockchainer.Blockchainer, name string, sender util.Uint160,
 		if err != nil {
 			return nil, util.Uint160{}, nil, fmt.Errorf("failed to parse configuration: %w", err)
 		}
-		o.Name = conf.Name
-		o.ContractEvents = conf.Events
-		o.ContractSupportedStandards = conf.SupportedStandards
-		o.SafeMethods = conf.SafeMethods
+		conf.Apply(o)
 	}
 	m, err := compiler.CreateManifest(di, o)
 	if err != nil {
@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -101,6 +102,11 @@ type codegen struct {
 
 	// emittedEvents contains all events emitted by contract.
 	emittedEvents map[string][][]string
+	// declaredEvents contains events declared in the main package comments.
+	declaredEvents []manifest.Event
+
+	// noOptimize disables bytecode optimizations.
+	noOptimize bool
 
 	// Label table for recording jump destinations.
 	l []int
@@ -668,6 +674,15 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			ast.Walk(c, n.Init)
 		}
 		if n.Cond != nil {
+			// Only one branch is reachable if condition is constant.
+			if tv := c.typeAndValueOf(n.Cond); !c.noOptimize && tv.Value != nil {
+				if constant.BoolVal(tv.Value) {
+					ast.Walk(c, n.Body)
+				} else if n.Else != nil {
+					ast.Walk(c, n.Else)
+				}
+				return nil
+			}
 			c.emitBoolExpr(n.Cond, true, false, lElse)
 		}
 
@@ -936,20 +951,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			ast.Walk(c, n.Fun)
 			emit.Opcodes(c.prog.BinWriter, opcode.CALLA)
 		case isSyscall(f):
-			if f.pkg.Name() == "runtime" && f.name == "Notify" {
-				tv := c.typeAndValueOf(n.Args[0])
-				params := make([]string, 0, len(n.Args[1:]))
-				for _, p := range n.Args[1:] {
-					st, _ := c.scAndVMTypeFromExpr(p)
-					params = append(params, st.String())
-				}
-				// Sometimes event name is stored in a var.
-				// Skip in this case.
-				if tv.Value != nil {
-					name := constant.StringVal(tv.Value)
-					c.emittedEvents[name] = append(c.emittedEvents[name], params)
-				}
-			}
 			c.convertSyscall(f, n)
 		default:
 			emit.Call(c.prog.BinWriter, opcode.CALLL, f.label)
@@ -1532,6 +1533,23 @@ func getJumpForToken(tok token.Token, typ types.Type) (opcode.Opcode, bool) {
 	return 0, false
 }
 
+// processNotify remembers parameter types of the event emitted by
+// runtime.Notify call to check them against manifest.
+func (c *codegen) processNotify(n *ast.CallExpr) {
+	tv := c.typeAndValueOf(n.Args[0])
+	params := make([]string, 0, len(n.Args[1:]))
+	for _, p := range n.Args[1:] {
+		st, _ := c.scAndVMTypeFromExpr(p)
+		params = append(params, st.String())
+	}
+	// Sometimes event name is stored in a var.
+	// Skip in this case.
+	if tv.Value != nil {
+		name := constant.StringVal(tv.Value)
+		c.emittedEvents[name] = append(c.emittedEvents[name], params)
+	}
+}
+
 func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
 	for _, arg := range expr.Args[1:] {
 		ast.Walk(c, arg)
@@ -1548,7 +1566,7 @@ func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
 	} else {
 		op, err := opcode.FromString(name)
 		if err != nil {
-			c.prog.Err = fmt.Errorf("invalid opcode: %s", op)
+			c.prog.Err = fmt.Errorf("invalid opcode: %s", name)
 			return
 		}
 		emit.Opcodes(c.prog.BinWriter, op)
@@ -1952,6 +1970,9 @@ func (c *codegen) newLambda(u uint16, lit *ast.FuncLit) {
 
 func (c *codegen) compile(info *buildInfo, pkg *loader.PackageInfo) error {
 	c.mainPkg = pkg
+	if err := c.collectEvents(); err != nil {
+		return err
+	}
 	c.analyzePkgOrder()
 	c.fillDocumentInfo()
 	funUsage := c.analyzeFuncUsage()
@@ -2029,6 +2050,7 @@ func newCodegen(info *buildInfo, pkg *loader.PackageInfo) *codegen {
 func CodeGen(info *buildInfo) ([]byte, *DebugInfo, error) {
 	pkg := info.program.Package(info.initialPackage)
 	c := newCodegen(info, pkg)
+	c.noOptimize = info.options != nil && info.options.NoOptimize
 
 	if err := c.compile(info, pkg); err != nil {
 		return nil, nil, err
@@ -2082,7 +2104,7 @@ func (c *codegen) writeJumps(b []byte) ([]byte, error) {
 			if err != nil {
 				return nil, err
 			}
-			if op != opcode.PUSHA && math.MinInt8 <= offset && offset <= math.MaxInt8 {
+			if !c.noOptimize && op != opcode.PUSHA && math.MinInt8 <= offset && offset <= math.MaxInt8 {
 				offsets = append(offsets, ctx.IP())
 			}
 		}
@@ -54,11 +54,28 @@ type Options struct {
 
 	// SafeMethods contains list of methods which will be marked as safe in manifest.
 	SafeMethods []string
+
+	// Permissions is a list of permissions for the manifest, by default
+	// contract is allowed to call any method of any contract.
+	Permissions []manifest.Permission
+
+	// Trusts is a list of contracts (hashes or groups) trusted by the contract,
+	// wildcard descriptor in this list makes contract trust any contract.
+	Trusts []manifest.PermissionDesc
+
+	// NoTests makes compiler skip `_test.go` files of the package, they're
+	// compiled along with other files by default.
+	NoTests bool
+
+	// NoOptimize disables optimizations (like jump shortening and removal of
+	// branches with constant conditions), it can be handy for debugging.
+	NoOptimize bool
 }
 
 type buildInfo struct {
 	initialPackage string
 	program        *loader.Program
+	options        *Options
 }
 
 // ForEachPackage executes fn on each package used in the current program
@@ -99,7 +116,7 @@ func (c *codegen) fillImportMap(f *ast.File, pkg *types.Package) {
 	}
 }
 
-func getBuildInfo(name string, src interface{}) (*buildInfo, error) {
+func getBuildInfo(name string, src interface{}, noTests bool) (*buildInfo, error) {
 	conf := loader.Config{ParserMode: parser.ParseComments}
 	if src != nil {
 		f, err := conf.ParseFile(name, src)
@@ -117,7 +134,8 @@ func getBuildInfo(name string, src interface{}) (*buildInfo, error) {
 				return nil, fmt.Errorf("'%s' is neither Go source nor a directory", name)
 			}
 			for i := range ds {
-				if !ds[i].IsDir() && strings.HasSuffix(ds[i].Name(), ".go") {
+				if !ds[i].IsDir() && strings.HasSuffix(ds[i].Name(), ".go") &&
+					!(noTests && strings.HasSuffix(ds[i].Name(), "_test.go")) {
 					names = append(names, path.Join(name, ds[i].Name()))
 				}
 			}
@@ -153,10 +171,17 @@ func Compile(name string, r io.Reader) ([]byte, error) {
 
 // CompileWithDebugInfo compiles a Go program into bytecode and emits debug info.
 func CompileWithDebugInfo(name string, r io.Reader) ([]byte, *DebugInfo, error) {
-	ctx, err := getBuildInfo(name, r)
+	return CompileWithOptions(name, r, nil)
+}
+
+// CompileWithOptions compiles a Go program into bytecode with provided compiler
+// options and emits debug info.
+func CompileWithOptions(name string, r io.Reader, o *Options) ([]byte, *DebugInfo, error) {
+	ctx, err := getBuildInfo(name, r, o != nil && o.NoTests)
 	if err != nil {
 		return nil, nil, err
 	}
+	ctx.options = o
 	return CodeGen(ctx)
 }
 
@@ -173,7 +198,7 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	if len(o.Ext) == 0 {
 		o.Ext = fileExt
 	}
-	b, di, err := CompileWithDebugInfo(src, nil)
+	b, di, err := CompileWithOptions(src, nil, o)
 	if err != nil {
 		return nil, fmt.Errorf("error while trying to compile smart contract file: %w", err)
 	}
@@ -195,8 +220,12 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	}
 
 	if o.DebugInfo != "" {
-		di.Events = make([]EventDebugInfo, len(o.ContractEvents))
-		for i, e := range o.ContractEvents {
+		events, err := mergeEvents(o.ContractEvents, di.DeclaredEvents)
+		if err != nil {
+			return b, err
+		}
+		di.Events = make([]EventDebugInfo, len(events))
+		for i, e := range events {
 			params := make([]DebugParam, len(e.Parameters))
 			for j, p := range e.Parameters {
 				params[j] = DebugParam{
@@ -242,6 +271,9 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 	if err != nil {
 		return m, fmt.Errorf("failed to convert debug info to manifest: %w", err)
 	}
+	if err := manifest.Permissions(m.Permissions).AreValid(); err != nil {
+		return m, fmt.Errorf("invalid permissions: %w", err)
+	}
 	if !o.NoStandardCheck {
 		if err := standard.CheckABI(m, o.ContractSupportedStandards...); err != nil {
 			return m, err
@@ -9,6 +9,8 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,6 +41,35 @@ func TestCompiler(t *testing.T) {
 				require.Contains(t, m, "Func2")
 			},
 		},
+		{
+			name: "TestCompileDirectoryWithTests",
+			function: func(t *testing.T) {
+				dir, err := ioutil.TempDir("", "neogo.compiler")
+				require.NoError(t, err)
+				t.Cleanup(func() { os.RemoveAll(dir) })
+				require.NoError(t, ioutil.WriteFile(path.Join(dir, "main.go"),
+					[]byte("package foo\nfunc Main() int { return 1 }\n"), 0644))
+				require.NoError(t, ioutil.WriteFile(path.Join(dir, "main_test.go"),
+					[]byte("package foo\nfunc TestMain() bool { return Main() == 1 }\n"), 0644))
+
+				methods := func(o *compiler.Options) map[string]bool {
+					_, di, err := compiler.CompileWithOptions(dir, nil, o)
+					require.NoError(t, err)
+					m := map[string]bool{}
+					for i := range di.Methods {
+						m[di.Methods[i].ID] = true
+					}
+					return m
+				}
+				m := methods(nil)
+				require.Contains(t, m, "Main")
+				require.Contains(t, m, "TestMain")
+
+				m = methods(&compiler.Options{NoTests: true})
+				require.Contains(t, m, "Main")
+				require.NotContains(t, m, "TestMain")
+			},
+		},
 		{
 			name: "TestCompile",
 			function: func(t *testing.T) {
@@ -130,3 +161,94 @@ func TestOnPayableChecks(t *testing.T) {
 		require.Error(t, compileAndCheck(t, src))
 	})
 }
+
+func TestEventDeclarations(t *testing.T) {
+	compileAndCheck := func(t *testing.T, src string, o *compiler.Options) (*manifest.Manifest, error) {
+		_, di, err := compiler.CompileWithDebugInfo("events", strings.NewReader(src))
+		if err != nil {
+			return nil, err
+		}
+		return compiler.CreateManifest(di, o)
+	}
+
+	t.Run("good", func(t *testing.T) {
+		src := `package events
+		import "github.com/nspcc-dev/neo-go/pkg/interop"
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		//neo:event Transfer(from Hash160, to Hash160, amount Integer)
+		//neo:event Empty()
+		func Main(from, to interop.Hash160, amount int) {
+			runtime.Notify("Transfer", from, to, amount)
+			runtime.Notify("Empty")
+		}`
+		m, err := compileAndCheck(t, src, &compiler.Options{})
+		require.NoError(t, err)
+		require.Equal(t, []manifest.Event{
+			{
+				Name: "Transfer",
+				Parameters: []manifest.Parameter{
+					manifest.NewParameter("from", smartcontract.Hash160Type),
+					manifest.NewParameter("to", smartcontract.Hash160Type),
+					manifest.NewParameter("amount", smartcontract.IntegerType),
+				},
+			},
+			{Name: "Empty", Parameters: []manifest.Parameter{}},
+		}, m.ABI.Events)
+	})
+	t.Run("wrong arity", func(t *testing.T) {
+		src := `package events
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		//neo:event Event(a Integer)
+		func Main() {
+			runtime.Notify("Event", 1, 2)
+		}`
+		_, err := compileAndCheck(t, src, &compiler.Options{})
+		require.Error(t, err)
+	})
+	t.Run("wrong type", func(t *testing.T) {
+		src := `package events
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		//neo:event Event(a Integer)
+		func Main() {
+			runtime.Notify("Event", "str")
+		}`
+		_, err := compileAndCheck(t, src, &compiler.Options{})
+		require.Error(t, err)
+	})
+	t.Run("configuration", func(t *testing.T) {
+		src := `package events
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		//neo:event Event(a Integer)
+		func Main() {
+			runtime.Notify("Event", 1)
+		}`
+		o := &compiler.Options{ContractEvents: []manifest.Event{{
+			Name:       "Event",
+			Parameters: []manifest.Parameter{manifest.NewParameter("b", smartcontract.IntegerType)},
+		}}}
+		m, err := compileAndCheck(t, src, o)
+		require.NoError(t, err)
+		require.Equal(t, o.ContractEvents, m.ABI.Events)
+
+		o.ContractEvents[0].Parameters[0].Type = smartcontract.StringType
+		_, err = compileAndCheck(t, src, o)
+		require.Error(t, err)
+	})
+	t.Run("invalid declaration", func(t *testing.T) {
+		for _, decl := range []string{"Event", "(a Integer)", "Event(a)", "Event(a Unknown)"} {
+			src := `package events
+			//neo:event ` + decl + `
+			func Main() {}`
+			_, err := compileAndCheck(t, src, &compiler.Options{})
+			require.Error(t, err, decl)
+		}
+	})
+	t.Run("duplicate declaration", func(t *testing.T) {
+		src := `package events
+		//neo:event Event(a Integer)
+		//neo:event Event(a String)
+		func Main() {}`
+		_, err := compileAndCheck(t, src, &compiler.Options{})
+		require.Error(t, err)
+	})
+}
@@ -24,6 +24,9 @@ type DebugInfo struct {
 	Events    []EventDebugInfo  `json:"events"`
 	// EmittedEvents contains events occurring in code.
 	EmittedEvents map[string][][]string `json:"-"`
+	// DeclaredEvents contains events declared in code with `//neo:event`
+	// comments.
+	DeclaredEvents []manifest.Event `json:"-"`
 	// StaticVariables contains list of static variable names and types.
 	StaticVariables []string `json:"static-variables"`
 }
@@ -180,6 +183,7 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 		d.Methods = append(d.Methods, *m)
 	}
 	d.EmittedEvents = c.emittedEvents
+	d.DeclaredEvents = c.declaredEvents
 	return d
 }
 
@@ -413,6 +417,16 @@ func parsePairJSON(data []byte, sep string) (string, string, error) {
 	return ss[0], ss[1], nil
 }
 
+// Labels returns names of all methods (including unexported ones) by their
+// start offsets, it's suitable for vm.Disassemble.
+func (di *DebugInfo) Labels() map[int]string {
+	labels := make(map[int]string, len(di.Methods))
+	for _, m := range di.Methods {
+		labels[int(m.Range.Start)] = m.ID
+	}
+	return labels
+}
+
 // ConvertToManifest converts contract to the manifest.Manifest struct for debugger.
 // Note: manifest is taken from the external source, however it can be generated ad-hoc. See #1038.
 func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
@@ -430,24 +444,40 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 		}
 	}
 
+	events, err := mergeEvents(o.ContractEvents, di.DeclaredEvents)
+	if err != nil {
+		return nil, err
+	}
+
 	result := manifest.NewManifest(o.Name)
 	if o.ContractSupportedStandards != nil {
 		result.SupportedStandards = o.ContractSupportedStandards
 	}
 	result.ABI = manifest.ABI{
 		Methods: methods,
-		Events:  o.ContractEvents,
+		Events:  events,
 	}
 	if result.ABI.Events == nil {
 		result.ABI.Events = make([]manifest.Event, 0)
 	}
-	result.Permissions = []manifest.Permission{
-		{
-			Contract: manifest.PermissionDesc{
-				Type: manifest.PermissionWildcard,
+	if len(o.Permissions) != 0 {
+		result.Permissions = o.Permissions
+	} else {
+		result.Permissions = []manifest.Permission{
+			{
+				Contract: manifest.PermissionDesc{
+					Type: manifest.PermissionWildcard,
+				},
+				Methods: manifest.WildStrings{},
 			},
-			Methods: manifest.WildStrings{},
-		},
+		}
+	}
+	for _, t := range o.Trusts {
+		if t.Type == manifest.PermissionWildcard {
+			result.Trusts = manifest.WildPermissionDescs{}
+			break
+		}
+		result.Trusts.Add(t)
 	}
 	return result, nil
 }
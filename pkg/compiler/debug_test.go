@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -66,7 +67,7 @@ func (ms *MyStruct) MethodOnPointerToStruct() { }
 func _deploy(data interface{}, isUpdate bool) { x := 1; _ = x }
 `
 
-	info, err := getBuildInfo("foo.go", src)
+	info, err := getBuildInfo("foo.go", src, false)
 	require.NoError(t, err)
 
 	pkg := info.program.Package(info.initialPackage)
@@ -294,6 +295,26 @@ func _deploy(data interface{}, isUpdate bool) { x := 1; _ = x }
 		require.Equal(t, expected.Trusts, actual.Trusts)
 		require.Equal(t, expected.Extra, actual.Extra)
 	})
+	t.Run("permissions and trusts", func(t *testing.T) {
+		h := util.Uint160{1, 2, 3}
+		p := manifest.NewPermission(manifest.PermissionHash, h)
+		p.Methods.Add("transfer")
+		actual, err := d.ConvertToManifest(&Options{
+			Name:        "MyCTR",
+			Permissions: []manifest.Permission{*p},
+			Trusts:      []manifest.PermissionDesc{p.Contract},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []manifest.Permission{*p}, actual.Permissions)
+		require.Equal(t, []manifest.PermissionDesc{p.Contract}, actual.Trusts.Value)
+
+		actual, err = d.ConvertToManifest(&Options{
+			Name:   "MyCTR",
+			Trusts: []manifest.PermissionDesc{p.Contract, {Type: manifest.PermissionWildcard}},
+		})
+		require.NoError(t, err)
+		require.True(t, actual.Trusts.IsWildcard())
+	})
 }
 
 func TestSequencePoints(t *testing.T) {
@@ -305,7 +326,7 @@ func TestSequencePoints(t *testing.T) {
 		return false
 	}`
 
-	info, err := getBuildInfo("foo.go", src)
+	info, err := getBuildInfo("foo.go", src, false)
 	require.NoError(t, err)
 
 	pkg := info.program.Package(info.initialPackage)
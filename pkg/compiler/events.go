@@ -0,0 +1,105 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// eventDirective is a prefix of comments declaring contract events in the
+// main package, like
+//   //neo:event Transfer(from Hash160, to Hash160, amount Integer)
+const eventDirective = "//neo:event "
+
+// collectEvents gathers events declared in the main package comments.
+func (c *codegen) collectEvents() error {
+	for _, f := range c.mainPkg.Files {
+		for _, cg := range f.Comments {
+			for _, cm := range cg.List {
+				if !strings.HasPrefix(cm.Text, eventDirective) {
+					continue
+				}
+				pos := c.buildInfo.program.Fset.Position(cm.Pos())
+				ev, err := parseEventDirective(cm.Text[len(eventDirective):])
+				if err != nil {
+					return fmt.Errorf("%s: %w", pos, err)
+				}
+				for i := range c.declaredEvents {
+					if c.declaredEvents[i].Name == ev.Name {
+						return fmt.Errorf("%s: event '%s' is already declared", pos, ev.Name)
+					}
+				}
+				c.declaredEvents = append(c.declaredEvents, *ev)
+			}
+		}
+	}
+	return nil
+}
+
+// parseEventDirective parses event declaration of `Name(param Type, ...)` form.
+func parseEventDirective(s string) (*manifest.Event, error) {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '(')
+	if open < 1 || !strings.HasSuffix(s, ")") {
+		return nil, errors.New("event declaration should be of 'Name(param Type, ...)' form")
+	}
+	ev := &manifest.Event{
+		Name:       strings.TrimSpace(s[:open]),
+		Parameters: []manifest.Parameter{},
+	}
+	params := strings.TrimSpace(s[open+1 : len(s)-1])
+	if params == "" {
+		return ev, nil
+	}
+	for _, p := range strings.Split(params, ",") {
+		fs := strings.Fields(p)
+		if len(fs) != 2 {
+			return nil, fmt.Errorf("invalid parameter of event '%s': '%s'", ev.Name, strings.TrimSpace(p))
+		}
+		typ, err := smartcontract.ParseParamType(fs[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter of event '%s': %w", ev.Name, err)
+		}
+		ev.Parameters = append(ev.Parameters, manifest.NewParameter(fs[0], typ))
+	}
+	return ev, nil
+}
+
+// mergeEvents returns events from configuration along with the ones declared
+// in code. The same event can be declared in both places if the parameter
+// types match.
+func mergeEvents(cfg, declared []manifest.Event) ([]manifest.Event, error) {
+	res := append([]manifest.Event{}, cfg...)
+	for i := range declared {
+		var found bool
+		for j := range cfg {
+			if cfg[j].Name != declared[i].Name {
+				continue
+			}
+			if !sameParamTypes(cfg[j].Parameters, declared[i].Parameters) {
+				return nil, fmt.Errorf("event '%s' is declared differently in code and configuration", cfg[j].Name)
+			}
+			found = true
+			break
+		}
+		if !found {
+			res = append(res, declared[i])
+		}
+	}
+	return res, nil
+}
+
+func sameParamTypes(a, b []manifest.Parameter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type {
+			return false
+		}
+	}
+	return true
+}
@@ -3,7 +3,12 @@ package compiler_test
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLT(t *testing.T) {
@@ -150,3 +155,34 @@ func TestCallExpIF(t *testing.T) {
 		eval(t, src, big.NewInt(5))
 	})
 }
+
+func TestIfConstantCondition(t *testing.T) {
+	src := `package foo
+	const debug = false
+	func Main() int {
+		x := 1
+		if debug {
+			x = 42
+			for i := 0; i < 10; i++ {
+				x += i
+			}
+		} else if !debug {
+			x += 1
+		} else {
+			x = 0
+		}
+		return x
+	}`
+	eval(t, src, big.NewInt(2))
+
+	optimized, err := compiler.Compile("foo.go", strings.NewReader(src))
+	require.NoError(t, err)
+	plain, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{NoOptimize: true})
+	require.NoError(t, err)
+	require.True(t, len(optimized) < len(plain))
+
+	v := vm.New()
+	v.LoadScript(plain)
+	require.NoError(t, v.Run())
+	require.Equal(t, big.NewInt(2), v.PopResult())
+}
@@ -16,6 +16,10 @@ import (
 //      <inline body of f directly>
 //   }
 func (c *codegen) inlineCall(f *funcScope, n *ast.CallExpr) {
+	if f.pkg.Path() == interopPrefix+"/runtime" && f.name == "Notify" {
+		c.processNotify(n)
+	}
+
 	labelSz := len(c.labelList)
 	offSz := len(c.inlineLabelOffsets)
 	c.inlineLabelOffsets = append(c.inlineLabelOffsets, labelSz)
@@ -18,6 +18,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	cinterop "github.com/nspcc-dev/neo-go/pkg/interop"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -187,7 +188,7 @@ func TestAppCall(t *testing.T) {
 	}
 
 	fc := fakechain.NewFakeChain()
-	ic := interop.NewContext(trigger.Application, fc, dao.NewSimple(storage.NewMemoryStore(), false), contractGetter, nil, nil, nil, zaptest.NewLogger(t))
+	ic := interop.NewContext(trigger.Application, fc, dao.NewSimple(storage.NewMemoryStore(), false, false), contractGetter, nil, nil, nil, zaptest.NewLogger(t))
 
 	t.Run("valid script", func(t *testing.T) {
 		src := getAppCallScript(fmt.Sprintf("%#v", ih.BytesBE()))
@@ -286,6 +287,112 @@ func TestAppCall(t *testing.T) {
 	})
 }
 
+func TestStorageContextAcrossCalls(t *testing.T) {
+	srcCallee := `package callee
+	import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	func Put(ctx storage.Context) bool {
+		storage.Put(ctx, "key", "callee")
+		return true
+	}
+	func Get(ctx storage.Context) interface{} {
+		return storage.Get(ctx, "key")
+	}`
+	calleeNef, di, err := compiler.CompileWithDebugInfo("callee.go", strings.NewReader(srcCallee))
+	require.NoError(t, err)
+	mCallee, err := di.ConvertToManifest(&compiler.Options{Name: "Callee"})
+	require.NoError(t, err)
+	calleeH := hash.Hash160(calleeNef)
+
+	srcOwner := `package owner
+	import "github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	import "github.com/nspcc-dev/neo-go/pkg/interop"
+	var callee = %s
+	func PutWritable() bool {
+		return contract.Call(callee, "put", contract.All, storage.GetContext()).(bool)
+	}
+	func PutReadOnly() bool {
+		ctx := storage.AsReadOnly(storage.GetContext())
+		return contract.Call(callee, "put", contract.All, ctx).(bool)
+	}
+	func PutConverted() bool {
+		ctx := storage.ConvertContextToReadOnly(storage.GetContext())
+		return contract.Call(callee, "put", contract.All, ctx).(bool)
+	}
+	func GetReadOnly() interface{} {
+		ctx := storage.GetContext()
+		storage.Put(ctx, "key", "owner")
+		return contract.Call(callee, "get", contract.All, storage.AsReadOnly(ctx))
+	}`
+	srcOwner = fmt.Sprintf(srcOwner, fmt.Sprintf("%#v", cinterop.Hash160(calleeH.BytesBE())))
+	ownerNef, di, err := compiler.CompileWithDebugInfo("owner.go", strings.NewReader(srcOwner))
+	require.NoError(t, err)
+	mOwner, err := di.ConvertToManifest(&compiler.Options{Name: "Owner"})
+	require.NoError(t, err)
+	ownerH := hash.Hash160(ownerNef)
+
+	contracts := make(map[util.Uint160]*state.Contract)
+	for i, c := range []struct {
+		h      util.Uint160
+		script []byte
+		m      *manifest.Manifest
+	}{{ownerH, ownerNef, mOwner}, {calleeH, calleeNef, mCallee}} {
+		nf, err := nef.NewFile(c.script)
+		require.NoError(t, err)
+		contracts[c.h] = &state.Contract{
+			ContractBase: state.ContractBase{
+				ID:       int32(i + 1),
+				Hash:     c.h,
+				NEF:      *nf,
+				Manifest: *c.m,
+			},
+		}
+	}
+	var contractGetter = func(_ dao.DAO, h util.Uint160) (*state.Contract, error) {
+		if c, ok := contracts[h]; ok {
+			return c, nil
+		}
+		return nil, errors.New("not found")
+	}
+	newContext := func() *interop.Context {
+		return interop.NewContext(trigger.Application, fakechain.NewFakeChain(),
+			dao.NewSimple(storage.NewMemoryStore(), false, false), contractGetter, nil, nil, nil, zaptest.NewLogger(t))
+	}
+	callOwner := func(t *testing.T, ic *interop.Context, method string) *vm.VM {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		import "github.com/nspcc-dev/neo-go/pkg/interop"
+		func Main() interface{} {
+			return contract.Call(` + fmt.Sprintf("%#v", cinterop.Hash160(ownerH.BytesBE())) + `, "` + method + `", contract.All)
+		}`
+		return spawnVM(t, ic, src)
+	}
+	ownerID := contracts[ownerH].ID
+
+	t.Run("writable context", func(t *testing.T) {
+		ic := newContext()
+		v := callOwner(t, ic, "putWritable")
+		require.NoError(t, v.Run())
+		assertResult(t, v, true)
+		// Callee is able to change owner's storage.
+		require.Equal(t, []byte("callee"), []byte(ic.DAO.GetStorageItem(ownerID, []byte("key"))))
+	})
+	for _, method := range []string{"putReadOnly", "putConverted"} {
+		t.Run(method, func(t *testing.T) {
+			ic := newContext()
+			v := callOwner(t, ic, method)
+			require.Error(t, v.Run())
+			require.Nil(t, ic.DAO.GetStorageItem(ownerID, []byte("key")))
+		})
+	}
+	t.Run("read-only get", func(t *testing.T) {
+		ic := newContext()
+		v := callOwner(t, ic, "getReadOnly")
+		require.NoError(t, v.Run())
+		assertResult(t, v, []byte("owner"))
+	})
+}
+
 func getAppCallScript(h string) string {
 	return `
 	package foo
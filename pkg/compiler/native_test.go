@@ -196,7 +196,9 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"deployWithData", []string{"nil", "nil", "123"}},
 		{"destroy", nil},
 		{"getContract", []string{u160}},
+		{"getContractById", []string{"1"}},
 		{"getMinimumDeploymentFee", nil},
+		{"hasMethod", []string{u160, `"method"`, "0"}},
 		{"setMinimumDeploymentFee", []string{"42"}},
 		{"update", []string{"nil", "nil"}},
 		{"updateWithData", []string{"nil", "nil", "123"}},
@@ -279,6 +281,7 @@ func runNativeTestCase(t *testing.T, ctr interop.ContractMD, name, method string
 	methodUpper := strings.ToUpper(method[:1]) + method[1:] // ASCII only
 	methodUpper = strings.ReplaceAll(methodUpper, "Gas", "GAS")
 	methodUpper = strings.ReplaceAll(methodUpper, "Json", "JSON")
+	methodUpper = strings.ReplaceAll(methodUpper, "ById", "ByID")
 	src := fmt.Sprintf(srcTmpl, name, name, methodUpper, strings.Join(params, ","))
 
 	v, s := vmAndCompileInterop(t, src)
@@ -88,6 +88,7 @@ func TestSyscallExecution(t *testing.T) {
 		"storage.GetReadOnlyContext":       {interopnames.SystemStorageGetReadOnlyContext, nil, false},
 		"storage.Put":                      {interopnames.SystemStoragePut, []string{sctx, b, b}, true},
 		"storage.ConvertContextToReadOnly": {interopnames.SystemStorageAsReadOnly, []string{sctx}, false},
+		"storage.AsReadOnly":               {interopnames.SystemStorageAsReadOnly, []string{sctx}, false},
 		"crypto.CheckMultisig":             {interopnames.SystemCryptoCheckMultisig, []string{pubs, sigs}, false},
 		"crypto.CheckSig":                  {interopnames.SystemCryptoCheckSig, []string{pub, sig}, false},
 	}
@@ -102,6 +103,20 @@ func TestSyscallExecution(t *testing.T) {
 			runSyscallTestCase(t, ic, goName, tc)
 		})
 	}
+	t.Run("all syscalls are wrapped", func(t *testing.T) {
+		wrapped := make(map[string]bool, len(interops))
+		for _, tc := range interops {
+			wrapped[tc.method] = true
+		}
+		for _, f := range core.Interops() {
+			switch f.Name {
+			case interopnames.SystemContractCallNative, interopnames.SystemContractNativeOnPersist,
+				interopnames.SystemContractNativePostPersist:
+				continue // Only used by native contracts.
+			}
+			require.True(t, wrapped[f.Name], "no wrapper for %s", f.Name)
+		}
+	})
 }
 
 func runSyscallTestCase(t *testing.T, ic *interop.Context, goName string, tc syscallTestCase) {
@@ -232,6 +247,55 @@ func TestOpcode(t *testing.T) {
 		}`
 		eval(t, src, big.NewInt(42))
 	})
+	t.Run("no arguments", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			neogointernal.Opcode0NoReturn("NOP")
+			return neogointernal.Opcode0("PUSH2").(int)
+		}`
+		eval(t, src, big.NewInt(2))
+	})
+	t.Run("no return", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			a := 42
+			neogointernal.Opcode1NoReturn("ASSERT", a > 0)
+			return a
+		}`
+		eval(t, src, big.NewInt(42))
+	})
+	t.Run("unused result", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			neogointernal.Opcode2("ADD", 1, 2)
+			return 42
+		}`
+		eval(t, src, big.NewInt(42))
+	})
+	t.Run("invalid opcode", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+		func Main() int {
+			return neogointernal.Opcode0("UNKNOWN").(int)
+		}`
+		_, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.Error(t, err)
+	})
+	t.Run("abort", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/util"
+		func Main() int {
+			defer func() { recover() }()
+			util.Abort()
+			return 42
+		}`
+		v := vmAndCompile(t, src)
+		require.Error(t, v.Run())
+		require.True(t, v.HasFailed())
+	})
 	t.Run("POW", func(t *testing.T) {
 		src := `package foo
 		import "github.com/nspcc-dev/neo-go/pkg/interop/math"
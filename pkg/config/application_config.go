@@ -16,9 +16,11 @@ type ApplicationConfiguration struct {
 	DBConfiguration   storage.DBConfiguration `yaml:"DBConfiguration"`
 	DialTimeout       time.Duration           `yaml:"DialTimeout"`
 	LogPath           string                  `yaml:"LogPath"`
+	Logger            Logger                  `yaml:"Logger"`
 	MaxPeers          int                     `yaml:"MaxPeers"`
 	MinPeers          int                     `yaml:"MinPeers"`
 	NodePort          uint16                  `yaml:"NodePort"`
+	NodeWSPort        uint16                  `yaml:"NodeWSPort"`
 	PingInterval      time.Duration           `yaml:"PingInterval"`
 	PingTimeout       time.Duration           `yaml:"PingTimeout"`
 	Pprof             metrics.Config          `yaml:"Pprof"`
@@ -29,7 +31,22 @@ type ApplicationConfiguration struct {
 	UnlockWallet      Wallet                  `yaml:"UnlockWallet"`
 	Oracle            OracleConfiguration     `yaml:"Oracle"`
 	P2PNotary         P2PNotary               `yaml:"P2PNotary"`
+	P2PAuth           P2PAuth                 `yaml:"P2PAuth"`
 	StateRoot         StateRoot               `yaml:"StateRoot"`
+	BalanceTracker    BalanceTracker          `yaml:"BalanceTracker"`
+	NAT               NAT                     `yaml:"NAT"`
+	PeerBan           PeerBan                 `yaml:"PeerBan"`
+	InventoryCache    InventoryCache          `yaml:"InventoryCache"`
 	// ExtensiblePoolSize is the maximum amount of the extensible payloads from a single sender.
 	ExtensiblePoolSize int `yaml:"ExtensiblePoolSize"`
+	// MaxPeersPerIP and MaxPeersPerSubnet limit the number of connections
+	// from a single IP address and a single /24 (IPv4) or /64 (IPv6) subnet,
+	// zero means no limit.
+	MaxPeersPerIP     int `yaml:"MaxPeersPerIP"`
+	MaxPeersPerSubnet int `yaml:"MaxPeersPerSubnet"`
+	// MaxInboundPeers and MaxOutboundPeers limit the number of accepted and
+	// established connections respectively (within MaxPeers), zero means
+	// no limit.
+	MaxInboundPeers  int `yaml:"MaxInboundPeers"`
+	MaxOutboundPeers int `yaml:"MaxOutboundPeers"`
 }
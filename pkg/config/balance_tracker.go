@@ -0,0 +1,14 @@
+package config
+
+// BalanceTracker contains configuration for the wallet balance tracker
+// service.
+type BalanceTracker struct {
+	Enabled bool `yaml:"Enabled"`
+	// Accounts is a list of addresses (or LE script hashes) to track NEP-17
+	// balances for.
+	Accounts []string `yaml:"Accounts"`
+	// FilePath is a path to the file balances are saved to after every
+	// block, if it's not set balances are synchronized with the chain on
+	// every start.
+	FilePath string `yaml:"FilePath"`
+}
@@ -35,8 +35,24 @@ func Load(path string, netMode netmode.Magic) (Config, error) {
 	return LoadFile(configPath)
 }
 
+// multiConfig is a configuration file layout with optional named networks.
+// Top-level sections are shared by all networks and every network can
+// override any part of them.
+type multiConfig struct {
+	Config   `yaml:",inline"`
+	Networks map[string]yaml.MapSlice `yaml:"Networks"`
+}
+
 // LoadFile loads config from the provided path.
 func LoadFile(configPath string) (Config, error) {
+	return LoadNetwork(configPath, "")
+}
+
+// LoadNetwork loads config of the named network from the provided path. Named
+// networks are defined in the `Networks` section of the file, settings
+// specified there override top-level ones. Empty name means top-level
+// configuration only.
+func LoadNetwork(configPath string, name string) (Config, error) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		return Config{}, fmt.Errorf("config '%s' doesn't exist", configPath)
 	}
@@ -46,12 +62,14 @@ func LoadFile(configPath string) (Config, error) {
 		return Config{}, fmt.Errorf("unable to read config: %w", err)
 	}
 
-	config := Config{
-		ApplicationConfiguration: ApplicationConfiguration{
-			PingInterval: 30,
-			PingTimeout:  90,
-			RPC: rpc.Config{
-				MaxIteratorResultItems: 100,
+	config := multiConfig{
+		Config: Config{
+			ApplicationConfiguration: ApplicationConfiguration{
+				PingInterval: 30,
+				PingTimeout:  90,
+				RPC: rpc.Config{
+					MaxIteratorResultItems: 100,
+				},
 			},
 		},
 	}
@@ -61,11 +79,30 @@ func LoadFile(configPath string) (Config, error) {
 		return Config{}, fmt.Errorf("failed to unmarshal config YAML: %w", err)
 	}
 
+	if name != "" {
+		network, ok := config.Networks[name]
+		if !ok {
+			return Config{}, fmt.Errorf("network '%s' is not defined in '%s'", name, configPath)
+		}
+		networkData, err := yaml.Marshal(network)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid '%s' network configuration: %w", name, err)
+		}
+		err = yaml.Unmarshal(networkData, &config.Config)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to unmarshal '%s' network configuration: %w", name, err)
+		}
+	}
+
+	if err := config.ProtocolConfiguration.ApplyNodeProfile(); err != nil {
+		return Config{}, err
+	}
+
 	for name := range config.ProtocolConfiguration.NativeUpdateHistories {
 		if !nativenames.IsValid(name) {
 			return Config{}, fmt.Errorf("NativeActivations configuration section contains unexpected native contract name: %s", name)
 		}
 	}
 
-	return config, nil
+	return config.Config, nil
 }
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -12,3 +17,258 @@ func TestUnexpectedNativeUpdateHistoryContract(t *testing.T) {
 	_, err := LoadFile(testConfigPath)
 	require.Error(t, err)
 }
+
+func TestValidateFile(t *testing.T) {
+	t.Run("default configs", func(t *testing.T) {
+		for _, name := range []string{"mainnet", "testnet", "privnet"} {
+			require.Empty(t, ValidateFile("../../config/protocol."+name+".yml", ""), name)
+		}
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		cfgPath := filepath.Join(newTempDir(t), "protocol.yml")
+		require.NoError(t, ioutil.WriteFile(cfgPath, []byte("ProtocolConfiguration:\n  Magics: 1\n"), 0644))
+		require.Len(t, ValidateFile(cfgPath, ""), 1)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		require.Len(t, ValidateFile(filepath.Join(newTempDir(t), "protocol.yml"), ""), 1)
+	})
+}
+
+const testMultiConfig = `ProtocolConfiguration:
+  Magic: 1
+  SeedList:
+    - 127.0.0.1:20333
+  NativeActivations:
+    ContractManagement: [0]
+ApplicationConfiguration:
+  NodePort: 20333
+  RPC:
+    Enabled: true
+    Port: 20332
+Networks:
+  custom:
+    ProtocolConfiguration:
+      Magic: 42
+      NativeActivations:
+        StdLib: [0]
+    ApplicationConfiguration:
+      NodePort: 30333
+      DBConfiguration:
+        Type: inmemory
+`
+
+func TestLoadNetwork(t *testing.T) {
+	cfgPath := filepath.Join(newTempDir(t), "protocol.yml")
+	require.NoError(t, ioutil.WriteFile(cfgPath, []byte(testMultiConfig), 0644))
+
+	t.Run("top-level", func(t *testing.T) {
+		cfg, err := LoadFile(cfgPath)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, cfg.ProtocolConfiguration.Magic)
+		require.EqualValues(t, 20333, cfg.ApplicationConfiguration.NodePort)
+	})
+	t.Run("custom", func(t *testing.T) {
+		cfg, err := LoadNetwork(cfgPath, "custom")
+		require.NoError(t, err)
+		require.EqualValues(t, 42, cfg.ProtocolConfiguration.Magic)
+		require.Equal(t, []string{"127.0.0.1:20333"}, cfg.ProtocolConfiguration.SeedList)
+		require.Equal(t, 2, len(cfg.ProtocolConfiguration.NativeUpdateHistories))
+		require.EqualValues(t, 30333, cfg.ApplicationConfiguration.NodePort)
+		require.EqualValues(t, 20332, cfg.ApplicationConfiguration.RPC.Port)
+		require.True(t, cfg.ApplicationConfiguration.RPC.Enabled)
+		require.Equal(t, "inmemory", cfg.ApplicationConfiguration.DBConfiguration.Type)
+		require.Empty(t, ValidateFile(cfgPath, "custom"))
+	})
+	t.Run("unknown network", func(t *testing.T) {
+		_, err := LoadNetwork(cfgPath, "unknown")
+		require.Error(t, err)
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Run("port conflict", func(t *testing.T) {
+		cfg := Config{}
+		cfg.ApplicationConfiguration.NodePort = 2112
+		cfg.ApplicationConfiguration.Prometheus.Enabled = true
+		cfg.ApplicationConfiguration.Prometheus.Port = "2112"
+		require.Len(t, cfg.Validate(), 1)
+
+		cfg.ApplicationConfiguration.Prometheus.Enabled = false
+		require.Empty(t, cfg.Validate())
+	})
+	t.Run("wallets", func(t *testing.T) {
+		cfg := Config{}
+		cfg.ApplicationConfiguration.UnlockWallet.Path = filepath.Join(newTempDir(t), "wallet.json")
+		cfg.ApplicationConfiguration.Oracle.Enabled = true
+		require.Len(t, cfg.Validate(), 2)
+
+		require.NoError(t, ioutil.WriteFile(cfg.ApplicationConfiguration.UnlockWallet.Path, []byte("{}"), 0644))
+		cfg.ApplicationConfiguration.Oracle.UnlockWallet = cfg.ApplicationConfiguration.UnlockWallet
+		require.Empty(t, cfg.Validate())
+
+		cfg.ApplicationConfiguration.UnlockWallet.Password = "pass"
+		cfg.ApplicationConfiguration.UnlockWallet.PasswordEnv = "NEOGO_PASS"
+		require.Len(t, cfg.Validate(), 1)
+	})
+	t.Run("balance tracker", func(t *testing.T) {
+		cfg := Config{}
+		cfg.ApplicationConfiguration.BalanceTracker.Enabled = true
+		require.Len(t, cfg.Validate(), 1)
+
+		cfg.ApplicationConfiguration.BalanceTracker.Accounts = []string{"NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc", "bad"}
+		require.Len(t, cfg.Validate(), 1)
+
+		cfg.ApplicationConfiguration.BalanceTracker.Accounts = cfg.ApplicationConfiguration.BalanceTracker.Accounts[:1]
+		require.Empty(t, cfg.Validate())
+	})
+	t.Run("RPC audit", func(t *testing.T) {
+		cfg := Config{}
+		cfg.ApplicationConfiguration.RPC.Enabled = true
+		cfg.ApplicationConfiguration.RPC.Audit.Enabled = true
+		require.Len(t, cfg.Validate(), 1)
+
+		cfg.ApplicationConfiguration.RPC.Audit.Syslog = true
+		require.Empty(t, cfg.Validate())
+	})
+	t.Run("RPC subscriber queue", func(t *testing.T) {
+		cfg := Config{}
+		cfg.ApplicationConfiguration.RPC.Enabled = true
+		cfg.ApplicationConfiguration.RPC.SubscriberQueue.OverflowPolicy = "ignore"
+		require.Len(t, cfg.Validate(), 1)
+
+		cfg.ApplicationConfiguration.RPC.SubscriberQueue.OverflowPolicy = "block"
+		require.Len(t, cfg.Validate(), 1)
+
+		cfg.ApplicationConfiguration.RPC.SubscriberQueue.BlockTimeout = time.Second
+		require.Empty(t, cfg.Validate())
+	})
+	t.Run("P2P authentication", func(t *testing.T) {
+		cfg := Config{}
+		cfg.ApplicationConfiguration.P2PAuth.Enabled = true
+		cfg.ApplicationConfiguration.P2PAuth.AllowedKeys = []string{
+			"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+			"bad",
+		}
+		require.Len(t, cfg.Validate(), 2)
+
+		cfg.ApplicationConfiguration.P2PAuth.UnlockWallet.Path = filepath.Join(newTempDir(t), "wallet.json")
+		require.NoError(t, ioutil.WriteFile(cfg.ApplicationConfiguration.P2PAuth.UnlockWallet.Path, []byte("{}"), 0644))
+		cfg.ApplicationConfiguration.P2PAuth.AllowedKeys = cfg.ApplicationConfiguration.P2PAuth.AllowedKeys[:1]
+		require.Empty(t, cfg.Validate())
+	})
+	t.Run("NAT", func(t *testing.T) {
+		cfg := Config{}
+		cfg.ApplicationConfiguration.NAT.Enabled = true
+		cfg.ApplicationConfiguration.NAT.Method = "magic"
+		cfg.ApplicationConfiguration.NAT.Gateway = "router"
+		require.Len(t, cfg.Validate(), 2)
+
+		cfg.ApplicationConfiguration.NAT.Method = "pmp"
+		cfg.ApplicationConfiguration.NAT.Gateway = "192.168.0.1"
+		require.Empty(t, cfg.Validate())
+	})
+}
+
+func TestWalletResolvePassword(t *testing.T) {
+	t.Run("plain", func(t *testing.T) {
+		pass, err := Wallet{Password: "pass"}.ResolvePassword()
+		require.NoError(t, err)
+		require.Equal(t, "pass", pass)
+
+		pass, err = Wallet{}.ResolvePassword()
+		require.NoError(t, err)
+		require.Equal(t, "", pass)
+	})
+	t.Run("environment", func(t *testing.T) {
+		const env = "NEOGO_TEST_WALLET_PASSWORD"
+		w := Wallet{PasswordEnv: env}
+		_, err := w.ResolvePassword()
+		require.Error(t, err)
+
+		require.NoError(t, os.Setenv(env, "pass"))
+		defer os.Unsetenv(env)
+		pass, err := w.ResolvePassword()
+		require.NoError(t, err)
+		require.Equal(t, "pass", pass)
+	})
+	t.Run("file", func(t *testing.T) {
+		w := Wallet{PasswordFile: filepath.Join(newTempDir(t), "pass")}
+		_, err := w.ResolvePassword()
+		require.Error(t, err)
+
+		require.NoError(t, ioutil.WriteFile(w.PasswordFile, []byte("pass\n"), 0600))
+		pass, err := w.ResolvePassword()
+		require.NoError(t, err)
+		require.Equal(t, "pass", pass)
+	})
+	t.Run("command", func(t *testing.T) {
+		if _, err := exec.LookPath("echo"); err != nil {
+			t.Skip("no echo command")
+		}
+		pass, err := Wallet{PasswordCommand: []string{"echo", "pass"}}.ResolvePassword()
+		require.NoError(t, err)
+		require.Equal(t, "pass", pass)
+
+		_, err = Wallet{PasswordCommand: []string{"unknown-neogo-command"}}.ResolvePassword()
+		require.Error(t, err)
+	})
+	t.Run("multiple sources", func(t *testing.T) {
+		_, err := Wallet{Password: "pass", PasswordCommand: []string{"echo", "pass"}}.ResolvePassword()
+		require.Error(t, err)
+	})
+}
+
+func TestApplyNodeProfile(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		for _, profile := range []string{"", DefaultProfile} {
+			p := ProtocolConfiguration{NodeProfile: profile, AppLogsRetention: 10}
+			require.NoError(t, p.ApplyNodeProfile())
+			require.Equal(t, ProtocolConfiguration{NodeProfile: profile, AppLogsRetention: 10}, p)
+		}
+	})
+	t.Run("light", func(t *testing.T) {
+		p := ProtocolConfiguration{NodeProfile: LightProfile}
+		require.NoError(t, p.ApplyNodeProfile())
+		require.True(t, p.KeepOnlyLatestState)
+		require.True(t, p.RemoveUntraceableBlocks)
+		require.True(t, p.SkipApplicationLogs)
+		require.True(t, p.SkipNEP17TransferLogs)
+	})
+	t.Run("archive", func(t *testing.T) {
+		p := ProtocolConfiguration{NodeProfile: ArchiveProfile}
+		require.NoError(t, p.ApplyNodeProfile())
+
+		p.RemoveUntraceableBlocks = true
+		require.Error(t, p.ApplyNodeProfile())
+	})
+	t.Run("spv", func(t *testing.T) {
+		p := ProtocolConfiguration{NodeProfile: SPVProfile}
+		require.NoError(t, p.ApplyNodeProfile())
+		require.True(t, p.HeadersOnly)
+
+		p = ProtocolConfiguration{NodeProfile: ArchiveProfile, HeadersOnly: true}
+		require.Error(t, p.ApplyNodeProfile())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		p := ProtocolConfiguration{NodeProfile: "full"}
+		require.Error(t, p.ApplyNodeProfile())
+	})
+	t.Run("load", func(t *testing.T) {
+		cfgPath := filepath.Join(newTempDir(t), "protocol.yml")
+		require.NoError(t, ioutil.WriteFile(cfgPath, []byte("ProtocolConfiguration:\n  NodeProfile: light\n"), 0644))
+		cfg, err := LoadFile(cfgPath)
+		require.NoError(t, err)
+		require.True(t, cfg.ProtocolConfiguration.KeepOnlyLatestState)
+
+		require.NoError(t, ioutil.WriteFile(cfgPath, []byte("ProtocolConfiguration:\n  NodeProfile: archive\n  KeepOnlyLatestState: true\n"), 0644))
+		_, err = LoadFile(cfgPath)
+		require.Error(t, err)
+	})
+}
+
+func newTempDir(t *testing.T) string {
+	d, err := ioutil.TempDir("", "neogo-config")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+	return d
+}
@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// InventoryCache contains configuration of recently seen inventory hashes
+// caches used to avoid duplicate data requests and announcements. Zero
+// values mean defaults.
+type InventoryCache struct {
+	// Size is the maximum number of hashes requested from peers that are
+	// remembered by the node.
+	Size int `yaml:"Size"`
+	// PeerSize is the maximum number of hashes announced by a single peer
+	// that are remembered for it.
+	PeerSize int `yaml:"PeerSize"`
+	// TTL is the time hashes are remembered for.
+	TTL time.Duration `yaml:"TTL"`
+	// RequestTimeout is the time after which a hash that wasn't received
+	// from the peer it was requested from can be requested from another
+	// peer announcing it.
+	RequestTimeout time.Duration `yaml:"RequestTimeout"`
+}
@@ -0,0 +1,20 @@
+package config
+
+// Logger contains node logging settings, log file path is set by LogPath
+// of ApplicationConfiguration.
+type Logger struct {
+	// Level is the default logging level ("debug", "info", "warn" or
+	// "error"), "info" is used if it's not set.
+	Level string `yaml:"Level"`
+	// Levels overrides the default level for subsystems, known subsystems
+	// are "core", "network", "consensus" and "rpc".
+	Levels map[string]string `yaml:"Levels"`
+	// Encoding is the log format, either "console" (default) or "json".
+	Encoding string `yaml:"Encoding"`
+	// MaxSize is the size of the log file in megabytes after which it's
+	// rotated, zero disables rotation.
+	MaxSize int `yaml:"MaxSize"`
+	// MaxBackups is the number of rotated log files kept, zero means that
+	// the log is just truncated on rotation.
+	MaxBackups int `yaml:"MaxBackups"`
+}
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// NAT contains configuration of automatic port mapping on the NAT gateway.
+// When it's enabled, node tries to map its P2P port via UPnP or NAT-PMP and
+// announces mapped external port to other nodes.
+type NAT struct {
+	Enabled bool `yaml:"Enabled"`
+	// Method is either "upnp", "pmp" or "any" (default).
+	Method string `yaml:"Method"`
+	// Gateway is NAT-PMP gateway address, default route is used if it's
+	// not specified.
+	Gateway string `yaml:"Gateway"`
+	// DiscoveryTimeout is the maximum time spent looking for the gateway.
+	DiscoveryTimeout time.Duration `yaml:"DiscoveryTimeout"`
+}
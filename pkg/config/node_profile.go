@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Node profiles combining storage-related settings.
+const (
+	// ArchiveProfile keeps everything: all blocks, all MPT states,
+	// application and transfer logs. It can't be combined with any setting
+	// removing data.
+	ArchiveProfile = "archive"
+	// DefaultProfile doesn't change any settings.
+	DefaultProfile = "default"
+	// LightProfile keeps only the latest MPT state, removes old blocks
+	// (leaving headers only) and doesn't store application and transfer logs.
+	LightProfile = "light"
+	// SPVProfile only synchronizes block headers (see HeadersOnly setting).
+	SPVProfile = "spv"
+)
+
+// ApplyNodeProfile adjusts storage settings according to NodeProfile, an
+// error is returned for unknown profiles and for archive profile combined
+// with settings that remove data.
+func (p *ProtocolConfiguration) ApplyNodeProfile() error {
+	switch p.NodeProfile {
+	case "", DefaultProfile:
+	case ArchiveProfile:
+		if p.KeepOnlyLatestState || p.RemoveUntraceableBlocks || p.AppLogsRetention != 0 ||
+			p.SkipApplicationLogs || p.SkipNEP17TransferLogs || p.HeadersOnly {
+			return errors.New("archive node profile can't be used with settings removing data")
+		}
+	case LightProfile:
+		p.KeepOnlyLatestState = true
+		p.RemoveUntraceableBlocks = true
+		p.SkipApplicationLogs = true
+		p.SkipNEP17TransferLogs = true
+	case SPVProfile:
+		p.HeadersOnly = true
+	default:
+		return fmt.Errorf("unknown node profile: %s", p.NodeProfile)
+	}
+	return nil
+}
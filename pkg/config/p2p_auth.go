@@ -0,0 +1,10 @@
+package config
+
+// P2PAuth contains configuration of authenticated P2P handshakes. When it's
+// enabled, every peer must prove possession of its node key and (if
+// AllowedKeys is not empty) this key must be in the list.
+type P2PAuth struct {
+	Enabled      bool     `yaml:"Enabled"`
+	UnlockWallet Wallet   `yaml:"UnlockWallet"`
+	AllowedKeys  []string `yaml:"AllowedKeys"`
+}
@@ -0,0 +1,23 @@
+package config
+
+import "time"
+
+// PeerBan contains configuration of misbehaving peers handling. Peers get
+// penalty points for malformed messages, protocol violations and handshake
+// timeouts, when they reach BanScore peer's IP address is banned for
+// BanDuration. Zero values mean defaults.
+type PeerBan struct {
+	// Disabled turns scoring and banning off (handshake timeout is still
+	// enforced).
+	Disabled bool `yaml:"Disabled"`
+	// HandshakeTimeout is the maximum time a peer can spend doing the
+	// handshake.
+	HandshakeTimeout time.Duration `yaml:"HandshakeTimeout"`
+	// BanScore is the number of penalty points for a peer to be banned.
+	BanScore int `yaml:"BanScore"`
+	// BanDuration is the time peers are banned for.
+	BanDuration time.Duration `yaml:"BanDuration"`
+	// File is the path to the file banned addresses are stored in to
+	// survive node restarts, they're not stored if it's empty.
+	File string `yaml:"File"`
+}
@@ -18,6 +18,21 @@ type (
 		KeepOnlyLatestState bool `yaml:"KeepOnlyLatestState"`
 		// RemoveUntraceableBlocks specifies if old blocks should be removed.
 		RemoveUntraceableBlocks bool `yaml:"RemoveUntraceableBlocks"`
+		// CompressAppLogs enables LZ4 compression of stored application logs.
+		// It's stored in the DB, so it can't be changed for existing DB.
+		CompressAppLogs bool `yaml:"CompressAppLogs"`
+		// BlockCacheSize and TransactionCacheSize are the numbers of
+		// recently accessed blocks and transactions cached in memory.
+		BlockCacheSize       int `yaml:"BlockCacheSize"`
+		TransactionCacheSize int `yaml:"TransactionCacheSize"`
+		// AppLogsRetention is the number of recent blocks to keep application
+		// logs for, logs of older blocks are removed. Zero keeps all logs.
+		AppLogsRetention uint32 `yaml:"AppLogsRetention"`
+		// HeadersOnly makes node synchronize and store only block headers
+		// without processing blocks and transactions (SPV mode). Node state
+		// is not available in this mode, so it can only serve a limited set
+		// of RPC calls.
+		HeadersOnly bool `yaml:"HeadersOnly"`
 		// MaxBlockSize is the maximum block size in bytes.
 		MaxBlockSize uint32 `yaml:"MaxBlockSize"`
 		// MaxBlockSystemFee is the maximum overall system fee per block.
@@ -26,17 +41,57 @@ type (
 		MaxTraceableBlocks uint32 `yaml:"MaxTraceableBlocks"`
 		// MaxTransactionsPerBlock is the maximum amount of transactions per block.
 		MaxTransactionsPerBlock uint16 `yaml:"MaxTransactionsPerBlock"`
+		// MaxReorgDepth is the number of recent blocks that can be rolled back
+		// to switch to another chain fork. It's only useful for private
+		// networks with consensus algorithms lacking immediate finality,
+		// zero (default) disables reorganizations.
+		MaxReorgDepth uint32 `yaml:"MaxReorgDepth"`
+		// NodeProfile is a shortcut for storage settings, it can be either
+		// "archive", "default", "light" or "spv" (see ApplyNodeProfile).
+		NodeProfile string `yaml:"NodeProfile"`
 		// NativeUpdateHistories is the list of histories of native contracts updates.
 		NativeUpdateHistories map[string][]uint32 `yaml:"NativeActivations"`
 		// P2PSigExtensions enables additional signature-related logic.
 		P2PSigExtensions bool `yaml:"P2PSigExtensions"`
 		// ReservedAttributes allows to have reserved attributes range for experimental or private purposes.
 		ReservedAttributes bool `yaml:"ReservedAttributes"`
+		// SkipApplicationLogs disables storing application logs, they're
+		// still available via notification subsystem.
+		SkipApplicationLogs bool `yaml:"SkipApplicationLogs"`
+		// SkipNEP17TransferLogs disables storing NEP-17 transfer logs,
+		// balances are tracked anyway.
+		SkipNEP17TransferLogs bool `yaml:"SkipNEP17TransferLogs"`
+		// SaveStateDiffs enables storing journals of all DB changes made by
+		// every block (available via getstatediff RPC call). If it's not
+		// set, journals are only kept for MaxReorgDepth recent blocks.
+		SaveStateDiffs bool `yaml:"SaveStateDiffs"`
 		// SaveStorageBatch enables storage batch saving before every persist.
 		SaveStorageBatch bool     `yaml:"SaveStorageBatch"`
 		SecondsPerBlock  int      `yaml:"SecondsPerBlock"`
 		SeedList         []string `yaml:"SeedList"`
 		StandbyCommittee []string `yaml:"StandbyCommittee"`
+		// StorageRefundPercent is the percentage of storage fee returned to the
+		// transaction sender for every byte released by deleting or shrinking
+		// stored values. Zero disables refunds.
+		StorageRefundPercent int `yaml:"StorageRefundPercent"`
+		// StorageRefundHeight is the height refunds are enabled at, it allows
+		// to enable them for existing networks without changing the state of
+		// already processed blocks.
+		StorageRefundHeight uint32 `yaml:"StorageRefundHeight"`
+		// ExtendedBlockItems enables PrimaryIndex and PrevStateRoot fields
+		// in block stack items returned to contracts.
+		ExtendedBlockItems bool `yaml:"ExtendedBlockItems"`
+		// ExtendedBlockItemsHeight is the height extended block items are
+		// enabled at, it allows to enable them for existing networks without
+		// changing the state of already processed blocks.
+		ExtendedBlockItemsHeight uint32 `yaml:"ExtendedBlockItemsHeight"`
+		// StrictBuffers makes Buffer items deserialized by contracts keep
+		// their type and MEMCPY check its destination type.
+		StrictBuffers bool `yaml:"StrictBuffers"`
+		// StrictBuffersHeight is the height strict buffers are enabled at,
+		// it allows to enable them for existing networks without changing
+		// the state of already processed blocks.
+		StrictBuffersHeight uint32 `yaml:"StrictBuffersHeight"`
 		// StateRooInHeader enables storing state root in block header.
 		StateRootInHeader bool `yaml:"StateRootInHeader"`
 		ValidatorsCount   int  `yaml:"ValidatorsCount"`
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/network/nat"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"gopkg.in/yaml.v2"
+)
+
+// ValidateFile performs static checks of the configuration file at the given
+// path for the given network (see LoadNetwork). It reports unknown keys in
+// addition to everything checked by Config.Validate. All problems found are
+// returned, nil means the file is correct.
+func ValidateFile(configPath string, network string) []error {
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return []error{fmt.Errorf("unable to read config: %w", err)}
+	}
+	var (
+		errs []error
+		mc   multiConfig
+	)
+	if err := yaml.UnmarshalStrict(data, &mc); err != nil {
+		errs = append(errs, fmt.Errorf("invalid config structure: %w", err))
+	}
+	for name, section := range mc.Networks {
+		sectionData, err := yaml.Marshal(section)
+		if err == nil {
+			err = yaml.UnmarshalStrict(sectionData, new(Config))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid '%s' network structure: %w", name, err))
+		}
+	}
+	cfg, err := LoadNetwork(configPath, network)
+	if err != nil {
+		return append(errs, err)
+	}
+	return append(errs, cfg.Validate()...)
+}
+
+// Validate checks the configuration for port conflicts between enabled
+// services and for missing wallet files. All problems found are returned.
+func (c Config) Validate() []error {
+	var (
+		errs []error
+		app  = c.ApplicationConfiguration
+	)
+
+	ports := make(map[string]string)
+	checkPort := func(service string, port string) {
+		if port == "" || port == "0" {
+			return
+		}
+		if other, ok := ports[port]; ok {
+			errs = append(errs, fmt.Errorf("port %s is used by both %s and %s", port, other, service))
+			return
+		}
+		ports[port] = service
+	}
+	checkPort("P2P", strconv.Itoa(int(app.NodePort)))
+	checkPort("P2P WebSocket", strconv.Itoa(int(app.NodeWSPort)))
+	if app.RPC.Enabled {
+		checkPort("RPC", strconv.Itoa(int(app.RPC.Port)))
+		if app.RPC.TLSConfig.Enabled {
+			checkPort("RPC TLS", strconv.Itoa(int(app.RPC.TLSConfig.Port)))
+		}
+		if audit := app.RPC.Audit; audit.Enabled && audit.Path == "" && !audit.Syslog {
+			errs = append(errs, errors.New("RPC audit log path is not set"))
+		}
+		switch q := app.RPC.SubscriberQueue; q.OverflowPolicy {
+		case "", rpc.OverflowDropNewest, rpc.OverflowDropOldest, rpc.OverflowDisconnect:
+		case rpc.OverflowBlock:
+			if q.BlockTimeout <= 0 {
+				errs = append(errs, errors.New("RPC subscriber queue block timeout is not set"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("unknown RPC subscriber queue overflow policy %q", q.OverflowPolicy))
+		}
+	}
+	if app.Prometheus.Enabled {
+		checkPort("Prometheus", app.Prometheus.Port)
+	}
+	if app.Pprof.Enabled {
+		checkPort("Pprof", app.Pprof.Port)
+	}
+
+	checkWallet := func(service string, w Wallet, required bool) {
+		if w.Path == "" {
+			if required {
+				errs = append(errs, fmt.Errorf("%s wallet path is not set", service))
+			}
+			return
+		}
+		if _, err := os.Stat(w.Path); err != nil {
+			errs = append(errs, fmt.Errorf("%s wallet: %w", service, err))
+		}
+		if w.passwordSources() > 1 {
+			errs = append(errs, fmt.Errorf("%s wallet has multiple password sources", service))
+		}
+	}
+	checkWallet("consensus", app.UnlockWallet, false)
+	if app.Oracle.Enabled {
+		checkWallet("oracle", app.Oracle.UnlockWallet, true)
+	}
+	if app.P2PNotary.Enabled {
+		checkWallet("P2PNotary", app.P2PNotary.UnlockWallet, true)
+	}
+	if app.P2PAuth.Enabled {
+		checkWallet("P2P authentication", app.P2PAuth.UnlockWallet, true)
+		for _, k := range app.P2PAuth.AllowedKeys {
+			if _, err := keys.NewPublicKeyFromString(k); err != nil {
+				errs = append(errs, fmt.Errorf("P2P authentication key %s: %w", k, err))
+			}
+		}
+	}
+	if app.NAT.Enabled {
+		if !nat.IsValidMethod(app.NAT.Method) {
+			errs = append(errs, fmt.Errorf("unknown NAT method %q", app.NAT.Method))
+		}
+		if app.NAT.Gateway != "" && net.ParseIP(app.NAT.Gateway) == nil {
+			errs = append(errs, fmt.Errorf("invalid NAT gateway address %q", app.NAT.Gateway))
+		}
+	}
+	if app.StateRoot.Enabled {
+		checkWallet("state root", app.StateRoot.UnlockWallet, true)
+	}
+	if app.BalanceTracker.Enabled {
+		if len(app.BalanceTracker.Accounts) == 0 {
+			errs = append(errs, errors.New("balance tracker has no accounts to track"))
+		}
+		for _, acc := range app.BalanceTracker.Accounts {
+			if _, err := address.ParseUint160(acc); err != nil {
+				errs = append(errs, fmt.Errorf("balance tracker account %s: %w", acc, err))
+			}
+		}
+	}
+	return errs
+}
@@ -1,7 +1,89 @@
 package config
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"time"
+)
+
 // Wallet is a wallet info.
 type Wallet struct {
 	Path     string `yaml:"Path"`
 	Password string `yaml:"Password"`
+	// PasswordEnv is the name of environment variable containing the
+	// password.
+	PasswordEnv string `yaml:"PasswordEnv"`
+	// PasswordFile is the path to the file containing the password, it
+	// can be a file descriptor like /dev/fd/3.
+	PasswordFile string `yaml:"PasswordFile"`
+	// PasswordCommand is an external command (with arguments) printing
+	// the password to its standard output.
+	PasswordCommand []string `yaml:"PasswordCommand"`
+	// RemoteSigners is a list of wallet accounts signing with external
+	// services instead of locally stored private keys.
+	RemoteSigners []RemoteSigner `yaml:"RemoteSigners"`
+}
+
+// RemoteSigner is a remote signing service configuration for a single wallet
+// account.
+type RemoteSigner struct {
+	// Address is an address of the wallet account.
+	Address string `yaml:"Address"`
+	// PublicKey is a hex-encoded public key of the account.
+	PublicKey string `yaml:"PublicKey"`
+	// URL is an HTTP endpoint of the signing service.
+	URL     string        `yaml:"URL"`
+	Timeout time.Duration `yaml:"Timeout"`
+}
+
+// passwordSources returns the number of password sources set.
+func (w Wallet) passwordSources() int {
+	var n int
+	for _, set := range []bool{w.Password != "", w.PasswordEnv != "",
+		w.PasswordFile != "", len(w.PasswordCommand) != 0} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// ResolvePassword returns the wallet password taken from the configured
+// source: Password itself, PasswordEnv environment variable, PasswordFile
+// contents or PasswordCommand output (trailing newlines are trimmed from the
+// last two). Empty password is returned if none of them is set. Sources other
+// than Password are read on every call, so it's up to the caller to keep the
+// result if needed.
+func (w Wallet) ResolvePassword() (string, error) {
+	if w.passwordSources() > 1 {
+		return "", errors.New("multiple wallet password sources are specified")
+	}
+	switch {
+	case w.PasswordEnv != "":
+		pass, ok := os.LookupEnv(w.PasswordEnv)
+		if !ok {
+			return "", fmt.Errorf("wallet password variable %s is not set", w.PasswordEnv)
+		}
+		return pass, nil
+	case w.PasswordFile != "":
+		data, err := ioutil.ReadFile(w.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("can't read wallet password: %w", err)
+		}
+		return string(bytes.TrimRight(data, "\r\n")), nil
+	case len(w.PasswordCommand) != 0:
+		cmd := exec.Command(w.PasswordCommand[0], w.PasswordCommand[1:]...)
+		cmd.Stderr = os.Stderr
+		data, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("wallet password command failed: %w", err)
+		}
+		return string(bytes.TrimRight(data, "\r\n")), nil
+	default:
+		return w.Password, nil
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/nspcc-dev/dbft"
@@ -24,6 +25,7 @@ import (
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/clock"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/atomic"
@@ -55,6 +57,13 @@ type Service interface {
 	OnPayload(p *npayload.Extensible)
 	// OnTransaction is a callback to notify Service about new received transaction.
 	OnTransaction(tx *transaction.Transaction)
+	// UpdateWallet loads the wallet specified by the configuration which
+	// replaces the current one starting with the next block, so that the
+	// current consensus round is finished with the old key.
+	UpdateWallet(w *config.Wallet) error
+	// GetState returns the current state of the consensus process, it can
+	// only be used after Start.
+	GetState() (*State, error)
 }
 
 type service struct {
@@ -70,9 +79,18 @@ type service struct {
 	transactions chan *transaction.Transaction
 	// blockEvents is used to pass a new block event to the consensus
 	// process.
-	blockEvents  chan *coreb.Block
+	blockEvents chan *coreb.Block
+	// stateRequests is used to get consensus state from the event loop.
+	stateRequests chan chan *State
+	// timer is dBFT timer tracking its deadline.
+	timer        *clockTimer
 	lastProposal []util.Uint256
 	wallet       *wallet.Wallet
+	// walletLock protects pendingWallet and pendingWalletCfg set by
+	// UpdateWallet.
+	walletLock       sync.Mutex
+	pendingWallet    *wallet.Wallet
+	pendingWalletCfg *config.Wallet
 	// started is a flag set with Start method that runs an event handling
 	// goroutine.
 	started  *atomic.Bool
@@ -103,6 +121,9 @@ type Config struct {
 	TimePerBlock time.Duration
 	// Wallet is a local-node wallet configuration.
 	Wallet *config.Wallet
+	// Clock is a time source for consensus timers and block timestamps,
+	// clock.Real is used if not set.
+	Clock clock.Clock
 }
 
 // NewService returns new consensus.Service instance.
@@ -114,6 +135,9 @@ func NewService(cfg Config) (Service, error) {
 	if cfg.Logger == nil {
 		return nil, errors.New("empty logger")
 	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.Real
+	}
 
 	srv := &service{
 		Config: cfg,
@@ -122,11 +146,13 @@ func NewService(cfg Config) (Service, error) {
 		txx:      newFIFOCache(cacheMaxCapacity),
 		messages: make(chan Payload, 100),
 
-		transactions: make(chan *transaction.Transaction, 100),
-		blockEvents:  make(chan *coreb.Block, 1),
-		started:      atomic.NewBool(false),
-		quit:         make(chan struct{}),
-		finished:     make(chan struct{}),
+		transactions:  make(chan *transaction.Transaction, 100),
+		blockEvents:   make(chan *coreb.Block, 1),
+		stateRequests: make(chan chan *State),
+		timer:         newClockTimer(cfg.Clock),
+		started:       atomic.NewBool(false),
+		quit:          make(chan struct{}),
+		finished:      make(chan struct{}),
 	}
 
 	if cfg.Wallet == nil {
@@ -135,27 +161,13 @@ func NewService(cfg Config) (Service, error) {
 
 	var err error
 
-	if srv.wallet, err = wallet.NewWalletFromFile(cfg.Wallet.Path); err != nil {
+	if srv.wallet, srv.Config.Wallet, err = openWallet(cfg.Wallet); err != nil {
 		return nil, err
 	}
 
-	// Check that wallet password is correct for at least one account.
-	var ok bool
-	for _, acc := range srv.wallet.Accounts {
-		err := acc.Decrypt(srv.Config.Wallet.Password)
-		if err == nil {
-			ok = true
-			break
-		}
-	}
-	if !ok {
-		return nil, errors.New("no account with provided password was found")
-	}
-
-	defer srv.wallet.Close()
-
 	srv.dbft = dbft.New(
 		dbft.WithLogger(srv.log),
+		dbft.WithTimer(srv.timer),
 		dbft.WithSecondsPerBlock(cfg.TimePerBlock),
 		dbft.WithGetKeyPair(srv.getKeyPair),
 		dbft.WithRequestTx(cfg.RequestTx),
@@ -245,6 +257,7 @@ func (s *service) newPrepareRequest() payload.PrepareRequest {
 func (s *service) Start() {
 	if s.started.CAS(false, true) {
 		s.log.Info("starting consensus service")
+		s.applyPendingWallet()
 		s.dbft.Start()
 		s.Chain.SubscribeForBlocks(s.blockEvents)
 		go s.eventLoop()
@@ -259,6 +272,41 @@ func (s *service) Shutdown() {
 	}
 }
 
+// UpdateWallet implements Service interface.
+func (s *service) UpdateWallet(cfg *config.Wallet) error {
+	if s.dbft == nil {
+		return errors.New("consensus service is not configured")
+	}
+	w, wc, err := openWallet(cfg)
+	if err != nil {
+		return err
+	}
+	s.walletLock.Lock()
+	if s.pendingWallet != nil {
+		s.pendingWallet.Close()
+	}
+	s.pendingWallet, s.pendingWalletCfg = w, wc
+	s.walletLock.Unlock()
+	s.log.Info("new consensus wallet loaded, it will be used since the next block",
+		zap.String("path", wc.Path))
+	return nil
+}
+
+// applyPendingWallet switches the service to the wallet set by UpdateWallet
+// if there is any. It must be called before starting new consensus round.
+func (s *service) applyPendingWallet() {
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+
+	if s.pendingWallet == nil {
+		return
+	}
+	s.wallet.Close()
+	s.wallet, s.Config.Wallet = s.pendingWallet, s.pendingWalletCfg
+	s.pendingWallet, s.pendingWalletCfg = nil, nil
+	s.log.Info("switched to the new consensus wallet", zap.String("path", s.Config.Wallet.Path))
+}
+
 func (s *service) eventLoop() {
 events:
 	for {
@@ -302,6 +350,8 @@ events:
 			s.dbft.OnTransaction(tx)
 		case b := <-s.blockEvents:
 			s.handleChainBlock(b)
+		case ch := <-s.stateRequests:
+			ch <- s.getState()
 		}
 		// Always process block event if there is any, we can add one above.
 		select {
@@ -320,6 +370,7 @@ func (s *service) handleChainBlock(b *coreb.Block) {
 			zap.Uint32("dbft index", s.dbft.BlockIndex),
 			zap.Uint32("chain index", s.Chain.BlockHeight()))
 		s.postBlock(b)
+		s.applyPendingWallet()
 		s.dbft.InitializeConsensus(0)
 	}
 }
@@ -343,6 +394,10 @@ func (s *service) getKeyPair(pubs []crypto.PublicKey) (int, crypto.PrivateKey, c
 			continue
 		}
 
+		if rs := acc.RemoteSigner(); rs != nil {
+			return i, &remoteKey{RemoteSigner: rs}, &publicKey{PublicKey: rs.PublicKey()}
+		}
+
 		key := acc.PrivateKey()
 		if acc.PrivateKey() == nil {
 			err := acc.Decrypt(s.Config.Wallet.Password)
@@ -359,6 +414,64 @@ func (s *service) getKeyPair(pubs []crypto.PublicKey) (int, crypto.PrivateKey, c
 	return -1, nil, nil
 }
 
+// openWallet opens the wallet specified by the configuration and checks that
+// it can be used for signing. Configuration with resolved password is returned
+// along with the wallet.
+func openWallet(cfg *config.Wallet) (*wallet.Wallet, *config.Wallet, error) {
+	var err error
+
+	// Password is resolved once as its source may be read only once (like
+	// a file descriptor).
+	wc := *cfg
+	if wc.Password, err = cfg.ResolvePassword(); err != nil {
+		return nil, nil, err
+	}
+
+	w, err := wallet.NewWalletFromFile(wc.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Keys are decrypted when needed, see getKeyPair.
+	defer w.Close()
+
+	// Check that wallet password is correct for at least one account or
+	// there is an account with a remote signer.
+	ok, err := setRemoteSigners(w, wc.RemoteSigners)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := 0; !ok && i < len(w.Accounts); i++ {
+		ok = w.Accounts[i].Decrypt(wc.Password) == nil
+	}
+	if !ok {
+		return nil, nil, errors.New("no account with provided password was found")
+	}
+	return w, &wc, nil
+}
+
+// setRemoteSigners attaches remote signers to the corresponding wallet
+// accounts, true is returned if there were any.
+func setRemoteSigners(w *wallet.Wallet, cfgs []config.RemoteSigner) (bool, error) {
+	for _, rc := range cfgs {
+		sh, err := address.StringToUint160(rc.Address)
+		if err != nil {
+			return false, fmt.Errorf("invalid remote signer address %s: %w", rc.Address, err)
+		}
+		acc := w.GetAccount(sh)
+		if acc == nil {
+			return false, fmt.Errorf("no account %s in the wallet for remote signer", rc.Address)
+		}
+		pub, err := keys.NewPublicKeyFromString(rc.PublicKey)
+		if err != nil {
+			return false, fmt.Errorf("invalid remote signer key for %s: %w", rc.Address, err)
+		}
+		if err := acc.SetRemoteSigner(wallet.NewRemoteSigner(rc.URL, pub, rc.Timeout)); err != nil {
+			return false, fmt.Errorf("can't set remote signer for %s: %w", rc.Address, err)
+		}
+	}
+	return len(cfgs) != 0, nil
+}
+
 func (s *service) payloadFromExtensible(ep *npayload.Extensible) *Payload {
 	return &Payload{
 		Extensible: *ep,
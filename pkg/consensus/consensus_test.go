@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/nspcc-dev/dbft/block"
+	"github.com/nspcc-dev/dbft/crypto"
 	"github.com/nspcc-dev/dbft/payload"
 	"github.com/nspcc-dev/dbft/timer"
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -25,6 +26,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/clock"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
@@ -337,6 +339,71 @@ func TestService_PrepareRequest(t *testing.T) {
 	})
 }
 
+func TestService_Clock(t *testing.T) {
+	c := clock.NewMock(time.Now())
+	srv := newTestServiceWithClock(t, newTestChain(t, false), c)
+	srv.dbft.Start()
+	t.Cleanup(srv.dbft.Timer.Stop)
+
+	require.Equal(t, srv.dbft.BlockIndex, srv.dbft.Timer.HV().Height)
+	if deadline := srv.timer.Deadline(); !deadline.IsZero() {
+		require.True(t, deadline.After(c.Now()))
+		select {
+		case <-srv.dbft.Timer.C():
+			t.Fatal("timer fired before the deadline")
+		default:
+		}
+		c.Set(deadline)
+	}
+	select {
+	case <-srv.dbft.Timer.C():
+	case <-time.After(time.Second):
+		t.Fatal("timer didn't fire")
+	}
+	require.True(t, srv.timer.Deadline().IsZero())
+}
+
+func TestService_UpdateWallet(t *testing.T) {
+	srv := newTestService(t)
+	pubs := srv.getValidators()
+	idx, _, pub := srv.getKeyPair(pubs)
+	require.NotEqual(t, -1, idx)
+
+	checkKey := func(t *testing.T, expectedIdx int, expectedPub crypto.PublicKey) {
+		i, _, p := srv.getKeyPair(pubs)
+		require.Equal(t, expectedIdx, i)
+		require.Equal(t, expectedPub, p)
+	}
+	t.Run("missing wallet", func(t *testing.T) {
+		require.Error(t, srv.UpdateWallet(&config.Wallet{Path: "./testdata/unknown.json", Password: "one"}))
+		srv.applyPendingWallet()
+		checkKey(t, idx, pub)
+	})
+	t.Run("bad password", func(t *testing.T) {
+		require.Error(t, srv.UpdateWallet(&config.Wallet{Path: "./testdata/wallet2.json", Password: "one"}))
+		srv.applyPendingWallet()
+		checkKey(t, idx, pub)
+	})
+
+	require.NoError(t, srv.UpdateWallet(&config.Wallet{Path: "./testdata/wallet2.json", Password: "two"}))
+	// The old key is used until the next block.
+	checkKey(t, idx, pub)
+	// Failed update doesn't discard the pending wallet.
+	require.Error(t, srv.UpdateWallet(&config.Wallet{Path: "./testdata/wallet3.json", Password: "two"}))
+
+	srv.applyPendingWallet()
+	newIdx, _, newPub := srv.getKeyPair(pubs)
+	require.NotEqual(t, -1, newIdx)
+	require.NotEqual(t, idx, newIdx)
+	require.NotEqual(t, pub, newPub)
+	require.Equal(t, "./testdata/wallet2.json", srv.Config.Wallet.Path)
+	require.Nil(t, srv.pendingWallet)
+
+	// Nothing changes without a new pending wallet.
+	srv.applyPendingWallet()
+	checkKey(t, newIdx, newPub)
+}
+
 func TestService_OnPayload(t *testing.T) {
 	srv := newTestService(t)
 	// This test directly reads things from srv.messages that normally
@@ -471,6 +538,10 @@ func newTestService(t *testing.T) *service {
 }
 
 func newTestServiceWithChain(t *testing.T, bc *core.Blockchain) *service {
+	return newTestServiceWithClock(t, bc, nil)
+}
+
+func newTestServiceWithClock(t *testing.T, bc *core.Blockchain, c clock.Clock) *service {
 	srv, err := NewService(Config{
 		Logger:                zaptest.NewLogger(t),
 		Broadcast:             func(*npayload.Extensible) {},
@@ -482,6 +553,7 @@ func newTestServiceWithChain(t *testing.T, bc *core.Blockchain) *service {
 			Path:     "./testdata/wallet1.json",
 			Password: "one",
 		},
+		Clock: c,
 	})
 	require.NoError(t, err)
 
@@ -5,8 +5,12 @@ import (
 	"errors"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
+// errRemoteKey is returned on attempts to serialize remote signer keys.
+var errRemoteKey = errors.New("remote signer key can't be serialized")
+
 // privateKey is a wrapper around keys.PrivateKey
 // which implements crypto.PrivateKey interface.
 type privateKey struct {
@@ -29,6 +33,28 @@ func (p *privateKey) Sign(data []byte) ([]byte, error) {
 	return p.PrivateKey.Sign(data), nil
 }
 
+// remoteKey is a wrapper around wallet.RemoteSigner which implements
+// crypto.PrivateKey interface. It can't be serialized since there is no
+// private key available locally.
+type remoteKey struct {
+	*wallet.RemoteSigner
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+func (p remoteKey) MarshalBinary() ([]byte, error) {
+	return nil, errRemoteKey
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (p *remoteKey) UnmarshalBinary([]byte) error {
+	return errRemoteKey
+}
+
+// Sign implements dbft's crypto.PrivateKey interface.
+func (p *remoteKey) Sign(data []byte) ([]byte, error) {
+	return p.RemoteSigner.Sign(data)
+}
+
 // publicKey is a wrapper around keys.PublicKey
 // which implements crypto.PublicKey interface.
 type publicKey struct {
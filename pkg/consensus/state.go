@@ -0,0 +1,108 @@
+package consensus
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nspcc-dev/dbft/payload"
+)
+
+// Consensus phases reported in State.
+const (
+	PhaseInitial      = "initial"
+	PhaseRequestSent  = "requestSentOrReceived"
+	PhaseCommitSent   = "commitSent"
+	PhaseBlockSent    = "blockSent"
+	PhaseViewChanging = "viewChanging"
+)
+
+// State is a snapshot of the consensus process state intended to be used for
+// debugging.
+type State struct {
+	// Height is the index of the block being accepted.
+	Height uint32
+	// View is the current view number.
+	View byte
+	// Primary is the index of the primary node for the current view.
+	Primary uint
+	// MyIndex is the index of this node in the validators list.
+	MyIndex int
+	// Validators is the number of validators.
+	Validators int
+	// Phase is one of Phase* constants.
+	Phase string
+	// Preparations is the number of known preparation (request and
+	// responses) payloads for the current view.
+	Preparations int
+	// Commits is the number of known commit payloads for the current view.
+	Commits int
+	// ChangeViews is the number of known change view payloads.
+	ChangeViews int
+	// TimerHeight and TimerView are the height and view timer is set for.
+	TimerHeight uint32
+	TimerView   byte
+	// Deadline is the time the timer fires at, it's zero if the timer
+	// is stopped.
+	Deadline time.Time
+}
+
+// GetState implements Service interface.
+func (s *service) GetState() (*State, error) {
+	if s.dbft == nil {
+		return nil, errors.New("consensus service is not configured")
+	}
+	if !s.started.Load() {
+		return nil, errors.New("consensus service is not started")
+	}
+	ch := make(chan *State, 1)
+	select {
+	case s.stateRequests <- ch:
+	case <-s.finished:
+		return nil, errors.New("consensus service is stopped")
+	}
+	return <-ch, nil
+}
+
+// getState returns the current consensus state, it must be called from the
+// event loop.
+func (s *service) getState() *State {
+	ctx := s.dbft.Context
+	st := &State{
+		Height:       ctx.BlockIndex,
+		View:         ctx.ViewNumber,
+		Primary:      ctx.PrimaryIndex,
+		MyIndex:      ctx.MyIndex,
+		Validators:   len(ctx.Validators),
+		Preparations: countPayloads(ctx.PreparationPayloads, int(ctx.ViewNumber)),
+		Commits:      countPayloads(ctx.CommitPayloads, int(ctx.ViewNumber)),
+		ChangeViews:  countPayloads(ctx.ChangeViewPayloads, -1),
+		Deadline:     s.timer.Deadline(),
+	}
+	hv := s.timer.HV()
+	st.TimerHeight, st.TimerView = hv.Height, hv.View
+	switch {
+	case ctx.BlockSent():
+		st.Phase = PhaseBlockSent
+	case ctx.CommitSent():
+		st.Phase = PhaseCommitSent
+	case ctx.ViewChanging():
+		st.Phase = PhaseViewChanging
+	case ctx.RequestSentOrReceived():
+		st.Phase = PhaseRequestSent
+	default:
+		st.Phase = PhaseInitial
+	}
+	return st
+}
+
+// countPayloads returns the number of non-nil payloads for the given view
+// (or any view if it's negative).
+func countPayloads(ps []payload.ConsensusPayload, view int) int {
+	var n int
+	for _, p := range ps {
+		if p != nil && (view < 0 || int(p.ViewNumber()) == view) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,143 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/dbft/timer"
+	"github.com/nspcc-dev/neo-go/pkg/util/clock"
+)
+
+// clockTimer is dBFT timer using clock.Clock as a time source and remembering
+// the time it fires at.
+type clockTimer struct {
+	clock clock.Clock
+	ch    chan time.Time
+
+	// lock protects fields below, they're changed from the event loop,
+	// but the timer fires in its own goroutine.
+	lock     sync.Mutex
+	hv       timer.HV
+	start    time.Time
+	d        time.Duration
+	deadline time.Time
+	t        clock.Timer
+	// gen is incremented every time the timer is stopped, so that callbacks
+	// of stopped underlying timers that are already running can be ignored.
+	gen uint64
+}
+
+var _ timer.Timer = (*clockTimer)(nil)
+
+// newClockTimer returns new dBFT timer using the given clock.
+func newClockTimer(c clock.Clock) *clockTimer {
+	return &clockTimer{
+		clock: c,
+		ch:    make(chan time.Time, 1),
+	}
+}
+
+// Now implements timer.Timer interface.
+func (t *clockTimer) Now() time.Time {
+	return t.clock.Now()
+}
+
+// Reset implements timer.Timer interface.
+func (t *clockTimer) Reset(hv timer.HV, d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.stop()
+	t.hv = hv
+	t.start = t.clock.Now()
+	t.d = d
+	t.schedule(d)
+}
+
+// Sleep implements timer.Timer interface.
+func (t *clockTimer) Sleep(d time.Duration) {
+	tm := t.clock.NewTimer(d)
+	<-tm.C()
+}
+
+// Extend implements timer.Timer interface.
+func (t *clockTimer) Extend(d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.d += d
+	if elapsed := t.clock.Now().Sub(t.start); t.d > elapsed {
+		t.stop()
+		t.schedule(t.d - elapsed)
+	}
+}
+
+// Stop implements timer.Timer interface.
+func (t *clockTimer) Stop() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.stop()
+}
+
+// HV implements timer.Timer interface.
+func (t *clockTimer) HV() timer.HV {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.hv
+}
+
+// C implements timer.Timer interface.
+func (t *clockTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// Deadline returns the time timer fires at or zero time if it's stopped.
+func (t *clockTimer) Deadline() time.Time {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.deadline
+}
+
+// schedule starts underlying timer for duration d, it must be called with
+// the lock held.
+func (t *clockTimer) schedule(d time.Duration) {
+	t.deadline = t.clock.Now().Add(d)
+	if d <= 0 {
+		t.t = nil
+		t.deadline = time.Time{}
+		t.tick()
+		return
+	}
+	gen := t.gen
+	t.t = t.clock.AfterFunc(d, func() {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		if t.gen != gen {
+			return // Stale timer, it's been stopped or reset.
+		}
+		t.deadline = time.Time{}
+		t.tick()
+	})
+}
+
+// tick sends timer event unless there is one already pending, it must be
+// called with the lock held.
+func (t *clockTimer) tick() {
+	select {
+	case t.ch <- t.clock.Now():
+	default:
+	}
+}
+
+// stop stops underlying timer and drops pending timer event, it must be
+// called with the lock held.
+func (t *clockTimer) stop() {
+	if t.t != nil {
+		t.t.Stop()
+		t.t = nil
+	}
+	t.gen++
+	t.deadline = time.Time{}
+	select {
+	case <-t.ch:
+	default:
+	}
+}
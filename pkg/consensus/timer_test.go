@@ -0,0 +1,92 @@
+package consensus
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/dbft/timer"
+	"github.com/nspcc-dev/neo-go/pkg/util/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock is a clock.Clock remembering AfterFunc callbacks without
+// running them, so that tests can run them at any moment.
+type manualClock struct {
+	lock  sync.Mutex
+	now   time.Time
+	funcs []func()
+}
+
+type nopTimer struct{}
+
+func (c *manualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *manualClock) NewTimer(d time.Duration) clock.Timer {
+	panic("not implemented")
+}
+
+func (c *manualClock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.funcs = append(c.funcs, f)
+	return nopTimer{}
+}
+
+func (nopTimer) C() <-chan time.Time        { return nil }
+func (nopTimer) Stop() bool                 { return true }
+func (nopTimer) Reset(d time.Duration) bool { return true }
+
+func isFired(t *clockTimer) bool {
+	select {
+	case <-t.C():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestClockTimer(t *testing.T) {
+	c := &manualClock{now: time.Now()}
+	tm := newClockTimer(c)
+
+	t.Run("fire", func(t *testing.T) {
+		tm.Reset(timer.HV{Height: 1}, time.Second)
+		require.Equal(t, 1, len(c.funcs))
+		require.False(t, tm.Deadline().IsZero())
+		c.funcs[0]()
+		require.True(t, tm.Deadline().IsZero())
+		require.True(t, isFired(tm))
+	})
+	t.Run("stale callback", func(t *testing.T) {
+		c.funcs = nil
+		tm.Reset(timer.HV{Height: 2}, time.Second)
+		tm.Reset(timer.HV{Height: 3}, time.Second)
+		require.Equal(t, 2, len(c.funcs))
+		c.funcs[0]() // Stopped by the second Reset.
+		require.False(t, isFired(tm))
+		require.False(t, tm.Deadline().IsZero())
+
+		c.funcs[1]()
+		require.True(t, isFired(tm))
+	})
+	t.Run("stop drops pending event", func(t *testing.T) {
+		c.funcs = nil
+		tm.Reset(timer.HV{Height: 4}, time.Second)
+		c.funcs[0]()
+		tm.Stop()
+		require.False(t, isFired(tm))
+	})
+	t.Run("reset drops pending event", func(t *testing.T) {
+		c.funcs = nil
+		tm.Reset(timer.HV{Height: 5}, time.Second)
+		c.funcs[0]()
+		tm.Reset(timer.HV{Height: 6}, time.Second)
+		require.False(t, isFired(tm))
+		require.Equal(t, uint32(6), tm.HV().Height)
+	})
+}
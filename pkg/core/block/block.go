@@ -215,6 +215,13 @@ func (b *Block) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SerializedSize returns the size of the serialized block using cached
+// transaction sizes, implementing io.Sizer interface. It must be defined for
+// Block explicitly, otherwise Header.SerializedSize would be used.
+func (b *Block) SerializedSize() int {
+	return b.GetExpectedBlockSize()
+}
+
 // GetExpectedBlockSize returns expected block size which should be equal to io.GetVarSize(b).
 func (b *Block) GetExpectedBlockSize() int {
 	var transactionsSize int
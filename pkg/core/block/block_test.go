@@ -243,14 +243,15 @@ func TestGetExpectedBlockSize(t *testing.T) {
 			b := newDumbBlock()
 			b.StateRootEnabled = stateRootEnabled
 			b.Transactions = []*transaction.Transaction{}
-			require.Equal(t, io.GetVarSize(b), b.GetExpectedBlockSize())
-			require.Equal(t, io.GetVarSize(b), b.GetExpectedBlockSizeWithoutTransactions(0))
+			require.Equal(t, io.GetEncodedSize(b), b.GetExpectedBlockSize())
+			require.Equal(t, io.GetEncodedSize(b), b.GetExpectedBlockSizeWithoutTransactions(0))
 		})
 		t.Run("with one transaction", func(t *testing.T) {
 			b := newDumbBlock()
 			b.StateRootEnabled = stateRootEnabled
-			expected := io.GetVarSize(b)
+			expected := io.GetEncodedSize(b)
 			require.Equal(t, expected, b.GetExpectedBlockSize())
+			require.Equal(t, expected, io.GetVarSize(b))
 			require.Equal(t, expected-b.Transactions[0].Size(), b.GetExpectedBlockSizeWithoutTransactions(len(b.Transactions)))
 		})
 		t.Run("with multiple transactions", func(t *testing.T) {
@@ -263,7 +264,7 @@ func TestGetExpectedBlockSize(t *testing.T) {
 				tx.Scripts = []transaction.Witness{{}}
 				b.Transactions[i] = tx
 			}
-			expected := io.GetVarSize(b)
+			expected := io.GetEncodedSize(b)
 			require.Equal(t, expected, b.GetExpectedBlockSize())
 			for _, tx := range b.Transactions {
 				expected -= tx.Size()
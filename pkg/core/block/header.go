@@ -11,6 +11,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
+// hashableFieldsSize is the size of the serialized hashable header fields
+// (without state root).
+const hashableFieldsSize = 4 + util.Uint256Size*2 + 8 + 4 + 1 + util.Uint160Size
+
 // Header holds the base info of a block.
 type Header struct {
 	// Version of the block.
@@ -72,6 +76,34 @@ func (b *Header) Hash() util.Uint256 {
 	return b.hash
 }
 
+// SerializedSize returns the size of the serialized header, it's calculated
+// from the header fields without encoding it, implementing io.Sizer interface.
+func (b *Header) SerializedSize() int {
+	size := hashableFieldsSize + 1 + // 1 is for the witness count.
+		io.GetVarSize(b.Script.InvocationScript) +
+		io.GetVarSize(b.Script.VerificationScript)
+	if b.StateRootEnabled {
+		size += util.Uint256Size
+	}
+	return size
+}
+
+// ViewNumber returns the consensus view number this block was accepted at
+// given the number of validators. It's derived from the primary index (primary
+// is chosen as (index - view) mod validatorsCount), so it's only precise modulo
+// validatorsCount.
+func (b *Header) ViewNumber(validatorsCount int) byte {
+	if validatorsCount <= 0 {
+		return 0
+	}
+	n := int64(validatorsCount)
+	view := (int64(b.Index) - int64(b.PrimaryIndex)) % n
+	if view < 0 {
+		view += n
+	}
+	return byte(view)
+}
+
 // DecodeBinary implements Serializable interface.
 func (b *Header) DecodeBinary(br *io.BinReader) {
 	b.decodeHashableFields(br)
@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -42,6 +43,7 @@ func testHeaderEncodeDecode(t *testing.T, stateRootEnabled bool) {
 	assert.Equal(t, header.Script.InvocationScript, headerDecode.Script.InvocationScript, "expected equal invocation scripts")
 	assert.Equal(t, header.Script.VerificationScript, headerDecode.Script.VerificationScript, "expected equal verification scripts")
 	assert.Equal(t, header.PrevStateRoot, headerDecode.PrevStateRoot, "expected equal state roots")
+	assert.Equal(t, io.GetEncodedSize(&header), header.SerializedSize(), "expected correct header size")
 }
 
 func TestHeaderEncodeDecode(t *testing.T) {
@@ -52,3 +54,22 @@ func TestHeaderEncodeDecode(t *testing.T) {
 		testHeaderEncodeDecode(t, true)
 	})
 }
+
+func TestHeaderViewNumber(t *testing.T) {
+	testCases := []struct {
+		index   uint32
+		primary byte
+		view    byte
+	}{
+		{index: 0, primary: 0, view: 0},
+		{index: 5, primary: 1, view: 0},
+		{index: 5, primary: 0, view: 1},
+		{index: 5, primary: 2, view: 3},
+		{index: 2, primary: 3, view: 3},
+	}
+	for _, tc := range testCases {
+		h := Header{Index: tc.index, PrimaryIndex: tc.primary}
+		assert.Equal(t, tc.view, h.ViewNumber(4), "index %d, primary %d", tc.index, tc.primary)
+	}
+	assert.Equal(t, byte(0), (&Header{Index: 5, PrimaryIndex: 3}).ViewNumber(0))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"runtime"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -27,6 +28,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -34,6 +36,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/clock"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"go.uber.org/zap"
@@ -42,7 +45,7 @@ import (
 // Tuning parameters.
 const (
 	headerBatchCount = 2000
-	version          = "0.1.0"
+	version          = "0.1.1"
 
 	defaultMemPoolSize                     = 50000
 	defaultP2PNotaryRequestPayloadPoolSize = 1000
@@ -50,6 +53,8 @@ const (
 	defaultMaxBlockSystemFee               = 900000000000
 	defaultMaxTraceableBlocks              = 2102400 // 1 year of 15s blocks
 	defaultMaxTransactionsPerBlock         = 512
+	defaultBlockCacheSize                  = 128
+	defaultTransactionCacheSize            = 4096
 	verificationGasLimit                   = 100000000 // 1 GAS
 )
 
@@ -71,6 +76,13 @@ var (
 	// conflicts with other transaction in the chain or pool according to
 	// Conflicts attribute.
 	ErrHasConflicts = errors.New("has conflicts")
+	// ErrHeadersOnly is returned when trying to add block or transaction
+	// to the chain running in HeadersOnly mode.
+	ErrHeadersOnly = errors.New("not supported in headers-only mode")
+	// ErrOnlyHeaderFound is returned when trying to get the block which is
+	// not yet processed, but its header is already known (it's stored
+	// during header-first synchronization).
+	ErrOnlyHeaderFound = errors.New("only header is found")
 )
 var (
 	persistInterval = 1 * time.Second
@@ -106,6 +118,9 @@ type Blockchain struct {
 	// Current top Block wrapped in an atomic.Value for safe access.
 	topBlock atomic.Value
 
+	// cache contains recently accessed blocks and transactions.
+	cache *chainCache
+
 	// Current persisted block count.
 	persistedHeight uint32
 
@@ -121,10 +136,16 @@ type Blockchain struct {
 	runToExitCh chan struct{}
 
 	memPool *mempool.Pool
+	// reverifyCh signals mempool reverification routine that a new block
+	// was added.
+	reverifyCh chan struct{}
 
 	// postBlock is a set of callback methods which should be run under the Blockchain lock after new block is persisted.
 	// Block's transactions are passed via mempool.
 	postBlock []func(blockchainer.Blockchainer, *mempool.Pool, *block.Block)
+	// changeHandlers are called with all DB changes made by the block after
+	// it's persisted.
+	changeHandlers []func(*block.Block, *state.StateDiff)
 
 	sbCommittee keys.PublicKeys
 
@@ -134,6 +155,21 @@ type Blockchain struct {
 
 	contracts native.Contracts
 
+	// interops contains all interop functions available to contracts
+	// (standard and custom ones) sorted by ID.
+	interops []interop.Function
+
+	// logSink receives contract messages and notifications, default
+	// logger-based one is used if it's nil.
+	logSink interop.LogSink
+
+	// clock is a time source for the persist timer and measurements.
+	clock clock.Clock
+
+	// appLogsRemovalFailed is set after a failure to remove old application
+	// logs and reset after successful removal, it's only used by storeBlock.
+	appLogsRemovalFailed bool
+
 	extensible atomic.Value
 
 	// defaultBlockWitness stores transaction.Witness with m out of n multisig,
@@ -161,9 +197,27 @@ type bcEvent struct {
 // given Store as its underlying storage. For it to work correctly you need
 // to spawn a goroutine for its Run method after this initialization.
 func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.Logger) (*Blockchain, error) {
+	return NewBlockchainWithInterops(s, cfg, log)
+}
+
+// NewBlockchainWithInterops is similar to NewBlockchain, but it also makes
+// the given custom interop functions (syscalls) available to contracts in
+// addition to the standard ones. It's intended to be used by applications
+// embedding the node for private networks, see interop.AddFunctions for
+// requirements to custom functions. All nodes of the network must have the
+// same set of interops.
+func NewBlockchainWithInterops(s storage.Store, cfg config.ProtocolConfiguration, log *zap.Logger,
+	custom ...interop.Function) (*Blockchain, error) {
 	if log == nil {
 		return nil, errors.New("empty logger")
 	}
+	if err := cfg.ApplyNodeProfile(); err != nil {
+		return nil, err
+	}
+	interops, err := interop.AddFunctions(systemInterops, custom...)
+	if err != nil {
+		return nil, fmt.Errorf("can't register custom interops: %w", err)
+	}
 
 	if cfg.MemPoolSize <= 0 {
 		cfg.MemPoolSize = defaultMemPoolSize
@@ -190,6 +244,18 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		log.Info("MaxTransactionsPerBlock is not set or wrong, using default value",
 			zap.Uint16("MaxTransactionsPerBlock", cfg.MaxTransactionsPerBlock))
 	}
+	if cfg.BlockCacheSize <= 0 {
+		cfg.BlockCacheSize = defaultBlockCacheSize
+		log.Info("BlockCacheSize is not set or wrong, using default value", zap.Int("BlockCacheSize", cfg.BlockCacheSize))
+	}
+	if cfg.TransactionCacheSize <= 0 {
+		cfg.TransactionCacheSize = defaultTransactionCacheSize
+		log.Info("TransactionCacheSize is not set or wrong, using default value",
+			zap.Int("TransactionCacheSize", cfg.TransactionCacheSize))
+	}
+	if cfg.StorageRefundPercent < 0 || cfg.StorageRefundPercent > 100 {
+		return nil, fmt.Errorf("invalid StorageRefundPercent: %d", cfg.StorageRefundPercent)
+	}
 	committee, err := committeeFromConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -198,17 +264,25 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		cfg.NativeUpdateHistories = map[string][]uint32{}
 		log.Info("NativeActivations are not set, using default values")
 	}
+	cache, err := newChainCache(cfg.BlockCacheSize, cfg.TransactionCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	bc := &Blockchain{
 		config:      cfg,
-		dao:         dao.NewSimple(s, cfg.StateRootInHeader),
+		cache:       cache,
+		dao:         dao.NewSimple(s, cfg.StateRootInHeader, cfg.CompressAppLogs),
 		stopCh:      make(chan struct{}),
 		runToExitCh: make(chan struct{}),
 		memPool:     mempool.New(cfg.MemPoolSize, 0, false),
+		reverifyCh:  make(chan struct{}, 1),
 		sbCommittee: committee,
 		log:         log,
 		events:      make(chan bcEvent),
 		subCh:       make(chan interface{}),
 		unsubCh:     make(chan interface{}),
+		interops:    interops,
+		clock:       clock.Real,
 
 		contracts: *native.NewContracts(cfg.P2PSigExtensions, cfg.NativeUpdateHistories),
 	}
@@ -243,12 +317,31 @@ func (bc *Blockchain) SetNotary(mod services.Notary) {
 	bc.contracts.Designate.NotaryService.Store(mod)
 }
 
+// SetLogSink makes all contract executions pass messages and notifications
+// emitted to the given sink instead of logging them. It's not protected by
+// mutex and must be called before `bc.Run()` to avoid data race.
+func (bc *Blockchain) SetLogSink(s interop.LogSink) {
+	bc.logSink = s
+}
+
+// SetClock replaces the time source used by Blockchain and its memory pool
+// (clock.Real by default). It's not protected by mutex and must be called
+// before `bc.Run()` to avoid data race.
+func (bc *Blockchain) SetClock(c clock.Clock) {
+	bc.clock = c
+	bc.memPool.SetClock(c)
+}
+
 func (bc *Blockchain) init() error {
 	// If we could not find the version in the Store, we know that there is nothing stored.
 	ver, err := bc.dao.GetVersion()
 	if err != nil {
 		bc.log.Info("no storage version found! creating genesis block")
-		if err = bc.dao.PutVersion(version); err != nil {
+		ver = dao.Version{
+			Value:           version,
+			CompressAppLogs: bc.config.CompressAppLogs,
+		}
+		if err = bc.dao.PutVersion(ver); err != nil {
 			return err
 		}
 		genesisBlock, err := createGenesisBlock(bc.config)
@@ -265,8 +358,12 @@ func (bc *Blockchain) init() error {
 		}
 		return bc.storeBlock(genesisBlock, nil)
 	}
-	if ver != version {
-		return fmt.Errorf("storage version mismatch betweeen %s and %s", version, ver)
+	if ver.Value != version {
+		return fmt.Errorf("storage version mismatch betweeen %s and %s", version, ver.Value)
+	}
+	if ver.CompressAppLogs != bc.config.CompressAppLogs {
+		return fmt.Errorf("CompressAppLogs setting mismatch (old=%t, new=%t)",
+			ver.CompressAppLogs, bc.config.CompressAppLogs)
 	}
 
 	// At this point there was no version found in the storage which
@@ -341,13 +438,13 @@ func (bc *Blockchain) init() error {
 		return fmt.Errorf("can't init cache for Management native contract: %w", err)
 	}
 
-	return bc.updateExtensibleWhitelist(bHeight)
+	return bc.updateExtensibleWhitelist(bHeight, false)
 }
 
 // Run runs chain loop, it needs to be run as goroutine and executing it is
 // critical for correct Blockchain operation.
 func (bc *Blockchain) Run() {
-	persistTimer := time.NewTimer(persistInterval)
+	persistTimer := bc.clock.NewTimer(persistInterval)
 	defer func() {
 		persistTimer.Stop()
 		if err := bc.persist(); err != nil {
@@ -359,11 +456,12 @@ func (bc *Blockchain) Run() {
 		close(bc.runToExitCh)
 	}()
 	go bc.notificationDispatcher()
+	go bc.mempoolReverifier()
 	for {
 		select {
 		case <-bc.stopCh:
 			return
-		case <-persistTimer.C:
+		case <-persistTimer.C():
 			go func() {
 				err := bc.persist()
 				if err != nil {
@@ -492,6 +590,9 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 	bc.addLock.Lock()
 	defer bc.addLock.Unlock()
 
+	if bc.config.HeadersOnly {
+		return ErrHeadersOnly
+	}
 	var mp *mempool.Pool
 	expectedHeight := bc.BlockHeight() + 1
 	if expectedHeight != block.Index {
@@ -513,7 +614,11 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 		if !block.MerkleRoot.Equals(merkle) {
 			return errors.New("invalid block: MerkleRoot mismatch")
 		}
+		if err := bc.verifyNextConsensus(block); err != nil {
+			return err
+		}
 		mp = mempool.New(len(block.Transactions), 0, false)
+		bc.preverifyTxWitnesses(block.Transactions)
 		for _, tx := range block.Transactions {
 			var err error
 			// Transactions are verified before adding them
@@ -536,16 +641,18 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 }
 
 // AddHeaders processes the given headers and add them to the
-// HeaderHashList. It expects headers to be sorted by index.
+// HeaderHashList. It expects headers to be sorted by index. Headers are
+// always verified in HeadersOnly mode, since they're the only source of
+// trusted data (including state roots) there.
 func (bc *Blockchain) AddHeaders(headers ...*block.Header) error {
-	return bc.addHeaders(bc.config.VerifyBlocks, headers...)
+	return bc.addHeaders(bc.config.VerifyBlocks || bc.config.HeadersOnly, headers...)
 }
 
 // addHeaders is an internal implementation of AddHeaders (`verify` parameter
 // tells it to verify or not verify given headers).
 func (bc *Blockchain) addHeaders(verify bool, headers ...*block.Header) error {
 	var (
-		start = time.Now()
+		start = bc.clock.Now()
 		batch = bc.dao.Store.Batch()
 		err   error
 	)
@@ -619,7 +726,7 @@ func (bc *Blockchain) addHeaders(verify bool, headers ...*block.Header) error {
 		bc.log.Debug("done processing headers",
 			zap.Int("headerIndex", len(bc.headerHashes)-1),
 			zap.Uint32("blockHeight", bc.BlockHeight()),
-			zap.Duration("took", time.Since(start)))
+			zap.Duration("took", bc.clock.Now().Sub(start)))
 	}
 	return nil
 }
@@ -633,7 +740,17 @@ func (bc *Blockchain) GetStateModule() blockchainer.StateRoot {
 // transactions with all appropriate side-effects and updates Blockchain state.
 // This is the only way to change Blockchain state.
 func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error {
-	cache := dao.NewCached(bc.dao)
+	var (
+		base    dao.DAO = bc.dao
+		changes *dao.Simple
+	)
+	if bc.config.MaxReorgDepth > 0 || bc.config.SaveStateDiffs || len(bc.changeHandlers) != 0 {
+		// Additional layer collects all block changes to save them
+		// as a state diff.
+		changes = bc.dao.GetWrapped().(*dao.Simple)
+		base = changes
+	}
+	cache := dao.NewCached(base)
 	writeBuf := io.NewBufBinWriter()
 	appExecResults := make([]*state.AppExecResult, 0, 2+len(block.Transactions))
 	if err := cache.StoreAsBlock(block, writeBuf); err != nil {
@@ -651,11 +768,13 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		return fmt.Errorf("onPersist failed: %w", err)
 	}
 	appExecResults = append(appExecResults, aer)
-	err = cache.PutAppExecResult(aer, writeBuf)
-	if err != nil {
-		return fmt.Errorf("failed to store onPersist exec result: %w", err)
+	if !bc.config.SkipApplicationLogs {
+		err = cache.PutAppExecResult(aer, writeBuf)
+		if err != nil {
+			return fmt.Errorf("failed to store onPersist exec result: %w", err)
+		}
+		writeBuf.Reset()
 	}
-	writeBuf.Reset()
 
 	for _, tx := range block.Transactions {
 		if err := cache.StoreAsTransaction(tx, block.Index, writeBuf); err != nil {
@@ -673,6 +792,10 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		err := v.Run()
 		var faultException string
 		if !v.HasFailed() {
+			if err := bc.refundReleasedStorage(systemInterop, tx, v.GasConsumed()); err != nil {
+				return fmt.Errorf("failed to refund storage fee: %w", err)
+			}
+			destroyed := bc.getDestroyedContractIDs(systemInterop.Notifications, cache)
 			_, err := systemInterop.DAO.Persist()
 			if err != nil {
 				return fmt.Errorf("failed to persist invocation results: %w", err)
@@ -680,6 +803,11 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 			for j := range systemInterop.Notifications {
 				bc.handleNotification(&systemInterop.Notifications[j], cache, block, tx.Hash())
 			}
+			for _, id := range destroyed {
+				if err := cache.DeleteNEP17Data(id); err != nil {
+					return fmt.Errorf("failed to remove NEP17 data of contract %d: %w", id, err)
+				}
+			}
 		} else {
 			bc.log.Warn("contract invocation failed",
 				zap.String("tx", tx.Hash().StringLE()),
@@ -699,18 +827,18 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 			},
 		}
 		appExecResults = append(appExecResults, aer)
-		err = cache.PutAppExecResult(aer, writeBuf)
-		if err != nil {
-			return fmt.Errorf("failed to store tx exec result: %w", err)
+		if !bc.config.SkipApplicationLogs {
+			err = cache.PutAppExecResult(aer, writeBuf)
+			if err != nil {
+				return fmt.Errorf("failed to store tx exec result: %w", err)
+			}
+			writeBuf.Reset()
 		}
-		writeBuf.Reset()
 
 		if bc.config.P2PSigExtensions {
 			for _, attr := range tx.GetAttributes(transaction.ConflictsT) {
 				hash := attr.Value.(*transaction.Conflicts).Hash
-				dummyTx := transaction.NewTrimmedTX(hash)
-				dummyTx.Version = transaction.DummyVersion
-				if err = cache.StoreAsTransaction(dummyTx, block.Index, writeBuf); err != nil {
+				if err = cache.StoreConflictRecord(hash, tx.Hash(), block.Index, writeBuf); err != nil {
 					return fmt.Errorf("failed to store conflicting transaction %s for transaction %s: %w", hash.StringLE(), tx.Hash().StringLE(), err)
 				}
 				writeBuf.Reset()
@@ -723,11 +851,13 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		return fmt.Errorf("postPersist failed: %w", err)
 	}
 	appExecResults = append(appExecResults, aer)
-	err = cache.AppendAppExecResult(aer, writeBuf)
-	if err != nil {
-		return fmt.Errorf("failed to store postPersist exec result: %w", err)
+	if !bc.config.SkipApplicationLogs {
+		err = cache.AppendAppExecResult(aer, writeBuf)
+		if err != nil {
+			return fmt.Errorf("failed to store postPersist exec result: %w", err)
+		}
+		writeBuf.Reset()
 	}
-	writeBuf.Reset()
 
 	d := cache.DAO.(*dao.Simple)
 	b := d.GetMPTBatch()
@@ -754,6 +884,23 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 			writeBuf.Reset()
 		}
 	}
+	if !bc.config.SkipApplicationLogs && bc.config.AppLogsRetention > 0 && block.Index >= bc.config.AppLogsRetention {
+		index := block.Index - bc.config.AppLogsRetention
+		old, err := cache.GetBlock(bc.GetHeaderHash(int(index)))
+		if err == nil {
+			err = cache.DeleteAppExecResults(old)
+		}
+		if err == nil {
+			bc.appLogsRemovalFailed = false
+		} else if !bc.appLogsRemovalFailed {
+			// It's likely to fail for every subsequent block too, so
+			// it's only logged once until removal succeeds again.
+			bc.appLogsRemovalFailed = true
+			bc.log.Warn("error while removing old application logs",
+				zap.Uint32("index", index),
+				zap.Error(err))
+		}
+	}
 	// Every persist cycle we also compact our in-memory MPT. It's flushed
 	// already in AddMPTBatch, so collapsing it is safe.
 	persistedHeight := atomic.LoadUint32(&bc.persistedHeight)
@@ -762,8 +909,12 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		mpt.Collapse(10)
 	}
 
+	var diff *state.StateDiff
 	bc.lock.Lock()
 	_, err = cache.Persist()
+	if err == nil && changes != nil {
+		diff, err = bc.persistStateDiff(changes, block.Index)
+	}
 	if err != nil {
 		bc.lock.Unlock()
 		return err
@@ -774,16 +925,26 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	bc.topBlock.Store(block)
 	atomic.StoreUint32(&bc.blockHeight, block.Index)
 	bc.memPool.RemoveStale(func(tx *transaction.Transaction) bool { return bc.IsTxStillRelevant(tx, txpool, false) }, bc)
+	select {
+	case bc.reverifyCh <- struct{}{}:
+	default: // Reverification is already scheduled.
+	}
 	for _, f := range bc.postBlock {
 		f(bc, txpool, block)
 	}
-	if err := bc.updateExtensibleWhitelist(block.Index); err != nil {
+	if err := bc.updateExtensibleWhitelist(block.Index, false); err != nil {
 		bc.lock.Unlock()
 		return err
 	}
 	bc.lock.Unlock()
 
+	if diff != nil {
+		for _, f := range bc.changeHandlers {
+			f(block, diff)
+		}
+	}
 	updateBlockHeightMetric(block.Index)
+	bc.updateConsensusMetrics(block)
 	// Genesis block is stored when Blockchain is not yet running, so there
 	// is no one to read this event. And it doesn't make much sense as event
 	// anyway.
@@ -793,14 +954,224 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	return nil
 }
 
-func (bc *Blockchain) updateExtensibleWhitelist(height uint32) error {
+// refundReleasedStorage returns a part of storage fee for the bytes released
+// during transaction execution back to its sender. Refund can't exceed the
+// amount of GAS consumed by the transaction script. It's only done starting
+// from StorageRefundHeight, the refund is recorded by GAS contract and paid
+// when the block is post-persisted.
+func (bc *Blockchain) refundReleasedStorage(ic *interop.Context, tx *transaction.Transaction, gasConsumed int64) error {
+	if bc.config.StorageRefundPercent == 0 || ic.ReleasedStorage == 0 ||
+		ic.Block.Index < bc.config.StorageRefundHeight {
+		return nil
+	}
+	refund := ic.ReleasedStorage * bc.GetStoragePrice() * int64(bc.config.StorageRefundPercent) / 100
+	if refund > gasConsumed {
+		refund = gasConsumed
+	}
+	return bc.contracts.GAS.AddStorageRefund(ic.DAO, tx.Sender(), big.NewInt(refund))
+}
+
+// updateConsensusMetrics updates primary, view number and block time drift
+// metrics using the given block and its predecessor.
+func (bc *Blockchain) updateConsensusMetrics(b *block.Block) {
+	if b.Index == 0 {
+		return
+	}
+	prev, err := bc.GetHeader(b.PrevHash)
+	if err != nil {
+		return
+	}
+	drift := int64(b.Timestamp-prev.Timestamp) - int64(bc.config.SecondsPerBlock)*1000
+	updateConsensusMetrics(b.PrimaryIndex, b.ViewNumber(bc.config.ValidatorsCount), drift)
+}
+
+// Rollback reverts the chain state to the given height removing all blocks
+// and headers above it, so that blocks from another fork can be added after
+// that. It only works for networks with MaxReorgDepth setting enabled and
+// can't revert more than MaxReorgDepth blocks. Transactions of the removed
+// blocks are returned to the memory pool if they're still valid.
+func (bc *Blockchain) Rollback(height uint32) error {
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+
+	current := bc.BlockHeight()
+	if bc.config.MaxReorgDepth == 0 {
+		return errors.New("chain reorganizations are disabled")
+	}
+	if height >= current {
+		return fmt.Errorf("can't rollback to %d, current height is %d", height, current)
+	}
+	if current-height > bc.config.MaxReorgDepth {
+		return fmt.Errorf("can't rollback %d blocks, MaxReorgDepth is %d", current-height, bc.config.MaxReorgDepth)
+	}
+
+	var (
+		cache = bc.dao.GetWrapped().(*dao.Simple)
+		txes  []*transaction.Transaction
+	)
+	for i := current; i > height; i-- {
+		b, err := cache.GetBlock(bc.GetHeaderHash(int(i)))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", i, err)
+		}
+		for _, t := range b.Transactions {
+			tx, _, err := cache.GetTransaction(t.Hash()) // Block is trimmed.
+			if err != nil {
+				return fmt.Errorf("failed to get transaction %s: %w", t.Hash().StringLE(), err)
+			}
+			txes = append(txes, tx)
+		}
+		d, err := cache.GetStateDiff(i)
+		if err != nil {
+			return fmt.Errorf("failed to get state diff for block %d: %w", i, err)
+		}
+		for _, c := range d.Changes {
+			if c.Old != nil {
+				err = cache.Store.Put(c.Key, c.Old)
+			} else {
+				err = cache.Store.Delete(c.Key)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := cache.DeleteStateDiff(i); err != nil {
+			return err
+		}
+	}
+	top, err := cache.GetBlock(bc.GetHeaderHash(int(height)))
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	bc.headerHashesLock.Lock()
+	// Headers above the new height are removed too, they belong to the
+	// old fork.
+	for _, h := range bc.headerHashes[height+1:] {
+		err = cache.Store.Delete(storage.AppendPrefix(storage.DataBlock, h.BytesBE()))
+		if err != nil {
+			break
+		}
+	}
+	storedHeaderCount := bc.storedHeaderCount
+	for err == nil && storedHeaderCount > height+1 {
+		storedHeaderCount -= headerBatchCount
+		err = cache.Store.Delete(storage.AppendPrefixInt(storage.IXHeaderHashList, int(storedHeaderCount)))
+	}
+	if err == nil {
+		err = cache.PutCurrentHeader(hashAndIndexToBytes(top.Hash(), top.Index))
+	}
+	if err == nil {
+		_, err = cache.Persist()
+	}
+	if err != nil {
+		bc.headerHashesLock.Unlock()
+		return err
+	}
+	bc.headerHashes = bc.headerHashes[:height+1]
+	bc.storedHeaderCount = storedHeaderCount
+	bc.headerHashesLock.Unlock()
+
+	bc.topBlock.Store(top)
+	atomic.StoreUint32(&bc.blockHeight, height)
+	bc.cache.reset()
+	if atomic.LoadUint32(&bc.persistedHeight) > height {
+		atomic.StoreUint32(&bc.persistedHeight, height)
+	}
+	if err := bc.stateRoot.Init(height, bc.config.KeepOnlyLatestState); err != nil {
+		return fmt.Errorf("can't init MPT at height %d: %w", height, err)
+	}
+	if err := bc.contracts.ResetCaches(bc, bc.dao); err != nil {
+		return fmt.Errorf("can't reset native contracts caches: %w", err)
+	}
+	if err := bc.updateExtensibleWhitelist(height, true); err != nil {
+		return err
+	}
+	bc.memPool.RemoveStale(func(tx *transaction.Transaction) bool { return bc.IsTxStillRelevant(tx, nil, false) }, bc)
+	for _, tx := range txes {
+		_ = bc.verifyAndPoolTx(tx, bc.memPool, bc)
+	}
+	updateHeaderHeightMetric(int(height))
+	updateBlockHeightMetric(height)
+	bc.log.Info("chain rolled back",
+		zap.Uint32("from", current),
+		zap.Uint32("to", height))
+	return nil
+}
+
+// persistStateDiff saves the journal of all changes made by the block along
+// with the block changes themselves (the journal itself is only saved if
+// it's needed for rollbacks or configured to be saved), it also removes the
+// journal of the block that can't be rolled back anymore (unless all journals
+// are to be saved). The journal is returned.
+func (bc *Blockchain) persistStateDiff(changes *dao.Simple, index uint32) (*state.StateDiff, error) {
+	batch := changes.GetBatch()
+	d := &state.StateDiff{
+		Index:   index,
+		Changes: make([]state.KeyChange, 0, len(batch.Put)+len(batch.Deleted)),
+	}
+	for _, kv := range batch.Put {
+		d.Changes = append(d.Changes, state.KeyChange{Key: kv.Key, New: nonNilValue(kv.Value)})
+	}
+	for _, kv := range batch.Deleted {
+		if kv.Exists { // Nothing was changed otherwise.
+			d.Changes = append(d.Changes, state.KeyChange{Key: kv.Key})
+		}
+	}
+	sort.Slice(d.Changes, func(i, j int) bool {
+		return bytes.Compare(d.Changes[i].Key, d.Changes[j].Key) < 0
+	})
+	for i := range d.Changes {
+		v, err := bc.dao.Store.Get(d.Changes[i].Key)
+		if err == nil {
+			d.Changes[i].Old = nonNilValue(v)
+		} else if !errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, fmt.Errorf("failed to get previous value: %w", err)
+		}
+	}
+	if bc.config.MaxReorgDepth > 0 || bc.config.SaveStateDiffs {
+		if err := changes.PutStateDiff(d); err != nil {
+			return nil, err
+		}
+		if !bc.config.SaveStateDiffs && index >= bc.config.MaxReorgDepth {
+			if err := changes.DeleteStateDiff(index - bc.config.MaxReorgDepth); err != nil {
+				return nil, err
+			}
+		}
+	}
+	_, err := changes.Persist()
+	return d, err
+}
+
+// nonNilValue returns v or an empty slice if v is nil, it allows to
+// distinguish empty values from missing ones in state diffs.
+func nonNilValue(v []byte) []byte {
+	if v == nil {
+		return []byte{}
+	}
+	return v
+}
+
+// GetStateDiff returns the journal of all DB changes made by the block with
+// the given index. Journals are only stored with SaveStateDiffs setting
+// enabled (or for MaxReorgDepth recent blocks).
+func (bc *Blockchain) GetStateDiff(index uint32) (*state.StateDiff, error) {
+	return bc.dao.GetStateDiff(index)
+}
+
+// updateExtensibleWhitelist updates the list of accounts allowed to send
+// extensible payloads if it could've been changed at the given height, force
+// makes it always recalculate the list.
+func (bc *Blockchain) updateExtensibleWhitelist(height uint32, force bool) error {
 	updateCommittee := native.ShouldUpdateCommittee(height, bc)
 	stateVals, sh, err := bc.contracts.Designate.GetDesignatedByRole(bc.dao, noderoles.StateValidator, height)
 	if err != nil {
 		return err
 	}
 
-	if bc.extensible.Load() != nil && !updateCommittee && sh != height {
+	if !force && bc.extensible.Load() != nil && !updateCommittee && sh != height {
 		return nil
 	}
 
@@ -822,10 +1193,7 @@ func (bc *Blockchain) updateExtensibleWhitelist(height uint32) error {
 		bc.updateExtensibleList(&newList, stateVals)
 	}
 
-	sort.Slice(newList, func(i, j int) bool {
-		return newList[i].Less(newList[j])
-	})
-	bc.extensible.Store(newList)
+	bc.extensible.Store(util.Uint160Slice(newList).Unique())
 	return nil
 }
 
@@ -837,9 +1205,8 @@ func (bc *Blockchain) updateExtensibleList(s *[]util.Uint160, pubs keys.PublicKe
 
 // IsExtensibleAllowed determines if script hash is allowed to send extensible payloads.
 func (bc *Blockchain) IsExtensibleAllowed(u util.Uint160) bool {
-	us := bc.extensible.Load().([]util.Uint160)
-	n := sort.Search(len(us), func(i int) bool { return !us[i].Less(u) })
-	return n < len(us)
+	us := bc.extensible.Load().(util.Uint160Slice)
+	return us.Contains(u)
 }
 
 func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.Cached, trig trigger.Type) (*state.AppExecResult, error) {
@@ -907,6 +1274,33 @@ func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.C
 	bc.processNEP17Transfer(d, h, b, note.ScriptHash, from, to, amount)
 }
 
+// getDestroyedContractIDs returns IDs of the contracts destroyed according to
+// the given notifications. It must be called before invocation results are
+// persisted into d, so that contract states can still be retrieved from it.
+func (bc *Blockchain) getDestroyedContractIDs(notes []state.NotificationEvent, d dao.DAO) []int32 {
+	var ids []int32
+	mgmt := bc.contracts.Management
+	for i := range notes {
+		if !notes[i].ScriptHash.Equals(mgmt.Hash) || notes[i].Name != "Destroy" {
+			continue
+		}
+		arr, ok := notes[i].Item.Value().([]stackitem.Item)
+		if !ok || len(arr) != 1 {
+			continue
+		}
+		h, err := arr[0].TryBytes()
+		if err != nil {
+			continue
+		}
+		cs, err := mgmt.GetContract(d, parseUint160(h))
+		if err != nil {
+			continue // Deployed and destroyed by the same transaction.
+		}
+		ids = append(ids, cs.ID)
+	}
+	return ids
+}
+
 func parseUint160(addr []byte) util.Uint160 {
 	if u, err := util.Uint160DecodeBytesBE(addr); err == nil {
 		return u
@@ -941,18 +1335,23 @@ func (bc *Blockchain) processNEP17Transfer(cache *dao.Cached, h util.Uint256, b
 		if err != nil {
 			return
 		}
+		if err := addNEP17Holder(cache, balances, nativeContract == nil, id, fromAddr); err != nil {
+			return
+		}
 		bs := balances.Trackers[id]
 		bs.Balance = *new(big.Int).Sub(&bs.Balance, amount)
 		bs.LastUpdatedBlock = b.Index
 		balances.Trackers[id] = bs
-		transfer.Amount = *new(big.Int).Sub(&transfer.Amount, amount)
-		balances.NewBatch, err = cache.AppendNEP17Transfer(fromAddr,
-			balances.NextTransferBatch, balances.NewBatch, transfer)
-		if err != nil {
-			return
-		}
-		if balances.NewBatch {
-			balances.NextTransferBatch++
+		if !bc.config.SkipNEP17TransferLogs {
+			transfer.Amount = *new(big.Int).Sub(&transfer.Amount, amount)
+			balances.NewBatch, err = cache.AppendNEP17Transfer(fromAddr,
+				balances.NextTransferBatch, balances.NewBatch, transfer)
+			if err != nil {
+				return
+			}
+			if balances.NewBatch {
+				balances.NextTransferBatch++
+			}
 		}
 		if err := cache.PutNEP17Balances(fromAddr, balances); err != nil {
 			return
@@ -963,19 +1362,24 @@ func (bc *Blockchain) processNEP17Transfer(cache *dao.Cached, h util.Uint256, b
 		if err != nil {
 			return
 		}
+		if err := addNEP17Holder(cache, balances, nativeContract == nil, id, toAddr); err != nil {
+			return
+		}
 		bs := balances.Trackers[id]
 		bs.Balance = *new(big.Int).Add(&bs.Balance, amount)
 		bs.LastUpdatedBlock = b.Index
 		balances.Trackers[id] = bs
 
-		transfer.Amount = *amount
-		balances.NewBatch, err = cache.AppendNEP17Transfer(toAddr,
-			balances.NextTransferBatch, balances.NewBatch, transfer)
-		if err != nil {
-			return
-		}
-		if balances.NewBatch {
-			balances.NextTransferBatch++
+		if !bc.config.SkipNEP17TransferLogs {
+			transfer.Amount = *amount
+			balances.NewBatch, err = cache.AppendNEP17Transfer(toAddr,
+				balances.NextTransferBatch, balances.NewBatch, transfer)
+			if err != nil {
+				return
+			}
+			if balances.NewBatch {
+				balances.NextTransferBatch++
+			}
 		}
 		if err := cache.PutNEP17Balances(toAddr, balances); err != nil {
 			return
@@ -983,18 +1387,73 @@ func (bc *Blockchain) processNEP17Transfer(cache *dao.Cached, h util.Uint256, b
 	}
 }
 
-// ForEachNEP17Transfer executes f for each nep17 transfer in log.
-func (bc *Blockchain) ForEachNEP17Transfer(acc util.Uint160, f func(*state.NEP17Transfer) (bool, error)) error {
+// addNEP17Holder adds the account to the holders index of the contract with
+// the given ID if it has no balance tracker for this contract yet. Native
+// contracts can't be destroyed, so they're not indexed.
+func addNEP17Holder(cache *dao.Cached, balances *state.NEP17Balances, indexed bool, id int32, acc util.Uint160) error {
+	if _, ok := balances.Trackers[id]; ok || !indexed {
+		return nil
+	}
+	return cache.PutNEP17Holder(id, acc)
+}
+
+// ForEachNEP17Transfer executes f for each nep17 transfer in log going from
+// the newest transfer to the oldest one starting at the (newestTimestamp,
+// index) position: transfers with timestamp exceeding newestTimestamp are
+// skipped as well as the first index transfers with timestamp equal to
+// newestTimestamp (several transfers can have the same timestamp). Thus the
+// iteration can be continued from the timestamp of the last transfer
+// processed and the number of processed transfers with this timestamp. Batch
+// to start from is found with a binary search, so newer transfers don't have
+// to be read.
+func (bc *Blockchain) ForEachNEP17Transfer(acc util.Uint160, newestTimestamp uint64, index int, f func(*state.NEP17Transfer) (bool, error)) error {
 	balances, err := bc.dao.GetNEP17Balances(acc)
 	if err != nil {
 		return nil
 	}
-	for i := int(balances.NextTransferBatch); i >= 0; i-- {
+	var (
+		searchErr error
+		last      = int(balances.NextTransferBatch)
+	)
+	if balances.NewBatch {
+		last-- // Batch with NextTransferBatch index is not yet created.
+	}
+	// Index of the first batch having only transfers that are too new.
+	start := sort.Search(last+1, func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		lg, err := bc.dao.GetNEP17TransferLog(acc, uint32(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		oldest, err := lg.Oldest()
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return oldest == nil || oldest.Timestamp > newestTimestamp
+	})
+	if searchErr != nil {
+		return searchErr
+	}
+	var skipped int
+	for i := start - 1; i >= 0; i-- {
 		lg, err := bc.dao.GetNEP17TransferLog(acc, uint32(i))
 		if err != nil {
 			return nil
 		}
-		cont, err := lg.ForEach(f)
+		cont, err := lg.ForEach(func(tr *state.NEP17Transfer) (bool, error) {
+			if tr.Timestamp > newestTimestamp {
+				return true, nil
+			}
+			if tr.Timestamp == newestTimestamp && skipped < index {
+				skipped++
+				return true, nil
+			}
+			return f(tr)
+		})
 		if err != nil {
 			return err
 		}
@@ -1061,7 +1520,7 @@ func (bc *Blockchain) LastBatch() *storage.MemBatch {
 // persist flushes current in-memory Store contents to the persistent storage.
 func (bc *Blockchain) persist() error {
 	var (
-		start     = time.Now()
+		start     = bc.clock.Now()
 		persisted int
 		err       error
 	)
@@ -1087,7 +1546,7 @@ func (bc *Blockchain) persist() error {
 			zap.Int("keys", persisted),
 			zap.Uint32("headerHeight", storedHeaderHeight),
 			zap.Uint32("blockHeight", bHeight),
-			zap.Duration("took", time.Since(start)))
+			zap.Duration("took", bc.clock.Now().Sub(start)))
 
 		// update monitoring metrics.
 		updatePersistedHeightMetric(bHeight)
@@ -1101,7 +1560,30 @@ func (bc *Blockchain) GetTransaction(hash util.Uint256) (*transaction.Transactio
 	if tx, ok := bc.memPool.TryGetValue(hash); ok {
 		return tx, math.MaxUint32, nil // the height is not actually defined for memPool transaction.
 	}
-	return bc.dao.GetTransaction(hash)
+	if tx, height, ok := bc.cache.getTransaction(hash); ok && bc.isTraceable(height) {
+		return tx, height, nil
+	}
+	gen := bc.cache.generation()
+	tx, height, err := bc.dao.GetTransaction(hash)
+	if err != nil {
+		return nil, 0, err
+	}
+	bc.cache.putTransaction(gen, tx, height)
+	return tx, height, nil
+}
+
+// isTraceable checks whether block with the given index is not yet removed
+// (if old blocks are removed at all).
+func (bc *Blockchain) isTraceable(index uint32) bool {
+	return !bc.config.RemoveUntraceableBlocks || index+bc.config.MaxTraceableBlocks > bc.BlockHeight()
+}
+
+// GetTransactionConflict returns the hash of on-chain transaction that has the
+// given hash in its Conflicts attribute and the height of the block it's
+// included in. Zero hash is returned for conflicts recorded by older node
+// versions.
+func (bc *Blockchain) GetTransactionConflict(hash util.Uint256) (util.Uint256, uint32, error) {
+	return bc.dao.GetConflictRecord(hash)
 }
 
 // GetAppExecResults returns application execution results with the specified trigger by the given
@@ -1115,11 +1597,6 @@ func (bc *Blockchain) GetStorageItem(id int32, key []byte) state.StorageItem {
 	return bc.dao.GetStorageItem(id, key)
 }
 
-// GetStorageItems returns all storage items for a given contract id.
-func (bc *Blockchain) GetStorageItems(id int32) (map[string]state.StorageItem, error) {
-	return bc.dao.GetStorageItems(id)
-}
-
 // GetBlock returns a Block by the given hash.
 func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	topBlock := bc.topBlock.Load()
@@ -1129,13 +1606,17 @@ func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 			return tb, nil
 		}
 	}
+	if b := bc.cache.getBlock(hash); b != nil && bc.isTraceable(b.Index) {
+		return b, nil
+	}
 
+	gen := bc.cache.generation()
 	block, err := bc.dao.GetBlock(hash)
 	if err != nil {
 		return nil, err
 	}
 	if !block.MerkleRoot.Equals(util.Uint256{}) && len(block.Transactions) == 0 {
-		return nil, errors.New("only header is found")
+		return nil, ErrOnlyHeaderFound
 	}
 	for _, tx := range block.Transactions {
 		stx, _, err := bc.dao.GetTransaction(tx.Hash())
@@ -1144,6 +1625,7 @@ func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 		}
 		*tx = *stx
 	}
+	bc.cache.putBlock(gen, block)
 	return block, nil
 }
 
@@ -1257,6 +1739,14 @@ func (bc *Blockchain) GetNatives() []state.NativeContract {
 	return res
 }
 
+// GetInterops returns a list of all interop functions (syscalls) available to
+// contracts on this chain including custom ones, sorted by ID.
+func (bc *Blockchain) GetInterops() []interop.Function {
+	res := make([]interop.Function, len(bc.interops))
+	copy(res, bc.interops)
+	return res
+}
+
 // GetConfig returns the config stored in the blockchain.
 func (bc *Blockchain) GetConfig() config.ProtocolConfiguration {
 	return bc.config
@@ -1382,10 +1872,12 @@ var (
 	ErrHdrInvalidTimestamp = errors.New("block is not newer than the previous one")
 	ErrHdrStateRootSetting = errors.New("state root setting mismatch")
 	ErrHdrInvalidStateRoot = errors.New("state root for previous block is invalid")
+	ErrHdrNextConsensus    = errors.New("next consensus doesn't match validators")
 )
 
 func (bc *Blockchain) verifyHeader(currHeader, prevHeader *block.Header) error {
-	if bc.config.StateRootInHeader {
+	// There is no local state to compare with in headers-only mode.
+	if bc.config.StateRootInHeader && !bc.config.HeadersOnly {
 		if sr := bc.stateRoot.CurrentLocalStateRoot(); currHeader.PrevStateRoot != sr {
 			return fmt.Errorf("%w: %s != %s",
 				ErrHdrInvalidStateRoot, currHeader.PrevStateRoot.StringLE(), sr.StringLE())
@@ -1403,12 +1895,38 @@ func (bc *Blockchain) verifyHeader(currHeader, prevHeader *block.Header) error {
 	return bc.verifyHeaderWitnesses(currHeader, prevHeader)
 }
 
+// verifyNextConsensus checks that NextConsensus of the block to be added is
+// the multisignature address of validators of the next block.
+func (bc *Blockchain) verifyNextConsensus(b *block.Block) error {
+	var (
+		validators keys.PublicKeys
+		err        error
+	)
+	if native.ShouldUpdateCommittee(b.Index, bc) {
+		validators, err = bc.GetValidators()
+	} else {
+		validators, err = bc.GetNextBlockValidators()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get validators: %w", err)
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(validators)
+	if err != nil {
+		return err
+	}
+	if h := hash.Hash160(script); !b.NextConsensus.Equals(h) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrHdrNextConsensus,
+			address.Uint160ToString(h), address.Uint160ToString(b.NextConsensus))
+	}
+	return nil
+}
+
 // Various errors that could be returned upon verification.
 var (
 	ErrTxExpired         = errors.New("transaction has expired")
 	ErrInsufficientFunds = errors.New("insufficient funds")
 	ErrTxSmallNetworkFee = errors.New("too small network fee")
-	ErrTxTooBig          = errors.New("too big transaction")
+	ErrTxTooBig          = transaction.ErrTooBig
 	ErrMemPoolConflict   = errors.New("invalid transaction due to conflicts with the memory pool")
 	ErrInvalidScript     = errors.New("invalid script")
 	ErrInvalidAttribute  = errors.New("invalid attribute")
@@ -1417,6 +1935,9 @@ var (
 // verifyAndPoolTx verifies whether a transaction is bonafide or not and tries
 // to add it to the mempool given.
 func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.Pool, feer mempool.Feer, data ...interface{}) error {
+	if bc.config.HeadersOnly {
+		return ErrHeadersOnly
+	}
 	// This code can technically be moved out of here, because it doesn't
 	// really require a chain lock.
 	err := vm.IsScriptCorrect(t.Script, nil)
@@ -1429,26 +1950,8 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	if t.ValidUntilBlock <= height || !isPartialTx && t.ValidUntilBlock > height+transaction.MaxValidUntilBlockIncrement {
 		return fmt.Errorf("%w: ValidUntilBlock = %d, current height = %d", ErrTxExpired, t.ValidUntilBlock, height)
 	}
-	// Policying.
-	if err := bc.contracts.Policy.CheckPolicy(bc.dao, t); err != nil {
-		// Only one %w can be used.
-		return fmt.Errorf("%w: %v", ErrPolicy, err)
-	}
-	size := t.Size()
-	if size > transaction.MaxTransactionSize {
-		return fmt.Errorf("%w: (%d > MaxTransactionSize %d)", ErrTxTooBig, size, transaction.MaxTransactionSize)
-	}
-	needNetworkFee := int64(size) * bc.FeePerByte()
-	if bc.P2PSigExtensionsEnabled() {
-		attrs := t.GetAttributes(transaction.NotaryAssistedT)
-		if len(attrs) != 0 {
-			na := attrs[0].Value.(*transaction.NotaryAssisted)
-			needNetworkFee += (int64(na.NKeys) + 1) * transaction.NotaryServiceFeePerKey
-		}
-	}
-	netFee := t.NetworkFee - needNetworkFee
-	if netFee < 0 {
-		return fmt.Errorf("%w: net fee is %v, need %v", ErrTxSmallNetworkFee, t.NetworkFee, needNetworkFee)
+	if err := bc.verifyTxPolicy(t); err != nil {
+		return err
 	}
 	// check that current tx wasn't included in the conflicts attributes of some other transaction which is already in the chain
 	if err := bc.dao.HasTransaction(t.Hash()); err != nil {
@@ -1456,14 +1959,24 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 		case errors.Is(err, dao.ErrAlreadyExists):
 			return fmt.Errorf("blockchain: %w", ErrAlreadyExists)
 		case errors.Is(err, dao.ErrHasConflicts):
+			if conflicting, _, err := bc.dao.GetConflictRecord(t.Hash()); err == nil && !conflicting.Equals(util.Uint256{}) {
+				return fmt.Errorf("blockchain: %w with on-chain transaction %s", ErrHasConflicts, conflicting.StringLE())
+			}
 			return fmt.Errorf("blockchain: %w", ErrHasConflicts)
 		default:
 			return err
 		}
 	}
-	err = bc.verifyTxWitnesses(t, nil, isPartialTx)
-	if err != nil {
-		return err
+	witnessKey, cacheable := bc.witnessCacheKey(t)
+	cacheable = cacheable && !isPartialTx
+	if !cacheable || !bc.memPool.WitnessesVerified(t.Hash(), witnessKey) {
+		err = bc.verifyTxWitnesses(t, nil, isPartialTx)
+		if err != nil {
+			return err
+		}
+		if cacheable {
+			bc.memPool.MarkWitnessesVerified(t.Hash(), witnessKey)
+		}
 	}
 	if err := bc.verifyTxAttributes(t, isPartialTx); err != nil {
 		return err
@@ -1489,6 +2002,79 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	return nil
 }
 
+// verifyTxPolicy checks transaction against the current Policy contract
+// settings (blocked accounts and fee per byte) and size limit.
+func (bc *Blockchain) verifyTxPolicy(t *transaction.Transaction) error {
+	if err := bc.contracts.Policy.CheckPolicy(bc.dao, t); err != nil {
+		// Only one %w can be used.
+		return fmt.Errorf("%w: %v", ErrPolicy, err)
+	}
+	size := t.Size()
+	if size > transaction.MaxTransactionSize {
+		return fmt.Errorf("%w: (%d > MaxTransactionSize %d)", ErrTxTooBig, size, transaction.MaxTransactionSize)
+	}
+	needNetworkFee := int64(size) * bc.FeePerByte()
+	if bc.P2PSigExtensionsEnabled() {
+		attrs := t.GetAttributes(transaction.NotaryAssistedT)
+		if len(attrs) != 0 {
+			na := attrs[0].Value.(*transaction.NotaryAssisted)
+			needNetworkFee += (int64(na.NKeys) + 1) * transaction.NotaryServiceFeePerKey
+		}
+	}
+	netFee := t.NetworkFee - needNetworkFee
+	if netFee < 0 {
+		return fmt.Errorf("%w: net fee is %v, need %v", ErrTxSmallNetworkFee, t.NetworkFee, needNetworkFee)
+	}
+	return nil
+}
+
+// mempoolReverifier is a goroutine that rechecks mempooled transactions after
+// every new block against the updated chain state evicting the ones that
+// can't be included into the next block anymore. Quick checks are done by
+// RemoveStale synchronously, while this routine does the heavier ones in the
+// background not to delay block processing.
+func (bc *Blockchain) mempoolReverifier() {
+	for {
+		select {
+		case <-bc.stopCh:
+			return
+		case <-bc.reverifyCh:
+			bc.reverifyMempool()
+		}
+	}
+}
+
+// reverifyMempool checks all mempooled transactions and removes the ones that
+// are no longer valid (which is signalled to mempool subscribers).
+func (bc *Blockchain) reverifyMempool() {
+	for _, tx := range bc.memPool.GetVerifiedTransactions() {
+		select {
+		case <-bc.stopCh:
+			return
+		default:
+		}
+		bc.lock.RLock()
+		err := bc.reverifyTx(tx)
+		bc.lock.RUnlock()
+		if err != nil {
+			bc.memPool.Remove(tx.Hash(), bc)
+			bc.log.Debug("transaction evicted from mempool",
+				zap.String("hash", tx.Hash().StringLE()),
+				zap.Error(err))
+		}
+	}
+}
+
+// reverifyTx checks whether already pooled transaction still conforms to the
+// current policy and can be included into the next block.
+func (bc *Blockchain) reverifyTx(t *transaction.Transaction) error {
+	height := bc.BlockHeight()
+	if t.ValidUntilBlock <= height {
+		return fmt.Errorf("%w: ValidUntilBlock = %d, current height = %d", ErrTxExpired, t.ValidUntilBlock, height)
+	}
+	return bc.verifyTxPolicy(t)
+}
+
 func (bc *Blockchain) verifyTxAttributes(tx *transaction.Transaction, isPartialTx bool) error {
 	for i := range tx.Attributes {
 		switch attrType := tx.Attributes[i].Type; attrType {
@@ -1675,6 +2261,12 @@ func (bc *Blockchain) GetEnrollments() ([]state.Validator, error) {
 	return bc.contracts.NEO.GetCandidates(bc.dao)
 }
 
+// GetAllCandidates returns all candidates including the ones that were
+// unregistered, but still have some votes.
+func (bc *Blockchain) GetAllCandidates() ([]state.Validator, error) {
+	return bc.contracts.NEO.GetAllCandidates(bc.dao)
+}
+
 // GetTestVM returns a VM and a Store setup for a test run of some sort of code.
 func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) *vm.VM {
 	d := bc.dao.GetWrapped().(*dao.Simple)
@@ -1790,6 +2382,10 @@ func (bc *Blockchain) verifyHashAgainstScript(hash util.Uint160, witness *transa
 // not yet added into any block.
 // Golang implementation of VerifyWitnesses method in C# (https://github.com/neo-project/neo/blob/master/neo/SmartContract/Helper.cs#L87).
 func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block.Block, isPartialTx bool) error {
+	// Transactions decoded from JSON or built locally aren't checked for it.
+	if len(t.Scripts) != len(t.Signers) {
+		return fmt.Errorf("%w: %d vs %d", transaction.ErrInvalidWitnessNum, len(t.Signers), len(t.Scripts))
+	}
 	interopCtx := bc.newInteropContext(trigger.Verification, bc.dao, block, t)
 	gasLimit := t.NetworkFee - int64(t.Size())*bc.FeePerByte()
 	if bc.P2PSigExtensionsEnabled() {
@@ -1811,6 +2407,78 @@ func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block
 	return nil
 }
 
+// witnessCacheKey returns the key for witness verification results cache (see
+// mempool.Pool.MarkWitnessesVerified) of the given transaction. It covers the
+// witnesses and policy values affecting verification GAS limit and price. False
+// is returned for transactions with non-standard verification scripts, as their
+// verification result can depend on the chain state.
+func (bc *Blockchain) witnessCacheKey(t *transaction.Transaction) (util.Uint256, bool) {
+	for i := range t.Scripts {
+		if !vm.IsStandardContract(t.Scripts[i].VerificationScript) {
+			return util.Uint256{}, false
+		}
+	}
+	w := io.NewBufBinWriter()
+	w.WriteArray(t.Scripts)
+	w.WriteU64LE(uint64(bc.FeePerByte()))
+	w.WriteU64LE(uint64(bc.GetBaseExecFee()))
+	if w.Err != nil {
+		return util.Uint256{}, false
+	}
+	return hash.Sha256(w.Bytes()), true
+}
+
+// preverifyTxWitnesses concurrently verifies witnesses of the given
+// transactions that are not in the memory pool and marks successfully verified
+// ones in the witness verification cache (see witnessCacheKey). Block
+// transactions are then checked sequentially by verifyAndPoolTx which skips
+// cached witnesses, so signature checks of blocks with many (multisig)
+// witnesses are spread over all available CPUs. Only transactions with
+// standard verification scripts are processed here as they don't depend on
+// the chain state, verification errors are ignored since they're reported by
+// verifyAndPoolTx.
+func (bc *Blockchain) preverifyTxWitnesses(txes []*transaction.Transaction) {
+	toVerify := make([]*transaction.Transaction, 0, len(txes))
+	for _, tx := range txes {
+		if bc.memPool.ContainsKey(tx.Hash()) {
+			continue
+		}
+		key, ok := bc.witnessCacheKey(tx)
+		if !ok || bc.memPool.WitnessesVerified(tx.Hash(), key) {
+			continue
+		}
+		toVerify = append(toVerify, tx)
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(toVerify) {
+		workers = len(toVerify)
+	}
+	if workers < 2 {
+		return
+	}
+	var (
+		wg  sync.WaitGroup
+		txc = make(chan *transaction.Transaction)
+	)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for tx := range txc {
+				key, _ := bc.witnessCacheKey(tx)
+				if bc.verifyTxWitnesses(tx, nil, false) == nil {
+					bc.memPool.MarkWitnessesVerified(tx.Hash(), key)
+				}
+			}
+		}()
+	}
+	for _, tx := range toVerify {
+		txc <- tx
+	}
+	close(txc)
+	wg.Wait()
+}
+
 // verifyHeaderWitnesses is a block-specific implementation of VerifyWitnesses logic.
 func (bc *Blockchain) verifyHeaderWitnesses(currHeader, prevHeader *block.Header) error {
 	var hash util.Uint160
@@ -1846,7 +2514,8 @@ func hashAndIndexToBytes(h util.Uint256, index uint32) []byte {
 
 func (bc *Blockchain) newInteropContext(trigger trigger.Type, d dao.DAO, block *block.Block, tx *transaction.Transaction) *interop.Context {
 	ic := interop.NewContext(trigger, bc, d, bc.contracts.Management.GetContract, bc.contracts.Contracts, block, tx, bc.log)
-	ic.Functions = systemInterops
+	ic.Functions = bc.interops
+	ic.LogSink = bc.logSink
 	switch {
 	case tx != nil:
 		ic.Container = tx
@@ -1861,6 +2530,22 @@ func (bc *Blockchain) P2PSigExtensionsEnabled() bool {
 	return bc.config.P2PSigExtensions
 }
 
+// RegisterChangeHandler appends provided function to the list of functions
+// which are called with all DB changes made by the block after it's stored.
+// Handlers are called synchronously, so the next block isn't processed until
+// they return, and they can use GetDAO to read the resulting state. Keys of
+// the changes can be decoded with dao helpers like dao.ParseStorageItemKey.
+// Handlers must be registered before the Blockchain is started.
+func (bc *Blockchain) RegisterChangeHandler(f func(*block.Block, *state.StateDiff)) {
+	bc.changeHandlers = append(bc.changeHandlers, f)
+}
+
+// GetDAO returns the read-only data access object of the Blockchain, it
+// can be used by external index builders to read the chain state.
+func (bc *Blockchain) GetDAO() dao.Reader {
+	return bc.dao
+}
+
 // RegisterPostBlock appends provided function to the list of functions which should be run after new block
 // is stored.
 func (bc *Blockchain) RegisterPostBlock(f func(blockchainer.Blockchainer, *mempool.Pool, *block.Block)) {
@@ -3,9 +3,11 @@ package core
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"path"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -17,7 +19,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
 	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
@@ -41,6 +45,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 )
 
 func TestVerifyHeader(t *testing.T) {
@@ -194,6 +199,54 @@ func TestAddBadBlock(t *testing.T) {
 	require.NoError(t, bc.AddBlock(b3))
 }
 
+func TestAddBlockNextConsensus(t *testing.T) {
+	bc := newTestChain(t)
+
+	b := bc.newBlock()
+	b.NextConsensus = util.Uint160{1, 2, 3}
+	b.Script.InvocationScript = testchain.Sign(b)
+	require.True(t, errors.Is(bc.AddBlock(b), ErrHdrNextConsensus))
+
+	bc.config.VerifyBlocks = false
+	require.NoError(t, bc.AddBlock(b))
+}
+
+func TestHeadersOnly(t *testing.T) {
+	bc := newTestChain(t)
+	blocks, err := bc.genBlocks(3)
+	require.NoError(t, err)
+
+	spv := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.HeadersOnly = true
+		c.ProtocolConfiguration.VerifyBlocks = false
+	})
+	require.True(t, errors.Is(spv.AddBlock(blocks[0]), ErrHeadersOnly))
+
+	hdrs := make([]*block.Header, len(blocks))
+	for i := range blocks {
+		hdrs[i] = &blocks[i].Header
+	}
+	// Headers are verified even though VerifyBlocks is disabled.
+	bad := *hdrs[0]
+	bad.Script.InvocationScript = []byte{byte(opcode.PUSH1)}
+	require.Error(t, spv.AddHeaders(&bad))
+	require.Equal(t, uint32(0), spv.HeaderHeight())
+
+	require.NoError(t, spv.AddHeaders(hdrs...))
+	require.Equal(t, uint32(3), spv.HeaderHeight())
+	require.Equal(t, uint32(0), spv.BlockHeight())
+
+	h, err := spv.GetHeader(blocks[2].Hash())
+	require.NoError(t, err)
+	require.Equal(t, blocks[2].Hash(), h.Hash())
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.ValidUntilBlock = 100
+	addSigners(neoOwner, tx)
+	require.NoError(t, testchain.SignTx(bc, tx))
+	require.True(t, errors.Is(spv.PoolTx(tx), ErrHeadersOnly))
+}
+
 func TestGetHeader(t *testing.T) {
 	bc := newTestChain(t)
 	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
@@ -234,6 +287,14 @@ func TestGetBlock(t *testing.T) {
 		assert.NoError(t, bc.persist())
 	}
 
+	t.Run("cached", func(t *testing.T) {
+		b1, err := bc.GetBlock(blocks[0].Hash())
+		require.NoError(t, err)
+		b2, err := bc.GetBlock(blocks[0].Hash())
+		require.NoError(t, err)
+		require.Same(t, b1, b2)
+	})
+
 	t.Run("store only header", func(t *testing.T) {
 		t.Run("non-empty block", func(t *testing.T) {
 			tx, err := testchain.NewTransferFromOwner(bc, bc.contracts.NEO.Hash,
@@ -243,7 +304,7 @@ func TestGetBlock(t *testing.T) {
 			require.NoError(t, bc.AddHeaders(&b.Header))
 
 			_, err = bc.GetBlock(b.Hash())
-			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrOnlyHeaderFound))
 
 			_, err = bc.GetHeader(b.Hash())
 			require.NoError(t, err)
@@ -342,6 +403,20 @@ func TestVerifyTx(t *testing.T) {
 		require.NoError(t, accs[1].SignTx(netmode.UnitTestNet, tx))
 		err := bc.VerifyTx(tx)
 		require.True(t, errors.Is(err, ErrPolicy))
+
+		t.Run("in block", func(t *testing.T) {
+			require.True(t, errors.Is(bc.AddBlock(bc.newBlock(tx)), ErrPolicy))
+		})
+	})
+	t.Run("BlockedCosigner", func(t *testing.T) {
+		tx := bc.newTestTx(h, testScript)
+		tx.Signers = append(tx.Signers, transaction.Signer{
+			Account: accs[1].PrivateKey().GetScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		})
+		require.NoError(t, accs[0].SignTx(netmode.UnitTestNet, tx))
+		require.NoError(t, accs[1].SignTx(netmode.UnitTestNet, tx))
+		checkErr(t, ErrPolicy, tx)
 	})
 	t.Run("InsufficientGas", func(t *testing.T) {
 		balance := bc.GetUtilityTokenBalance(h)
@@ -356,6 +431,12 @@ func TestVerifyTx(t *testing.T) {
 		require.NoError(t, accs[0].SignTx(netmode.UnitTestNet, tx))
 		checkErr(t, ErrTxTooBig, tx)
 	})
+	t.Run("InvalidWitnessNum", func(t *testing.T) {
+		tx := bc.newTestTx(h, testScript)
+		require.NoError(t, accs[0].SignTx(netmode.UnitTestNet, tx))
+		tx.Scripts = nil
+		checkErr(t, transaction.ErrInvalidWitnessNum, tx)
+	})
 	t.Run("NetworkFee", func(t *testing.T) {
 		t.Run("SmallNetworkFee", func(t *testing.T) {
 			tx := bc.newTestTx(h, testScript)
@@ -480,6 +561,11 @@ func TestVerifyTx(t *testing.T) {
 		tx.Scripts[0].InvocationScript[10] = ^tx.Scripts[0].InvocationScript[10]
 		checkErr(t, ErrVerificationFailed, tx)
 	})
+	t.Run("OtherNetworkSignature", func(t *testing.T) {
+		tx := bc.newTestTx(h, testScript)
+		require.NoError(t, accs[0].SignTx(netmode.TestNet, tx))
+		checkErr(t, ErrVerificationFailed, tx)
+	})
 	t.Run("InsufficientNetworkFeeForSecondWitness", func(t *testing.T) {
 		tx := bc.newTestTx(h, testScript)
 		tx.Signers = append(tx.Signers, transaction.Signer{
@@ -767,10 +853,11 @@ func TestVerifyTx(t *testing.T) {
 				t.Run("dummy on-chain conflict", func(t *testing.T) {
 					tx := bc.newTestTx(h, testScript)
 					require.NoError(t, accs[0].SignTx(netmode.UnitTestNet, tx))
-					dummyTx := transaction.NewTrimmedTX(tx.Hash())
-					dummyTx.Version = transaction.DummyVersion
-					require.NoError(t, bc.dao.StoreAsTransaction(dummyTx, bc.blockHeight, nil))
-					require.True(t, errors.Is(bc.VerifyTx(tx), ErrHasConflicts))
+					conflicting := random.Uint256()
+					require.NoError(t, bc.dao.StoreConflictRecord(tx.Hash(), conflicting, bc.blockHeight, nil))
+					err := bc.VerifyTx(tx)
+					require.True(t, errors.Is(err, ErrHasConflicts))
+					require.Contains(t, err.Error(), conflicting.StringLE())
 				})
 				t.Run("attribute on-chain conflict", func(t *testing.T) {
 					tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
@@ -786,6 +873,23 @@ func TestVerifyTx(t *testing.T) {
 					txConflict := getConflictsTx(tx.Hash())
 					require.Error(t, bc.VerifyTx(txConflict))
 				})
+				t.Run("conflicted by on-chain transaction", func(t *testing.T) {
+					conflicted := bc.newTestTx(h, testScript)
+					require.NoError(t, accs[0].SignTx(netmode.UnitTestNet, conflicted))
+					tx := getConflictsTx(conflicted.Hash())
+					require.NoError(t, bc.AddBlock(bc.newBlock(tx)))
+
+					conflicting, height, err := bc.GetTransactionConflict(conflicted.Hash())
+					require.NoError(t, err)
+					require.Equal(t, tx.Hash(), conflicting)
+					require.Equal(t, bc.BlockHeight(), height)
+					_, _, err = bc.GetTransactionConflict(tx.Hash())
+					require.Error(t, err)
+
+					err = bc.PoolTx(conflicted)
+					require.True(t, errors.Is(err, ErrHasConflicts))
+					require.Contains(t, err.Error(), tx.Hash().StringLE())
+				})
 				t.Run("positive", func(t *testing.T) {
 					tx := getConflictsTx(random.Uint256())
 					require.NoError(t, bc.VerifyTx(tx))
@@ -1271,6 +1375,108 @@ func TestMemPoolRemoval(t *testing.T) {
 	}
 }
 
+func TestMemPoolReverification(t *testing.T) {
+	bc := newTestChain(t)
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	h := acc.Contract.ScriptHash()
+	transferTokenFromMultisigAccountCheckOK(t, bc, h, bc.contracts.GAS.Hash, 1_0000_0000)
+	// Committee pays for blockAccount invocation.
+	transferTokenFromMultisigAccountCheckOK(t, bc, testchain.CommitteeScriptHash(), bc.contracts.GAS.Hash, 100_0000_0000)
+
+	blocked := bc.newTestTx(h, []byte{byte(opcode.PUSH1)})
+	require.NoError(t, acc.SignTx(netmode.UnitTestNet, blocked))
+	require.NoError(t, bc.PoolTx(blocked))
+	valid := bc.newTestTx(testchain.MultisigScriptHash(), []byte{byte(opcode.PUSH1)})
+	require.NoError(t, testchain.SignTx(bc, valid))
+	require.NoError(t, bc.PoolTx(valid))
+
+	res, err := invokeContractMethodGeneric(bc, 100000000, bc.contracts.Policy.Hash, "blockAccount", true, h.BytesBE())
+	require.NoError(t, err)
+	checkResult(t, res, stackitem.NewBool(true))
+
+	mp := bc.GetMemPool()
+	require.Eventually(t, func() bool { return !mp.ContainsKey(blocked.Hash()) }, time.Second, time.Millisecond*10)
+	require.True(t, mp.ContainsKey(valid.Hash()))
+}
+
+func TestWitnessVerificationCache(t *testing.T) {
+	bc := newTestChain(t)
+	newTx := func(t *testing.T) *transaction.Transaction {
+		tx := bc.newTestTx(testchain.MultisigScriptHash(), []byte{byte(opcode.PUSH1)})
+		require.NoError(t, testchain.SignTx(bc, tx))
+		return tx
+	}
+
+	tx := newTx(t)
+	require.NoError(t, bc.PoolTx(tx))
+	key, ok := bc.witnessCacheKey(tx)
+	require.True(t, ok)
+	require.True(t, bc.memPool.WitnessesVerified(tx.Hash(), key))
+
+	// Result is kept after removal from the pool and is used for block
+	// transactions.
+	bc.memPool.Remove(tx.Hash(), bc)
+	require.True(t, bc.memPool.WitnessesVerified(tx.Hash(), key))
+	require.NoError(t, bc.AddBlock(bc.newBlock(tx)))
+
+	t.Run("witnesses changed", func(t *testing.T) {
+		tx := newTx(t)
+		require.NoError(t, bc.VerifyTx(tx))
+		tx.Scripts[0].InvocationScript[10] ^= 0xFF
+		require.Error(t, bc.VerifyTx(tx))
+	})
+	t.Run("cached result is used", func(t *testing.T) {
+		tx := newTx(t)
+		tx.Scripts[0].InvocationScript[10] ^= 0xFF
+		require.Error(t, bc.VerifyTx(tx))
+		key, ok := bc.witnessCacheKey(tx)
+		require.True(t, ok)
+		bc.memPool.MarkWitnessesVerified(tx.Hash(), key)
+		require.NoError(t, bc.VerifyTx(tx))
+	})
+	t.Run("non-standard witness", func(t *testing.T) {
+		tx := newTx(t)
+		tx.Scripts[0].VerificationScript = []byte{byte(opcode.PUSHT)}
+		_, ok := bc.witnessCacheKey(tx)
+		require.False(t, ok)
+	})
+}
+
+func TestPreverifyTxWitnesses(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	bc := newTestChain(t)
+	txes := make([]*transaction.Transaction, 5)
+	for i := range txes {
+		txes[i] = bc.newTestTx(testchain.MultisigScriptHash(), []byte{byte(opcode.PUSH1)})
+		require.NoError(t, testchain.SignTx(bc, txes[i]))
+	}
+	txes[1].Scripts[0].InvocationScript[10] ^= 0xFF
+	txes[3].Scripts[0].VerificationScript = []byte{byte(opcode.PUSHT)}
+	require.NoError(t, bc.PoolTx(txes[4]))
+
+	bc.preverifyTxWitnesses(txes)
+	for i, tx := range txes {
+		key, ok := bc.witnessCacheKey(tx)
+		switch i {
+		case 0, 2, 4:
+			require.True(t, ok)
+			require.True(t, bc.memPool.WitnessesVerified(tx.Hash(), key), i)
+		case 1:
+			require.True(t, ok)
+			require.False(t, bc.memPool.WitnessesVerified(tx.Hash(), key))
+		case 3:
+			require.False(t, ok)
+		}
+	}
+
+	t.Run("invalid witness in block", func(t *testing.T) {
+		require.Error(t, bc.AddBlock(bc.newBlock(txes[0], txes[1])))
+	})
+	require.NoError(t, bc.AddBlock(bc.newBlock(txes[0], txes[2], txes[4])))
+}
+
 func TestHasBlock(t *testing.T) {
 	bc := newTestChain(t)
 	blocks, err := bc.genBlocks(50)
@@ -1583,6 +1789,80 @@ func TestRemoveUntraceable(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAppLogsRetention(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.AppLogsRetention = 2
+		c.ProtocolConfiguration.CompressAppLogs = true
+	})
+
+	tx, err := testchain.NewTransferFromOwner(bc, bc.contracts.NEO.Hash, util.Uint160{}, 1, 0, bc.BlockHeight()+1)
+	require.NoError(t, err)
+	b1 := bc.newBlock(tx)
+	require.NoError(t, bc.AddBlock(b1))
+	aers, err := bc.GetAppExecResults(tx.Hash(), trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+	require.Equal(t, vm.HaltState, aers[0].VMState)
+
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+	_, err = bc.GetAppExecResults(b1.Hash(), trigger.All)
+	require.NoError(t, err)
+
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+	_, err = bc.GetAppExecResults(tx.Hash(), trigger.Application)
+	require.Error(t, err)
+	_, err = bc.GetAppExecResults(b1.Hash(), trigger.All)
+	require.Error(t, err)
+	_, _, err = bc.GetTransaction(tx.Hash())
+	require.NoError(t, err)
+	_, err = bc.GetAppExecResults(bc.CurrentBlockHash(), trigger.All)
+	require.NoError(t, err)
+}
+
+func TestCompressAppLogsMismatch(t *testing.T) {
+	st := storage.NewMemoryStore()
+	bc := initTestChain(t, st, func(c *config.Config) {
+		c.ProtocolConfiguration.CompressAppLogs = true
+	})
+	_, err := bc.dao.Persist()
+	require.NoError(t, err)
+
+	cfg, err := config.Load("../../config", testchain.Network())
+	require.NoError(t, err)
+	_, err = NewBlockchain(st, cfg.ProtocolConfiguration, zaptest.NewLogger(t))
+	require.Error(t, err)
+
+	cfg.ProtocolConfiguration.CompressAppLogs = true
+	_, err = NewBlockchain(st, cfg.ProtocolConfiguration, zaptest.NewLogger(t))
+	require.NoError(t, err)
+}
+
+func TestBlockSystemExecutions(t *testing.T) {
+	bc := newTestChain(t)
+
+	b := bc.newBlock()
+	require.NoError(t, bc.AddBlock(b))
+
+	aers, err := bc.GetAppExecResults(b.Hash(), trigger.All)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(aers))
+	require.Equal(t, trigger.OnPersist, aers[0].Trigger)
+	require.Equal(t, trigger.PostPersist, aers[1].Trigger)
+	for i := range aers {
+		require.Equal(t, b.Hash(), aers[i].Container)
+		require.Equal(t, vm.HaltState, aers[i].VMState)
+	}
+	// Committee member reward is minted in PostPersist.
+	require.Equal(t, 1, len(aers[1].Events))
+	require.Equal(t, bc.contracts.GAS.Hash, aers[1].Events[0].ScriptHash)
+	require.Equal(t, "Transfer", aers[1].Events[0].Name)
+
+	aers, err = bc.GetAppExecResults(b.Hash(), trigger.PostPersist)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+	require.Equal(t, trigger.PostPersist, aers[0].Trigger)
+}
+
 func TestInvalidNotification(t *testing.T) {
 	bc := newTestChain(t)
 
@@ -1643,3 +1923,315 @@ func TestConfigNativeUpdateHistory(t *testing.T) {
 		check(t, tc)
 	}
 }
+
+func TestNewBlockchainWithInterops(t *testing.T) {
+	cfg, err := config.Load("../../config", testchain.Network())
+	require.NoError(t, err)
+
+	newChain := func(t *testing.T, custom ...interop.Function) (*Blockchain, error) {
+		bc, err := NewBlockchainWithInterops(storage.NewMemoryStore(), cfg.ProtocolConfiguration,
+			zaptest.NewLogger(t), custom...)
+		if err == nil {
+			go bc.Run()
+			t.Cleanup(bc.Close)
+		}
+		return bc, err
+	}
+	answer := func(ic *interop.Context) error {
+		ic.VM.Estack().PushVal(42)
+		return nil
+	}
+
+	t.Run("collision", func(t *testing.T) {
+		_, err := newChain(t, interop.Function{Name: interopnames.SystemRuntimeGetTime, Func: answer})
+		require.Error(t, err)
+		_, err = newChain(t, interop.Function{
+			ID:   interopnames.ToID([]byte(interopnames.SystemRuntimeGetTime)),
+			Name: "Custom.Answer",
+			Func: answer,
+		})
+		require.Error(t, err)
+	})
+	t.Run("good", func(t *testing.T) {
+		bc, err := newChain(t, interop.Function{
+			Name:          "Custom.Answer",
+			Func:          answer,
+			Price:         1 << 10,
+			RequiredFlags: callflag.ReadStates,
+		})
+		require.NoError(t, err)
+
+		w := io.NewBufBinWriter()
+		emit.Syscall(w.BinWriter, "Custom.Answer")
+		require.NoError(t, w.Err)
+		script := w.Bytes()
+
+		v := bc.GetTestVM(trigger.Application, nil, nil)
+		v.LoadScriptWithFlags(script, callflag.All)
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, v.Estack().Len())
+		require.Equal(t, big.NewInt(42), v.Estack().Pop().BigInt())
+		require.Equal(t, int64(1<<10)*bc.GetBaseExecFee(), v.GasConsumed()-fee.Opcode(bc.GetBaseExecFee(), opcode.SYSCALL))
+
+		v = bc.GetTestVM(trigger.Application, nil, nil)
+		v.LoadScriptWithFlags(script, callflag.ReadOnly&^callflag.ReadStates)
+		require.Error(t, v.Run())
+
+		fs := bc.GetInterops()
+		require.Equal(t, len(Interops())+1, len(fs))
+		var found bool
+		for i := range fs {
+			found = found || fs[i].Name == "Custom.Answer"
+		}
+		require.True(t, found)
+
+		ic := bc.newInteropContext(trigger.Application, bc.dao, nil, nil)
+		v = SpawnVM(ic)
+		v.LoadScriptWithFlags(script, callflag.All)
+		require.NoError(t, v.Run())
+		require.Equal(t, big.NewInt(42), v.Estack().Pop().BigInt())
+	})
+	t.Run("standard chain", func(t *testing.T) {
+		bc := newTestChain(t)
+		w := io.NewBufBinWriter()
+		emit.Syscall(w.BinWriter, "Custom.Answer")
+		require.NoError(t, w.Err)
+
+		v := bc.GetTestVM(trigger.Application, nil, nil)
+		v.LoadScriptWithFlags(w.Bytes(), callflag.All)
+		require.Error(t, v.Run())
+	})
+}
+
+func TestLightNodeProfile(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.NodeProfile = config.LightProfile
+	})
+	require.True(t, bc.GetConfig().KeepOnlyLatestState)
+	require.True(t, bc.GetConfig().RemoveUntraceableBlocks)
+
+	acc := random.Uint160()
+	tx, err := testchain.NewTransferFromOwner(bc, bc.contracts.NEO.Hash, acc, 1, 0, bc.BlockHeight()+1)
+	require.NoError(t, err)
+	b := bc.newBlock(tx)
+	require.NoError(t, bc.AddBlock(b))
+
+	_, _, err = bc.GetTransaction(tx.Hash())
+	require.NoError(t, err)
+	_, err = bc.GetAppExecResults(tx.Hash(), trigger.Application)
+	require.Error(t, err)
+	_, err = bc.GetAppExecResults(b.Hash(), trigger.All)
+	require.Error(t, err)
+
+	bs := bc.GetNEP17Balances(acc).Trackers[bc.contracts.NEO.ID]
+	require.EqualValues(t, 1, bs.Balance.Int64())
+	var transfers int
+	require.NoError(t, bc.ForEachNEP17Transfer(acc, math.MaxUint64, 0, func(*state.NEP17Transfer) (bool, error) {
+		transfers++
+		return true, nil
+	}))
+	require.Equal(t, 0, transfers)
+}
+
+func TestForEachNEP17Transfer(t *testing.T) {
+	bc := newTestChain(t)
+
+	var (
+		acc = random.Uint160()
+		bs  = state.NewNEP17Balances()
+		trs []*state.NEP17Transfer
+	)
+	// 3 full batches, transfers with the same timestamp cross batch boundaries.
+	for i := 0; i < 3*state.NEP17TransferBatchSize; i++ {
+		tr := &state.NEP17Transfer{
+			Asset:     1,
+			Amount:    *big.NewInt(int64(i + 1)),
+			Block:     uint32(i),
+			Timestamp: uint64(1000 + i/3*10),
+		}
+		trs = append(trs, tr)
+		var err error
+		bs.NewBatch, err = bc.dao.AppendNEP17Transfer(acc, bs.NextTransferBatch, bs.NewBatch, tr)
+		require.NoError(t, err)
+		if bs.NewBatch {
+			bs.NextTransferBatch++
+		}
+	}
+	require.NoError(t, bc.dao.PutNEP17Balances(acc, bs))
+
+	check := func(t *testing.T, newest uint64) {
+		var expected, actual []uint32
+		for i := len(trs) - 1; i >= 0; i-- {
+			if trs[i].Timestamp <= newest {
+				expected = append(expected, trs[i].Block)
+			}
+		}
+		require.NoError(t, bc.ForEachNEP17Transfer(acc, newest, 0, func(tr *state.NEP17Transfer) (bool, error) {
+			actual = append(actual, tr.Block)
+			return true, nil
+		}))
+		require.Equal(t, expected, actual)
+	}
+	for _, newest := range []uint64{0, 999, 1000, 1005,
+		trs[state.NEP17TransferBatchSize].Timestamp,
+		trs[state.NEP17TransferBatchSize].Timestamp + 1,
+		trs[2*state.NEP17TransferBatchSize-1].Timestamp,
+		trs[len(trs)-1].Timestamp, math.MaxUint64} {
+		t.Run(fmt.Sprint(newest), func(t *testing.T) {
+			check(t, newest)
+		})
+	}
+	t.Run("stop", func(t *testing.T) {
+		var actual []uint32
+		require.NoError(t, bc.ForEachNEP17Transfer(acc, trs[200].Timestamp, 0, func(tr *state.NEP17Transfer) (bool, error) {
+			actual = append(actual, tr.Block)
+			return len(actual) < 5, nil
+		}))
+		require.Equal(t, []uint32{200, 199, 198, 197, 196}, actual)
+	})
+	t.Run("cursor", func(t *testing.T) {
+		var (
+			actual []uint32
+			newest uint64 = math.MaxUint64
+			index  int
+		)
+		for {
+			var n int
+			require.NoError(t, bc.ForEachNEP17Transfer(acc, newest, index, func(tr *state.NEP17Transfer) (bool, error) {
+				actual = append(actual, tr.Block)
+				if tr.Timestamp != newest {
+					newest, index = tr.Timestamp, 0
+				}
+				index++
+				n++
+				return n < 4, nil
+			}))
+			if n == 0 {
+				break
+			}
+		}
+		require.Equal(t, len(trs), len(actual))
+		for i := range actual {
+			require.Equal(t, trs[len(trs)-1-i].Block, actual[i])
+		}
+	})
+	t.Run("other account", func(t *testing.T) {
+		other := acc
+		other[util.Uint160Size-1]++
+		require.NoError(t, bc.ForEachNEP17Transfer(other, math.MaxUint64, 0, func(tr *state.NEP17Transfer) (bool, error) {
+			t.Fatal("unexpected transfer")
+			return false, nil
+		}))
+	})
+}
+
+func TestBlockchain_Rollback(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		bc := newTestChain(t)
+		_, err := bc.genBlocks(1)
+		require.NoError(t, err)
+		require.Error(t, bc.Rollback(0))
+	})
+
+	st := memoryStore{storage.NewMemoryStore()}
+	cfg := func(c *config.Config) {
+		c.ProtocolConfiguration.MaxReorgDepth = 3
+	}
+	bc := newTestChainWithCustomCfgAndStore(t, st, cfg)
+	_, err := bc.genBlocks(2)
+	require.NoError(t, err)
+	height := bc.BlockHeight()
+	hash := bc.CurrentBlockHash()
+	root := bc.GetStateModule().CurrentLocalStateRoot()
+	gasBalance := bc.GetUtilityTokenBalance(testchain.MultisigScriptHash())
+
+	acc := random.Uint160()
+	tx, err := testchain.NewTransferFromOwner(bc, bc.contracts.NEO.Hash, acc, 1, 0, bc.BlockHeight()+10)
+	require.NoError(t, err)
+	b := bc.newBlock(tx)
+	require.NoError(t, bc.AddBlock(b))
+	_, err = bc.genBlocks(2)
+	require.NoError(t, err)
+	gov, _ := bc.GetGoverningTokenBalance(acc)
+	require.EqualValues(t, 1, gov.Int64())
+	// Fill the caches.
+	_, err = bc.GetBlock(b.Hash())
+	require.NoError(t, err)
+	_, txHeight, err := bc.GetTransaction(tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, b.Index, txHeight)
+
+	require.Error(t, bc.Rollback(height-1)) // Too deep.
+	require.Error(t, bc.Rollback(bc.BlockHeight()))
+	require.NoError(t, bc.Rollback(height))
+
+	require.Equal(t, height, bc.BlockHeight())
+	require.Equal(t, height, bc.HeaderHeight())
+	require.Equal(t, hash, bc.CurrentBlockHash())
+	require.Equal(t, root, bc.GetStateModule().CurrentLocalStateRoot())
+	require.Equal(t, gasBalance, bc.GetUtilityTokenBalance(testchain.MultisigScriptHash()))
+	gov, _ = bc.GetGoverningTokenBalance(acc)
+	require.EqualValues(t, 0, gov.Int64())
+	_, _, err = bc.dao.GetTransaction(tx.Hash())
+	require.Error(t, err)
+	require.False(t, bc.HasBlock(b.Hash()))
+	require.True(t, bc.memPool.ContainsKey(tx.Hash()))
+	_, err = bc.GetBlock(b.Hash())
+	require.Error(t, err)
+	_, txHeight, err = bc.GetTransaction(tx.Hash())
+	require.NoError(t, err)
+	require.EqualValues(t, math.MaxUint32, txHeight) // From the mempool.
+
+	// Another fork.
+	b2 := bc.newBlock()
+	require.NotEqual(t, b.Hash(), b2.Hash())
+	require.NoError(t, bc.AddBlock(b2))
+	require.Equal(t, height+1, bc.BlockHeight())
+	require.NoError(t, bc.AddBlock(bc.newBlock(tx)))
+	gov, _ = bc.GetGoverningTokenBalance(acc)
+	require.EqualValues(t, 1, gov.Int64())
+
+	_, err = bc.dao.Persist()
+	require.NoError(t, err)
+	bc2 := newTestChainWithCustomCfgAndStore(t, st, cfg)
+	require.Equal(t, height+2, bc2.BlockHeight())
+	require.Equal(t, height+2, bc2.HeaderHeight())
+	require.Equal(t, b2.Hash(), bc2.GetHeaderHash(int(height+1)))
+}
+
+func TestBlockchain_RegisterChangeHandler(t *testing.T) {
+	bc := initTestChain(t, nil, nil)
+	var (
+		diffs   []*state.StateDiff
+		heights []uint32
+	)
+	bc.RegisterChangeHandler(func(b *block.Block, d *state.StateDiff) {
+		require.Equal(t, b.Index, d.Index)
+		h, err := bc.GetDAO().GetCurrentBlockHeight()
+		require.NoError(t, err)
+		heights = append(heights, h)
+		diffs = append(diffs, d)
+	})
+	go bc.Run()
+	t.Cleanup(bc.Close)
+
+	acc := random.Uint160()
+	tx, err := testchain.NewTransferFromOwner(bc, bc.contracts.NEO.Hash, acc, 1, 0, bc.BlockHeight()+10)
+	require.NoError(t, err)
+	b := bc.newBlock(tx)
+	require.NoError(t, bc.AddBlock(b))
+	require.Equal(t, 1, len(diffs))
+	require.Equal(t, []uint32{b.Index}, heights)
+
+	var neoChanged bool
+	for _, c := range diffs[0].Changes {
+		if id, _, ok := dao.ParseStorageItemKey(c.Key); ok && id == bc.contracts.NEO.ID {
+			neoChanged = true
+		}
+	}
+	require.True(t, neoChanged)
+
+	// Diffs are not saved unless configured.
+	_, err = bc.GetStateDiff(b.Index)
+	require.Error(t, err)
+}
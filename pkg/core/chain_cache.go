@@ -0,0 +1,106 @@
+package core
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/atomic"
+)
+
+// chainCache is a bounded LRU cache of recently accessed blocks and
+// transactions, it's used by GetBlock and GetTransaction methods of the
+// Blockchain and thus shared by RPC server and interop functions. Entries
+// are tagged with cache generation that is changed on every chain rollback,
+// so that objects read from the DB before the rollback are never returned
+// after it. Contract states are not cached here, because Management native
+// contract keeps all of them in memory already.
+type chainCache struct {
+	gen    atomic.Uint32
+	blocks *lru.Cache
+	txes   *lru.Cache
+}
+
+type (
+	cachedBlock struct {
+		gen   uint32
+		block *block.Block
+	}
+	cachedTx struct {
+		gen    uint32
+		tx     *transaction.Transaction
+		height uint32
+	}
+)
+
+// Cache names used for metrics.
+const (
+	blockCacheName       = "block"
+	transactionCacheName = "transaction"
+)
+
+func newChainCache(blocks, txes int) (*chainCache, error) {
+	bc, err := lru.New(blocks)
+	if err != nil {
+		return nil, err
+	}
+	tc, err := lru.New(txes)
+	if err != nil {
+		return nil, err
+	}
+	return &chainCache{blocks: bc, txes: tc}, nil
+}
+
+// generation returns current cache generation, it must be obtained before
+// reading the object to be cached from the DB.
+func (c *chainCache) generation() uint32 {
+	return c.gen.Load()
+}
+
+// getBlock returns cached block or nil if it's not in the cache.
+func (c *chainCache) getBlock(h util.Uint256) *block.Block {
+	v, ok := c.blocks.Get(h)
+	if ok {
+		if cb := v.(cachedBlock); cb.gen == c.generation() {
+			updateCacheHitMetric(blockCacheName)
+			return cb.block
+		}
+	}
+	updateCacheMissMetric(blockCacheName)
+	return nil
+}
+
+// putBlock adds block read at the given generation to the cache.
+func (c *chainCache) putBlock(gen uint32, b *block.Block) {
+	if gen == c.generation() {
+		c.blocks.Add(b.Hash(), cachedBlock{gen: gen, block: b})
+	}
+}
+
+// getTransaction returns cached transaction with its height, the last value
+// is false if there is no such transaction in the cache.
+func (c *chainCache) getTransaction(h util.Uint256) (*transaction.Transaction, uint32, bool) {
+	v, ok := c.txes.Get(h)
+	if ok {
+		if ct := v.(cachedTx); ct.gen == c.generation() {
+			updateCacheHitMetric(transactionCacheName)
+			return ct.tx, ct.height, true
+		}
+	}
+	updateCacheMissMetric(transactionCacheName)
+	return nil, 0, false
+}
+
+// putTransaction adds transaction read at the given generation to the cache.
+func (c *chainCache) putTransaction(gen uint32, tx *transaction.Transaction, height uint32) {
+	if gen == c.generation() {
+		c.txes.Add(tx.Hash(), cachedTx{gen: gen, tx: tx, height: height})
+	}
+}
+
+// reset invalidates all cached objects.
+func (c *chainCache) reset() {
+	c.gen.Inc()
+	c.blocks.Purge()
+	c.txes.Purge()
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainCache(t *testing.T) {
+	_, err := newChainCache(0, 1)
+	require.Error(t, err)
+
+	c, err := newChainCache(1, 1)
+	require.NoError(t, err)
+
+	b1 := &block.Block{Header: block.Header{Index: 1}}
+	b2 := &block.Block{Header: block.Header{Index: 2}}
+	require.Nil(t, c.getBlock(b1.Hash()))
+	c.putBlock(c.generation(), b1)
+	require.Same(t, b1, c.getBlock(b1.Hash()))
+	c.putBlock(c.generation(), b2)
+	require.Nil(t, c.getBlock(b1.Hash())) // Evicted.
+	require.Same(t, b2, c.getBlock(b2.Hash()))
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	_, _, ok := c.getTransaction(tx.Hash())
+	require.False(t, ok)
+	c.putTransaction(c.generation(), tx, 5)
+	actual, height, ok := c.getTransaction(tx.Hash())
+	require.True(t, ok)
+	require.Same(t, tx, actual)
+	require.EqualValues(t, 5, height)
+
+	t.Run("reset", func(t *testing.T) {
+		gen := c.generation()
+		c.reset()
+		require.Nil(t, c.getBlock(b2.Hash()))
+		_, _, ok := c.getTransaction(tx.Hash())
+		require.False(t, ok)
+
+		// Objects read before the reset are not cached.
+		c.putBlock(gen, b2)
+		require.Nil(t, c.getBlock(b2.Hash()))
+		c.putTransaction(gen, tx, 5)
+		_, _, ok = c.getTransaction(tx.Hash())
+		require.False(t, ok)
+	})
+}
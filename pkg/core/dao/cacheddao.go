@@ -2,6 +2,7 @@ package dao
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -78,6 +79,97 @@ func (cd *Cached) AppendNEP17Transfer(acc util.Uint160, index uint32, isNew bool
 	return lg.Size() >= state.NEP17TransferBatchSize, cd.PutNEP17TransferLog(acc, index, lg)
 }
 
+// DeleteNEP17Data removes balances and transfers of the NEP17 contract with
+// the given ID from the data of its holders (see GetNEP17Holders), so only
+// accounts that have ever dealt with this contract are processed. Transfer
+// logs are rewritten without this contract's transfers keeping all batches
+// but the last one full.
+func (cd *Cached) DeleteNEP17Data(id int32) error {
+	accs, err := cd.GetNEP17Holders(id)
+	if err != nil {
+		return err
+	}
+	for _, acc := range accs {
+		if err := cd.deleteNEP17AccountData(acc, id); err != nil {
+			return fmt.Errorf("account %s: %w", acc.StringLE(), err)
+		}
+		if err := cd.DeleteNEP17Holder(id, acc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteNEP17AccountData removes balance and transfers of the NEP17 contract
+// with the given ID from the data of the given account.
+func (cd *Cached) deleteNEP17AccountData(acc util.Uint160, id int32) error {
+	bs, err := cd.GetNEP17Balances(acc)
+	if err != nil {
+		return err
+	}
+	_, changed := bs.Trackers[id]
+	delete(bs.Trackers, id)
+
+	last := bs.NextTransferBatch
+	if bs.NewBatch && last > 0 {
+		last-- // Batch with NextTransferBatch index is not yet created.
+	}
+	var kept []state.NEP17Transfer
+	for i := uint32(0); i <= last; i++ {
+		lg, err := cd.GetNEP17TransferLog(acc, i)
+		if err != nil {
+			return err
+		}
+		var batch []state.NEP17Transfer
+		_, err = lg.ForEach(func(tr *state.NEP17Transfer) (bool, error) {
+			if tr.Asset == id {
+				changed = true
+			} else {
+				batch = append(batch, *tr)
+			}
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		// ForEach goes from the newest transfer to the oldest one.
+		for j := len(batch) - 1; j >= 0; j-- {
+			kept = append(kept, batch[j])
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	var (
+		index uint32
+		lg    = new(state.NEP17TransferLog)
+	)
+	bs.NewBatch = false
+	for i := range kept {
+		if err := lg.Append(&kept[i]); err != nil {
+			return err
+		}
+		bs.NewBatch = lg.Size() >= state.NEP17TransferBatchSize
+		if bs.NewBatch {
+			if err := cd.PutNEP17TransferLog(acc, index, lg); err != nil {
+				return err
+			}
+			index++
+			lg = new(state.NEP17TransferLog)
+		}
+	}
+	bs.NextTransferBatch = index
+	// Non-full last batch is saved and all the remaining ones are emptied.
+	for ; index <= last; index++ {
+		if err := cd.PutNEP17TransferLog(acc, index, lg); err != nil {
+			return err
+		}
+		lg = new(state.NEP17TransferLog)
+	}
+	return cd.PutNEP17Balances(acc, bs)
+}
+
 // Persist flushes all the changes made into the (supposedly) persistent
 // underlying store.
 func (cd *Cached) Persist() (int, error) {
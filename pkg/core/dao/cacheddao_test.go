@@ -1,11 +1,13 @@
 package dao
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,7 +15,7 @@ import (
 func TestCachedCachedDao(t *testing.T) {
 	store := storage.NewMemoryStore()
 	// Persistent DAO to check for backing storage.
-	pdao := NewSimple(store, false)
+	pdao := NewSimple(store, false, false)
 	assert.NotEqual(t, store, pdao.Store)
 	// Cached DAO.
 	cdao := NewCached(pdao)
@@ -55,3 +57,99 @@ func TestCachedCachedDao(t *testing.T) {
 	resi = pdao.GetStorageItem(id, key)
 	assert.Equal(t, si, resi)
 }
+
+func TestCachedDeleteNEP17Data(t *testing.T) {
+	pdao := NewSimple(storage.NewMemoryStore(), false, false)
+	acc1 := util.Uint160{1, 2, 3}
+	acc2 := util.Uint160{3, 2, 1}
+
+	// addTransfers emulates transfer processing done by the Blockchain.
+	addTransfers := func(t *testing.T, d DAO, acc util.Uint160, assets ...int32) {
+		bs, err := d.GetNEP17Balances(acc)
+		require.NoError(t, err)
+		for _, asset := range assets {
+			if _, ok := bs.Trackers[asset]; !ok {
+				require.NoError(t, d.PutNEP17Holder(asset, acc))
+			}
+			tr := bs.Trackers[asset]
+			tr.Balance.Add(&tr.Balance, big.NewInt(1))
+			bs.Trackers[asset] = tr
+			bs.NewBatch, err = d.AppendNEP17Transfer(acc, bs.NextTransferBatch, bs.NewBatch,
+				&state.NEP17Transfer{Asset: asset, Amount: *big.NewInt(1)})
+			require.NoError(t, err)
+			if bs.NewBatch {
+				bs.NextTransferBatch++
+			}
+		}
+		require.NoError(t, d.PutNEP17Balances(acc, bs))
+	}
+	getTransfers := func(t *testing.T, d DAO, acc util.Uint160) []int32 {
+		bs, err := d.GetNEP17Balances(acc)
+		require.NoError(t, err)
+		var assets []int32
+		for i := uint32(0); i <= bs.NextTransferBatch; i++ {
+			lg, err := d.GetNEP17TransferLog(acc, i)
+			require.NoError(t, err)
+			if i < bs.NextTransferBatch {
+				require.Equal(t, state.NEP17TransferBatchSize, lg.Size())
+			} else if bs.NewBatch {
+				require.Equal(t, 0, lg.Size())
+			}
+			var batch []int32
+			_, err = lg.ForEach(func(tr *state.NEP17Transfer) (bool, error) {
+				batch = append([]int32{tr.Asset}, batch...)
+				return true, nil
+			})
+			require.NoError(t, err)
+			assets = append(assets, batch...)
+		}
+		return assets
+	}
+
+	var assets1, expected1 []int32
+	for i := 0; i < 2*state.NEP17TransferBatchSize+10; i++ {
+		asset := int32(i%3 + 1)
+		assets1 = append(assets1, asset)
+		if asset != 2 {
+			expected1 = append(expected1, asset)
+		}
+	}
+	addTransfers(t, pdao, acc1, assets1...)
+	cdao := NewCached(pdao)
+	// acc2 only exists in the cache.
+	addTransfers(t, cdao, acc2, 2, 2, 3)
+	require.Equal(t, assets1, getTransfers(t, cdao, acc1))
+
+	holders, err := cdao.GetNEP17Holders(2)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []util.Uint160{acc1, acc2}, holders)
+
+	require.NoError(t, cdao.DeleteNEP17Data(2))
+	require.Equal(t, expected1, getTransfers(t, cdao, acc1))
+	require.Equal(t, []int32{3}, getTransfers(t, cdao, acc2))
+	holders, err = cdao.GetNEP17Holders(2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(holders))
+	holders, err = cdao.GetNEP17Holders(3)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []util.Uint160{acc1, acc2}, holders)
+
+	_, err = cdao.Persist()
+	require.NoError(t, err)
+	for _, acc := range []util.Uint160{acc1, acc2} {
+		bs, err := pdao.GetNEP17Balances(acc)
+		require.NoError(t, err)
+		_, ok := bs.Trackers[2]
+		require.False(t, ok)
+		_, ok = bs.Trackers[3]
+		require.True(t, ok)
+	}
+	require.Equal(t, expected1, getTransfers(t, pdao, acc1))
+	require.Equal(t, []int32{3}, getTransfers(t, pdao, acc2))
+
+	// Emptied batch is removed from the store.
+	bs, err := pdao.GetNEP17Balances(acc1)
+	require.NoError(t, err)
+	_, err = pdao.Store.Get(getNEP17TransferLogKey(acc1, bs.NextTransferBatch+1))
+	require.Equal(t, storage.ErrKeyNotFound, err)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	iocore "io"
 	"sort"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/pierrec/lz4"
 )
 
 // HasTransaction errors.
@@ -26,42 +28,54 @@ var (
 	ErrHasConflicts = errors.New("transaction has conflicts")
 )
 
-// DAO is a data access object.
-type DAO interface {
-	AppendAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error
-	AppendNEP17Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP17Transfer) (bool, error)
-	DeleteBlock(h util.Uint256, buf *io.BufBinWriter) error
-	DeleteContractID(id int32) error
-	DeleteStorageItem(id int32, key []byte) error
-	GetAndDecode(entity io.Serializable, key []byte) error
+// Reader is the read-only part of DAO. It's kept stable, so that external
+// index builders embedding the node can read decoded chain state without
+// depending on the storage key layout.
+type Reader interface {
 	GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]state.AppExecResult, error)
-	GetBatch() *storage.MemBatch
 	GetBlock(hash util.Uint256) (*block.Block, error)
+	GetConflictRecord(hash util.Uint256) (util.Uint256, uint32, error)
 	GetContractScriptHash(id int32) (util.Uint160, error)
 	GetCurrentBlockHeight() (uint32, error)
 	GetCurrentHeaderHeight() (i uint32, h util.Uint256, err error)
 	GetHeaderHashes() ([]util.Uint256, error)
+	GetNEP17Holders(id int32) ([]util.Uint160, error)
 	GetNEP17Balances(acc util.Uint160) (*state.NEP17Balances, error)
 	GetNEP17TransferLog(acc util.Uint160, index uint32) (*state.NEP17TransferLog, error)
 	GetStorageItem(id int32, key []byte) state.StorageItem
-	GetStorageItems(id int32) (map[string]state.StorageItem, error)
 	GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error)
 	GetTransaction(hash util.Uint256) (*transaction.Transaction, uint32, error)
-	GetVersion() (string, error)
-	GetWrapped() DAO
+	GetVersion() (Version, error)
 	HasTransaction(hash util.Uint256) error
+	Seek(id int32, prefix []byte, f func(k, v []byte))
+}
+
+// DAO is a data access object.
+type DAO interface {
+	Reader
+	AppendAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error
+	AppendNEP17Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP17Transfer) (bool, error)
+	DeleteAppExecResults(b *block.Block) error
+	DeleteBlock(h util.Uint256, buf *io.BufBinWriter) error
+	DeleteContractID(id int32) error
+	DeleteNEP17Holder(id int32, acc util.Uint160) error
+	DeleteStorageItem(id int32, key []byte) error
+	GetAndDecode(entity io.Serializable, key []byte) error
+	GetBatch() *storage.MemBatch
+	GetWrapped() DAO
 	Persist() (int, error)
 	PutAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error
 	PutContractID(id int32, hash util.Uint160) error
 	PutCurrentHeader(hashAndIndex []byte) error
 	PutNEP17Balances(acc util.Uint160, bs *state.NEP17Balances) error
+	PutNEP17Holder(id int32, acc util.Uint160) error
 	PutNEP17TransferLog(acc util.Uint160, index uint32, lg *state.NEP17TransferLog) error
 	PutStorageItem(id int32, key []byte, si state.StorageItem) error
-	PutVersion(v string) error
-	Seek(id int32, prefix []byte, f func(k, v []byte))
+	PutVersion(v Version) error
 	StoreAsBlock(block *block.Block, buf *io.BufBinWriter) error
 	StoreAsCurrentBlock(block *block.Block, buf *io.BufBinWriter) error
 	StoreAsTransaction(tx *transaction.Transaction, index uint32, buf *io.BufBinWriter) error
+	StoreConflictRecord(hash, conflicting util.Uint256, index uint32, buf *io.BufBinWriter) error
 	putNEP17Balances(acc util.Uint160, bs *state.NEP17Balances, buf *io.BufBinWriter) error
 }
 
@@ -70,12 +84,14 @@ type Simple struct {
 	Store *storage.MemCachedStore
 	// stateRootInHeader specifies if block header contains state root.
 	stateRootInHeader bool
+	// compressAppLogs specifies if application logs are stored compressed.
+	compressAppLogs bool
 }
 
 // NewSimple creates new simple dao using provided backend store.
-func NewSimple(backend storage.Store, stateRootInHeader bool) *Simple {
+func NewSimple(backend storage.Store, stateRootInHeader bool, compressAppLogs bool) *Simple {
 	st := storage.NewMemCachedStore(backend)
-	return &Simple{Store: st, stateRootInHeader: stateRootInHeader}
+	return &Simple{Store: st, stateRootInHeader: stateRootInHeader, compressAppLogs: compressAppLogs}
 }
 
 // GetBatch returns currently accumulated DB changeset.
@@ -86,7 +102,7 @@ func (dao *Simple) GetBatch() *storage.MemBatch {
 // GetWrapped returns new DAO instance with another layer of wrapped
 // MemCachedStore around the current DAO Store.
 func (dao *Simple) GetWrapped() DAO {
-	d := NewSimple(dao.Store, dao.stateRootInHeader)
+	d := NewSimple(dao.Store, dao.stateRootInHeader, dao.compressAppLogs)
 	return d
 }
 
@@ -164,15 +180,63 @@ func (dao *Simple) putNEP17Balances(acc util.Uint160, bs *state.NEP17Balances, b
 	return dao.putWithBuffer(bs, key, buf)
 }
 
+// makeNEP17HolderKey returns the key of the NEP17 holders index entry, the
+// index allows to find accounts having data of the given (non-native) NEP17
+// contract without iterating over all of them.
+func makeNEP17HolderKey(id int32, acc util.Uint160) []byte {
+	key := make([]byte, 5+util.Uint160Size)
+	key[0] = byte(storage.STNEP17Holders)
+	binary.LittleEndian.PutUint32(key[1:], uint32(id))
+	copy(key[5:], acc.BytesBE())
+	return key
+}
+
+// GetNEP17Holders returns all accounts having balances or transfers of the
+// NEP17 contract with the given ID.
+func (dao *Simple) GetNEP17Holders(id int32) ([]util.Uint160, error) {
+	var (
+		accs []util.Uint160
+		err  error
+	)
+	prefix := makeNEP17HolderKey(id, util.Uint160{})[:5]
+	dao.Store.Seek(prefix, func(k, _ []byte) {
+		if err != nil {
+			return
+		}
+		var acc util.Uint160
+		acc, err = util.Uint160DecodeBytesBE(k[len(prefix):])
+		accs = append(accs, acc)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return accs, nil
+}
+
+// PutNEP17Holder adds the account to the holders index of the NEP17 contract
+// with the given ID.
+func (dao *Simple) PutNEP17Holder(id int32, acc util.Uint160) error {
+	return dao.Store.Put(makeNEP17HolderKey(id, acc), []byte{1})
+}
+
+// DeleteNEP17Holder removes the account from the holders index of the NEP17
+// contract with the given ID.
+func (dao *Simple) DeleteNEP17Holder(id int32, acc util.Uint160) error {
+	return dao.Store.Delete(makeNEP17HolderKey(id, acc))
+}
+
 // -- end nep17 balances.
 
 // -- start transfer log.
 
+// getNEP17TransferLogKey returns the key of the transfer log batch. Batches
+// are filled in chronological order, so big-endian index keeps keys ordered
+// by account and time.
 func getNEP17TransferLogKey(acc util.Uint160, index uint32) []byte {
 	key := make([]byte, 1+util.Uint160Size+4)
 	key[0] = byte(storage.STNEP17Transfers)
 	copy(key[1:], acc.BytesBE())
-	binary.LittleEndian.PutUint32(key[util.Uint160Size:], index)
+	binary.BigEndian.PutUint32(key[1+util.Uint160Size:], index)
 	return key
 }
 
@@ -189,9 +253,13 @@ func (dao *Simple) GetNEP17TransferLog(acc util.Uint160, index uint32) (*state.N
 	return &state.NEP17TransferLog{Raw: value}, nil
 }
 
-// PutNEP17TransferLog saves given transfer log in the cache.
+// PutNEP17TransferLog saves given transfer log in the cache, empty log is
+// deleted.
 func (dao *Simple) PutNEP17TransferLog(acc util.Uint160, index uint32, lg *state.NEP17TransferLog) error {
 	key := getNEP17TransferLogKey(acc, index)
+	if lg.Size() == 0 {
+		return dao.Store.Delete(key)
+	}
 	return dao.Store.Put(key, lg.Raw)
 }
 
@@ -222,7 +290,7 @@ func (dao *Simple) AppendNEP17Transfer(acc util.Uint160, index uint32, isNew boo
 // given store.
 func (dao *Simple) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]state.AppExecResult, error) {
 	key := storage.AppendPrefix(storage.STNotification, hash.BytesBE())
-	aers, err := dao.Store.Get(key)
+	aers, err := dao.getAppExecResults(key)
 	if err != nil {
 		return nil, err
 	}
@@ -249,7 +317,7 @@ func (dao *Simple) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]st
 // for the purpose of value serialization.
 func (dao *Simple) AppendAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error {
 	key := storage.AppendPrefix(storage.STNotification, aer.Container.BytesBE())
-	aers, err := dao.Store.Get(key)
+	aers, err := dao.getAppExecResults(key)
 	if err != nil && err != storage.ErrKeyNotFound {
 		return err
 	}
@@ -264,7 +332,7 @@ func (dao *Simple) AppendAppExecResult(aer *state.AppExecResult, buf *io.BufBinW
 		return buf.Err
 	}
 	aers = append(aers, buf.Bytes()...)
-	return dao.Store.Put(key, aers)
+	return dao.putAppExecResults(key, aers)
 }
 
 // PutAppExecResult puts given application execution result into the
@@ -272,9 +340,97 @@ func (dao *Simple) AppendAppExecResult(aer *state.AppExecResult, buf *io.BufBinW
 func (dao *Simple) PutAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error {
 	key := storage.AppendPrefix(storage.STNotification, aer.Container.BytesBE())
 	if buf == nil {
-		return dao.Put(aer, key)
+		buf = io.NewBufBinWriter()
+	}
+	aer.EncodeBinary(buf.BinWriter)
+	if buf.Err != nil {
+		return buf.Err
+	}
+	return dao.putAppExecResults(key, buf.Bytes())
+}
+
+// DeleteAppExecResults removes application execution results of the given
+// block and all of its transactions.
+func (dao *Simple) DeleteAppExecResults(b *block.Block) error {
+	batch := dao.Store.Batch()
+	for _, tx := range b.Transactions {
+		batch.Delete(storage.AppendPrefix(storage.STNotification, tx.Hash().BytesBE()))
+	}
+	batch.Delete(storage.AppendPrefix(storage.STNotification, b.Hash().BytesBE()))
+	return dao.Store.PutBatch(batch)
+}
+
+// getAppExecResults returns serialized application execution results stored
+// with the given key.
+func (dao *Simple) getAppExecResults(key []byte) ([]byte, error) {
+	data, err := dao.Store.Get(key)
+	if err != nil || !dao.compressAppLogs {
+		return data, err
+	}
+	return decompressAppLog(data)
+}
+
+// putAppExecResults stores serialized application execution results with the
+// given key compressing them if needed.
+func (dao *Simple) putAppExecResults(key []byte, data []byte) error {
+	if dao.compressAppLogs {
+		data = compressAppLog(data)
+	}
+	return dao.Store.Put(key, data)
+}
+
+// Compressed application log formats, the first byte of the stored value.
+const (
+	appLogRaw byte = iota
+	appLogLZ4
+)
+
+// maxAppLogSize is the maximum size of decompressed application log, it's
+// way more than any real log can take.
+const maxAppLogSize = 64 * 1024 * 1024
+
+// compressAppLog compresses serialized application execution results with LZ4
+// if it makes them smaller (including the decompressed size header).
+func compressAppLog(data []byte) []byte {
+	res := make([]byte, 5+lz4.CompressBlockBound(len(data)))
+	n, err := lz4.CompressBlock(data, res[5:], nil)
+	if err != nil || n == 0 || n+4 >= len(data) {
+		res[0] = appLogRaw
+		return append(res[:1], data...)
+	}
+	res[0] = appLogLZ4
+	binary.LittleEndian.PutUint32(res[1:5], uint32(len(data)))
+	return res[:5+n]
+}
+
+// decompressAppLog is the inverse of compressAppLog.
+func decompressAppLog(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty application log")
+	}
+	switch data[0] {
+	case appLogRaw:
+		return data[1:], nil
+	case appLogLZ4:
+		if len(data) < 5 {
+			return nil, errors.New("invalid compressed application log")
+		}
+		size := binary.LittleEndian.Uint32(data[1:5])
+		if size > maxAppLogSize {
+			return nil, fmt.Errorf("application log is too big: %d", size)
+		}
+		res := make([]byte, size)
+		n, err := lz4.UncompressBlock(data[5:], res)
+		if err != nil {
+			return nil, err
+		}
+		if n != len(res) {
+			return nil, errors.New("decompressed application log size mismatch")
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("unknown application log format %d", data[0])
 	}
-	return dao.putWithBuffer(aer, key, buf)
 }
 
 // -- end notification event.
@@ -304,11 +460,6 @@ func (dao *Simple) DeleteStorageItem(id int32, key []byte) error {
 	return dao.Store.Delete(stKey)
 }
 
-// GetStorageItems returns all storage items for a given id.
-func (dao *Simple) GetStorageItems(id int32) (map[string]state.StorageItem, error) {
-	return dao.GetStorageItemsWithPrefix(id, nil)
-}
-
 // GetStorageItemsWithPrefix returns all storage items with given id for a
 // given scripthash.
 func (dao *Simple) GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error) {
@@ -327,8 +478,11 @@ func (dao *Simple) GetStorageItemsWithPrefix(id int32, prefix []byte) (map[strin
 	return siMap, nil
 }
 
-// Seek executes f for all items with a given prefix.
-// If key is to be used outside of f, they must be copied.
+// Seek executes f for all storage items of the contract with the given id
+// and the given prefix (keys are passed to f without this prefix). It doesn't
+// load all items into memory, so it's to be preferred over
+// GetStorageItemsWithPrefix for potentially big sets of items. If key or
+// value is to be used outside of f, they must be copied.
 func (dao *Simple) Seek(id int32, prefix []byte, f func(k, v []byte)) {
 	lookupKey := makeStorageItemKey(id, nil)
 	if prefix != nil {
@@ -349,6 +503,16 @@ func makeStorageItemKey(id int32, key []byte) []byte {
 	return buf
 }
 
+// ParseStorageItemKey returns the contract ID and the item key from the given
+// storage item DB key (like the ones found in state diffs), ok is false if
+// it's not a storage item key.
+func ParseStorageItemKey(k []byte) (id int32, key []byte, ok bool) {
+	if len(k) < 5 || k[0] != byte(storage.STStorage) {
+		return 0, nil, false
+	}
+	return int32(binary.LittleEndian.Uint32(k[1:])), k[5:], true
+}
+
 // -- end storage item.
 
 // -- other.
@@ -368,11 +532,57 @@ func (dao *Simple) GetBlock(hash util.Uint256) (*block.Block, error) {
 	return block, nil
 }
 
+// Version is the DB version along with the settings that can't be changed
+// for the existing DB.
+type Version struct {
+	Value           string
+	CompressAppLogs bool
+}
+
+// Version settings flags.
+const (
+	versionCompressAppLogs byte = 1 << iota
+)
+
+// FromBytes decodes Version from its stored representation. Version can be
+// stored without settings (by older nodes), they're all disabled then.
+func (v *Version) FromBytes(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("missing version")
+	}
+	i := bytes.IndexByte(data, 0)
+	if i < 0 {
+		v.Value = string(data)
+		v.CompressAppLogs = false
+		return nil
+	}
+	if len(data) != i+2 {
+		return errors.New("version is invalid")
+	}
+	v.Value = string(data[:i])
+	v.CompressAppLogs = data[i+1]&versionCompressAppLogs != 0
+	return nil
+}
+
+// Bytes encodes Version into its stored representation.
+func (v *Version) Bytes() []byte {
+	var flags byte
+	if v.CompressAppLogs {
+		flags |= versionCompressAppLogs
+	}
+	return append([]byte(v.Value+"\x00"), flags)
+}
+
 // GetVersion attempts to get the current version stored in the
 // underlying store.
-func (dao *Simple) GetVersion() (string, error) {
-	version, err := dao.Store.Get(storage.SYSVersion.Bytes())
-	return string(version), err
+func (dao *Simple) GetVersion() (Version, error) {
+	var version Version
+
+	data, err := dao.Store.Get(storage.SYSVersion.Bytes())
+	if err == nil {
+		err = version.FromBytes(data)
+	}
+	return version, err
 }
 
 // GetCurrentBlockHeight returns the current block height found in the
@@ -456,8 +666,8 @@ func (dao *Simple) GetTransaction(hash util.Uint256) (*transaction.Transaction,
 }
 
 // PutVersion stores the given version in the underlying store.
-func (dao *Simple) PutVersion(v string) error {
-	return dao.Store.Put(storage.SYSVersion.Bytes(), []byte(v))
+func (dao *Simple) PutVersion(v Version) error {
+	return dao.Store.Put(storage.SYSVersion.Bytes(), v.Bytes())
 }
 
 // PutCurrentHeader stores current header.
@@ -497,6 +707,50 @@ func (dao *Simple) HasTransaction(hash util.Uint256) error {
 	return ErrAlreadyExists
 }
 
+// GetConflictRecord returns the hash of on-chain transaction that has the given
+// hash in its Conflicts attribute and the height of the block it's included in.
+// storage.ErrKeyNotFound is returned if there is no such transaction. Records
+// stored without conflicting transaction hash return zero hash.
+func (dao *Simple) GetConflictRecord(hash util.Uint256) (util.Uint256, uint32, error) {
+	key := storage.AppendPrefix(storage.DataTransaction, hash.BytesBE())
+	b, err := dao.Store.Get(key)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	if len(b) < 5 || b[4] != transaction.DummyVersion {
+		return util.Uint256{}, 0, storage.ErrKeyNotFound
+	}
+	r := io.NewBinReaderFromBuf(b)
+	height := r.ReadU32LE()
+	r.ReadB()
+	var conflicting util.Uint256
+	if len(b) > 5 {
+		r.ReadBytes(conflicting[:])
+	}
+	if r.Err != nil {
+		return util.Uint256{}, 0, r.Err
+	}
+	return conflicting, height, nil
+}
+
+// StoreConflictRecord stores a record that transaction with the given hash is
+// conflicting with (and thus can't be accepted after) on-chain transaction
+// conflicting included in the block with the given index. It can reuse given
+// buffer for the purpose of value serialization.
+func (dao *Simple) StoreConflictRecord(hash, conflicting util.Uint256, index uint32, buf *io.BufBinWriter) error {
+	key := storage.AppendPrefix(storage.DataTransaction, hash.BytesBE())
+	if buf == nil {
+		buf = io.NewBufBinWriter()
+	}
+	buf.WriteU32LE(index)
+	buf.WriteB(transaction.DummyVersion)
+	buf.WriteBytes(conflicting.BytesBE())
+	if buf.Err != nil {
+		return buf.Err
+	}
+	return dao.Store.Put(key, buf.Bytes())
+}
+
 // StoreAsBlock stores given block as DataBlock. It can reuse given buffer for
 // the purpose of value serialization.
 func (dao *Simple) StoreAsBlock(block *block.Block, buf *io.BufBinWriter) error {
@@ -558,6 +812,33 @@ func (dao *Simple) DeleteBlock(h util.Uint256, w *io.BufBinWriter) error {
 	return dao.Store.PutBatch(batch)
 }
 
+// PutStateDiff stores the given state diff.
+func (dao *Simple) PutStateDiff(d *state.StateDiff) error {
+	return dao.Put(d, makeStateDiffKey(d.Index))
+}
+
+// GetStateDiff returns the state diff of the block with the given index.
+func (dao *Simple) GetStateDiff(index uint32) (*state.StateDiff, error) {
+	d := new(state.StateDiff)
+	err := dao.GetAndDecode(d, makeStateDiffKey(index))
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DeleteStateDiff removes the state diff of the block with the given index.
+func (dao *Simple) DeleteStateDiff(index uint32) error {
+	return dao.Store.Delete(makeStateDiffKey(index))
+}
+
+func makeStateDiffKey(index uint32) []byte {
+	key := make([]byte, 5)
+	key[0] = byte(storage.SYSStateDiff)
+	binary.BigEndian.PutUint32(key[1:], index)
+	return key
+}
+
 // StoreAsCurrentBlock stores a hash of the given block with prefix
 // SYSCurrentBlock. It can reuse given buffer for the purpose of value
 // serialization.
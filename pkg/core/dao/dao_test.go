@@ -2,6 +2,7 @@ package dao
 
 import (
 	"encoding/binary"
+	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -11,13 +12,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
 func TestPutGetAndDecode(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	serializable := &TestSerializable{field: random.String(4)}
 	hash := []byte{1}
 	err := dao.Put(serializable, hash)
@@ -42,7 +44,7 @@ func (t *TestSerializable) DecodeBinary(reader *io.BinReader) {
 }
 
 func TestPutGetAppExecResult(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	hash := random.Uint256()
 	appExecResult := &state.AppExecResult{
 		Container: hash,
@@ -59,8 +61,71 @@ func TestPutGetAppExecResult(t *testing.T) {
 	require.Equal(t, []state.AppExecResult{*appExecResult}, gotAppExecResult)
 }
 
+func TestCompressedAppExecResult(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false, true)
+	newAER := func(hash util.Uint256, trig trigger.Type, item stackitem.Item) *state.AppExecResult {
+		return &state.AppExecResult{
+			Container: hash,
+			Execution: state.Execution{
+				Trigger: trig,
+				Events:  []state.NotificationEvent{},
+				Stack:   []stackitem.Item{item},
+			},
+		}
+	}
+	compressible := newAER(random.Uint256(), trigger.OnPersist, stackitem.NewByteArray(make([]byte, 1024)))
+	require.NoError(t, dao.PutAppExecResult(compressible, nil))
+	raw, err := dao.Store.Get(storage.AppendPrefix(storage.STNotification, compressible.Container.BytesBE()))
+	require.NoError(t, err)
+	require.Equal(t, appLogLZ4, raw[0])
+	require.True(t, len(raw) < 1024)
+
+	post := newAER(compressible.Container, trigger.PostPersist, stackitem.NewByteArray(random.Bytes(16)))
+	require.NoError(t, dao.AppendAppExecResult(post, nil))
+	res, err := dao.GetAppExecResults(compressible.Container, trigger.All)
+	require.NoError(t, err)
+	require.Equal(t, []state.AppExecResult{*compressible, *post}, res)
+
+	incompressible := newAER(random.Uint256(), trigger.Application, stackitem.NewByteArray(random.Bytes(1024)))
+	require.NoError(t, dao.AppendAppExecResult(incompressible, nil))
+	raw, err = dao.Store.Get(storage.AppendPrefix(storage.STNotification, incompressible.Container.BytesBE()))
+	require.NoError(t, err)
+	require.Equal(t, appLogRaw, raw[0])
+	res, err = dao.GetAppExecResults(incompressible.Container, trigger.All)
+	require.NoError(t, err)
+	require.Equal(t, []state.AppExecResult{*incompressible}, res)
+
+	data := random.Bytes(1024)
+	raw = compressAppLog(data)
+	require.Equal(t, appLogRaw, raw[0])
+	require.Equal(t, data, raw[1:])
+
+	_, err = decompressAppLog(nil)
+	require.Error(t, err)
+	_, err = decompressAppLog([]byte{appLogLZ4, 1})
+	require.Error(t, err)
+	_, err = decompressAppLog([]byte{42})
+	require.Error(t, err)
+	_, err = decompressAppLog([]byte{appLogLZ4, 0xff, 0xff, 0xff, 0xff, 0})
+	require.Error(t, err)
+}
+
+func TestDeleteAppExecResults(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
+	b := &block.Block{Transactions: []*transaction.Transaction{tx}}
+	for _, h := range []util.Uint256{b.Hash(), tx.Hash()} {
+		require.NoError(t, dao.PutAppExecResult(&state.AppExecResult{Container: h}, nil))
+	}
+	require.NoError(t, dao.DeleteAppExecResults(b))
+	for _, h := range []util.Uint256{b.Hash(), tx.Hash()} {
+		_, err := dao.GetAppExecResults(h, trigger.All)
+		require.Error(t, err)
+	}
+}
+
 func TestPutGetStorageItem(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	id := int32(random.Int(0, 1024))
 	key := []byte{0}
 	storageItem := state.StorageItem{}
@@ -71,7 +136,7 @@ func TestPutGetStorageItem(t *testing.T) {
 }
 
 func TestDeleteStorageItem(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	id := int32(random.Int(0, 1024))
 	key := []byte{0}
 	storageItem := state.StorageItem{}
@@ -83,8 +148,47 @@ func TestDeleteStorageItem(t *testing.T) {
 	require.Nil(t, gotStorageItem)
 }
 
+func TestPutGetDeleteStateDiff(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
+	_, err := dao.GetStateDiff(1)
+	require.Error(t, err)
+
+	d := &state.StateDiff{
+		Index: 1,
+		Changes: []state.KeyChange{
+			{Key: []byte{1, 2}, Old: []byte{3}, New: []byte{4}},
+			{Key: []byte{5}, New: []byte{}},
+		},
+	}
+	require.NoError(t, dao.PutStateDiff(d))
+	actual, err := dao.GetStateDiff(1)
+	require.NoError(t, err)
+	require.Equal(t, d, actual)
+
+	require.NoError(t, dao.DeleteStateDiff(1))
+	_, err = dao.GetStateDiff(1)
+	require.Error(t, err)
+}
+
+func TestPutGetDeleteContractID(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
+	h := util.Uint160{1, 2, 3}
+
+	_, err := dao.GetContractScriptHash(5)
+	require.Error(t, err)
+
+	require.NoError(t, dao.PutContractID(5, h))
+	actual, err := dao.GetContractScriptHash(5)
+	require.NoError(t, err)
+	require.Equal(t, h, actual)
+
+	require.NoError(t, dao.DeleteContractID(5))
+	_, err = dao.GetContractScriptHash(5)
+	require.Error(t, err)
+}
+
 func TestGetBlock_NotExists(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	hash := random.Uint256()
 	block, err := dao.GetBlock(hash)
 	require.Error(t, err)
@@ -92,7 +196,7 @@ func TestGetBlock_NotExists(t *testing.T) {
 }
 
 func TestPutGetBlock(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	b := &block.Block{
 		Header: block.Header{
 			Script: transaction.Witness{
@@ -110,30 +214,43 @@ func TestPutGetBlock(t *testing.T) {
 }
 
 func TestGetVersion_NoVersion(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	version, err := dao.GetVersion()
 	require.Error(t, err)
-	require.Equal(t, "", version)
+	require.Equal(t, "", version.Value)
 }
 
 func TestGetVersion(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
-	err := dao.PutVersion("testVersion")
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
+	expected := Version{Value: "testVersion", CompressAppLogs: true}
+	err := dao.PutVersion(expected)
 	require.NoError(t, err)
 	version, err := dao.GetVersion()
 	require.NoError(t, err)
-	require.NotNil(t, version)
+	require.Equal(t, expected, version)
+
+	t.Run("without settings", func(t *testing.T) {
+		require.NoError(t, dao.Store.Put(storage.SYSVersion.Bytes(), []byte("0.1.0")))
+		version, err := dao.GetVersion()
+		require.NoError(t, err)
+		require.Equal(t, Version{Value: "0.1.0"}, version)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		require.NoError(t, dao.Store.Put(storage.SYSVersion.Bytes(), []byte("0.1.0\x00")))
+		_, err := dao.GetVersion()
+		require.Error(t, err)
+	})
 }
 
 func TestGetCurrentHeaderHeight_NoHeader(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	height, err := dao.GetCurrentBlockHeight()
 	require.Error(t, err)
 	require.Equal(t, uint32(0), height)
 }
 
 func TestGetCurrentHeaderHeight_Store(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	b := &block.Block{
 		Header: block.Header{
 			Script: transaction.Witness{
@@ -150,7 +267,7 @@ func TestGetCurrentHeaderHeight_Store(t *testing.T) {
 }
 
 func TestStoreAsTransaction(t *testing.T) {
-	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
 	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
 	hash := tx.Hash()
 	err := dao.StoreAsTransaction(tx, 0, nil)
@@ -159,6 +276,39 @@ func TestStoreAsTransaction(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestConflictRecord(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false, false)
+	hash, conflicting := random.Uint256(), random.Uint256()
+
+	_, _, err := dao.GetConflictRecord(hash)
+	require.True(t, errors.Is(err, storage.ErrKeyNotFound))
+
+	require.NoError(t, dao.StoreConflictRecord(hash, conflicting, 42, nil))
+	require.True(t, errors.Is(dao.HasTransaction(hash), ErrHasConflicts))
+	_, _, err = dao.GetTransaction(hash)
+	require.Error(t, err)
+	actual, height, err := dao.GetConflictRecord(hash)
+	require.NoError(t, err)
+	require.Equal(t, conflicting, actual)
+	require.Equal(t, uint32(42), height)
+
+	t.Run("old format", func(t *testing.T) {
+		dummyTx := transaction.NewTrimmedTX(hash)
+		dummyTx.Version = transaction.DummyVersion
+		require.NoError(t, dao.StoreAsTransaction(dummyTx, 7, nil))
+		actual, height, err := dao.GetConflictRecord(hash)
+		require.NoError(t, err)
+		require.Equal(t, util.Uint256{}, actual)
+		require.Equal(t, uint32(7), height)
+	})
+	t.Run("real transaction", func(t *testing.T) {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
+		require.NoError(t, dao.StoreAsTransaction(tx, 0, nil))
+		_, _, err := dao.GetConflictRecord(tx.Hash())
+		require.True(t, errors.Is(err, storage.ErrKeyNotFound))
+	})
+}
+
 func TestMakeStorageItemKey(t *testing.T) {
 	var id int32 = 5
 
@@ -171,3 +321,15 @@ func TestMakeStorageItemKey(t *testing.T) {
 	actual = makeStorageItemKey(id, nil)
 	require.Equal(t, expected, actual)
 }
+
+func TestParseStorageItemKey(t *testing.T) {
+	id, key, ok := ParseStorageItemKey(makeStorageItemKey(-5, []byte{1, 2, 3}))
+	require.True(t, ok)
+	require.EqualValues(t, -5, id)
+	require.Equal(t, []byte{1, 2, 3}, key)
+
+	_, _, ok = ParseStorageItemKey([]byte{byte(storage.STStorage), 1})
+	require.False(t, ok)
+	_, _, ok = ParseStorageItemKey([]byte{byte(storage.DataBlock), 0, 0, 0, 0})
+	require.False(t, ok)
+}
@@ -2,6 +2,19 @@
 Package core implements Neo ledger functionality.
 It's built around the Blockchain structure that maintains state of the ledger.
 
+Standalone usage
+
+Blockchain doesn't depend on the network server, it can be used on its own by
+applications implementing custom transports or simulation tools. Such an
+application creates Blockchain with NewBlockchain (using any storage.Store,
+storage.NewMemoryStore for simulations), starts its loop with Run in a
+separate goroutine and stops it with Close. Transactions are submitted with
+PoolTx and can then be retrieved from GetMemPool, blocks are added with
+AddBlock. Blocks are to be signed by validators unless VerifyBlocks is
+disabled in the configuration which allows to create them without consensus.
+Oracle and Notary services as well as SetClock are optional too. See the
+standalone example for the complete sequence.
+
 Events
 
 You can subscribe to Blockchain events using a set of Subscribe and Unsubscribe
@@ -25,5 +38,14 @@ Be careful using these subscriptions, this mechanism is not intended to be used
 by lots of subscribers and failing to read from event channels can affect
 other Blockchain operations.
 
+Change handlers
+
+Index builders embedding the node can register change handlers with
+RegisterChangeHandler. They're called synchronously after each block is
+stored with the journal of all DB changes made by it, so an indexer can't
+fall behind the chain. The resulting state is available via GetDAO which
+returns a read-only dao.Reader, helpers like dao.ParseStorageItemKey decode
+changed keys.
+
 */
 package core
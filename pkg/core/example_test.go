@@ -0,0 +1,50 @@
+package core_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"go.uber.org/zap"
+)
+
+func Example_standalone() {
+	cfg, err := config.Load("../../config", netmode.UnitTestNet)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	// There is no consensus to sign blocks.
+	cfg.ProtocolConfiguration.VerifyBlocks = false
+
+	chain, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, zap.NewNop())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	go chain.Run()
+	defer chain.Close()
+
+	prev, err := chain.GetHeader(chain.CurrentBlockHash())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	b := block.New(cfg.ProtocolConfiguration.StateRootInHeader)
+	b.PrevHash = prev.Hash()
+	b.Index = prev.Index + 1
+	b.Timestamp = prev.Timestamp + uint64(cfg.ProtocolConfiguration.SecondsPerBlock)*1000
+	b.NextConsensus = prev.NextConsensus
+	b.Transactions = chain.GetMemPool().GetVerifiedTransactions()
+	b.RebuildMerkleRoot()
+	if err := chain.AddBlock(b); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println(chain.BlockHeight())
+	// Output: 1
+}
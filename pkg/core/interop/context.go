@@ -45,7 +45,13 @@ type Context struct {
 	Log           *zap.Logger
 	VM            *vm.VM
 	Functions     []Function
-	getContract   func(dao.DAO, util.Uint160) (*state.Contract, error)
+	// LogSink receives contract messages and notifications, messages are
+	// written to Log if it's not set (see GetLogSink).
+	LogSink LogSink
+	// ReleasedStorage is the number of storage bytes released by deleting
+	// or shrinking stored values during execution.
+	ReleasedStorage int64
+	getContract     func(dao.DAO, util.Uint160) (*state.Contract, error)
 }
 
 // NewContext returns new interop context.
@@ -222,6 +228,49 @@ func Sort(fs []Function) {
 	sort.Slice(fs, func(i, j int) bool { return fs[i].ID < fs[j].ID })
 }
 
+// AddFunctions returns a new list of interop functions sorted by id containing
+// all of fs and custom functions. ID of a custom function is calculated from
+// its name (the same way it's done for built-in ones) if it's not set. Name
+// and handler are mandatory and neither name nor ID can be the same as the
+// one of any other function. fs is not modified.
+func AddFunctions(fs []Function, custom ...Function) ([]Function, error) {
+	var (
+		res   = make([]Function, len(fs), len(fs)+len(custom))
+		ids   = make(map[uint32]string, len(fs)+len(custom))
+		names = make(map[string]bool, len(fs)+len(custom))
+	)
+	copy(res, fs)
+	for i := range fs {
+		ids[fs[i].ID] = fs[i].Name
+		names[fs[i].Name] = true
+	}
+	for _, f := range custom {
+		if f.Name == "" {
+			return nil, errors.New("interop function without name")
+		}
+		if f.Func == nil {
+			return nil, fmt.Errorf("interop function %s has no handler", f.Name)
+		}
+		if f.Price < 0 || f.ParamCount < 0 {
+			return nil, fmt.Errorf("interop function %s has negative price or parameter count", f.Name)
+		}
+		if names[f.Name] {
+			return nil, fmt.Errorf("interop function %s is already registered", f.Name)
+		}
+		if f.ID == 0 {
+			f.ID = interopnames.ToID([]byte(f.Name))
+		}
+		if other, ok := ids[f.ID]; ok {
+			return nil, fmt.Errorf("interop function %s has the same ID (%d) as %s", f.Name, f.ID, other)
+		}
+		ids[f.ID] = f.Name
+		names[f.Name] = true
+		res = append(res, f)
+	}
+	Sort(res)
+	return res, nil
+}
+
 // GetContract returns contract by its hash in current interop context.
 func (ic *Context) GetContract(hash util.Uint160) (*state.Contract, error) {
 	return ic.getContract(ic.DAO, hash)
@@ -246,6 +295,23 @@ func (ic *Context) BaseExecFee() int64 {
 	return ic.Chain.GetPolicer().GetBaseExecFee()
 }
 
+// StrictBuffers checks whether strict Buffer handling (see the protocol
+// configuration) is enabled at the height being processed.
+func (ic *Context) StrictBuffers() bool {
+	if ic.Chain == nil {
+		return false
+	}
+	cfg := ic.Chain.GetConfig()
+	if !cfg.StrictBuffers {
+		return false
+	}
+	height := ic.Chain.BlockHeight() + 1
+	if ic.Block != nil {
+		height = ic.Block.Index
+	}
+	return height >= cfg.StrictBuffersHeight
+}
+
 // SyscallHandler handles syscall with id.
 func (ic *Context) SyscallHandler(_ *vm.VM, id uint32) error {
 	f := ic.GetFunction(id)
@@ -267,6 +333,7 @@ func (ic *Context) SpawnVM() *vm.VM {
 	v := vm.NewWithTrigger(ic.Trigger)
 	v.GasLimit = -1
 	v.SyscallHandler = ic.SyscallHandler
+	v.StrictBuffers = ic.StrictBuffers()
 	ic.VM = v
 	return v
 }
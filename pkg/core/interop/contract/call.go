@@ -78,7 +78,8 @@ func callInternal(ic *interop.Context, cs *state.Contract, name string, f callfl
 		curr, err := ic.GetContract(ic.VM.GetCurrentScriptHash())
 		if err == nil {
 			if !curr.Manifest.CanCall(cs.Hash, &cs.Manifest, name) {
-				return errors.New("disallowed method call")
+				return fmt.Errorf("disallowed method call: '%s' method of %s (%s) is not allowed by %s permissions",
+					name, cs.Manifest.Name, cs.Hash.StringLE(), curr.Manifest.Name)
 			}
 		}
 	}
@@ -0,0 +1,80 @@
+package interop
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// LogSink receives messages and notifications emitted by contracts via
+// System.Runtime.Log and System.Runtime.Notify syscalls. It allows
+// applications embedding the node to capture them programmatically. Note that
+// notifications are passed to the sink as soon as they're emitted, so they can
+// belong to an execution that fails later. Sink methods are called from the
+// execution goroutine and must not modify context passed.
+type LogSink interface {
+	// Log is called for every message logged by the contract with the
+	// given script hash.
+	Log(ic *Context, script util.Uint160, msg string)
+	// Notify is called for every notification emitted.
+	Notify(ic *Context, ne *state.NotificationEvent)
+}
+
+// zapLogSink is the default LogSink writing messages to the logger.
+type zapLogSink struct {
+	log *zap.Logger
+}
+
+// nopLogSink is a LogSink that discards everything.
+type nopLogSink struct{}
+
+// NewZapLogSink returns LogSink that logs contract messages with Info level
+// and notifications with Debug level to the given logger. It's the sink used
+// by default.
+func NewZapLogSink(log *zap.Logger) LogSink {
+	return zapLogSink{log: log}
+}
+
+// Log implements LogSink interface.
+func (s zapLogSink) Log(ic *Context, script util.Uint160, msg string) {
+	var txHash string
+	if ic.Tx != nil {
+		txHash = ic.Tx.Hash().StringLE()
+	}
+	s.log.Info("runtime log",
+		zap.String("tx", txHash),
+		zap.String("script", script.StringLE()),
+		zap.String("msg", msg))
+}
+
+// Notify implements LogSink interface.
+func (s zapLogSink) Notify(ic *Context, ne *state.NotificationEvent) {
+	if ce := s.log.Check(zap.DebugLevel, "runtime notify"); ce != nil {
+		var txHash string
+		if ic.Tx != nil {
+			txHash = ic.Tx.Hash().StringLE()
+		}
+		ce.Write(zap.String("tx", txHash),
+			zap.String("script", ne.ScriptHash.StringLE()),
+			zap.String("name", ne.Name))
+	}
+}
+
+// Log implements LogSink interface.
+func (nopLogSink) Log(*Context, util.Uint160, string) {}
+
+// Notify implements LogSink interface.
+func (nopLogSink) Notify(*Context, *state.NotificationEvent) {}
+
+// GetLogSink returns LogSink to be used for contract messages: LogSink if it's
+// set, the one writing to Log if it's set or the one discarding everything.
+func (ic *Context) GetLogSink() LogSink {
+	switch {
+	case ic.LogSink != nil:
+		return ic.LogSink
+	case ic.Log != nil:
+		return NewZapLogSink(ic.Log)
+	default:
+		return nopLogSink{}
+	}
+}
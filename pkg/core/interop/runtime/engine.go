@@ -7,7 +7,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
-	"go.uber.org/zap"
 )
 
 const (
@@ -74,6 +73,7 @@ func Notify(ic *interop.Context) error {
 		Item:       stackitem.DeepCopy(stackitem.NewArray(args)).(*stackitem.Array),
 	}
 	ic.Notifications = append(ic.Notifications, ne)
+	ic.GetLogSink().Notify(ic, &ne)
 	return nil
 }
 
@@ -83,14 +83,7 @@ func Log(ic *interop.Context) error {
 	if len(state) > MaxNotificationSize {
 		return fmt.Errorf("message length shouldn't exceed %v", MaxNotificationSize)
 	}
-	var txHash string
-	if ic.Tx != nil {
-		txHash = ic.Tx.Hash().StringLE()
-	}
-	ic.Log.Info("runtime log",
-		zap.String("tx", txHash),
-		zap.String("script", ic.VM.GetCurrentScriptHash().StringLE()),
-		zap.String("msg", state))
+	ic.GetLogSink().Log(ic, ic.VM.GetCurrentScriptHash(), state)
 	return nil
 }
 
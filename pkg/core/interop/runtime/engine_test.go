@@ -9,6 +9,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -170,3 +171,40 @@ func TestNotify(t *testing.T) {
 		require.NotEqual(t, arr, ev.Item)
 	})
 }
+
+type testLogSink struct {
+	scripts  []util.Uint160
+	messages []string
+	events   []state.NotificationEvent
+}
+
+func (s *testLogSink) Log(_ *interop.Context, script util.Uint160, msg string) {
+	s.scripts = append(s.scripts, script)
+	s.messages = append(s.messages, msg)
+}
+
+func (s *testLogSink) Notify(_ *interop.Context, ne *state.NotificationEvent) {
+	s.events = append(s.events, *ne)
+}
+
+func TestLogSink(t *testing.T) {
+	h := random.Uint160()
+	sink := new(testLogSink)
+	ic := &interop.Context{Log: zap.NewNop(), LogSink: sink, VM: vm.New()}
+	ic.VM.LoadScriptWithHash([]byte{1}, h, callflag.All)
+
+	ic.VM.Estack().PushVal("hello")
+	require.NoError(t, Log(ic))
+	require.Equal(t, []util.Uint160{h}, sink.scripts)
+	require.Equal(t, []string{"hello"}, sink.messages)
+
+	arr := stackitem.NewArray([]stackitem.Item{stackitem.Make(42)})
+	ic.VM.Estack().PushVal(arr)
+	ic.VM.Estack().PushVal("event")
+	require.NoError(t, Notify(ic))
+	require.Equal(t, ic.Notifications, sink.events)
+
+	ic.VM.Estack().PushVal(string(make([]byte, MaxNotificationSize+1)))
+	require.Error(t, Log(ic))
+	require.Equal(t, 1, len(sink.messages))
+}
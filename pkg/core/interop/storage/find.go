@@ -1,6 +1,12 @@
 package storage
 
-import "github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+import (
+	"bytes"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
 
 // Storage iterator options.
 const (
@@ -22,12 +28,15 @@ type Iterator struct {
 	opts       int64
 	index      int
 	prefixSize int
+	// StrictBuffers makes FindDeserialize keep Buffer items as Buffer.
+	StrictBuffers bool
 }
 
-// NewIterator creates a new Iterator with given options for a given map.
-func NewIterator(m *stackitem.Map, prefix int, opts int64) *Iterator {
+// NewIterator creates a new Iterator with given options for a given set of
+// key-value pairs (see SeekItems).
+func NewIterator(m []stackitem.MapElement, prefix int, opts int64) *Iterator {
 	return &Iterator{
-		m:          m.Value().([]stackitem.MapElement),
+		m:          m,
 		opts:       opts,
 		index:      -1,
 		prefixSize: prefix,
@@ -57,7 +66,11 @@ func (s *Iterator) Value() stackitem.Item {
 	if s.opts&FindDeserialize != 0 {
 		bs := s.m[s.index].Value.Value().([]byte)
 		var err error
-		value, err = stackitem.DeserializeItem(bs)
+		if s.StrictBuffers {
+			value, err = stackitem.DeserializeItemStrict(bs)
+		} else {
+			value, err = stackitem.DeserializeItem(bs)
+		}
 		if err != nil {
 			panic(err)
 		}
@@ -75,3 +88,28 @@ func (s *Iterator) Value() stackitem.Item {
 		value,
 	})
 }
+
+// SeekItems returns storage items of the contract with the given id and
+// prefix as key-value pairs sorted by key (keys include the prefix). Items
+// are collected via dao.Seek without intermediate maps, but they're still
+// copied into the resulting slice, because iterators must not see storage
+// changes made after their creation.
+func SeekItems(d dao.DAO, id int32, prefix []byte) []stackitem.MapElement {
+	var res []stackitem.MapElement
+	d.Seek(id, prefix, func(k, v []byte) {
+		// Must copy here, #1468.
+		key := make([]byte, len(prefix)+len(k))
+		copy(key, prefix)
+		copy(key[len(prefix):], k)
+		val := make([]byte, len(v))
+		copy(val, v)
+		res = append(res, stackitem.MapElement{
+			Key:   stackitem.NewByteArray(key),
+			Value: stackitem.NewByteArray(val),
+		})
+	})
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].Key.Value().([]byte), res[j].Key.Value().([]byte)) == -1
+	})
+	return res
+}
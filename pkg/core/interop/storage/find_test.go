@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeekItems(t *testing.T) {
+	d := dao.NewSimple(storage.NewMemoryStore(), false, false)
+	require.NoError(t, d.PutStorageItem(1, []byte{1, 2}, state.StorageItem{3}))
+	require.NoError(t, d.PutStorageItem(1, []byte{1, 1}, state.StorageItem{2}))
+	require.NoError(t, d.PutStorageItem(1, []byte{2}, state.StorageItem{4}))
+	require.NoError(t, d.PutStorageItem(2, []byte{1, 0}, state.StorageItem{5}))
+
+	// Items from the persisted store and from the cache are merged and sorted.
+	_, err := d.Persist()
+	require.NoError(t, err)
+	d = dao.NewSimple(d.Store, false, false)
+	require.NoError(t, d.PutStorageItem(1, []byte{1, 0}, state.StorageItem{1}))
+	require.NoError(t, d.DeleteStorageItem(1, []byte{1, 1}))
+
+	require.Equal(t, []stackitem.MapElement{
+		{Key: stackitem.NewByteArray([]byte{1, 0}), Value: stackitem.NewByteArray([]byte{1})},
+		{Key: stackitem.NewByteArray([]byte{1, 2}), Value: stackitem.NewByteArray([]byte{3})},
+	}, SeekItems(d, 1, []byte{1}))
+	require.Len(t, SeekItems(d, 1, nil), 3)
+	require.Nil(t, SeekItems(d, 3, nil))
+
+	it := NewIterator(SeekItems(d, 1, []byte{1}), 1, FindKeysOnly|FindRemovePrefix)
+	require.True(t, it.Next())
+	require.Equal(t, stackitem.NewByteArray([]byte{0}), it.Value())
+	require.True(t, it.Next())
+	require.Equal(t, stackitem.NewByteArray([]byte{2}), it.Value())
+	require.False(t, it.Next())
+}
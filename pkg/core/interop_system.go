@@ -1,12 +1,10 @@
 package core
 
 import (
-	"bytes"
 	"crypto/elliptic"
 	"errors"
 	"fmt"
 	"math"
-	"sort"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
@@ -40,7 +38,7 @@ func engineGetScriptContainer(ic *interop.Context) error {
 	case *transaction.Transaction:
 		item = native.TransactionToStackItem(t)
 	case *block.Block:
-		item = native.BlockToStackItem(t)
+		item = native.BlockToStackItem(t, native.IsBlockItemExtended(ic))
 	default:
 		return errors.New("unknown script container")
 	}
@@ -59,6 +57,9 @@ func storageDelete(ic *interop.Context) error {
 		return errors.New("StorageContext is read only")
 	}
 	key := ic.VM.Estack().Pop().Bytes()
+	if si := ic.DAO.GetStorageItem(stc.ID, key); si != nil {
+		ic.ReleasedStorage += int64(len(key) + len(si))
+	}
 	return ic.DAO.DeleteStorageItem(stc.ID, key)
 }
 
@@ -125,6 +126,9 @@ func putWithContext(ic *interop.Context, stc *StorageContext, key []byte, value
 			sizeInc = (len(si)-1)/4 + 1 + len(value) - len(si)
 		}
 	}
+	if si != nil && len(value) < len(si) {
+		ic.ReleasedStorage += int64(len(si) - len(value))
+	}
 	if !ic.VM.AddGas(int64(sizeInc) * ic.Chain.GetPolicer().GetStoragePrice()) {
 		return errGasLimitExceeded
 	}
@@ -187,24 +191,9 @@ func storageFind(ic *interop.Context) error {
 	if opts&istorage.FindDeserialize == 0 && (opts&istorage.FindPick0 != 0 || opts&istorage.FindPick1 != 0) {
 		return fmt.Errorf("%w: PickN is specified without Deserialize", errFindInvalidOptions)
 	}
-	siMap, err := ic.DAO.GetStorageItemsWithPrefix(stc.ID, prefix)
-	if err != nil {
-		return err
-	}
-
-	filteredMap := stackitem.NewMap()
-	for k, v := range siMap {
-		key := append(prefix, []byte(k)...)
-		keycopy := make([]byte, len(key))
-		copy(keycopy, key)
-		filteredMap.Add(stackitem.NewByteArray(keycopy), stackitem.NewByteArray(v))
-	}
-	sort.Slice(filteredMap.Value().([]stackitem.MapElement), func(i, j int) bool {
-		return bytes.Compare(filteredMap.Value().([]stackitem.MapElement)[i].Key.Value().([]byte),
-			filteredMap.Value().([]stackitem.MapElement)[j].Key.Value().([]byte)) == -1
-	})
-
-	item := istorage.NewIterator(filteredMap, len(prefix), opts)
+	items := istorage.SeekItems(ic.DAO, stc.ID, prefix)
+	item := istorage.NewIterator(items, len(prefix), opts)
+	item.StrictBuffers = ic.StrictBuffers()
 	ic.VM.Estack().PushVal(stackitem.NewInterop(item))
 
 	return nil
@@ -220,7 +209,11 @@ func contractCreateMultisigAccount(ic *interop.Context) error {
 	arr := ic.VM.Estack().Pop().Array()
 	pubs := make(keys.PublicKeys, len(arr))
 	for i, pk := range arr {
-		p, err := keys.NewPublicKeyFromBytes(pk.Value().([]byte), elliptic.P256())
+		b, err := pk.TryBytes()
+		if err != nil {
+			return fmt.Errorf("invalid public key #%d: %w", i, err)
+		}
+		p, err := keys.NewPublicKeyFromBytes(b, elliptic.P256())
 		if err != nil {
 			return err
 		}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
@@ -77,11 +78,36 @@ func TestContractCreateMultisigAccount(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, hash.Hash160(expected), u)
 	})
+	t.Run("key order", func(t *testing.T) {
+		pubs := make(keys.PublicKeys, 3)
+		for i := range pubs {
+			pk, err := keys.NewPrivateKey()
+			require.NoError(t, err)
+			pubs[i] = pk.PublicKey()
+		}
+		expected, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+		require.NoError(t, err)
+
+		// Keys are sorted now, so pass them in reverse order.
+		arr := make([]stackitem.Item, len(pubs))
+		for i := range pubs {
+			arr[len(pubs)-1-i] = stackitem.Make(pubs[i].Bytes())
+		}
+		v.Estack().PushVal(stackitem.Make(arr))
+		v.Estack().PushVal(2)
+		require.NoError(t, contractCreateMultisigAccount(ic))
+		require.Equal(t, hash.Hash160(expected).BytesBE(), v.Estack().Pop().Bytes())
+	})
 	t.Run("InvalidKey", func(t *testing.T) {
 		v.Estack().PushVal(stackitem.Make([]stackitem.Item{stackitem.Make([]byte{1, 2, 3})}))
 		v.Estack().PushVal(1)
 		require.Error(t, contractCreateMultisigAccount(ic))
 	})
+	t.Run("key is not a byte string", func(t *testing.T) {
+		v.Estack().PushVal(stackitem.Make([]stackitem.Item{stackitem.NewMap()}))
+		v.Estack().PushVal(1)
+		require.Error(t, contractCreateMultisigAccount(ic))
+	})
 	t.Run("Invalid m", func(t *testing.T) {
 		pk, err := keys.NewPrivateKey()
 		require.NoError(t, err)
@@ -168,6 +194,45 @@ func TestRuntimeGetInvocationCounter(t *testing.T) {
 	})
 }
 
+func TestRuntimeGetScriptContainer(t *testing.T) {
+	v, tx, ic, bc := createVMAndTX(t)
+
+	t.Run("Transaction", func(t *testing.T) {
+		require.NoError(t, engineGetScriptContainer(ic))
+		actual := v.Estack().Pop().Item()
+		require.Equal(t, native.TransactionToStackItem(tx), actual)
+		arr := actual.Value().([]stackitem.Item)
+		require.Equal(t, tx.Hash().BytesBE(), arr[0].Value())
+		require.Equal(t, tx.Script, arr[7].Value())
+	})
+	t.Run("Block", func(t *testing.T) {
+		b := bc.newBlock(tx)
+		ic.Trigger = trigger.OnPersist
+		ic.Container = b
+		require.NoError(t, engineGetScriptContainer(ic))
+		actual := v.Estack().Pop().Item()
+		require.Equal(t, native.BlockToStackItem(b, false), actual)
+		arr := actual.Value().([]stackitem.Item)
+		require.Equal(t, 8, len(arr))
+		require.Equal(t, b.Hash().BytesBE(), arr[0].Value())
+		require.EqualValues(t, 1, arr[7].Value().(*big.Int).Int64())
+
+		arr = native.BlockToStackItem(b, true).Value().([]stackitem.Item)
+		require.Equal(t, 10, len(arr))
+		require.EqualValues(t, b.PrimaryIndex, arr[8].Value().(*big.Int).Int64())
+		require.Equal(t, stackitem.Null{}, arr[9])
+
+		b.StateRootEnabled = true
+		b.PrevStateRoot = util.Uint256{1, 2, 3}
+		arr = native.BlockToStackItem(b, true).Value().([]stackitem.Item)
+		require.Equal(t, b.PrevStateRoot.BytesBE(), arr[9].Value())
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		ic.Container = nil
+		require.Error(t, engineGetScriptContainer(ic))
+	})
+}
+
 func TestStoragePut(t *testing.T) {
 	_, cs, ic, bc := createVMAndContractState(t)
 
@@ -201,6 +266,7 @@ func TestStoragePut(t *testing.T) {
 		require.NoError(t, storagePut(ic))
 		initVM(t, []byte{4}, []byte{5, 6}, native.DefaultStoragePrice)
 		require.NoError(t, storagePut(ic))
+		require.EqualValues(t, 2, ic.ReleasedStorage)
 	})
 
 	t.Run("check limits", func(t *testing.T) {
@@ -244,6 +310,14 @@ func TestStorageDelete(t *testing.T) {
 		v.Estack().PushVal("key1")
 		require.NoError(t, storageGetContext(ic))
 		require.NoError(t, storageDelete(ic))
+		require.EqualValues(t, len("key1")+len("value1"), ic.ReleasedStorage)
+	})
+	t.Run("missing key", func(t *testing.T) {
+		ic.ReleasedStorage = 0
+		v.Estack().PushVal("key1")
+		require.NoError(t, storageGetContext(ic))
+		require.NoError(t, storageDelete(ic))
+		require.EqualValues(t, 0, ic.ReleasedStorage)
 	})
 	t.Run("readonly context", func(t *testing.T) {
 		v.Estack().PushVal("key2")
@@ -445,7 +519,7 @@ func TestStorageFind(t *testing.T) {
 func createVM(t *testing.T) (*vm.VM, *interop.Context, *Blockchain) {
 	chain := newTestChain(t)
 	context := chain.newInteropContext(trigger.Application,
-		dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader), nil, nil)
+		dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader, false), nil, nil)
 	v := context.SpawnVM()
 	return v, context, chain
 }
@@ -465,7 +539,7 @@ func createVMAndContractState(t *testing.T) (*vm.VM, *state.Contract, *interop.C
 	}
 
 	chain := newTestChain(t)
-	d := dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader)
+	d := dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader, false)
 	context := chain.newInteropContext(trigger.Application, d, nil, nil)
 	v := context.SpawnVM()
 	return v, contractState, context, chain
@@ -477,7 +551,7 @@ func createVMAndTX(t *testing.T) (*vm.VM, *transaction.Transaction, *interop.Con
 	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3, 4}}}
 	tx.Scripts = []transaction.Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
 	chain := newTestChain(t)
-	d := dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader)
+	d := dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader, false)
 	context := chain.newInteropContext(trigger.Application, d, nil, tx)
 	v := context.SpawnVM()
 	return v, tx, context, chain
@@ -928,6 +1002,19 @@ func TestContractCall(t *testing.T) {
 			"add", h.BytesBE()))
 	})
 
+	t.Run("NotPermitted", func(t *testing.T) {
+		loadScriptWithHashAndFlags(ic, currScript, currCs.Hash, callflag.All, 42)
+		ic.VM.Context().NEF = &currCs.NEF
+		ic.VM.Estack().PushVal(stackitem.NewArray(nil))
+		ic.VM.Estack().PushVal(callflag.All)
+		ic.VM.Estack().PushVal("ret7")
+		ic.VM.Estack().PushVal(h.BytesBE())
+		err := contract.Call(ic)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "'ret7' method")
+		require.Contains(t, err.Error(), "not allowed")
+	})
+
 	t.Run("ReturnValues", func(t *testing.T) {
 		t.Run("Many", func(t *testing.T) {
 			loadScript(ic, currScript, 42)
@@ -1235,3 +1322,34 @@ func TestRuntimeBurnGas(t *testing.T) {
 		checkFAULTState(t, aer)
 	})
 }
+
+func TestStrictBuffers(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.StrictBuffers = true
+		c.ProtocolConfiguration.StrictBuffersHeight = 2
+	})
+	data, err := stackitem.SerializeItem(stackitem.NewBuffer([]byte{1, 2, 3}))
+	require.NoError(t, err)
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, bc.contracts.Std.Hash, "deserialize", callflag.All, data)
+	emit.Instruction(w.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.BufferT)})
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	check := func(t *testing.T, strict bool) {
+		ic := bc.newInteropContext(trigger.Application, bc.dao, nil, nil)
+		v := ic.SpawnVM()
+		require.Equal(t, strict, v.StrictBuffers)
+		v.LoadScriptWithFlags(script, callflag.All)
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, v.Estack().Len())
+		require.Equal(t, strict, v.Estack().Pop().Bool())
+	}
+	t.Run("before height", func(t *testing.T) {
+		check(t, false) // executed in block 1
+	})
+	t.Run("after height", func(t *testing.T) {
+		require.NoError(t, bc.AddBlock(bc.newBlock()))
+		check(t, true) // executed in block 2
+	})
+}
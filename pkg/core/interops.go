@@ -21,10 +21,14 @@ import (
 )
 
 // SpawnVM returns a VM with script getter and interop functions set
-// up for current blockchain.
+// up for current blockchain. Interop functions already set in the context
+// (like the ones registered for the chain) are kept, standard ones are used
+// otherwise.
 func SpawnVM(ic *interop.Context) *vm.VM {
 	vm := ic.SpawnVM()
-	ic.Functions = systemInterops
+	if len(ic.Functions) == 0 {
+		ic.Functions = systemInterops
+	}
 	return vm
 }
 
@@ -75,6 +79,27 @@ var systemInterops = []interop.Function{
 		RequiredFlags: callflag.ReadStates, ParamCount: 1},
 }
 
+// ReadOnlySyscalls returns IDs of syscalls that can't change the chain state
+// or emit notifications (those not requiring any flags beyond ReadOnly), they
+// can be passed to (*vm.VM).RestrictSyscalls to evaluate untrusted scripts.
+func ReadOnlySyscalls() []uint32 {
+	var ids []uint32
+	for i := range systemInterops {
+		if systemInterops[i].RequiredFlags&^callflag.ReadOnly == 0 {
+			ids = append(ids, systemInterops[i].ID)
+		}
+	}
+	return ids
+}
+
+// Interops returns a list of all standard system interops sorted by ID, use
+// (*Blockchain).GetInterops to get the ones available on the particular chain.
+func Interops() []interop.Function {
+	res := make([]interop.Function, len(systemInterops))
+	copy(res, systemInterops)
+	return res
+}
+
 // init initializes IDs in the global interop slices.
 func init() {
 	for i := range systemInterops {
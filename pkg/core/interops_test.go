@@ -3,13 +3,19 @@ package core
 import (
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,7 +23,7 @@ func testNonInterop(t *testing.T, value interface{}, f func(*interop.Context) er
 	v := vm.New()
 	v.Estack().PushVal(value)
 	chain := newTestChain(t)
-	d := dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader)
+	d := dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader, false)
 	context := chain.newInteropContext(trigger.Application, d, nil, nil)
 	context.VM = v
 	require.Error(t, f(context))
@@ -48,3 +54,42 @@ func TestUnexpectedNonInterops(t *testing.T) {
 		}
 	}
 }
+
+func TestReadOnlySyscalls(t *testing.T) {
+	chain := newTestChain(t)
+	run := func(name string) error {
+		w := io.NewBufBinWriter()
+		emit.Syscall(w.BinWriter, name)
+		v := chain.GetTestVM(trigger.Application, &transaction.Transaction{}, nil)
+		v.LoadScriptWithFlags(w.Bytes(), callflag.All)
+		v.RestrictSyscalls(ReadOnlySyscalls())
+		return v.Run()
+	}
+	require.NoError(t, run(interopnames.SystemRuntimeGetTrigger))
+	for _, f := range Interops() {
+		err := run(f.Name)
+		denied := err != nil && strings.Contains(err.Error(), "is not allowed")
+		require.Equal(t, f.RequiredFlags&^callflag.ReadOnly != 0, denied, f.Name)
+	}
+	for _, name := range []string{
+		interopnames.SystemRuntimeBurnGas,
+		interopnames.SystemContractCall,
+		interopnames.SystemStorageFind,
+	} {
+		err := run(name)
+		if err != nil {
+			require.NotContains(t, err.Error(), "is not allowed", name)
+		}
+	}
+	for _, name := range []string{
+		interopnames.SystemRuntimeLog,
+		interopnames.SystemRuntimeNotify,
+		interopnames.SystemStoragePut,
+		interopnames.SystemStorageDelete,
+		interopnames.SystemContractNativeOnPersist,
+	} {
+		err := run(name)
+		require.Error(t, err, name)
+		require.Contains(t, err.Error(), "is not allowed", name)
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"math/bits"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/clock"
 	"go.uber.org/atomic"
 )
 
@@ -40,9 +42,19 @@ var (
 type item struct {
 	txn        *transaction.Transaction
 	blockStamp uint32
+	timestamp  time.Time
 	data       interface{}
 }
 
+// PooledTx is a pooled transaction along with the details of its addition.
+type PooledTx struct {
+	Tx *transaction.Transaction
+	// Height is the blockchain height at the moment of addition.
+	Height uint32
+	// Timestamp is the time of addition.
+	Timestamp time.Time
+}
+
 // items is a slice of item.
 type items []item
 
@@ -63,6 +75,12 @@ type Pool struct {
 	conflicts map[util.Uint256][]util.Uint256
 	// oracleResp contains ids of oracle responses for tx in pool.
 	oracleResp map[uint64]util.Uint256
+	// verifiedWitnesses contains results of successful witness verification
+	// (see MarkWitnessesVerified), it's limited by the pool capacity and is
+	// not affected by transactions removal from the pool.
+	verifiedWitnesses map[util.Uint256]util.Uint256
+	verifiedOrder     []util.Uint256
+	verifiedIndex     int
 
 	capacity   int
 	feePerByte int64
@@ -71,6 +89,9 @@ type Pool struct {
 	resendThreshold uint32
 	resendFunc      func(*transaction.Transaction, interface{})
 
+	// clock is a time source for transaction addition timestamps.
+	clock clock.Clock
+
 	// subscriptions for mempool events
 	subscriptionsEnabled bool
 	subscriptionsOn      atomic.Bool
@@ -198,6 +219,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 	var pItem = item{
 		txn:        t,
 		blockStamp: fee.BlockHeight(),
+		timestamp:  mp.clock.Now(),
 	}
 	if data != nil {
 		pItem.data = data[0]
@@ -426,16 +448,59 @@ func New(capacity int, payerIndex int, enableSubscriptions bool) *Pool {
 		fees:                 make(map[util.Uint160]utilityBalanceAndFees),
 		conflicts:            make(map[util.Uint256][]util.Uint256),
 		oracleResp:           make(map[uint64]util.Uint256),
+		verifiedWitnesses:    make(map[util.Uint256]util.Uint256, capacity),
+		verifiedOrder:        make([]util.Uint256, capacity),
 		subscriptionsEnabled: enableSubscriptions,
 		stopCh:               make(chan struct{}),
 		events:               make(chan Event),
 		subCh:                make(chan chan<- Event),
 		unsubCh:              make(chan chan<- Event),
+		clock:                clock.Real,
 	}
 	mp.subscriptionsOn.Store(false)
 	return mp
 }
 
+// SetClock replaces the time source used for pooled transaction timestamps
+// (clock.Real by default). It's not protected by mutex and must be called
+// before the pool is used.
+func (mp *Pool) SetClock(c clock.Clock) {
+	mp.clock = c
+}
+
+// MarkWitnessesVerified remembers that witnesses of the transaction with the
+// given hash were successfully verified. The key is an arbitrary value covering
+// everything verification result depends on (like witnesses themselves), it's
+// checked by WitnessesVerified. The oldest results are dropped when the number
+// of them exceeds pool capacity.
+func (mp *Pool) MarkWitnessesVerified(hash util.Uint256, key util.Uint256) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	if len(mp.verifiedOrder) == 0 {
+		return
+	}
+	if _, ok := mp.verifiedWitnesses[hash]; !ok {
+		old := mp.verifiedOrder[mp.verifiedIndex]
+		if len(mp.verifiedWitnesses) == len(mp.verifiedOrder) {
+			delete(mp.verifiedWitnesses, old)
+		}
+		mp.verifiedOrder[mp.verifiedIndex] = hash
+		mp.verifiedIndex = (mp.verifiedIndex + 1) % len(mp.verifiedOrder)
+	}
+	mp.verifiedWitnesses[hash] = key
+}
+
+// WitnessesVerified returns true if witnesses of the transaction with the given
+// hash were marked as verified with the same key.
+func (mp *Pool) WitnessesVerified(hash util.Uint256, key util.Uint256) bool {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+
+	k, ok := mp.verifiedWitnesses[hash]
+	return ok && k == key
+}
+
 // SetResendThreshold sets threshold after which transaction will be considered stale
 // and returned for retransmission by `GetStaleTransactions`.
 func (mp *Pool) SetResendThreshold(h uint32, f func(*transaction.Transaction, interface{})) {
@@ -500,6 +565,25 @@ func (mp *Pool) GetVerifiedTransactions() []*transaction.Transaction {
 	return t
 }
 
+// GetVerifiedPooledTxs returns a slice of pooled transactions along with the
+// details of their addition ordered by priority.
+func (mp *Pool) GetVerifiedPooledTxs() []PooledTx {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+
+	var t = make([]PooledTx, len(mp.verifiedTxes))
+
+	for i := range mp.verifiedTxes {
+		t[i] = PooledTx{
+			Tx:        mp.verifiedTxes[i].txn,
+			Height:    mp.verifiedTxes[i].blockStamp,
+			Timestamp: mp.verifiedTxes[i].timestamp,
+		}
+	}
+
+	return t
+}
+
 // checkTxConflicts is an internal unprotected version of Verify. It takes into
 // consideration conflicting transactions which are about to be removed from mempool.
 func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*transaction.Transaction, error) {
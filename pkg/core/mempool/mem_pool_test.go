@@ -11,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/clock"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -64,6 +65,44 @@ func testMemPoolAddRemoveWithFeer(t *testing.T, fs Feer) {
 	assert.Equal(t, 0, len(mp.verifiedTxes))
 }
 
+func TestGetVerifiedPooledTxs(t *testing.T) {
+	mp := New(5, 0, false)
+	start := time.Now()
+	txs := make([]*transaction.Transaction, 3)
+	for i := range txs {
+		txs[i] = transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		txs[i].Nonce = uint32(i)
+		txs[i].NetworkFee = int64(i)
+		txs[i].Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		require.NoError(t, mp.Add(txs[i], &FeerStub{blockHeight: uint32(10 + i), balance: 100}))
+	}
+	pooled := mp.GetVerifiedPooledTxs()
+	require.Equal(t, len(txs), len(pooled))
+	for i, p := range pooled {
+		// The most prioritized (with the highest fee) transaction is the first one.
+		j := len(txs) - 1 - i
+		require.Equal(t, txs[j], p.Tx)
+		require.Equal(t, uint32(10+j), p.Height)
+		require.False(t, p.Timestamp.Before(start))
+		require.False(t, p.Timestamp.After(time.Now()))
+	}
+}
+
+func TestMemPoolClock(t *testing.T) {
+	c := clock.NewMock(time.Unix(1000, 0))
+	mp := New(10, 0, false)
+	mp.SetClock(c)
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	require.NoError(t, mp.Add(tx, &FeerStub{}))
+	c.Add(time.Hour)
+
+	pooled := mp.GetVerifiedPooledTxs()
+	require.Equal(t, 1, len(pooled))
+	require.Equal(t, time.Unix(1000, 0), pooled[0].Timestamp)
+}
+
 func TestMemPoolRemoveStale(t *testing.T) {
 	mp := New(5, 0, false)
 	txs := make([]*transaction.Transaction, 5)
@@ -655,3 +694,27 @@ func TestMempoolAddWithDataGetData(t *testing.T) {
 	_, ok = mp.TryGetData(r7.FallbackTransaction.Hash())
 	require.False(t, ok)
 }
+
+func TestMempoolWitnessesVerified(t *testing.T) {
+	mp := New(2, 0, false)
+	hashes := []util.Uint256{random.Uint256(), random.Uint256(), random.Uint256()}
+	key := random.Uint256()
+
+	require.False(t, mp.WitnessesVerified(hashes[0], key))
+	mp.MarkWitnessesVerified(hashes[0], key)
+	require.True(t, mp.WitnessesVerified(hashes[0], key))
+	require.False(t, mp.WitnessesVerified(hashes[0], random.Uint256()))
+
+	// Marking the same hash again doesn't evict anything.
+	mp.MarkWitnessesVerified(hashes[1], key)
+	mp.MarkWitnessesVerified(hashes[1], key)
+	require.True(t, mp.WitnessesVerified(hashes[0], key))
+	require.True(t, mp.WitnessesVerified(hashes[1], key))
+
+	// The oldest result is dropped when capacity is exceeded.
+	mp.MarkWitnessesVerified(hashes[2], key)
+	require.False(t, mp.WitnessesVerified(hashes[0], key))
+	require.True(t, mp.WitnessesVerified(hashes[1], key))
+	require.True(t, mp.WitnessesVerified(hashes[2], key))
+	require.Equal(t, 2, len(mp.verifiedWitnesses))
+}
@@ -3,8 +3,11 @@ package native
 import (
 	"strings"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -54,6 +57,29 @@ func (cs *Contracts) ByName(name string) interop.Contract {
 	return nil
 }
 
+// ResetCaches drops cached values of native contracts and reloads them from
+// the given DAO. It's needed when the chain state is changed not by regular
+// block processing (like when some blocks are rolled back).
+func (cs *Contracts) ResetCaches(bc blockchainer.Blockchainer, d dao.DAO) error {
+	cs.Policy.lock.Lock()
+	cs.Policy.isValid = false
+	cs.Policy.lock.Unlock()
+	if cs.Notary != nil {
+		cs.Notary.lock.Lock()
+		cs.Notary.isValid = false
+		cs.Notary.lock.Unlock()
+	}
+	cs.Designate.rolesChangedFlag.Store(true)
+	cs.Oracle.requestPriceChanged.Store(true)
+	cs.NEO.votesChanged.Store(true)
+	cs.NEO.validators.Store(keys.PublicKeys(nil))
+	cs.NEO.registerPriceChanged.Store(true)
+	if err := cs.NEO.InitializeCache(bc, d); err != nil {
+		return err
+	}
+	return cs.Management.InitializeCache(d)
+}
+
 // NewContracts returns new set of native contracts with new GAS, NEO, Policy, Oracle,
 // Designate and (optional) Notary contracts.
 func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string][]uint32) *Contracts {
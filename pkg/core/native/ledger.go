@@ -103,11 +103,13 @@ func (l *Ledger) currentIndex(ic *interop.Context, _ []stackitem.Item) stackitem
 // getBlock implements getBlock SC method.
 func (l *Ledger) getBlock(ic *interop.Context, params []stackitem.Item) stackitem.Item {
 	hash := getBlockHashFromItem(ic.Chain, params[0])
+	// Blocks with only headers known are always above the current height,
+	// so they're not traceable the same way as unknown blocks are.
 	block, err := ic.Chain.GetBlock(hash)
 	if err != nil || !isTraceableBlock(ic.Chain, block.Index) {
 		return stackitem.Null{}
 	}
-	return BlockToStackItem(block)
+	return BlockToStackItem(block, IsBlockItemExtended(ic))
 }
 
 // getTransaction returns transaction to the SC.
@@ -192,9 +194,25 @@ func getTransactionAndHeight(cd *dao.Cached, item stackitem.Item) (*transaction.
 	return cd.GetTransaction(hash)
 }
 
-// BlockToStackItem converts block.Block to stackitem.Item.
-func BlockToStackItem(b *block.Block) stackitem.Item {
-	return stackitem.NewArray([]stackitem.Item{
+// IsBlockItemExtended checks whether block stack items should contain
+// PrimaryIndex and PrevStateRoot fields at the height being processed.
+func IsBlockItemExtended(ic *interop.Context) bool {
+	cfg := ic.Chain.GetConfig()
+	if !cfg.ExtendedBlockItems {
+		return false
+	}
+	height := ic.Chain.BlockHeight() + 1
+	if ic.Block != nil {
+		height = ic.Block.Index
+	}
+	return height >= cfg.ExtendedBlockItemsHeight
+}
+
+// BlockToStackItem converts block.Block to stackitem.Item. If extended is set,
+// PrimaryIndex and PrevStateRoot (Null for blocks without state root in header)
+// are added to the resulting item.
+func BlockToStackItem(b *block.Block, extended bool) stackitem.Item {
+	items := []stackitem.Item{
 		stackitem.NewByteArray(b.Hash().BytesBE()),
 		stackitem.NewBigInteger(big.NewInt(int64(b.Version))),
 		stackitem.NewByteArray(b.PrevHash.BytesBE()),
@@ -203,7 +221,17 @@ func BlockToStackItem(b *block.Block) stackitem.Item {
 		stackitem.NewBigInteger(big.NewInt(int64(b.Index))),
 		stackitem.NewByteArray(b.NextConsensus.BytesBE()),
 		stackitem.NewBigInteger(big.NewInt(int64(len(b.Transactions)))),
-	})
+	}
+	if extended {
+		var prevStateRoot stackitem.Item = stackitem.Null{}
+		if b.StateRootEnabled {
+			prevStateRoot = stackitem.NewByteArray(b.PrevStateRoot.BytesBE())
+		}
+		items = append(items,
+			stackitem.NewBigInteger(big.NewInt(int64(b.PrimaryIndex))),
+			prevStateRoot)
+	}
+	return stackitem.NewArray(items)
 }
 
 // TransactionToStackItem converts transaction.Transaction to stackitem.Item.
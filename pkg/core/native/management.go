@@ -72,6 +72,18 @@ func newManagement() *Management {
 	md := newMethodAndPrice(m.getContract, 1<<15, callflag.ReadStates)
 	m.AddMethod(md, desc)
 
+	desc = newDescriptor("getContractById", smartcontract.ArrayType,
+		manifest.NewParameter("id", smartcontract.IntegerType))
+	md = newMethodAndPrice(m.getContractByID, 1<<15, callflag.ReadStates)
+	m.AddMethod(md, desc)
+
+	desc = newDescriptor("hasMethod", smartcontract.BoolType,
+		manifest.NewParameter("hash", smartcontract.Hash160Type),
+		manifest.NewParameter("method", smartcontract.StringType),
+		manifest.NewParameter("pcount", smartcontract.IntegerType))
+	md = newMethodAndPrice(m.hasMethod, 1<<15, callflag.ReadStates)
+	m.AddMethod(md, desc)
+
 	desc = newDescriptor("deploy", smartcontract.ArrayType,
 		manifest.NewParameter("nefFile", smartcontract.ByteArrayType),
 		manifest.NewParameter("manifest", smartcontract.ByteArrayType))
@@ -152,6 +164,49 @@ func (m *Management) GetContract(d dao.DAO, hash util.Uint160) (*state.Contract,
 	return m.getContractFromDAO(d, hash)
 }
 
+// getContractByID is an implementation of public getContractById method, it's
+// run under VM protections, so it's OK for it to panic instead of returning
+// errors.
+func (m *Management) getContractByID(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	ctr, err := m.GetContractByID(ic.DAO, toInt32(args[0]))
+	if err != nil {
+		if err == storage.ErrKeyNotFound {
+			return stackitem.Null{}
+		}
+		panic(err)
+	}
+	return contractToStack(ctr)
+}
+
+// GetContractByID returns contract with given ID from given DAO.
+func (m *Management) GetContractByID(d dao.DAO, id int32) (*state.Contract, error) {
+	hash, err := d.GetContractScriptHash(id)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetContract(d, hash)
+}
+
+// hasMethod is an implementation of public hasMethod method, it returns true
+// if the contract with given hash has the method with given name and number of
+// parameters (-1 matches any number).
+func (m *Management) hasMethod(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	hash := toUint160(args[0])
+	method := toString(args[1])
+	pcount := toInt32(args[2])
+	if pcount < -1 {
+		panic("invalid parameters count")
+	}
+	ctr, err := m.GetContract(ic.DAO, hash)
+	if err != nil {
+		if err == storage.ErrKeyNotFound {
+			return stackitem.NewBool(false)
+		}
+		panic(err)
+	}
+	return stackitem.NewBool(ctr.Manifest.ABI.GetMethod(method, int(pcount)) != nil)
+}
+
 func (m *Management) getContractFromDAO(d dao.DAO, hash util.Uint160) (*state.Contract, error) {
 	contract := new(state.Contract)
 	key := makeContractKey(hash)
@@ -343,7 +398,9 @@ func (m *Management) Update(d dao.DAO, hash util.Uint160, neff *nef.File, manif
 		if err != nil {
 			return nil, fmt.Errorf("invalid manifest: %w", err)
 		}
-		m.markUpdated(hash)
+		if !manifestsEqual(&contract.Manifest, manif) {
+			m.markUpdated(hash)
+		}
 		contract.Manifest = *manif
 	}
 	err = checkScriptAndMethods(contract.NEF.Script, contract.Manifest.ABI.Methods)
@@ -358,6 +415,17 @@ func (m *Management) Update(d dao.DAO, hash util.Uint160, neff *nef.File, manif
 	return contract, nil
 }
 
+// manifestsEqual checks whether two manifests are semantically identical by
+// comparing their canonical hashes.
+func manifestsEqual(a, b *manifest.Manifest) bool {
+	ha, err := a.Hash()
+	if err != nil {
+		return false
+	}
+	hb, err := b.Hash()
+	return err == nil && ha == hb
+}
+
 // destroy is an implementation of destroy update method, it's run under
 // VM protections, so it's OK for it to panic instead of returning errors.
 func (m *Management) destroy(ic *interop.Context, sis []stackitem.Item) stackitem.Item {
@@ -385,12 +453,16 @@ func (m *Management) Destroy(d dao.DAO, hash util.Uint160) error {
 	if err != nil {
 		return err
 	}
-	siMap, err := d.GetStorageItems(contract.ID)
-	if err != nil {
-		return err
-	}
-	for k := range siMap {
-		err := d.DeleteStorageItem(contract.ID, []byte(k))
+	// Items can't be deleted while seeking, so only keys are collected.
+	var toDelete [][]byte
+	d.Seek(contract.ID, nil, func(k, _ []byte) {
+		// Must copy here, #1468.
+		key := make([]byte, len(k))
+		copy(key, k)
+		toDelete = append(toDelete, key)
+	})
+	for _, k := range toDelete {
+		err := d.DeleteStorageItem(contract.ID, k)
 		if err != nil {
 			return err
 		}
@@ -482,6 +554,7 @@ func (m *Management) InitializeCache(d dao.DAO) error {
 	defer m.mtx.Unlock()
 
 	var initErr error
+	m.contracts = make(map[util.Uint160]*state.Contract)
 	d.Seek(m.ID, []byte{prefixContract}, func(_, v []byte) {
 		var cs state.Contract
 		r := io.NewBinReaderFromBuf(v)
@@ -17,7 +17,7 @@ import (
 
 func TestDeployGetUpdateDestroyContract(t *testing.T) {
 	mgmt := newManagement()
-	d := dao.NewCached(dao.NewSimple(storage.NewMemoryStore(), false))
+	d := dao.NewCached(dao.NewSimple(storage.NewMemoryStore(), false, false))
 	err := mgmt.Initialize(&interop.Context{DAO: d})
 	require.NoError(t, err)
 	script := []byte{byte(opcode.RET)}
@@ -40,6 +40,9 @@ func TestDeployGetUpdateDestroyContract(t *testing.T) {
 	require.Equal(t, h, contract.Hash)
 	require.Equal(t, ne, &contract.NEF)
 	require.Equal(t, *manif, contract.Manifest)
+	idHash, err := d.GetContractScriptHash(contract.ID)
+	require.NoError(t, err)
+	require.Equal(t, h, idHash)
 
 	// Double deploy.
 	_, err = mgmt.Deploy(d, sender, ne, manif)
@@ -63,21 +66,39 @@ func TestDeployGetUpdateDestroyContract(t *testing.T) {
 	refContract.UpdateCounter++
 	require.NoError(t, err)
 	require.Equal(t, refContract, upContract)
+	idHash, err = d.GetContractScriptHash(contract.ID)
+	require.NoError(t, err)
+	require.Equal(t, h, idHash)
+
+	// Semantically identical manifest is stored as is.
+	manif.Extra = []byte(`{"a":1,"b":2}`)
+	_, err = mgmt.Update(d, h, nil, manif)
+	require.NoError(t, err)
+	sameManif := *manif
+	sameManif.Extra = []byte(`{"b": 2, "a": 1}`)
+	upContract, err = mgmt.Update(d, h, nil, &sameManif)
+	require.NoError(t, err)
+	require.Equal(t, sameManif.Extra, upContract.Manifest.Extra)
+	refContract, err = mgmt.GetContract(d, h)
+	require.NoError(t, err)
+	require.Equal(t, sameManif.Extra, refContract.Manifest.Extra)
 
 	err = mgmt.Destroy(d, h)
 	require.NoError(t, err)
 	_, err = mgmt.GetContract(d, h)
 	require.Error(t, err)
+	_, err = d.GetContractScriptHash(contract.ID)
+	require.Error(t, err)
 }
 
 func TestManagement_Initialize(t *testing.T) {
 	t.Run("good", func(t *testing.T) {
-		d := dao.NewSimple(storage.NewMemoryStore(), false)
+		d := dao.NewSimple(storage.NewMemoryStore(), false, false)
 		mgmt := newManagement()
 		require.NoError(t, mgmt.InitializeCache(d))
 	})
 	t.Run("invalid contract state", func(t *testing.T) {
-		d := dao.NewSimple(storage.NewMemoryStore(), false)
+		d := dao.NewSimple(storage.NewMemoryStore(), false, false)
 		mgmt := newManagement()
 		require.NoError(t, d.PutStorageItem(mgmt.ID, []byte{prefixContract}, state.StorageItem{0xFF}))
 		require.Error(t, mgmt.InitializeCache(d))
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -25,6 +27,10 @@ type GAS struct {
 
 const gasContractID = -6
 
+// prefixStorageRefund is the prefix of pending storage fee refunds of the
+// current block.
+const prefixStorageRefund = 40
+
 // GASFactor is a divisor for finding GAS integral value.
 const GASFactor = NEOTotalSupply
 const initialGAS = 30000000
@@ -132,11 +138,49 @@ func (g *GAS) OnPersist(ic *interop.Context) error {
 	return nil
 }
 
+// AddStorageRefund records given amount of GAS to be returned to the account
+// as a refund for released storage. Refunds are minted when the block is
+// post-persisted.
+func (g *GAS) AddStorageRefund(d dao.DAO, acc util.Uint160, amount *big.Int) error {
+	if amount.Sign() == 0 {
+		return nil
+	}
+	key := makeStorageRefundKey(acc)
+	var refund = new(big.Int)
+	if si := d.GetStorageItem(g.ID, key); si != nil {
+		refund = bigint.FromBytes(si)
+	}
+	refund.Add(refund, amount)
+	return d.PutStorageItem(g.ID, key, bigint.ToBytes(refund))
+}
+
 // PostPersist implements Contract interface.
 func (g *GAS) PostPersist(ic *interop.Context) error {
+	var (
+		accs    []util.Uint160
+		amounts []*big.Int
+	)
+	ic.DAO.Seek(g.ID, []byte{prefixStorageRefund}, func(k, v []byte) {
+		acc, err := util.Uint160DecodeBytesBE(k)
+		if err != nil {
+			return
+		}
+		accs = append(accs, acc)
+		amounts = append(amounts, bigint.FromBytes(v))
+	})
+	for i := range accs {
+		if err := ic.DAO.DeleteStorageItem(g.ID, makeStorageRefundKey(accs[i])); err != nil {
+			return err
+		}
+		g.mint(ic, accs[i], amounts[i], false)
+	}
 	return nil
 }
 
+func makeStorageRefundKey(acc util.Uint160) []byte {
+	return makeUint160Key(prefixStorageRefund, acc)
+}
+
 func getStandbyValidatorsHash(ic *interop.Context) (util.Uint160, error) {
 	s, err := smartcontract.CreateDefaultMultiSigRedeemScript(ic.Chain.GetStandByValidators())
 	if err != nil {
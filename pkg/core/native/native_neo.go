@@ -76,9 +76,9 @@ const (
 	effectiveVoterTurnout = 5
 	// neoHolderRewardRatio is a percent of generated GAS that is distributed to NEO holders.
 	neoHolderRewardRatio = 10
-	// neoHolderRewardRatio is a percent of generated GAS that is distributed to committee.
+	// committeeRewardRatio is a percent of generated GAS that is distributed to committee.
 	committeeRewardRatio = 10
-	// neoHolderRewardRatio is a percent of generated GAS that is distributed to voters.
+	// voterRewardRatio is a percent of generated GAS that is distributed to voters.
 	voterRewardRatio = 80
 )
 
@@ -304,27 +304,17 @@ func (n *NEO) PostPersist(ic *interop.Context) error {
 	pubs := n.GetCommitteeMembers()
 	committeeSize := len(ic.Chain.GetConfig().StandbyCommittee)
 	index := int(ic.Block.Index) % committeeSize
-	committeeReward := new(big.Int).Mul(gas, big.NewInt(committeeRewardRatio))
-	n.GAS.mint(ic, pubs[index].GetScriptHash(), committeeReward.Div(committeeReward, big.NewInt(100)), false)
+	n.GAS.mint(ic, pubs[index].GetScriptHash(), committeeReward(gas), false)
 
 	if ShouldUpdateCommittee(ic.Block.Index, ic.Chain) {
-		var voterReward = big.NewInt(voterRewardRatio)
-		voterReward.Mul(voterReward, gas)
-		voterReward.Mul(voterReward, big.NewInt(voterRewardFactor*int64(committeeSize)))
 		var validatorsCount = ic.Chain.GetConfig().ValidatorsCount
-		voterReward.Div(voterReward, big.NewInt(int64(committeeSize+validatorsCount)))
-		voterReward.Div(voterReward, big.NewInt(100))
+		var voterReward = votersReward(gas, committeeSize, validatorsCount)
 
 		var cs = n.committee.Load().(keysWithVotes)
 		var key = make([]byte, 38)
 		for i := range cs {
 			if cs[i].Votes.Sign() > 0 {
-				tmp := big.NewInt(1)
-				if i < validatorsCount {
-					tmp = big.NewInt(2)
-				}
-				tmp.Mul(tmp, voterReward)
-				tmp.Div(tmp, cs[i].Votes)
+				tmp := voterRewardPerVote(voterReward, cs[i].Votes, i < validatorsCount)
 
 				key = makeVoterKey([]byte(cs[i].Key), key)
 				var reward = n.getGASPerVote(ic.DAO, key[:34], ic.Block.Index+1)
@@ -355,6 +345,36 @@ func (n *NEO) PostPersist(ic *interop.Context) error {
 	return nil
 }
 
+// committeeReward returns the amount of GAS minted to the committee member
+// for a single block given the amount of GAS generated per block.
+func committeeReward(gas *big.Int) *big.Int {
+	r := new(big.Int).Mul(gas, big.NewInt(committeeRewardRatio))
+	return r.Div(r, big.NewInt(100))
+}
+
+// votersReward returns a single share of voters' GAS reward (in
+// voterRewardFactor units) accumulated over committeeSize blocks. Reward is
+// split between committee members, every validator gets two shares and every
+// other member gets one.
+func votersReward(gas *big.Int, committeeSize, validatorsCount int) *big.Int {
+	r := new(big.Int).Mul(gas, big.NewInt(voterRewardRatio))
+	r.Mul(r, big.NewInt(voterRewardFactor*int64(committeeSize)))
+	r.Div(r, big.NewInt(int64(committeeSize+validatorsCount)))
+	return r.Div(r, big.NewInt(100))
+}
+
+// voterRewardPerVote returns GAS reward (in voterRewardFactor units) for a
+// single vote given to the committee member with the specified number of
+// votes. Every division truncates the result, so the sum of rewards never
+// exceeds the amount of GAS generated.
+func voterRewardPerVote(reward, votes *big.Int, isValidator bool) *big.Int {
+	r := new(big.Int).Set(reward)
+	if isValidator {
+		r.Mul(r, big.NewInt(2))
+	}
+	return r.Div(r, votes)
+}
+
 func (n *NEO) getGASPerVote(d dao.DAO, key []byte, index ...uint32) []big.Int {
 	var max = make([]uint32, len(index))
 	var reward = make([]big.Int, len(index))
@@ -844,6 +864,32 @@ func (n *NEO) GetCandidates(d dao.DAO) ([]state.Validator, error) {
 			return nil, err
 		}
 		arr[i].Votes = kvs[i].Votes
+		arr[i].Registered = true
+	}
+	return arr, nil
+}
+
+// GetAllCandidates returns all candidates known to the contract sorted by key
+// including the ones that were unregistered, but still have some votes.
+func (n *NEO) GetAllCandidates(d dao.DAO) ([]state.Validator, error) {
+	siMap, err := d.GetStorageItemsWithPrefix(n.ID, []byte{prefixCandidate})
+	if err != nil {
+		return nil, err
+	}
+	ks := make([]string, 0, len(siMap))
+	for key := range siMap {
+		ks = append(ks, key)
+	}
+	sort.Slice(ks, func(i, j int) bool { return strings.Compare(ks[i][1:], ks[j][1:]) == -1 })
+	arr := make([]state.Validator, len(ks))
+	for i, key := range ks {
+		c := new(candidate).FromBytes(siMap[key])
+		arr[i].Key, err = keys.NewPublicKeyFromBytes([]byte(key), elliptic.P256())
+		if err != nil {
+			return nil, err
+		}
+		arr[i].Votes = &c.Votes
+		arr[i].Registered = c.Registered
 	}
 	return arr, nil
 }
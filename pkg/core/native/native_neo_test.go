@@ -16,3 +16,23 @@ func TestCandidate_Bytes(t *testing.T) {
 	actual := new(candidate).FromBytes(data)
 	require.Equal(t, expected, actual)
 }
+
+func TestNEO_RewardRounding(t *testing.T) {
+	t.Run("committee", func(t *testing.T) {
+		require.EqualValues(t, 50000000, committeeReward(big.NewInt(5*GASFactor)).Int64())
+		require.EqualValues(t, 1, committeeReward(big.NewInt(19)).Int64())
+		require.EqualValues(t, 0, committeeReward(big.NewInt(9)).Int64())
+	})
+	t.Run("voters", func(t *testing.T) {
+		require.EqualValues(t, 30000000000000000, votersReward(big.NewInt(5*GASFactor), 21, 7).Int64())
+		// 1 * 80% * 7 * 10^8 / 11 is truncated at every step.
+		require.EqualValues(t, 50909090, votersReward(big.NewInt(1), 7, 4).Int64())
+	})
+	t.Run("per vote", func(t *testing.T) {
+		reward := big.NewInt(10)
+		require.EqualValues(t, 3, voterRewardPerVote(reward, big.NewInt(3), false).Int64())
+		require.EqualValues(t, 6, voterRewardPerVote(reward, big.NewInt(3), true).Int64())
+		require.EqualValues(t, 0, voterRewardPerVote(reward, big.NewInt(21), false).Int64())
+		require.EqualValues(t, 10, reward.Int64())
+	})
+}
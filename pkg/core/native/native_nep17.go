@@ -318,6 +318,18 @@ func toUint160(s stackitem.Item) util.Uint160 {
 	return u
 }
 
+func toInt32(s stackitem.Item) int32 {
+	bigInt := toBigInt(s)
+	if !bigInt.IsInt64() {
+		panic("bigint is not an int64")
+	}
+	int64Value := bigInt.Int64()
+	if int64Value < math.MinInt32 || int64Value > math.MaxInt32 {
+		panic("bigint does not fit into int32")
+	}
+	return int32(int64Value)
+}
+
 func toUint32(s stackitem.Item) uint32 {
 	bigInt := toBigInt(s)
 	if !bigInt.IsInt64() {
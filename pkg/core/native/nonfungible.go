@@ -1,10 +1,8 @@
 package native
 
 import (
-	"bytes"
 	"errors"
 	"math/big"
-	"sort"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
@@ -217,19 +215,8 @@ func (n *nonfungible) BalanceOf(ic *interop.Context, args []stackitem.Item) stac
 
 func (n *nonfungible) tokens(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	prefix := []byte{prefixNFTToken}
-	siMap, err := ic.DAO.GetStorageItemsWithPrefix(n.ID, prefix)
-	if err != nil {
-		panic(err)
-	}
-	filteredMap := stackitem.NewMap()
-	for k, v := range siMap {
-		filteredMap.Add(stackitem.NewByteArray(append(prefix, []byte(k)...)), stackitem.NewByteArray(v))
-	}
-	sort.Slice(filteredMap.Value().([]stackitem.MapElement), func(i, j int) bool {
-		return bytes.Compare(filteredMap.Value().([]stackitem.MapElement)[i].Key.Value().([]byte),
-			filteredMap.Value().([]stackitem.MapElement)[j].Key.Value().([]byte)) == -1
-	})
-	iter := istorage.NewIterator(filteredMap, 1, istorage.FindValuesOnly|istorage.FindDeserialize|istorage.FindPick1)
+	items := istorage.SeekItems(ic.DAO, n.ID, prefix)
+	iter := istorage.NewIterator(items, 1, istorage.FindValuesOnly|istorage.FindDeserialize|istorage.FindPick1)
 	return stackitem.NewInterop(iter)
 }
 
@@ -3,7 +3,6 @@ package native
 import (
 	"fmt"
 	"math/big"
-	"sort"
 	"sync"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
@@ -61,7 +60,7 @@ type Policy struct {
 	feePerByte         int64
 	maxVerificationGas int64
 	storagePrice       uint32
-	blockedAccounts    []util.Uint160
+	blockedAccounts    util.Uint160Slice
 }
 
 var _ interop.Contract = (*Policy)(nil)
@@ -173,9 +172,7 @@ func (p *Policy) PostPersist(ic *interop.Context) error {
 		}
 		p.blockedAccounts = append(p.blockedAccounts, hash)
 	}
-	sort.Slice(p.blockedAccounts, func(i, j int) bool {
-		return p.blockedAccounts[i].Less(p.blockedAccounts[j])
-	})
+	p.blockedAccounts.Sort()
 
 	p.isValid = true
 	return nil
@@ -248,14 +245,7 @@ func (p *Policy) IsBlockedInternal(dao dao.DAO, hash util.Uint160) bool {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 	if p.isValid {
-		length := len(p.blockedAccounts)
-		i := sort.Search(length, func(i int) bool {
-			return !p.blockedAccounts[i].Less(hash)
-		})
-		if length != 0 && i != length && p.blockedAccounts[i].Equals(hash) {
-			return true
-		}
-		return false
+		return p.blockedAccounts.Contains(hash)
 	}
 	key := append([]byte{blockedAccountPrefix}, hash.BytesBE()...)
 	return dao.GetStorageItem(p.ID, key) != nil
@@ -160,13 +160,18 @@ func (s *Std) serialize(_ *interop.Context, args []stackitem.Item) stackitem.Ite
 	return stackitem.NewByteArray(data)
 }
 
-func (s *Std) deserialize(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+func (s *Std) deserialize(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	data, err := args[0].TryBytes()
 	if err != nil {
 		panic(err)
 	}
 
-	item, err := stackitem.DeserializeItem(data)
+	var item stackitem.Item
+	if ic.StrictBuffers() {
+		item, err = stackitem.DeserializeItemStrict(data)
+	} else {
+		item, err = stackitem.DeserializeItem(data)
+	}
 	if err != nil {
 		panic(err)
 	}
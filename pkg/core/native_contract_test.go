@@ -226,7 +226,7 @@ func TestNativeContract_InvokeInternal(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	d := dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader)
+	d := dao.NewSimple(storage.NewMemoryStore(), chain.config.StateRootInHeader, false)
 	ic := chain.newInteropContext(trigger.Application, d, nil, nil)
 
 	sumOffset := 0
@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
@@ -68,3 +71,61 @@ func TestGAS_Refuel(t *testing.T) {
 		require.Equal(t, vm.FaultState, aer.VMState)
 	})
 }
+
+func TestGAS_TransferOnPayment(t *testing.T) {
+	bc := newTestChain(t)
+
+	cs, _ := getTestContractState(bc)
+	require.NoError(t, bc.contracts.Management.PutContractState(bc.dao, cs))
+
+	const amount = 1_0000_0000
+
+	t.Run("good", func(t *testing.T) {
+		tx := transferTokenFromMultisigAccount(t, bc, cs.Hash, bc.contracts.GAS.Hash, amount)
+		aer, err := bc.GetAppExecResults(tx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		require.Equal(t, vm.HaltState, aer[0].VMState)
+		require.Len(t, aer[0].Events, 2) // transfer + onPayment
+
+		e := aer[0].Events[1]
+		require.Equal(t, "LastPayment", e.Name)
+		arr := e.Item.Value().([]stackitem.Item)
+		require.Equal(t, bc.contracts.GAS.Hash.BytesBE(), arr[0].Value())
+		require.Equal(t, testchain.MultisigScriptHash().BytesBE(), arr[1].Value())
+		require.Equal(t, big.NewInt(amount), arr[2].Value())
+		require.Equal(t, big.NewInt(amount), bc.GetUtilityTokenBalance(cs.Hash))
+	})
+	t.Run("no callback", func(t *testing.T) {
+		// Policy contract has no onNEP17Payment method, so the transfer
+		// must be aborted.
+		policyHash := bc.contracts.Policy.Hash
+		tx := transferTokenFromMultisigAccount(t, bc, policyHash, bc.contracts.GAS.Hash, amount)
+		aer, err := bc.GetAppExecResults(tx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		checkFAULTState(t, &aer[0])
+		require.Equal(t, 0, bc.GetUtilityTokenBalance(policyHash).Sign())
+	})
+}
+
+func TestGAS_StorageRefund(t *testing.T) {
+	bc := newTestChain(t)
+	gas := bc.contracts.GAS
+	acc := random.Uint160()
+
+	ic := bc.newInteropContext(trigger.PostPersist, bc.dao, nil, nil)
+	require.NoError(t, gas.AddStorageRefund(ic.DAO, acc, big.NewInt(1)))
+	require.NoError(t, gas.AddStorageRefund(ic.DAO, acc, big.NewInt(2)))
+	require.Equal(t, 0, len(ic.Notifications))
+
+	require.NoError(t, gas.PostPersist(ic))
+	require.Equal(t, 1, len(ic.Notifications))
+	require.Equal(t, "Transfer", ic.Notifications[0].Name)
+	arr := ic.Notifications[0].Item.Value().([]stackitem.Item)
+	require.Equal(t, stackitem.Null{}, arr[0])
+	require.Equal(t, acc.BytesBE(), arr[1].Value())
+	require.Equal(t, big.NewInt(3), arr[2].Value())
+
+	// Refunds are paid only once.
+	require.NoError(t, gas.PostPersist(ic))
+	require.Equal(t, 1, len(ic.Notifications))
+}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -121,6 +122,7 @@ func TestLedgerGetTransactionFromBlock(t *testing.T) {
 	t.Run("isn't traceable", func(t *testing.T) {
 		b.Index = chain.BlockHeight() + 1
 		require.NoError(t, chain.dao.StoreAsBlock(b, nil))
+		chain.cache.reset() // Block is changed in the DB directly.
 		res, err := invokeContractMethod(chain, 100000000, ledger, "getTransactionFromBlock", bhash.BytesBE(), int64(0))
 		require.NoError(t, err)
 		checkResult(t, res, stackitem.Null{})
@@ -171,3 +173,35 @@ func TestLedgerGetBlock(t *testing.T) {
 		checkResult(t, res, stackitem.Null{})
 	})
 }
+
+func TestLedgerGetBlockExtended(t *testing.T) {
+	chain := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.ExtendedBlockItems = true
+		c.ProtocolConfiguration.ExtendedBlockItemsHeight = 3
+	})
+	ledger := chain.contracts.ByName(nativenames.Ledger).Metadata().Hash
+
+	_, err := invokeContractMethod(chain, 100000000, ledger, "currentHash") // adds a block
+	require.NoError(t, err)
+	b, err := chain.GetBlock(chain.GetHeaderHash(1))
+	require.NoError(t, err)
+
+	getBlock := func(t *testing.T) []stackitem.Item {
+		res, err := invokeContractMethod(chain, 100000000, ledger, "getBlock", b.Hash().BytesBE())
+		require.NoError(t, err)
+		require.Equal(t, vm.HaltState, res.VMState, res.FaultException)
+		require.Equal(t, 1, len(res.Stack))
+		actual, ok := res.Stack[0].Value().([]stackitem.Item)
+		require.True(t, ok)
+		return actual
+	}
+	t.Run("before height", func(t *testing.T) {
+		require.Equal(t, 8, len(getBlock(t))) // executed in block 2
+	})
+	t.Run("after height", func(t *testing.T) {
+		actual := getBlock(t) // executed in block 3
+		require.Equal(t, 10, len(actual))
+		require.Equal(t, int64(b.PrimaryIndex), actual[8].Value().(*big.Int).Int64())
+		require.Equal(t, stackitem.Null{}, actual[9])
+	})
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"encoding/json"
+	"math"
 	"math/big"
 	"testing"
 
@@ -545,6 +546,79 @@ func TestGetContract(t *testing.T) {
 	})
 }
 
+func TestGetContractByID(t *testing.T) {
+	bc := newTestChain(t)
+
+	mgmtHash := bc.ManagementContractHash()
+	cs1, _ := getTestContractState(bc)
+	err := bc.contracts.Management.PutContractState(bc.dao, cs1)
+	require.NoError(t, err)
+
+	t.Run("bad parameter type", func(t *testing.T) {
+		res, err := invokeContractMethod(bc, 1_00000000, mgmtHash, "getContractById", []interface{}{int64(1)})
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+	})
+	t.Run("out of range", func(t *testing.T) {
+		res, err := invokeContractMethod(bc, 1_00000000, mgmtHash, "getContractById", int64(math.MaxInt32)+1)
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+	})
+	t.Run("missing", func(t *testing.T) {
+		res, err := invokeContractMethod(bc, 1_00000000, mgmtHash, "getContractById", int64(cs1.ID)+1)
+		require.NoError(t, err)
+		require.Equal(t, vm.HaltState, res.VMState)
+		require.Equal(t, 1, len(res.Stack))
+		require.Equal(t, stackitem.Null{}, res.Stack[0])
+	})
+	t.Run("positive", func(t *testing.T) {
+		res, err := invokeContractMethod(bc, 1_00000000, mgmtHash, "getContractById", int64(cs1.ID))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res.Stack))
+		compareContractStates(t, cs1, res.Stack[0])
+	})
+	t.Run("native", func(t *testing.T) {
+		cs, err := bc.contracts.Management.GetContractByID(bc.dao, bc.contracts.Management.ID)
+		require.NoError(t, err)
+		require.Equal(t, mgmtHash, cs.Hash)
+	})
+}
+
+func TestHasMethod(t *testing.T) {
+	bc := newTestChain(t)
+
+	mgmtHash := bc.ManagementContractHash()
+	cs1, _ := getTestContractState(bc)
+	err := bc.contracts.Management.PutContractState(bc.dao, cs1)
+	require.NoError(t, err)
+
+	check := func(t *testing.T, expected bool, h util.Uint160, method string, pcount int64) {
+		res, err := invokeContractMethod(bc, 1_00000000, mgmtHash, "hasMethod", h.BytesBE(), method, pcount)
+		require.NoError(t, err)
+		require.Equal(t, vm.HaltState, res.VMState)
+		require.Equal(t, 1, len(res.Stack))
+		require.Equal(t, stackitem.NewBool(expected), res.Stack[0])
+	}
+	t.Run("positive", func(t *testing.T) {
+		check(t, true, cs1.Hash, "add", 2)
+		check(t, true, cs1.Hash, "add", -1)
+	})
+	t.Run("wrong parameters count", func(t *testing.T) {
+		check(t, false, cs1.Hash, "add", 1)
+	})
+	t.Run("unknown method", func(t *testing.T) {
+		check(t, false, cs1.Hash, "sub", -1)
+	})
+	t.Run("unknown contract", func(t *testing.T) {
+		check(t, false, util.Uint160{1, 2, 3}, "add", 2)
+	})
+	t.Run("invalid parameters count", func(t *testing.T) {
+		res, err := invokeContractMethod(bc, 1_00000000, mgmtHash, "hasMethod", cs1.Hash.BytesBE(), "add", int64(-2))
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+	})
+}
+
 func TestContractDestroy(t *testing.T) {
 	bc := newTestChain(t)
 
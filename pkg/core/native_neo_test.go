@@ -39,6 +39,9 @@ func checkTxHalt(t *testing.T, bc *Blockchain, h util.Uint256) {
 
 func TestNEO_Vote(t *testing.T) {
 	bc := newTestChain(t)
+	// Test blocks are always signed by standby validators with the same
+	// NextConsensus, so they're invalid after voting for new validators.
+	bc.config.VerifyBlocks = false
 
 	neo := bc.contracts.NEO
 	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -30,6 +32,65 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	// blockPrimary prometheus metric.
+	blockPrimary = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Primary node index of the last processed block",
+			Name:      "current_block_primary",
+			Namespace: "neogo",
+		},
+	)
+	// blockViewNumber prometheus metric.
+	blockViewNumber = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Consensus view number of the last processed block",
+			Name:      "current_block_view_number",
+			Namespace: "neogo",
+		},
+	)
+	// blockTimeDrift prometheus metric.
+	blockTimeDrift = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Difference between the actual and expected time between the last two blocks in milliseconds",
+			Name:      "current_block_time_drift",
+			Namespace: "neogo",
+		},
+	)
+	// primaryBlocks prometheus metric.
+	primaryBlocks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of processed blocks per primary node index",
+			Name:      "primary_blocks",
+			Namespace: "neogo",
+		},
+		[]string{"primary"},
+	)
+	// viewChanges prometheus metric.
+	viewChanges = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of view changes happened for processed blocks",
+			Name:      "view_changes",
+			Namespace: "neogo",
+		},
+	)
+	// cacheHits prometheus metric.
+	cacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of block and transaction cache hits",
+			Name:      "chain_cache_hits",
+			Namespace: "neogo",
+		},
+		[]string{"cache"},
+	)
+	// cacheMisses prometheus metric.
+	cacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of block and transaction cache misses",
+			Name:      "chain_cache_misses",
+			Namespace: "neogo",
+		},
+		[]string{"cache"},
+	)
 )
 
 func init() {
@@ -37,6 +98,13 @@ func init() {
 		blockHeight,
 		persistedHeight,
 		headerHeight,
+		blockPrimary,
+		blockViewNumber,
+		blockTimeDrift,
+		primaryBlocks,
+		viewChanges,
+		cacheHits,
+		cacheMisses,
 	)
 }
 
@@ -51,3 +119,19 @@ func updateHeaderHeightMetric(hHeight int) {
 func updateBlockHeightMetric(bHeight uint32) {
 	blockHeight.Set(float64(bHeight))
 }
+
+func updateConsensusMetrics(primary byte, view byte, drift int64) {
+	blockPrimary.Set(float64(primary))
+	blockViewNumber.Set(float64(view))
+	blockTimeDrift.Set(float64(drift))
+	primaryBlocks.WithLabelValues(strconv.Itoa(int(primary))).Inc()
+	viewChanges.Add(float64(view))
+}
+
+func updateCacheHitMetric(cache string) {
+	cacheHits.WithLabelValues(cache).Inc()
+}
+
+func updateCacheMissMetric(cache string) {
+	cacheMisses.WithLabelValues(cache).Inc()
+}
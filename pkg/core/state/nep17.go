@@ -130,6 +130,21 @@ func (lg *NEP17TransferLog) ForEach(f func(*NEP17Transfer) (bool, error)) (bool,
 	return true, nil
 }
 
+// Oldest returns the first (the oldest) transfer of the log or nil if the log
+// is empty.
+func (lg *NEP17TransferLog) Oldest() (*NEP17Transfer, error) {
+	if lg == nil || len(lg.Raw) == 0 {
+		return nil, nil
+	}
+	tr := new(NEP17Transfer)
+	r := io.NewBinReaderFromBuf(lg.Raw[1:])
+	tr.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return tr, nil
+}
+
 // Size returns an amount of transfer written in log.
 func (lg *NEP17TransferLog) Size() int {
 	if len(lg.Raw) == 0 {
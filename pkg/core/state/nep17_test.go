@@ -36,6 +36,14 @@ func TestNEP17TransferLog_Append(t *testing.T) {
 	})
 	require.NoError(t, err)
 	require.True(t, cont)
+
+	oldest, err := lg.Oldest()
+	require.NoError(t, err)
+	require.Equal(t, expected[0], oldest)
+
+	oldest, err = new(NEP17TransferLog).Oldest()
+	require.NoError(t, err)
+	require.Nil(t, oldest)
 }
 
 func TestNEP17Tracker_EncodeBinary(t *testing.T) {
@@ -0,0 +1,73 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// KeyChange is a change of a single DB key made by some block.
+type KeyChange struct {
+	Key []byte
+	// Old is the previous value, nil if the key didn't exist.
+	Old []byte
+	// New is the new value, nil if the key was deleted.
+	New []byte
+}
+
+// StateDiff is a journal of all DB changes made by some block. It contains
+// both old and new values, so it can be used to revert block changes.
+type StateDiff struct {
+	Index   uint32
+	Changes []KeyChange
+}
+
+// Flags of encoded KeyChange.
+const (
+	hasOldValue byte = 1 << iota
+	hasNewValue
+)
+
+// EncodeBinary implements io.Serializable interface.
+func (d *StateDiff) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(d.Index)
+	w.WriteVarUint(uint64(len(d.Changes)))
+	for i := range d.Changes {
+		c := &d.Changes[i]
+		var flags byte
+		if c.Old != nil {
+			flags |= hasOldValue
+		}
+		if c.New != nil {
+			flags |= hasNewValue
+		}
+		w.WriteVarBytes(c.Key)
+		w.WriteB(flags)
+		if c.Old != nil {
+			w.WriteVarBytes(c.Old)
+		}
+		if c.New != nil {
+			w.WriteVarBytes(c.New)
+		}
+	}
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (d *StateDiff) DecodeBinary(r *io.BinReader) {
+	d.Index = r.ReadU32LE()
+	n := r.ReadVarUint()
+	if r.Err != nil {
+		return
+	}
+	d.Changes = make([]KeyChange, 0, n)
+	for i := uint64(0); i < n && r.Err == nil; i++ {
+		var c KeyChange
+		c.Key = r.ReadVarBytes()
+		flags := r.ReadB()
+		if flags&hasOldValue != 0 {
+			c.Old = r.ReadVarBytes()
+		}
+		if flags&hasNewValue != 0 {
+			c.New = r.ReadVarBytes()
+		}
+		d.Changes = append(d.Changes, c)
+	}
+}
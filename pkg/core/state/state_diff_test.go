@@ -0,0 +1,19 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+)
+
+func TestStateDiff_Serializable(t *testing.T) {
+	d := &StateDiff{
+		Index: 42,
+		Changes: []KeyChange{
+			{Key: []byte{1}, Old: []byte{2}, New: []byte{3}},
+			{Key: []byte{4}, New: []byte{}},
+			{Key: []byte{5}, Old: []byte{6}},
+		},
+	}
+	testserdes.EncodeDecodeBinary(t, d, new(StateDiff))
+}
@@ -10,4 +10,7 @@ import (
 type Validator struct {
 	Key   *keys.PublicKey
 	Votes *big.Int
+	// Registered is false for candidates that were unregistered, but still
+	// have some votes.
+	Registered bool
 }
@@ -1,10 +1,20 @@
 package storage
 
+import "sync"
+
 // MemCachedStore is a wrapper around persistent store that caches all changes
-// being made for them to be later flushed in one batch.
+// being made for them to be later flushed in one batch. Changes being flushed
+// are still available for reading, so Persist doesn't block readers.
 type MemCachedStore struct {
 	MemoryStore
 
+	// plock prevents concurrent Persist calls.
+	plock sync.Mutex
+	// Changes being flushed to the persistent store, nil if there is no
+	// Persist in progress. Protected by MemoryStore's mutex, its maps are
+	// never modified.
+	flushing *MemoryStore
+
 	// Persistent Store.
 	ps Store
 }
@@ -44,6 +54,14 @@ func (s *MemCachedStore) Get(key []byte) ([]byte, error) {
 	if _, ok := s.del[k]; ok {
 		return nil, ErrKeyNotFound
 	}
+	if s.flushing != nil {
+		if val, ok := s.flushing.mem[k]; ok {
+			return val, nil
+		}
+		if _, ok := s.flushing.del[k]; ok {
+			return nil, ErrKeyNotFound
+		}
+	}
 	return s.ps.Get(key)
 }
 
@@ -76,13 +94,23 @@ func (s *MemCachedStore) Seek(key []byte, f func(k, v []byte)) {
 	s.mut.RLock()
 	defer s.mut.RUnlock()
 	s.MemoryStore.seek(key, f)
+	if s.flushing != nil {
+		s.flushing.seek(key, func(k, v []byte) {
+			if !s.isCached(string(k)) {
+				f(k, v)
+			}
+		})
+	}
 	s.ps.Seek(key, func(k, v []byte) {
 		elem := string(k)
 		// If it's in mem, we already called f() for it in MemoryStore.Seek().
-		_, present := s.mem[elem]
-		if !present {
-			// If it's in del, we shouldn't be calling f() anyway.
-			_, present = s.del[elem]
+		// If it's in del, we shouldn't be calling f() anyway.
+		present := s.isCached(elem)
+		if !present && s.flushing != nil {
+			_, present = s.flushing.mem[elem]
+			if !present {
+				_, present = s.flushing.del[elem]
+			}
 		}
 		if !present {
 			f(k, v)
@@ -90,20 +118,38 @@ func (s *MemCachedStore) Seek(key []byte, f func(k, v []byte)) {
 	})
 }
 
+// isCached checks whether the key is either changed or deleted in the cache,
+// it's supposed to be called with mutex locked.
+func (s *MemCachedStore) isCached(key string) bool {
+	if _, ok := s.mem[key]; ok {
+		return true
+	}
+	_, ok := s.del[key]
+	return ok
+}
+
 // Persist flushes all the MemoryStore contents into the (supposedly) persistent
-// store ps.
+// store ps. Cached changes are moved aside before flushing, so that the store
+// can be read from and written to while Persist is in progress.
 func (s *MemCachedStore) Persist() (int, error) {
 	var err error
 	var keys, dkeys int
 
-	s.mut.Lock()
-	defer s.mut.Unlock()
+	s.plock.Lock()
+	defer s.plock.Unlock()
 
+	s.mut.Lock()
 	keys = len(s.mem)
 	dkeys = len(s.del)
 	if keys == 0 && dkeys == 0 {
+		s.mut.Unlock()
 		return 0, nil
 	}
+	flushing := &MemoryStore{mem: s.mem, del: s.del}
+	s.flushing = flushing
+	s.mem = make(map[string][]byte)
+	s.del = make(map[string]bool)
+	s.mut.Unlock()
 
 	memStore, ok := s.ps.(*MemoryStore)
 	if !ok {
@@ -114,27 +160,40 @@ func (s *MemCachedStore) Persist() (int, error) {
 	}
 	if memStore != nil {
 		memStore.mut.Lock()
-		for k := range s.mem {
-			memStore.put(k, s.mem[k])
+		for k := range flushing.mem {
+			memStore.put(k, flushing.mem[k])
 		}
-		for k := range s.del {
+		for k := range flushing.del {
 			memStore.drop(k)
 		}
 		memStore.mut.Unlock()
 	} else {
 		batch := s.ps.Batch()
-		for k := range s.mem {
-			batch.Put([]byte(k), s.mem[k])
+		for k := range flushing.mem {
+			batch.Put([]byte(k), flushing.mem[k])
 		}
-		for k := range s.del {
+		for k := range flushing.del {
 			batch.Delete([]byte(k))
 		}
 		err = s.ps.PutBatch(batch)
 	}
-	if err == nil {
-		s.mem = make(map[string][]byte)
-		s.del = make(map[string]bool)
+
+	s.mut.Lock()
+	if err != nil {
+		// Return changes back unless they were overwritten during Persist.
+		for k, v := range flushing.mem {
+			if !s.isCached(k) {
+				s.mem[k] = v
+			}
+		}
+		for k := range flushing.del {
+			if !s.isCached(k) {
+				s.del[k] = true
+			}
+		}
 	}
+	s.flushing = nil
+	s.mut.Unlock()
 	return keys, err
 }
 
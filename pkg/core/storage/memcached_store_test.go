@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -174,6 +175,89 @@ func TestCachedSeek(t *testing.T) {
 	}
 }
 
+// blockingStore is a Store that blocks PutBatch until unblocked.
+type blockingStore struct {
+	MemoryStore
+	started chan struct{}
+	unblock chan struct{}
+	err     error
+}
+
+func (s *blockingStore) PutBatch(b Batch) error {
+	close(s.started)
+	<-s.unblock
+	if s.err != nil {
+		return s.err
+	}
+	return s.MemoryStore.PutBatch(b)
+}
+
+func TestCachedReadDuringPersist(t *testing.T) {
+	check := func(t *testing.T, ts *MemCachedStore, expected map[string]string) {
+		for k, v := range expected {
+			actual, err := ts.Get([]byte(k))
+			if v == "" {
+				require.Equal(t, ErrKeyNotFound, err, k)
+				continue
+			}
+			require.NoError(t, err, k)
+			require.Equal(t, v, string(actual), k)
+		}
+		found := make(map[string]string)
+		ts.Seek([]byte("k"), func(k, v []byte) {
+			_, ok := found[string(k)]
+			require.False(t, ok, "duplicate key %s", k)
+			found[string(k)] = string(v)
+		})
+		for k, v := range expected {
+			if v == "" {
+				delete(expected, k)
+			}
+		}
+		require.Equal(t, expected, found)
+	}
+	for _, persistErr := range []error{nil, errors.New("can't persist")} {
+		ps := &blockingStore{
+			MemoryStore: *NewMemoryStore(),
+			started:     make(chan struct{}),
+			unblock:     make(chan struct{}),
+			err:         persistErr,
+		}
+		require.NoError(t, ps.MemoryStore.Put([]byte("k1"), []byte("old1")))
+		require.NoError(t, ps.MemoryStore.Put([]byte("k2"), []byte("old2")))
+		ts := NewMemCachedStore(ps)
+		require.NoError(t, ts.Put([]byte("k1"), []byte("v1")))
+		require.NoError(t, ts.Delete([]byte("k2")))
+		require.NoError(t, ts.Put([]byte("k3"), []byte("v3")))
+
+		errCh := make(chan error)
+		go func() {
+			_, err := ts.Persist()
+			errCh <- err
+		}()
+		<-ps.started
+		check(t, ts, map[string]string{"k1": "v1", "k2": "", "k3": "v3"})
+		require.NoError(t, ts.Put([]byte("k3"), []byte("new3")))
+		require.NoError(t, ts.Put([]byte("k4"), []byte("v4")))
+		check(t, ts, map[string]string{"k1": "v1", "k2": "", "k3": "new3", "k4": "v4"})
+		close(ps.unblock)
+		require.Equal(t, persistErr, <-errCh)
+		check(t, ts, map[string]string{"k1": "v1", "k2": "", "k3": "new3", "k4": "v4"})
+		if persistErr != nil {
+			checkBatch(t, ts, []KeyValue{
+				{Key: []byte("k1"), Value: []byte("v1"), Exists: true},
+				{Key: []byte("k3"), Value: []byte("new3")},
+				{Key: []byte("k4"), Value: []byte("v4")},
+			}, []KeyValue{{Key: []byte("k2"), Exists: true}})
+		} else {
+			checkBatch(t, ts, []KeyValue{
+				{Key: []byte("k3"), Value: []byte("new3"), Exists: true},
+				{Key: []byte("k4"), Value: []byte("v4")},
+			}, nil)
+		}
+	}
+}
+
 func newMemCachedStoreForTesting(t *testing.T) Store {
 	return NewMemCachedStore(NewMemoryStore())
 }
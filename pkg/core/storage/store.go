@@ -17,9 +17,11 @@ const (
 	STStorage        KeyPrefix = 0x70
 	STNEP17Transfers KeyPrefix = 0x72
 	STNEP17Balances  KeyPrefix = 0x73
+	STNEP17Holders   KeyPrefix = 0x74
 	IXHeaderHashList KeyPrefix = 0x80
 	SYSCurrentBlock  KeyPrefix = 0xc0
 	SYSCurrentHeader KeyPrefix = 0xc1
+	SYSStateDiff     KeyPrefix = 0xc2
 	SYSVersion       KeyPrefix = 0xf0
 )
 
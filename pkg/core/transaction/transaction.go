@@ -30,9 +30,6 @@ const (
 	DummyVersion = 255
 )
 
-// ErrInvalidWitnessNum returns when the number of witnesses does not match signers.
-var ErrInvalidWitnessNum = errors.New("number of signers doesn't match witnesses")
-
 // Transaction is a process recorded in the NEO blockchain.
 type Transaction struct {
 	// The trading version which is currently 0.
@@ -142,9 +139,6 @@ func (t *Transaction) decodeHashableFields(br *io.BinReader) {
 	br.ReadArray(&t.Signers, MaxAttributes)
 	br.ReadArray(&t.Attributes, MaxAttributes-len(t.Signers))
 	t.Script = br.ReadVarBytes(MaxScriptLength)
-	if br.Err == nil {
-		br.Err = t.isValid()
-	}
 }
 
 // DecodeBinary implements Serializable interface.
@@ -153,17 +147,25 @@ func (t *Transaction) DecodeBinary(br *io.BinReader) {
 	if br.Err != nil {
 		return
 	}
+	// Check hashable fields first to not read witnesses
+	// of obviously invalid transaction.
+	br.Err = t.isValidHashable()
+	if br.Err != nil {
+		return
+	}
 	br.ReadArray(&t.Scripts, len(t.Signers))
 	if len(t.Signers) != len(t.Scripts) {
 		br.Err = fmt.Errorf("%w: %d vs %d", ErrInvalidWitnessNum, len(t.Signers), len(t.Scripts))
 		return
 	}
+	if br.Err == nil {
+		br.Err = t.isValidSize()
+	}
 
 	// Create the hash of the transaction at decode, so we dont need
 	// to do it anymore.
 	if br.Err == nil {
 		br.Err = t.createHash()
-		_ = t.Size()
 	}
 }
 
@@ -216,6 +218,9 @@ func (t *Transaction) createHash() error {
 func (t *Transaction) DecodeHashableFields(buf []byte) error {
 	r := io.NewBinReaderFromBuf(buf)
 	t.decodeHashableFields(r)
+	if r.Err == nil {
+		r.Err = t.isValidHashable()
+	}
 	if r.Err != nil {
 		return r.Err
 	}
@@ -350,10 +355,34 @@ var (
 	ErrNonUniqueSigners   = errors.New("transaction signers should be unique")
 	ErrInvalidAttribute   = errors.New("invalid attribute")
 	ErrEmptyScript        = errors.New("no script")
+	ErrTooManySigners     = errors.New("too many signers")
+	ErrTooManyAttributes  = errors.New("too many attributes")
+	ErrTooBig             = errors.New("too big transaction")
+	ErrInvalidWitnessNum  = errors.New("number of signers doesn't match witnesses")
 )
 
-// isValid checks whether decoded/unmarshalled transaction has all fields valid.
+// isValid checks whether decoded/unmarshalled transaction has all fields valid
+// including transaction size limit. The number of witnesses is only checked
+// for transactions received from the network (see DecodeBinary), JSON can
+// contain transactions that are not signed yet.
 func (t *Transaction) isValid() error {
+	if err := t.isValidHashable(); err != nil {
+		return err
+	}
+	return t.isValidSize()
+}
+
+// isValidSize checks that the transaction doesn't exceed MaxTransactionSize.
+func (t *Transaction) isValidSize() error {
+	if size := t.Size(); size > MaxTransactionSize {
+		return fmt.Errorf("%w: %d > MaxTransactionSize %d", ErrTooBig, size, MaxTransactionSize)
+	}
+	return nil
+}
+
+// isValidHashable checks whether hashable fields of the transaction (all
+// except witnesses) are valid.
+func (t *Transaction) isValidHashable() error {
 	if t.Version > 0 && t.Version != DummyVersion {
 		return ErrInvalidVersion
 	}
@@ -369,6 +398,13 @@ func (t *Transaction) isValid() error {
 	if len(t.Signers) == 0 {
 		return ErrEmptySigners
 	}
+	if len(t.Signers) > MaxAttributes {
+		return fmt.Errorf("%w: %d > %d", ErrTooManySigners, len(t.Signers), MaxAttributes)
+	}
+	if len(t.Signers)+len(t.Attributes) > MaxAttributes {
+		return fmt.Errorf("%w: %d signers and %d attributes (max %d)", ErrTooManyAttributes,
+			len(t.Signers), len(t.Attributes), MaxAttributes)
+	}
 	for i := 0; i < len(t.Signers); i++ {
 		for j := i + 1; j < len(t.Signers); j++ {
 			if t.Signers[i].Account.Equals(t.Signers[j].Account) {
@@ -12,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -259,6 +260,34 @@ func TestTransaction_isValid(t *testing.T) {
 		tx.Script = []byte{}
 		require.True(t, errors.Is(tx.isValid(), ErrEmptyScript))
 	})
+	t.Run("TooManySigners", func(t *testing.T) {
+		tx := newTx()
+		tx.Signers = make([]Signer, MaxAttributes+1)
+		for i := range tx.Signers {
+			tx.Signers[i].Account = util.Uint160{byte(i)}
+		}
+		require.True(t, errors.Is(tx.isValid(), ErrTooManySigners))
+	})
+	t.Run("TooManyAttributes", func(t *testing.T) {
+		tx := newTx()
+		tx.Attributes = make([]Attribute, MaxAttributes-len(tx.Signers)+1)
+		for i := range tx.Attributes {
+			tx.Attributes[i].Type = NotValidBeforeT
+			tx.Attributes[i].Value = &NotValidBefore{Height: uint32(i)}
+		}
+		require.True(t, errors.Is(tx.isValid(), ErrTooManyAttributes))
+	})
+	t.Run("NoWitnesses", func(t *testing.T) {
+		// Witnesses are only checked when decoding from binary.
+		tx := newTx()
+		tx.Scripts = nil
+		require.NoError(t, tx.isValid())
+	})
+	t.Run("TooBig", func(t *testing.T) {
+		tx := newTx()
+		tx.Script = make([]byte, MaxTransactionSize)
+		require.True(t, errors.Is(tx.isValid(), ErrTooBig))
+	})
 }
 
 func TestTransaction_GetAttributes(t *testing.T) {
@@ -303,3 +332,7 @@ func TestTransaction_HasSigner(t *testing.T) {
 	require.True(t, tx.HasSigner(u1))
 	require.False(t, tx.HasSigner(util.Uint160{}))
 }
+
+func TestEmitMaxScriptSize(t *testing.T) {
+	require.Equal(t, MaxScriptLength, emit.MaxScriptSize)
+}
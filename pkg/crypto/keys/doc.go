@@ -1,4 +1,12 @@
 /*
 Package keys wraps public/private keys and implements NEP-2 and WIF.
+
+Private keys can be wiped from memory with PrivateKey.Destroy, NEP-2
+decryption wipes its intermediate data and compares address hashes in
+constant time. Locking keys in memory (mlock) and constant-time signing are
+out of scope of this package: keys are stored in math/big integers that can
+be copied by the Go runtime and library code at will and ECDSA signing is
+done via math/big arithmetic which is not constant-time. Use an external
+signer (like HSM) if that is required.
 */
 package keys
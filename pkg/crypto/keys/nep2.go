@@ -2,6 +2,7 @@ package keys
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 
@@ -52,10 +53,14 @@ func NEP2Encrypt(priv *PrivateKey, passphrase string) (s string, err error) {
 	if err != nil {
 		return s, err
 	}
+	defer wipe(derivedKey)
 
 	derivedKey1 := derivedKey[:32]
 	derivedKey2 := derivedKey[32:]
-	xr := xor(priv.Bytes(), derivedKey1)
+	privBytes := priv.Bytes()
+	xr := xor(privBytes, derivedKey1)
+	wipe(privBytes)
+	defer wipe(xr)
 
 	encrypted, err := aesEncrypt(xr, derivedKey2)
 	if err != nil {
@@ -93,6 +98,7 @@ func NEP2Decrypt(key, passphrase string) (*PrivateKey, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer wipe(derivedKey)
 
 	derivedKey1 := derivedKey[:32]
 	derivedKey2 := derivedKey[32:]
@@ -102,8 +108,10 @@ func NEP2Decrypt(key, passphrase string) (*PrivateKey, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer wipe(decrypted)
 
 	privBytes := xor(decrypted, derivedKey1)
+	defer wipe(privBytes)
 
 	// Rebuild the private key.
 	privKey, err := NewPrivateKeyFromBytes(privBytes)
@@ -112,6 +120,7 @@ func NEP2Decrypt(key, passphrase string) (*PrivateKey, error) {
 	}
 
 	if !compareAddressHash(privKey, addrHash) {
+		privKey.Destroy()
 		return nil, errors.New("password mismatch")
 	}
 
@@ -121,7 +130,14 @@ func NEP2Decrypt(key, passphrase string) (*PrivateKey, error) {
 func compareAddressHash(priv *PrivateKey, inhash []byte) bool {
 	address := priv.Address()
 	addrHash := hash.Checksum([]byte(address))
-	return bytes.Equal(addrHash, inhash)
+	return subtle.ConstantTimeCompare(addrHash, inhash) == 1
+}
+
+// wipe zeroes the given slice, it's used for sensitive intermediate data.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
 }
 
 func validateNEP2Format(b []byte) error {
@@ -149,7 +149,8 @@ func (p *PrivateKey) Sign(data []byte) []byte {
 	return p.SignHash(digest)
 }
 
-// SignHash signs particular hash the private key.
+// SignHash signs particular hash the private key. Notice that signing is not
+// constant-time (see package documentation).
 func (p *PrivateKey) SignHash(digest util.Uint256) []byte {
 	r, s := rfc6979.SignECDSA(&p.PrivateKey, digest[:], sha256.New)
 	return getSignatureSlice(p.PrivateKey.Curve, r, s)
@@ -172,6 +173,19 @@ func getSignatureSlice(curve elliptic.Curve, r, s *big.Int) []byte {
 	return signature
 }
 
+// Destroy wipes the private key from memory, the key can't be used after
+// this call. Notice that it only clears the key itself, copies made by the
+// user (like the ones returned from Bytes or WIF) should be taken care of
+// separately. The key is not locked in memory, so it still can be swapped to
+// disk before this call.
+func (p *PrivateKey) Destroy() {
+	bits := p.D.Bits()
+	for i := range bits {
+		bits[i] = 0
+	}
+	p.D.SetInt64(0)
+}
+
 // String implements the stringer interface.
 func (p *PrivateKey) String() string {
 	return hex.EncodeToString(p.Bytes())
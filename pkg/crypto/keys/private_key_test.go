@@ -45,6 +45,18 @@ func TestNewPrivateKeyOnCurve(t *testing.T) {
 	})
 }
 
+func TestPrivateKeyDestroy(t *testing.T) {
+	p, err := NewPrivateKey()
+	require.NoError(t, err)
+	bits := p.D.Bits()
+	p.Destroy()
+	require.Equal(t, 0, p.D.Sign())
+	for i := range bits {
+		require.Zero(t, bits[i])
+	}
+	require.Equal(t, make([]byte, 32), p.Bytes())
+}
+
 func TestPrivateKeyFromWIF(t *testing.T) {
 	for _, testCase := range keytestcases.Arr {
 		key, err := NewPrivateKeyFromWIF(testCase.Wif)
@@ -2,6 +2,8 @@ package address
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/nspcc-dev/neo-go/pkg/encoding/base58"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -20,20 +22,47 @@ var Prefix = NEO3Prefix
 
 // Uint160ToString returns the "NEO address" from the given Uint160.
 func Uint160ToString(u util.Uint160) string {
-	// Dont forget to prepend the Address version 0x17 (23) A
-	b := append([]byte{Prefix}, u.BytesBE()...)
+	return Uint160ToStringPrefix(u, Prefix)
+}
+
+// Uint160ToStringPrefix returns the address of the given Uint160 using the
+// given address version (prefix) byte.
+func Uint160ToStringPrefix(u util.Uint160, prefix byte) string {
+	b := append([]byte{prefix}, u.BytesBE()...)
 	return base58.CheckEncode(b)
 }
 
 // StringToUint160 attempts to decode the given NEO address string
 // into an Uint160.
-func StringToUint160(s string) (u util.Uint160, err error) {
+func StringToUint160(s string) (util.Uint160, error) {
+	return StringToUint160Prefix(s, Prefix)
+}
+
+// StringToUint160Prefix attempts to decode the given address string into an
+// Uint160 checking that it has the given address version (prefix) byte.
+func StringToUint160Prefix(s string, prefix byte) (u util.Uint160, err error) {
 	b, err := base58.CheckDecode(s)
 	if err != nil {
 		return u, err
 	}
-	if b[0] != Prefix {
+	if len(b) != 1+util.Uint160Size {
+		return u, fmt.Errorf("wrong address length %d", len(b))
+	}
+	if b[0] != prefix {
 		return u, errors.New("wrong address prefix")
 	}
-	return util.Uint160DecodeBytesBE(b[1:21])
+	return util.Uint160DecodeBytesBE(b[1:])
+}
+
+// ParseUint160 parses Uint160 given either as an address or as a little-endian
+// hex string (with or without 0x prefix). It's the format accepted by RPC
+// parameters and CLI flags.
+func ParseUint160(s string) (util.Uint160, error) {
+	const uint160size = 2 * util.Uint160Size
+	switch len(s) {
+	case uint160size, uint160size + 2:
+		return util.Uint160DecodeStringLE(strings.TrimPrefix(s, "0x"))
+	default:
+		return StringToUint160(s)
+	}
 }
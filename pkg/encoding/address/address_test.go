@@ -1,8 +1,10 @@
 package address
 
 import (
+	"math/rand"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/encoding/base58"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -55,3 +57,73 @@ func TestPrefixFirstLetter(t *testing.T) {
 	}
 	require.EqualValues(t, 'N', Uint160ToString(u)[0])
 }
+
+func TestUint160Prefix(t *testing.T) {
+	u, err := StringToUint160("NNnFn8iHWWnJe9QYoN1r4PeXMuVpfLVRS7")
+	require.NoError(t, err)
+
+	neo2 := Uint160ToStringPrefix(u, NEO2Prefix)
+	require.EqualValues(t, 'A', neo2[0])
+	actual, err := StringToUint160Prefix(neo2, NEO2Prefix)
+	require.NoError(t, err)
+	require.Equal(t, u, actual)
+
+	_, err = StringToUint160(neo2)
+	require.Error(t, err)
+	_, err = StringToUint160Prefix(Uint160ToString(u), NEO2Prefix)
+	require.Error(t, err)
+}
+
+func TestUint160DecodeBadLength(t *testing.T) {
+	for _, b := range [][]byte{{}, {Prefix}, {Prefix, 1, 2, 3}, make([]byte, 1+util.Uint160Size+1)} {
+		_, err := StringToUint160(base58.CheckEncode(b))
+		require.Error(t, err)
+	}
+}
+
+func TestParseUint160(t *testing.T) {
+	u, err := StringToUint160("NNnFn8iHWWnJe9QYoN1r4PeXMuVpfLVRS7")
+	require.NoError(t, err)
+
+	for _, s := range []string{
+		"NNnFn8iHWWnJe9QYoN1r4PeXMuVpfLVRS7",
+		u.StringLE(),
+		"0x" + u.StringLE(),
+	} {
+		actual, err := ParseUint160(s)
+		require.NoError(t, err, s)
+		require.Equal(t, u, actual, s)
+	}
+	for _, s := range []string{
+		"",
+		"0x",
+		u.StringLE()[2:],
+		"0X" + u.StringLE(),
+		"zz" + u.StringLE()[2:],
+		"NNnFn8iHWWnJe9QYoN1r4PeXMuVpfLVRS8",
+	} {
+		_, err := ParseUint160(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParseUint160Random(t *testing.T) {
+	const alphabet = "0123456789abcdefxABCDEFGHJKLMNPQRSTUVWXYZ"
+	for i := 0; i < 1000; i++ {
+		var u util.Uint160
+		rand.Read(u[:])
+		for _, s := range []string{Uint160ToString(u), u.StringLE(), "0x" + u.StringLE()} {
+			actual, err := ParseUint160(s)
+			require.NoError(t, err)
+			require.Equal(t, u, actual)
+		}
+
+		// Arbitrary input must never cause panic.
+		garbage := make([]byte, rand.Intn(50))
+		for j := range garbage {
+			garbage[j] = alphabet[rand.Intn(len(alphabet))]
+		}
+		_, _ = ParseUint160(string(garbage))
+		_, _ = StringToUint160(base58.CheckEncode(garbage))
+	}
+}
@@ -6,6 +6,16 @@ be substituted with appropriate NeoVM system calls implemented by Neo. Usually
 these system calls have additional price in NeoVM, so they're explicitly written
 in the documentation of respective functions.
 
+Subpackages form a standard library for contract authors:
+	contract - contract calls and account script hash calculations
+	crypto - signature checks for script container
+	iterator - iteration over storage search results and other iterators
+	runtime - execution environment data, witness checks and notifications
+	storage - contract storage access
+	native/* - wrappers for native contracts methods (including hashing
+	  functions provided by native/crypto)
+	convert, math, util - builtins implemented by compiler with VM opcodes
+
 Types defined here are used for proper manifest generation. Here is how Go types
 correspond to smartcontract and VM types:
 	int-like - Integer
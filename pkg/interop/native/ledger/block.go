@@ -26,4 +26,12 @@ type Block struct {
 	NextConsensus interop.Hash160
 	// TransactionsLength represents the length of block's transactions array.
 	TransactionsLength int
+	// PrimaryIndex is the index of primary consensus node for this block.
+	// This field and PrevStateRoot are only available on networks with
+	// ExtendedBlockItems enabled.
+	PrimaryIndex int
+	// PrevStateRoot represents the state root (256 bit BE value in a 32 byte
+	// slice) of the previous block, it's nil for networks without state root
+	// in block header.
+	PrevStateRoot interop.Hash256
 }
@@ -34,11 +34,23 @@ func GetContract(addr interop.Hash160) *Contract {
 	return contract.Call(interop.Hash160(Hash), "getContract", contract.ReadStates, addr).(*Contract)
 }
 
+// GetContractByID represents `getContractById` method of Management native contract.
+func GetContractByID(id int) *Contract {
+	return contract.Call(interop.Hash160(Hash), "getContractById", contract.ReadStates, id).(*Contract)
+}
+
 // GetMinimumDeploymentFee represents `getMinimumDeploymentFee` method of Management native contract.
 func GetMinimumDeploymentFee() int {
 	return contract.Call(interop.Hash160(Hash), "getMinimumDeploymentFee", contract.ReadStates).(int)
 }
 
+// HasMethod represents `hasMethod` method of Management native contract. It
+// checks whether the contract has the method with the given name and number of
+// parameters (-1 matches any number).
+func HasMethod(hash interop.Hash160, method string, pcount int) bool {
+	return contract.Call(interop.Hash160(Hash), "hasMethod", contract.ReadStates, hash, method, pcount).(bool)
+}
+
 // SetMinimumDeploymentFee represents `setMinimumDeploymentFee` method of Management native contract.
 func SetMinimumDeploymentFee(value int) {
 	contract.Call(interop.Hash160(Hash), "setMinimumDeploymentFee", contract.States, value)
@@ -13,8 +13,10 @@ import (
 
 // Context represents storage context that is mandatory for Put/Get/Delete
 // operations. It's an opaque type that can only be created properly by
-// GetContext, GetReadOnlyContext or ConvertContextToReadOnly. It's similar
-// to Neo .net framework's StorageContext class.
+// GetContext, GetReadOnlyContext, ConvertContextToReadOnly or AsReadOnly.
+// It's similar to Neo .net framework's StorageContext class. Contexts can be
+// passed to other contracts, so that they could access this contract's
+// storage, but only read-only ones should be passed this way.
 type Context struct{}
 
 // FindFlags represents parameters to `Find` iterator.
@@ -46,6 +48,14 @@ func ConvertContextToReadOnly(ctx Context) Context {
 	return neogointernal.Syscall1("System.Storage.AsReadOnly", ctx).(Context)
 }
 
+// AsReadOnly is the same as ConvertContextToReadOnly, it returns read-only
+// version of the given context. Contexts passed to other contracts should
+// always be converted with it, otherwise the callee is able to modify
+// caller's storage. It uses `System.Storage.AsReadOnly` syscall.
+func AsReadOnly(ctx Context) Context {
+	return neogointernal.Syscall1("System.Storage.AsReadOnly", ctx).(Context)
+}
+
 // GetContext returns current contract's (that invokes this function) storage
 // context. It uses `System.Storage.GetContext` syscall.
 func GetContext() Context {
@@ -115,13 +115,10 @@ func (r *BinReader) ReadArray(t interface{}, maxSize ...int) {
 		ms = maxSize[0]
 	}
 
-	lu := r.ReadVarUint()
-	if lu > uint64(ms) {
-		r.Err = fmt.Errorf("array is too big (%d)", lu)
+	l := r.ReadArrayLength(ms)
+	if r.Err != nil {
 		return
 	}
-
-	l := int(lu)
 	arr := reflect.MakeSlice(sliceType, l, l)
 
 	for i := 0; i < l; i++ {
@@ -144,6 +141,21 @@ func (r *BinReader) ReadArray(t interface{}, maxSize ...int) {
 	value.Elem().Set(arr)
 }
 
+// ReadArrayLength reads the number of array elements checking it against
+// the given limit, it's useful for arrays that can't be read with ReadArray.
+// It returns 0 and sets Err if the limit is exceeded.
+func (r *BinReader) ReadArrayLength(maxSize int) int {
+	lu := r.ReadVarUint()
+	if r.Err != nil {
+		return 0
+	}
+	if lu > uint64(maxSize) {
+		r.Err = fmt.Errorf("array is too big (%d)", lu)
+		return 0
+	}
+	return int(lu)
+}
+
 // ReadVarUint reads a variable-length-encoded integer from the
 // underlying reader.
 func (r *BinReader) ReadVarUint() uint64 {
@@ -462,6 +462,20 @@ func TestBinReader_ReadArray(t *testing.T) {
 	require.Panics(t, func() { r.ReadArray(1) })
 }
 
+func TestBinReader_ReadArrayLength(t *testing.T) {
+	r := NewBinReaderFromBuf([]byte{3})
+	require.Equal(t, 3, r.ReadArrayLength(3))
+	require.NoError(t, r.Err)
+
+	r = NewBinReaderFromBuf([]byte{3})
+	require.Equal(t, 0, r.ReadArrayLength(2))
+	require.Error(t, r.Err)
+
+	r = NewBinReaderFromBuf([]byte{})
+	require.Equal(t, 0, r.ReadArrayLength(2))
+	require.Error(t, r.Err)
+}
+
 func TestBinReader_ReadBytes(t *testing.T) {
 	data := []byte{0, 1, 2, 3, 4, 5, 6, 7}
 	r := NewBinReaderFromBuf(data)
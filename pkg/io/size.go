@@ -19,6 +19,12 @@ func (cw *counterWriter) Write(p []byte) (int, error) {
 	return n, nil
 }
 
+// Sizer is implemented by types that can calculate their serialized size
+// without encoding, GetVarSize uses it when available.
+type Sizer interface {
+	SerializedSize() int
+}
+
 // getVarIntSize returns the size in number of bytes of a variable integer.
 // (reference: GetVarSize(int value),  https://github.com/neo-project/neo/blob/master/neo/IO/Helper.cs)
 func getVarIntSize(value int) int {
@@ -61,13 +67,10 @@ func GetVarSize(value interface{}) int {
 		if !ok {
 			panic("unable to calculate GetVarSize for a non-Serializable pointer")
 		}
-		cw := counterWriter{}
-		w := NewBinWriterFromIO(&cw)
-		vser.EncodeBinary(w)
-		if w.Err != nil {
-			panic(fmt.Sprintf("error serializing %s: %s", reflect.TypeOf(value), w.Err.Error()))
+		if s, ok := vser.(Sizer); ok {
+			return s.SerializedSize()
 		}
-		return cw.counter
+		return GetEncodedSize(vser)
 	case reflect.Slice, reflect.Array:
 		valueLength := v.Len()
 		valueSize := 0
@@ -94,3 +97,16 @@ func GetVarSize(value interface{}) int {
 		panic(fmt.Sprintf("unable to calculate GetVarSize, %s", reflect.TypeOf(value)))
 	}
 }
+
+// GetEncodedSize returns the number of bytes in the serialized value by
+// actually encoding it. Unlike GetVarSize it never uses Sizer, so it can be
+// used to implement one.
+func GetEncodedSize(value Serializable) int {
+	cw := counterWriter{}
+	w := NewBinWriterFromIO(&cw)
+	value.EncodeBinary(w)
+	if w.Err != nil {
+		panic(fmt.Sprintf("error serializing %s: %s", reflect.TypeOf(value), w.Err.Error()))
+	}
+	return cw.counter
+}
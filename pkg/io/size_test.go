@@ -29,6 +29,15 @@ func (*smthNotReallySerializable) EncodeBinary(bw *io.BinWriter) {
 	bw.Err = fmt.Errorf("smth bad happened in smthNotReallySerializable")
 }
 
+// Mock structure with a known size that differs from the encoded one.
+type smthSized struct {
+	smthSerializable
+}
+
+func (*smthSized) SerializedSize() int {
+	return 1
+}
+
 func TestVarSize(t *testing.T) {
 	testCases := []struct {
 		variable interface{}
@@ -205,3 +214,10 @@ func TestVarSizePanic(t *testing.T) {
 	panicVarSize(t, struct{}{})
 	panicVarSize(t, &smthNotReallySerializable{})
 }
+
+func TestVarSizeSizer(t *testing.T) {
+	s := &smthSized{}
+	assert.Equal(t, 1, io.GetVarSize(s))
+	assert.Equal(t, 42, io.GetEncodedSize(s))
+	assert.Equal(t, 1+2, io.GetVarSize([]*smthSized{s, s}))
+}
@@ -0,0 +1,141 @@
+package network
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+var (
+	errNoNodeKey       = errors.New("peer has no node key")
+	errNodeKeyDenied   = errors.New("peer node key is not allowed")
+	errInvalidNodeAuth = errors.New("invalid peer authentication")
+)
+
+// nodeAuth implements authenticated handshakes. Every node announces its
+// public key along with a random challenge in the version message and then
+// signs the challenge of the other node in the version acknowledgement.
+type nodeAuth struct {
+	key *keys.PrivateKey
+	// allowed is a list of peer keys allowed to connect, any key with a
+	// valid signature is accepted if it's empty.
+	allowed keys.PublicKeys
+}
+
+// newNodeAuth creates nodeAuth using the first account that can be unlocked
+// in the configured wallet as a node key.
+func newNodeAuth(cfg config.P2PAuth) (*nodeAuth, error) {
+	pass, err := cfg.UnlockWallet.ResolvePassword()
+	if err != nil {
+		return nil, err
+	}
+	w, err := wallet.NewWalletFromFile(cfg.UnlockWallet.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+
+	a := new(nodeAuth)
+	for _, acc := range w.Accounts {
+		if err := acc.Decrypt(pass); err == nil {
+			a.key = acc.PrivateKey()
+			break
+		}
+	}
+	if a.key == nil {
+		return nil, errors.New("no wallet account could be unlocked")
+	}
+	for _, s := range cfg.AllowedKeys {
+		pub, err := keys.NewPublicKeyFromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed key %s: %w", s, err)
+		}
+		a.allowed = append(a.allowed, pub)
+	}
+	return a, nil
+}
+
+// newAuthChallenge returns a new random challenge for the peer.
+func newAuthChallenge() []byte {
+	c := make([]byte, capability.ChallengeSize)
+	_, _ = rand.Read(c)
+	return c
+}
+
+// capability returns NodeKey capability with the given challenge.
+func (a *nodeAuth) capability(challenge []byte) capability.Capability {
+	k := &capability.Key{PublicKey: a.key.PublicKey()}
+	copy(k.Challenge[:], challenge)
+	return capability.Capability{
+		Type: capability.NodeKey,
+		Data: k,
+	}
+}
+
+// getNodeKey returns NodeKey capability of the version or nil if there is
+// none.
+func getNodeKey(v *payload.Version) *capability.Key {
+	for _, c := range v.Capabilities {
+		if c.Type == capability.NodeKey {
+			return c.Data.(*capability.Key)
+		}
+	}
+	return nil
+}
+
+// checkVersion checks that the peer has an allowed node key.
+func (a *nodeAuth) checkVersion(v *payload.Version) error {
+	k := getNodeKey(v)
+	if k == nil {
+		return errNoNodeKey
+	}
+	if len(a.allowed) != 0 && !a.allowed.Contains(k.PublicKey) {
+		return errNodeKeyDenied
+	}
+	return nil
+}
+
+// authDigest returns the hash of the data signed by the node to authenticate
+// itself to the node with the given key and challenge. Both network magic and
+// the key of the other node are included, so that signature can't be reused
+// for some other node.
+func authDigest(magic netmode.Magic, challenge []byte, pub *keys.PublicKey) util.Uint256 {
+	w := io.NewBufBinWriter()
+	w.WriteU32LE(uint32(magic))
+	w.WriteBytes(challenge)
+	pub.EncodeBinary(w.BinWriter)
+	return hash.Sha256(w.Bytes())
+}
+
+// sign returns authentication payload for the peer with the given (checked)
+// version.
+func (a *nodeAuth) sign(magic netmode.Magic, v *payload.Version) *payload.Auth {
+	k := getNodeKey(v)
+	res := new(payload.Auth)
+	copy(res.Signature[:], a.key.SignHash(authDigest(magic, k.Challenge[:], k.PublicKey)))
+	return res
+}
+
+// verify checks the signature of the challenge sent to the peer with the
+// given (checked) version.
+func (a *nodeAuth) verify(magic netmode.Magic, v *payload.Version, challenge []byte, p payload.Payload) error {
+	auth, ok := p.(*payload.Auth)
+	if !ok {
+		return errInvalidNodeAuth
+	}
+	digest := authDigest(magic, challenge, a.key.PublicKey())
+	if !getNodeKey(v).PublicKey.Verify(auth.Signature[:], digest[:]) {
+		return errInvalidNodeAuth
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package network
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNodeAuth(t *testing.T, allowed ...*keys.PublicKey) *nodeAuth {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	return &nodeAuth{key: priv, allowed: allowed}
+}
+
+func newAuthVersion(a *nodeAuth, challenge []byte) *payload.Version {
+	return payload.NewVersion(netmode.UnitTestNet, 1, "/test/",
+		[]capability.Capability{a.capability(challenge)})
+}
+
+func TestNodeAuth(t *testing.T) {
+	a, b := newTestNodeAuth(t), newTestNodeAuth(t)
+	aChallenge, bChallenge := newAuthChallenge(), newAuthChallenge()
+	aVer, bVer := newAuthVersion(a, aChallenge), newAuthVersion(b, bChallenge)
+
+	require.NoError(t, a.checkVersion(bVer))
+	require.NoError(t, b.checkVersion(aVer))
+
+	// a signs b's challenge and b checks it.
+	auth := a.sign(netmode.UnitTestNet, bVer)
+	require.NoError(t, b.verify(netmode.UnitTestNet, aVer, bChallenge, auth))
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		require.Error(t, b.verify(netmode.UnitTestNet, aVer, aChallenge, auth))
+	})
+	t.Run("wrong network", func(t *testing.T) {
+		require.Error(t, b.verify(netmode.PrivNet, aVer, bChallenge, auth))
+	})
+	t.Run("other node", func(t *testing.T) {
+		c := newTestNodeAuth(t)
+		require.Error(t, c.verify(netmode.UnitTestNet, aVer, bChallenge, auth))
+	})
+	t.Run("no signature", func(t *testing.T) {
+		require.Error(t, b.verify(netmode.UnitTestNet, aVer, bChallenge, payload.NewNullPayload()))
+	})
+	t.Run("no key", func(t *testing.T) {
+		v := payload.NewVersion(netmode.UnitTestNet, 1, "/test/", nil)
+		require.Equal(t, errNoNodeKey, a.checkVersion(v))
+	})
+	t.Run("whitelist", func(t *testing.T) {
+		c := newTestNodeAuth(t, a.key.PublicKey())
+		require.NoError(t, c.checkVersion(aVer))
+		require.Equal(t, errNodeKeyDenied, c.checkVersion(bVer))
+	})
+}
+
+func TestNewNodeAuth(t *testing.T) {
+	d, err := ioutil.TempDir("", "neogo-p2pauth")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+
+	cfg := config.P2PAuth{
+		Enabled: true,
+		UnlockWallet: config.Wallet{
+			Path:     filepath.Join(d, "wallet.json"),
+			Password: "pass",
+		},
+	}
+	_, err = newNodeAuth(cfg)
+	require.Error(t, err)
+
+	w, err := wallet.NewWallet(cfg.UnlockWallet.Path)
+	require.NoError(t, err)
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	require.NoError(t, acc.Encrypt(cfg.UnlockWallet.Password))
+	w.AddAccount(acc)
+	require.NoError(t, w.Save())
+	w.Close()
+
+	cfg.UnlockWallet.Password = "wrong"
+	_, err = newNodeAuth(cfg)
+	require.Error(t, err)
+
+	cfg.UnlockWallet.Password = "pass"
+	cfg.AllowedKeys = []string{"bad"}
+	_, err = newNodeAuth(cfg)
+	require.Error(t, err)
+
+	cfg.AllowedKeys = []string{hex.EncodeToString(acc.PrivateKey().PublicKey().Bytes())}
+	a, err := newNodeAuth(cfg)
+	require.NoError(t, err)
+	require.Equal(t, acc.PrivateKey().PublicKey(), a.key.PublicKey())
+	require.Equal(t, keys.PublicKeys{a.key.PublicKey()}, a.allowed)
+}
+
+func TestServerAuthHandshake(t *testing.T) {
+	s := newTestServer(t, ServerConfig{Net: netmode.UnitTestNet})
+	s.auth = newTestNodeAuth(t)
+	p := newLocalPeer(t, s)
+
+	t.Run("no key", func(t *testing.T) {
+		v := payload.NewVersion(netmode.UnitTestNet, 2, "/test/", nil)
+		require.Equal(t, errNoNodeKey, s.handleVersionCmd(p, v))
+	})
+	t.Run("with key", func(t *testing.T) {
+		a := newTestNodeAuth(t)
+		challenge := newAuthChallenge()
+		var sent bool
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			sent = true
+			require.Equal(t, CMDVerack, msg.Command)
+			require.NoError(t, a.verify(netmode.UnitTestNet, newAuthVersion(s.auth, nil), challenge, msg.Payload))
+		}
+		require.NoError(t, s.handleVersionCmd(p, newAuthVersion(a, challenge)))
+		require.True(t, sent)
+	})
+}
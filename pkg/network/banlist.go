@@ -0,0 +1,188 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+)
+
+const (
+	// defaultHandshakeTimeout is the time a peer can spend doing the
+	// handshake if it's not configured.
+	defaultHandshakeTimeout = 10 * time.Second
+	// defaultBanScore is the number of penalty points for a peer to be
+	// banned if it's not configured.
+	defaultBanScore = 100
+	// defaultBanDuration is the time peers are banned for if it's not
+	// configured.
+	defaultBanDuration = 24 * time.Hour
+
+	// Penalty points for different kinds of peer misbehavior.
+	scoreMalformedMessage  = 50
+	scoreProtocolViolation = 50
+	scoreHandshakeTimeout  = 20
+)
+
+var (
+	errBanned            = errors.New("peer is banned")
+	errHandshakeTimeout  = errors.New("handshake timeout")
+	errMalformedMessage  = errors.New("malformed message")
+	errProtocolViolation = errors.New("protocol violation")
+)
+
+// banList keeps misbehavior scores of peers and temporary bans of the ones
+// that reached the threshold. Peers are identified by their IP addresses.
+type banList struct {
+	score    int
+	duration time.Duration
+	file     string
+
+	lock   sync.Mutex
+	scores map[string]peerScore
+	bans   map[string]time.Time
+
+	// now returns the current time, it's replaceable for tests.
+	now func() time.Time
+}
+
+// peerScore is the number of penalty points of a peer along with the time
+// of its last misbehavior.
+type peerScore struct {
+	points int
+	last   time.Time
+}
+
+// newBanList returns a new banList with the given configuration and bans
+// loaded from the configured file (if any).
+func newBanList(cfg config.PeerBan) (*banList, error) {
+	b := &banList{
+		score:    cfg.BanScore,
+		duration: cfg.BanDuration,
+		file:     cfg.File,
+		scores:   make(map[string]peerScore),
+		bans:     make(map[string]time.Time),
+		now:      time.Now,
+	}
+	if b.score <= 0 {
+		b.score = defaultBanScore
+	}
+	if b.duration <= 0 {
+		b.duration = defaultBanDuration
+	}
+	if b.file == "" {
+		return b, nil
+	}
+	data, err := ioutil.ReadFile(b.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return b, nil
+		}
+		return nil, fmt.Errorf("can't read banned peers: %w", err)
+	}
+	if err := json.Unmarshal(data, &b.bans); err != nil {
+		return nil, fmt.Errorf("can't decode banned peers: %w", err)
+	}
+	b.removeExpired()
+	return b, nil
+}
+
+// misbehaviorScore returns the number of penalty points for the peer
+// disconnected with the given error.
+func misbehaviorScore(reason error) int {
+	switch {
+	case errors.Is(reason, errMalformedMessage):
+		return scoreMalformedMessage
+	case errors.Is(reason, errProtocolViolation),
+		errors.Is(reason, errInvalidNetwork),
+		errors.Is(reason, errInvalidInvType):
+		return scoreProtocolViolation
+	case errors.Is(reason, errHandshakeTimeout):
+		return scoreHandshakeTimeout
+	}
+	return 0
+}
+
+// isBanned returns true if the given IP address is banned.
+func (b *banList) isBanned(ip string) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	until, ok := b.bans[ip]
+	if !ok {
+		return false
+	}
+	if !b.now().Before(until) {
+		delete(b.bans, ip)
+		return false
+	}
+	return true
+}
+
+// misbehave adds penalty points to the given IP address and bans it if its
+// score reaches the threshold. Points are forgotten if there was no
+// misbehavior for the ban duration. It returns true if the address is
+// banned.
+func (b *banList) misbehave(ip string, points int) (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.now()
+	s := b.scores[ip]
+	if now.Sub(s.last) >= b.duration {
+		s.points = 0
+	}
+	s.points += points
+	s.last = now
+	if s.points < b.score {
+		b.scores[ip] = s
+		return false, nil
+	}
+	delete(b.scores, ip)
+	b.bans[ip] = now.Add(b.duration)
+	return true, b.save()
+}
+
+// banned returns a copy of the currently banned addresses with the time
+// their bans expire.
+func (b *banList) banned() map[string]time.Time {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.removeExpired()
+	res := make(map[string]time.Time, len(b.bans))
+	for ip, until := range b.bans {
+		res[ip] = until
+	}
+	return res
+}
+
+// removeExpired removes expired bans, it must be called with the lock held.
+func (b *banList) removeExpired() {
+	now := b.now()
+	for ip, until := range b.bans {
+		if !now.Before(until) {
+			delete(b.bans, ip)
+		}
+	}
+}
+
+// save stores bans into the configured file (if any), it must be called
+// with the lock held.
+func (b *banList) save() error {
+	if b.file == "" {
+		return nil
+	}
+	b.removeExpired()
+	data, err := json.Marshal(b.bans)
+	if err != nil {
+		return err
+	}
+	tmp := b.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, b.file)
+}
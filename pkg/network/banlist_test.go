@@ -0,0 +1,74 @@
+package network
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMisbehaviorScore(t *testing.T) {
+	require.Equal(t, scoreMalformedMessage, misbehaviorScore(fmt.Errorf("%w: bad", errMalformedMessage)))
+	require.Equal(t, scoreProtocolViolation, misbehaviorScore(fmt.Errorf("%w: bad", errProtocolViolation)))
+	require.Equal(t, scoreProtocolViolation, misbehaviorScore(errInvalidNetwork))
+	require.Equal(t, scoreHandshakeTimeout, misbehaviorScore(errHandshakeTimeout))
+	require.Equal(t, 0, misbehaviorScore(errMaxPeers))
+	require.Equal(t, 0, misbehaviorScore(errPingPong))
+}
+
+func TestBanList(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	b, err := newBanList(config.PeerBan{BanScore: 50, BanDuration: time.Hour})
+	require.NoError(t, err)
+	b.now = func() time.Time { return now }
+
+	banned, err := b.misbehave("10.0.0.1", 30)
+	require.NoError(t, err)
+	require.False(t, banned)
+	require.False(t, b.isBanned("10.0.0.1"))
+
+	t.Run("decay", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		banned, err := b.misbehave("10.0.0.1", 30)
+		require.NoError(t, err)
+		require.False(t, banned)
+	})
+
+	banned, err = b.misbehave("10.0.0.1", 20)
+	require.NoError(t, err)
+	require.True(t, banned)
+	require.True(t, b.isBanned("10.0.0.1"))
+	require.False(t, b.isBanned("10.0.0.2"))
+	require.Equal(t, map[string]time.Time{"10.0.0.1": now.Add(time.Hour)}, b.banned())
+
+	now = now.Add(time.Hour)
+	require.False(t, b.isBanned("10.0.0.1"))
+	require.Equal(t, 0, len(b.banned()))
+}
+
+func TestBanListFile(t *testing.T) {
+	d, err := ioutil.TempDir("", "neogo-banlist")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+
+	cfg := config.PeerBan{
+		BanScore:    10,
+		BanDuration: time.Hour,
+		File:        filepath.Join(d, "bans.json"),
+	}
+	b, err := newBanList(cfg)
+	require.NoError(t, err)
+	banned, err := b.misbehave("10.0.0.1", 10)
+	require.NoError(t, err)
+	require.True(t, banned)
+
+	b, err = newBanList(cfg)
+	require.NoError(t, err)
+	require.True(t, b.isBanned("10.0.0.1"))
+	require.False(t, b.isBanned("10.0.0.2"))
+}
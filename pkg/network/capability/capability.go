@@ -3,19 +3,25 @@ package capability
 import (
 	"errors"
 
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 )
 
 // MaxCapabilities is the maximum number of capabilities per payload.
 const MaxCapabilities = 32
 
+// ChallengeSize is the size of the random challenge of NodeKey capability.
+const ChallengeSize = 32
+
 // Capabilities is a list of Capability.
 type Capabilities []Capability
 
 // DecodeBinary implements Serializable interface.
 func (cs *Capabilities) DecodeBinary(br *io.BinReader) {
 	br.ReadArray(cs, MaxCapabilities)
-	br.Err = cs.checkUniqueCapabilities()
+	if br.Err == nil {
+		br.Err = cs.checkUniqueCapabilities()
+	}
 }
 
 // EncodeBinary implements Serializable interface.
@@ -26,7 +32,7 @@ func (cs *Capabilities) EncodeBinary(br *io.BinWriter) {
 // checkUniqueCapabilities checks whether payload capabilities have unique type.
 func (cs Capabilities) checkUniqueCapabilities() error {
 	err := errors.New("capabilities with the same type are not allowed")
-	var isFullNode, isTCP, isWS bool
+	var isFullNode, isTCP, isWS, isKey bool
 	for _, cap := range cs {
 		switch cap.Type {
 		case FullNode:
@@ -44,6 +50,11 @@ func (cs Capabilities) checkUniqueCapabilities() error {
 				return err
 			}
 			isWS = true
+		case NodeKey:
+			if isKey {
+				return err
+			}
+			isKey = true
 		}
 	}
 	return nil
@@ -63,6 +74,8 @@ func (c *Capability) DecodeBinary(br *io.BinReader) {
 		c.Data = &Node{}
 	case TCPServer, WSServer:
 		c.Data = &Server{}
+	case NodeKey:
+		c.Data = &Key{}
 	default:
 		br.Err = errors.New("unknown node capability type")
 		return
@@ -110,3 +123,24 @@ func (s *Server) DecodeBinary(br *io.BinReader) {
 func (s *Server) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteU16LE(s.Port)
 }
+
+// Key represents node identity key capability with the public key of the
+// node and a random challenge the other side is to sign to authenticate
+// itself.
+type Key struct {
+	PublicKey *keys.PublicKey
+	Challenge [ChallengeSize]byte
+}
+
+// DecodeBinary implements Serializable interface.
+func (k *Key) DecodeBinary(br *io.BinReader) {
+	k.PublicKey = new(keys.PublicKey)
+	k.PublicKey.DecodeBinary(br)
+	br.ReadBytes(k.Challenge[:])
+}
+
+// EncodeBinary implements Serializable interface.
+func (k *Key) EncodeBinary(bw *io.BinWriter) {
+	k.PublicKey.EncodeBinary(bw)
+	bw.WriteBytes(k.Challenge[:])
+}
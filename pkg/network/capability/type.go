@@ -10,4 +10,7 @@ const (
 	WSServer Type = 0x02
 	// FullNode represents full node capability type.
 	FullNode Type = 0x10
+	// NodeKey represents node identity key capability type used for
+	// authenticated handshakes (it's specific to neo-go).
+	NodeKey Type = 0x20
 )
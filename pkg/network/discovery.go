@@ -1,6 +1,8 @@
 package network
 
 import (
+	"context"
+	"net"
 	"sync"
 	"time"
 
@@ -49,6 +51,16 @@ type DefaultDiscovery struct {
 	isDead           bool
 	requestCh        chan int
 	pool             chan string
+	// seedCh receives reachable seed addresses from the goroutine resolving
+	// and probing them, there is at most one such goroutine at a time.
+	seedCh chan []string
+	// seedIdx is the index of the seed to start with on the next seed
+	// list refill, it's only used by seed resolving goroutine.
+	seedIdx int
+	// lookupHost and probe are used to resolve seed names and to check
+	// resolved addresses reachability, they're replaceable for tests.
+	lookupHost func(host string, timeout time.Duration) ([]string, error)
+	probe      func(addr string, timeout time.Duration) error
 }
 
 // NewDefaultDiscovery returns a new DefaultDiscovery.
@@ -64,6 +76,9 @@ func NewDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter) *Defa
 		attempted:        make(map[string]bool),
 		requestCh:        make(chan int),
 		pool:             make(chan string, maxPoolSize),
+		seedCh:           make(chan []string, 1),
+		lookupHost:       lookupHost,
+		probe:            ts.Probe,
 	}
 	go d.run()
 	return d
@@ -201,6 +216,84 @@ func (d *DefaultDiscovery) tryAddress(addr string) {
 	}
 }
 
+// lookupHost resolves the given host name into a list of IP addresses.
+func lookupHost(host string, timeout time.Duration) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+// resolveSeed returns the list of addresses the seed resolves to. IP addresses
+// and seeds that can't be resolved are returned as is. WebSocket seeds are
+// not resolved either, they're returned with their scheme (the host name is
+// needed for the HTTP handshake).
+func (d *DefaultDiscovery) resolveSeed(seed string) []string {
+	if isWSAddress(seed) {
+		return []string{seed}
+	}
+	host, port, err := net.SplitHostPort(seed)
+	if err != nil || net.ParseIP(host) != nil {
+		return []string{seed}
+	}
+	ips, err := d.lookupHost(host, d.dialTimeout)
+	if err != nil || len(ips) == 0 {
+		return []string{seed}
+	}
+	addrs := make([]string, len(ips))
+	for i := range ips {
+		addrs[i] = net.JoinHostPort(ips[i], port)
+	}
+	return addrs
+}
+
+// seedAddresses resolves seeds into addresses, probes all of them concurrently
+// and returns the reachable ones. Seeds are rotated on every call, so a seed
+// that is down doesn't always come first. If no address is reachable all of
+// them are returned to be tried by the transport anyway.
+func (d *DefaultDiscovery) seedAddresses() []string {
+	if len(d.seeds) == 0 {
+		return nil
+	}
+	var (
+		addrs []string
+		seen  = make(map[string]bool)
+		start = d.seedIdx % len(d.seeds)
+	)
+	d.seedIdx = start + 1
+	for i := range d.seeds {
+		for _, addr := range d.resolveSeed(d.seeds[(start+i)%len(d.seeds)]) {
+			if !seen[addr] {
+				seen[addr] = true
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		good = make([]bool, len(addrs))
+	)
+	wg.Add(len(addrs))
+	for i := range addrs {
+		go func(i int) {
+			defer wg.Done()
+			good[i] = d.probe(addrs[i], d.dialTimeout) == nil
+		}(i)
+	}
+	wg.Wait()
+
+	var res []string
+	for i := range addrs {
+		if good[i] {
+			res = append(res, addrs[i])
+		}
+	}
+	if len(res) == 0 {
+		return addrs
+	}
+	return res
+}
+
 // Close stops discoverer pool processing making discoverer almost useless.
 func (d *DefaultDiscovery) Close() {
 	d.closeMtx.Lock()
@@ -217,7 +310,7 @@ func (d *DefaultDiscovery) Close() {
 // to other nodes.
 func (d *DefaultDiscovery) run() {
 	var requested, oldRequest, r int
-	var ok bool
+	var ok, resolving bool
 
 	for {
 		if requested == 0 {
@@ -225,6 +318,13 @@ func (d *DefaultDiscovery) run() {
 		}
 		oldRequest = requested
 		for ok && requested > 0 {
+			if !resolving && d.PoolCount() == 0 {
+				// Seeds are resolved and probed in a separate goroutine,
+				// so that requests and addresses pushed into the pool
+				// are still processed.
+				resolving = true
+				go func() { d.seedCh <- d.seedAddresses() }()
+			}
 			select {
 			case r, ok = <-d.requestCh:
 				if requested <= r {
@@ -239,10 +339,11 @@ func (d *DefaultDiscovery) run() {
 					requested--
 				}
 				d.lock.Unlock()
-			default: // Empty pool
+			case seeds := <-d.seedCh:
 				var added int
+				resolving = false
 				d.lock.Lock()
-				for _, addr := range d.seeds {
+				for _, addr := range seeds {
 					if !d.connectedAddrs[addr] {
 						delete(d.badAddrs, addr)
 						d.unconnectedAddrs[addr] = connRetries
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net"
 	"sort"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -34,6 +35,9 @@ func (ft *fakeTransp) Dial(addr string, timeout time.Duration) error {
 	}
 	return nil
 }
+func (ft *fakeTransp) Probe(addr string, timeout time.Duration) error {
+	return nil
+}
 func (ft *fakeTransp) Accept() {
 	if ft.started.Load() {
 		panic("started twice")
@@ -207,3 +211,100 @@ func TestSeedDiscovery(t *testing.T) {
 		}
 	}
 }
+
+func TestSeedAddresses(t *testing.T) {
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	d := NewDefaultDiscovery([]string{"seed.neo.org:10333", "3.3.3.3:10333", "4.4.4.4:10333"}, time.Second/10, ts)
+	defer d.Close()
+
+	var down atomic2.String
+	d.lookupHost = func(host string, _ time.Duration) ([]string, error) {
+		require.Equal(t, "seed.neo.org", host)
+		return []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, nil
+	}
+	d.probe = func(addr string, _ time.Duration) error {
+		if down.Load() == "all" || addr == down.Load() {
+			return errors.New("down")
+		}
+		return nil
+	}
+
+	down.Store("1.1.1.1:10333")
+	require.Equal(t, []string{"2.2.2.2:10333", "3.3.3.3:10333", "4.4.4.4:10333"}, d.seedAddresses())
+	require.Equal(t, []string{"3.3.3.3:10333", "4.4.4.4:10333", "2.2.2.2:10333"}, d.seedAddresses())
+	require.Equal(t, []string{"4.4.4.4:10333", "2.2.2.2:10333", "3.3.3.3:10333"}, d.seedAddresses())
+
+	down.Store("all")
+	require.Equal(t, []string{"1.1.1.1:10333", "2.2.2.2:10333", "3.3.3.3:10333", "4.4.4.4:10333"}, d.seedAddresses())
+
+	t.Run("unresolvable", func(t *testing.T) {
+		d.lookupHost = func(string, time.Duration) ([]string, error) {
+			return nil, errors.New("no such host")
+		}
+		down.Store("")
+		require.Equal(t, []string{"3.3.3.3:10333", "4.4.4.4:10333", "seed.neo.org:10333"}, d.seedAddresses())
+	})
+
+	t.Run("dial", func(t *testing.T) {
+		down.Store("3.3.3.3:10333")
+		d.RequestRemote(1)
+		select {
+		case a := <-ts.dialCh:
+			require.NotEqual(t, "3.3.3.3:10333", a)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout expecting for transport dial")
+		}
+	})
+}
+
+func TestSeedResolvingDoesntBlock(t *testing.T) {
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	d := NewDefaultDiscovery([]string{"seed.neo.org:10333"}, time.Second/10, ts)
+	defer d.Close()
+
+	release := make(chan struct{})
+	d.lookupHost = func(string, time.Duration) ([]string, error) {
+		<-release
+		return []string{"1.1.1.1"}, nil
+	}
+	expectDial := func(addr string) {
+		select {
+		case a := <-ts.dialCh:
+			require.Equal(t, addr, a)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout expecting for transport dial")
+		}
+	}
+
+	d.RequestRemote(2)
+	d.BackFill("2.2.2.2:10333")
+	expectDial("2.2.2.2:10333")
+	close(release)
+	expectDial("1.1.1.1:10333")
+}
+
+func TestSeedAddressesWS(t *testing.T) {
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	d := NewDefaultDiscovery([]string{"ws://seed.neo.org:10334", "3.3.3.3:10333"}, time.Second/10, ts)
+	defer d.Close()
+
+	d.lookupHost = func(host string, _ time.Duration) ([]string, error) {
+		return []string{"1.1.1.1"}, nil
+	}
+	var (
+		lock   sync.Mutex
+		probed []string
+	)
+	d.probe = func(addr string, _ time.Duration) error {
+		lock.Lock()
+		probed = append(probed, addr)
+		lock.Unlock()
+		return nil
+	}
+	require.Equal(t, []string{"ws://seed.neo.org:10334", "3.3.3.3:10333"}, d.seedAddresses())
+	sort.Strings(probed)
+	require.Equal(t, []string{"3.3.3.3:10333", "ws://seed.neo.org:10334"}, probed)
+}
@@ -0,0 +1,24 @@
+// +build gofuzz
+
+package network
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// Fuzz is an entry point for go-fuzz (and oss-fuzz) decoding arbitrary data
+// as a P2P message (both with and without state root in block headers). It
+// returns 1 for successfully decoded messages to give them priority in the
+// corpus and 0 otherwise. Build it with:
+//
+//	go-fuzz-build github.com/nspcc-dev/neo-go/pkg/network
+func Fuzz(data []byte) int {
+	var res int
+	for _, stateRoot := range []bool{false, true} {
+		m := &Message{StateRootInHeader: stateRoot}
+		if m.Decode(io.NewBinReaderFromBuf(data)) == nil {
+			res = 1
+		}
+	}
+	return res
+}
@@ -0,0 +1,185 @@
+package network
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+const (
+	// defaultInvCacheSize is the number of requested inventory hashes
+	// remembered if it's not configured.
+	defaultInvCacheSize = 50000
+	// defaultInvCachePeerSize is the number of inventory hashes remembered
+	// for every peer if it's not configured.
+	defaultInvCachePeerSize = 4096
+	// defaultInvCacheTTL is the time inventory hashes are remembered for if
+	// it's not configured.
+	defaultInvCacheTTL = 15 * time.Second
+	// defaultInvRequestTimeout is the time after which a requested inventory
+	// hash can be requested again if it's not configured.
+	defaultInvRequestTimeout = 3 * time.Second
+)
+
+// hashCache is a size-bounded set of recently seen hashes, every hash is
+// kept for a limited time after its addition. The oldest hashes are evicted
+// when the cache is full. It's safe for concurrent use, nil hashCache is an
+// always empty cache.
+type hashCache struct {
+	size  int
+	ttl   time.Duration
+	retry time.Duration
+
+	lock  sync.Mutex
+	order *list.List
+	items map[util.Uint256]*list.Element
+
+	// now returns the current time, it's replaceable for tests.
+	now func() time.Time
+}
+
+// hashCacheEntry is a hash along with the time of its addition and the peer
+// it was requested from (if any).
+type hashCacheEntry struct {
+	hash  util.Uint256
+	added time.Time
+	peer  Peer
+}
+
+// newHashCache returns a new empty hashCache.
+func newHashCache(size int, ttl time.Duration) *hashCache {
+	return &hashCache{
+		size:  size,
+		ttl:   ttl,
+		order: list.New(),
+		items: make(map[util.Uint256]*list.Element),
+		now:   time.Now,
+	}
+}
+
+// newInvCaches returns node-wide cache and per-peer cache size and TTL
+// according to the configuration.
+func newInvCaches(cfg config.InventoryCache) (*hashCache, int, time.Duration) {
+	if cfg.Size <= 0 {
+		cfg.Size = defaultInvCacheSize
+	}
+	if cfg.PeerSize <= 0 {
+		cfg.PeerSize = defaultInvCachePeerSize
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultInvCacheTTL
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = defaultInvRequestTimeout
+	}
+	c := newHashCache(cfg.Size, cfg.TTL)
+	c.retry = cfg.RequestTimeout
+	return c, cfg.PeerSize, cfg.TTL
+}
+
+// add adds the hash to the cache, it returns false if the hash is already
+// there.
+func (c *hashCache) add(h util.Uint256) bool {
+	if c == nil {
+		return true
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	c.removeExpired(now)
+	if _, ok := c.items[h]; ok {
+		return false
+	}
+	if c.order.Len() >= c.size {
+		c.removeOldest()
+	}
+	c.items[h] = c.order.PushBack(hashCacheEntry{hash: h, added: now})
+	return true
+}
+
+// request marks the hash as requested from the given peer. It returns false
+// if the hash was requested less than the retry timeout ago, otherwise the
+// hash is (re)assigned to the peer and true is returned.
+func (c *hashCache) request(h util.Uint256, p Peer) bool {
+	if c == nil {
+		return true
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	c.removeExpired(now)
+	if el, ok := c.items[h]; ok {
+		if now.Sub(el.Value.(hashCacheEntry).added) < c.retry {
+			return false
+		}
+		c.order.Remove(el)
+		delete(c.items, h)
+	}
+	if c.order.Len() >= c.size {
+		c.removeOldest()
+	}
+	c.items[h] = c.order.PushBack(hashCacheEntry{hash: h, added: now, peer: p})
+	return true
+}
+
+// removePeer removes all hashes requested from the given peer, so that they
+// can be requested from other peers right away.
+func (c *hashCache) removePeer(p Peer) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if e := el.Value.(hashCacheEntry); e.peer == p {
+			c.order.Remove(el)
+			delete(c.items, e.hash)
+		}
+		el = next
+	}
+}
+
+// has returns true if the hash is in the cache.
+func (c *hashCache) has(h util.Uint256) bool {
+	if c == nil {
+		return false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.removeExpired(c.now())
+	_, ok := c.items[h]
+	return ok
+}
+
+// len returns the number of hashes in the cache.
+func (c *hashCache) len() int {
+	if c == nil {
+		return 0
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.removeExpired(c.now())
+	return c.order.Len()
+}
+
+// removeExpired removes hashes added earlier than TTL ago, it must be called
+// with the lock held.
+func (c *hashCache) removeExpired(now time.Time) {
+	for c.order.Len() > 0 {
+		e := c.order.Front().Value.(hashCacheEntry)
+		if now.Sub(e.added) < c.ttl {
+			return
+		}
+		c.removeOldest()
+	}
+}
+
+// removeOldest removes the oldest hash, it must be called with the lock held.
+func (c *hashCache) removeOldest() {
+	e := c.order.Remove(c.order.Front()).(hashCacheEntry)
+	delete(c.items, e.hash)
+}
@@ -0,0 +1,116 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashCache(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	c := newHashCache(2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	h1, h2, h3 := random.Uint256(), random.Uint256(), random.Uint256()
+	require.True(t, c.add(h1))
+	require.False(t, c.add(h1))
+	now = now.Add(30 * time.Second)
+	require.True(t, c.add(h2))
+	require.Equal(t, 2, c.len())
+
+	t.Run("capacity", func(t *testing.T) {
+		now = now.Add(10 * time.Second)
+		require.True(t, c.add(h3))
+		require.False(t, c.has(h1))
+		require.True(t, c.has(h2))
+		require.True(t, c.has(h3))
+	})
+	t.Run("TTL", func(t *testing.T) {
+		// h2 was added 10 seconds before h3.
+		now = now.Add(50*time.Second - time.Nanosecond)
+		require.True(t, c.has(h2))
+		require.Equal(t, 2, c.len())
+
+		// Hash expires exactly TTL after it was added.
+		now = now.Add(time.Nanosecond)
+		require.False(t, c.has(h2))
+		require.True(t, c.has(h3))
+		require.Equal(t, 1, c.len())
+		require.True(t, c.add(h2))
+	})
+	t.Run("nil", func(t *testing.T) {
+		var c *hashCache
+		require.True(t, c.add(h1))
+		require.True(t, c.add(h1))
+		require.False(t, c.has(h1))
+		require.Equal(t, 0, c.len())
+	})
+}
+
+func TestHashCacheRequest(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	c := newHashCache(10, time.Minute)
+	c.retry = 3 * time.Second
+	c.now = func() time.Time { return now }
+
+	p1, p2 := &localPeer{}, &localPeer{}
+	h1, h2 := random.Uint256(), random.Uint256()
+	require.True(t, c.request(h1, p1))
+	require.True(t, c.request(h2, p1))
+	require.False(t, c.request(h1, p2))
+
+	now = now.Add(3 * time.Second)
+	require.True(t, c.request(h1, p2))
+	require.False(t, c.request(h1, p1))
+	require.Equal(t, 2, c.len())
+
+	c.removePeer(p1)
+	require.False(t, c.has(h2))
+	require.True(t, c.has(h1))
+	require.True(t, c.request(h2, p2))
+
+	c.removePeer(p2)
+	require.Equal(t, 0, c.len())
+
+	var nc *hashCache
+	require.True(t, nc.request(h1, p1))
+	nc.removePeer(p1)
+}
+
+func TestNewInvCaches(t *testing.T) {
+	c, size, ttl := newInvCaches(config.InventoryCache{})
+	require.Equal(t, defaultInvCacheSize, c.size)
+	require.Equal(t, defaultInvCacheTTL, c.ttl)
+	require.Equal(t, defaultInvRequestTimeout, c.retry)
+	require.Equal(t, defaultInvCachePeerSize, size)
+	require.Equal(t, defaultInvCacheTTL, ttl)
+
+	c, size, ttl = newInvCaches(config.InventoryCache{Size: 10, PeerSize: 5, TTL: time.Second, RequestTimeout: time.Millisecond})
+	require.Equal(t, 10, c.size)
+	require.Equal(t, time.Second, c.ttl)
+	require.Equal(t, time.Millisecond, c.retry)
+	require.Equal(t, 5, size)
+	require.Equal(t, time.Second, ttl)
+}
+
+func TestServerPeerInventory(t *testing.T) {
+	s := startTestServer(t)
+	p := newLocalPeer(t, s)
+	p.handshaked = true
+
+	hs := []util.Uint256{random.Uint256(), random.Uint256()}
+	require.Nil(t, s.peerInventory(p))
+	require.False(t, s.peerKnowsAll(p, hs))
+
+	s.lock.Lock()
+	s.peers[p] = true
+	s.lock.Unlock()
+	s.testHandleMessage(t, p, CMDInv, &payload.Inventory{Type: payload.TXType, Hashes: hs})
+	require.True(t, s.peerKnowsAll(p, hs))
+	require.False(t, s.peerKnowsAll(p, append(hs, random.Uint256())))
+}
@@ -66,6 +66,7 @@ var defaultMessageHandler = func(t *testing.T, msg *Message) {}
 
 type localPeer struct {
 	netaddr        net.TCPAddr
+	inbound        bool
 	server         *Server
 	version        *payload.Version
 	lastBlockIndex uint32
@@ -94,6 +95,9 @@ func (p *localPeer) RemoteAddr() net.Addr {
 func (p *localPeer) PeerAddr() net.Addr {
 	return &p.netaddr
 }
+func (p *localPeer) IsInbound() bool {
+	return p.inbound
+}
 func (p *localPeer) StartProtocol() {}
 func (p *localPeer) Disconnect(err error) {
 	if p.droppedWith.Load() == nil {
@@ -139,7 +143,7 @@ func (p *localPeer) HandleVersion(v *payload.Version) error {
 	return nil
 }
 func (p *localPeer) SendVersion() error {
-	m, err := p.server.getVersionMsg()
+	m, err := p.server.getVersionMsg(nil)
 	if err != nil {
 		return err
 	}
@@ -154,6 +158,9 @@ func (p *localPeer) HandleVersionAck() error {
 	p.handshaked = true
 	return nil
 }
+func (p *localPeer) AuthChallenge() []byte {
+	return nil
+}
 func (p *localPeer) SendPing(m *Message) error {
 	p.pingSent++
 	_ = p.EnqueueMessage(m)
@@ -135,6 +135,8 @@ func (m *Message) decodePayload() error {
 	switch m.Command {
 	case CMDVersion:
 		p = &payload.Version{}
+	case CMDVerack:
+		p = &payload.Auth{}
 	case CMDInv, CMDGetData:
 		p = &payload.Inventory{}
 	case CMDAddr:
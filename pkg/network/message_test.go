@@ -254,6 +254,16 @@ func TestInvalidMessages(t *testing.T) {
 		require.NoError(t, w.Err)
 		require.Error(t, testserdes.Decode(w.Bytes(), &Message{}))
 	})
+	t.Run("short compressed payload", func(t *testing.T) {
+		for _, l := range []int{1, 3} {
+			w := io.NewBufBinWriter()
+			w.WriteB(byte(Compressed))
+			w.WriteB(byte(CMDTX))
+			w.WriteVarBytes(make([]byte, l))
+			require.NoError(t, w.Err)
+			require.Error(t, testserdes.Decode(w.Bytes(), &Message{}))
+		}
+	})
 	t.Run("fail to encode message if payload can't be serialized", func(t *testing.T) {
 		m := NewMessage(CMDBlock, failSer(true))
 		_, err := m.Bytes()
@@ -273,6 +283,48 @@ func TestInvalidMessages(t *testing.T) {
 	})
 }
 
+func TestDecodeMalformedMessages(t *testing.T) {
+	caps := capability.Capabilities{{Type: capability.TCPServer, Data: &capability.Server{Port: 20333}}}
+	msgs := []*Message{
+		NewMessage(CMDVersion, payload.NewVersion(netmode.UnitTestNet, 1, "/NEO-GO:/", caps)),
+		NewMessage(CMDAddr, &payload.AddressList{Addrs: []*payload.AddressAndTime{
+			{Timestamp: 1, Capabilities: caps},
+		}}),
+		NewMessage(CMDInv, payload.NewInventory(payload.TXType, []util.Uint256{random.Uint256()})),
+		NewMessage(CMDGetBlocks, payload.NewGetBlocks(random.Uint256(), 10)),
+		NewMessage(CMDGetBlockByIndex, payload.NewGetBlockByIndex(1, 10)),
+		NewMessage(CMDHeaders, &payload.Headers{Hdrs: []*block.Header{&newDummyBlock(1, 0).Header}}),
+		NewMessage(CMDMerkleBlock, &payload.MerkleBlock{
+			Header:  &newDummyBlock(1, 1).Header,
+			TxCount: 1,
+			Hashes:  []util.Uint256{random.Uint256()},
+			Flags:   []byte{1},
+		}),
+		NewMessage(CMDBlock, newDummyBlock(1, 2)),
+		NewMessage(CMDTX, newDummyTx()),
+		NewMessage(CMDPing, payload.NewPing(1, 2)),
+	}
+	for _, m := range msgs {
+		data, err := testserdes.Encode(m)
+		require.NoError(t, err)
+		require.NotPanics(t, func() {
+			for i := 0; i < len(data); i++ {
+				// Truncated message.
+				_ = testserdes.Decode(data[:i], &Message{})
+
+				// Corrupted byte.
+				for _, b := range []byte{0x00, 0x01, 0x7f, 0xfd, 0xfe, 0xff} {
+					corrupted := make([]byte, len(data))
+					copy(corrupted, data)
+					corrupted[i] = b
+					_ = testserdes.Decode(corrupted, &Message{})
+					_ = testserdes.Decode(corrupted, &Message{StateRootInHeader: true})
+				}
+			}
+		}, "command %s", m.Command)
+	}
+}
+
 type failSer bool
 
 func (f failSer) EncodeBinary(r *io.BinWriter) {
@@ -0,0 +1,122 @@
+/*
+Package nat implements automatic port forwarding on home routers via UPnP IGD
+and NAT-PMP protocols. It allows nodes behind NAT to accept inbound P2P
+connections and to learn their external address.
+*/
+package nat
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// Supported port mapping methods.
+const (
+	MethodAny  = "any"
+	MethodUPnP = "upnp"
+	MethodPMP  = "pmp"
+)
+
+// Interface is a NAT traversal mechanism able to map ports on the gateway.
+type Interface interface {
+	// ExternalIP returns external (public) address of the gateway.
+	ExternalIP() (net.IP, error)
+	// AddMapping maps external port of the gateway to the given internal
+	// port of this host for the given protocol ("tcp" or "udp"). Mapped
+	// external port is returned, it may differ from the one requested.
+	AddMapping(protocol string, extPort, intPort uint16, desc string, lifetime time.Duration) (uint16, error)
+	// DeleteMapping removes mapping created by AddMapping.
+	DeleteMapping(protocol string, extPort, intPort uint16) error
+	// String returns human-readable mechanism description.
+	String() string
+}
+
+// Discover finds NAT gateway using the specified method (MethodUPnP,
+// MethodPMP or MethodAny/empty string to try both). gateway is an optional
+// NAT-PMP gateway address, default route gateway is used if it's empty.
+func Discover(method string, gateway string, timeout time.Duration) (Interface, error) {
+	switch method {
+	case MethodUPnP:
+		u, err := discoverUPnP(timeout)
+		if err != nil {
+			return nil, err
+		}
+		return u, nil
+	case MethodPMP:
+		p, err := discoverPMP(gateway, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "", MethodAny:
+		u, err := discoverUPnP(timeout)
+		if err == nil {
+			return u, nil
+		}
+		p, perr := discoverPMP(gateway, timeout)
+		if perr == nil {
+			return p, nil
+		}
+		return nil, fmt.Errorf("no NAT gateway found: upnp: %v, pmp: %v", err, perr)
+	default:
+		return nil, fmt.Errorf("unknown NAT method %q", method)
+	}
+}
+
+// IsValidMethod checks whether the given method name is supported.
+func IsValidMethod(method string) bool {
+	switch method {
+	case "", MethodAny, MethodUPnP, MethodPMP:
+		return true
+	}
+	return false
+}
+
+// defaultGateway returns default route gateway address, it only works on
+// Linux (via /proc/net/route).
+func defaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, fmt.Errorf("can't determine default gateway: %w", err)
+	}
+	defer f.Close()
+	return parseRoutes(f)
+}
+
+// parseRoutes extracts default gateway address from the /proc/net/route
+// formatted data.
+func parseRoutes(r io.Reader) (net.IP, error) {
+	sc := bufio.NewScanner(r)
+	sc.Scan() // Header.
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		gw, err := hex.DecodeString(fields[2])
+		if err != nil || len(gw) != net.IPv4len {
+			continue
+		}
+		// Address is stored in host (little-endian) byte order.
+		return net.IPv4(gw[3], gw[2], gw[1], gw[0]), nil
+	}
+	return nil, errors.New("no default route found")
+}
+
+// localAddrFor returns local address used to communicate with the given
+// remote host.
+func localAddrFor(remote net.IP) (net.IP, error) {
+	conn, err := net.Dial("udp4", net.JoinHostPort(remote.String(), "1"))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
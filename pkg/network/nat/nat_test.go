@@ -0,0 +1,143 @@
+package nat
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoutes(t *testing.T) {
+	routes := `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	0000A8C0	00000000	0001	0	0	100	00FFFFFF	0	0	0
+eth0	00000000	0100A8C0	0003	0	0	100	00000000	0	0	0
+`
+	ip, err := parseRoutes(strings.NewReader(routes))
+	require.NoError(t, err)
+	require.Equal(t, "192.168.0.1", ip.String())
+
+	_, err = parseRoutes(strings.NewReader(strings.SplitN(routes, "\n", 3)[0]))
+	require.Error(t, err)
+}
+
+func TestPMP(t *testing.T) {
+	srv, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer srv.Close()
+
+	go func() {
+		buf := make([]byte, 16)
+		for {
+			n, from, err := srv.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			var resp []byte
+			switch {
+			case n == 2 && buf[1] == pmpOpExternal:
+				resp = []byte{0, pmpResponseBit, 0, 0, 0, 0, 0, 1, 203, 0, 113, 7}
+			case n == 12 && buf[1] == pmpOpMapTCP:
+				resp = make([]byte, 16)
+				resp[1] = pmpOpMapTCP | pmpResponseBit
+				copy(resp[8:], buf[4:6])
+				ext := binary.BigEndian.Uint16(buf[6:])
+				if ext != 0 {
+					ext++
+				}
+				binary.BigEndian.PutUint16(resp[10:], ext)
+				copy(resp[12:], buf[8:12])
+			case n == 12 && buf[1] == pmpOpMapUDP:
+				resp = []byte{0, pmpOpMapUDP | pmpResponseBit, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+			}
+			_, _ = srv.WriteToUDP(resp, from)
+		}
+	}()
+
+	p := &pmp{gw: srv.LocalAddr().(*net.UDPAddr)}
+	ip, err := p.ExternalIP()
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.7", ip.String())
+
+	port, err := p.AddMapping("tcp", 20333, 20333, "", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, uint16(20334), port)
+	require.NoError(t, p.DeleteMapping("tcp", port, 20333))
+
+	_, err = p.AddMapping("udp", 20333, 20333, "", time.Hour)
+	require.Error(t, err)
+	_, err = p.AddMapping("sctp", 20333, 20333, "", time.Hour)
+	require.Error(t, err)
+}
+
+func TestUPnP(t *testing.T) {
+	var lastAction string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/desc.xml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceList><device><deviceList><device>
+      <serviceList><service>
+        <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+        <controlURL>/ctl/IPConn</controlURL>
+      </service></serviceList>
+    </device></deviceList></device></deviceList>
+  </device>
+</root>`))
+	})
+	mux.HandleFunc("/ctl/IPConn", func(w http.ResponseWriter, r *http.Request) {
+		lastAction = r.Header.Get("SOAPAction")
+		body, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case strings.HasSuffix(lastAction, `#GetExternalIPAddress"`):
+			_, _ = w.Write([]byte(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>` +
+				`<u:GetExternalIPAddressResponse xmlns:u="urn:schemas-upnp-org:service:WANIPConnection:1">` +
+				`<NewExternalIPAddress>203.0.113.7</NewExternalIPAddress>` +
+				`</u:GetExternalIPAddressResponse></s:Body></s:Envelope>`))
+		case strings.Contains(string(body), "<NewExternalPort>1</NewExternalPort>"):
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><s:Fault>` +
+				`<detail><UPnPError xmlns="urn:schemas-upnp-org:control-1-0"><errorCode>718</errorCode>` +
+				`<errorDescription>ConflictInMappingEntry</errorDescription></UPnPError></detail>` +
+				`</s:Fault></s:Body></s:Envelope>`))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	u, err := newUPnP(srv.Client(), srv.URL+"/desc.xml")
+	require.NoError(t, err)
+	require.Equal(t, srv.URL+"/ctl/IPConn", u.controlURL)
+	u.localIP = net.IPv4(192, 168, 0, 2)
+
+	ip, err := u.ExternalIP()
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.7", ip.String())
+
+	port, err := u.AddMapping("tcp", 20333, 20333, "neo-go", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, uint16(20333), port)
+	require.Equal(t, `"urn:schemas-upnp-org:service:WANIPConnection:1#AddPortMapping"`, lastAction)
+
+	_, err = u.AddMapping("tcp", 1, 1, "neo-go", time.Hour)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "718")
+
+	require.NoError(t, u.DeleteMapping("tcp", 20333, 20333))
+
+	_, err = newUPnP(srv.Client(), srv.URL+"/missing.xml")
+	require.Error(t, err)
+}
+
+func TestDiscoverUnknownMethod(t *testing.T) {
+	_, err := Discover("magic", "", time.Second)
+	require.Error(t, err)
+	require.False(t, IsValidMethod("magic"))
+	require.True(t, IsValidMethod(MethodPMP))
+}
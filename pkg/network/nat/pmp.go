@@ -0,0 +1,172 @@
+package nat
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// NAT-PMP protocol constants, see RFC 6886.
+const (
+	pmpPort        = 5351
+	pmpVersion     = 0
+	pmpOpExternal  = 0
+	pmpOpMapUDP    = 1
+	pmpOpMapTCP    = 2
+	pmpResponseBit = 128
+	pmpRetries     = 4
+	pmpInitialWait = 250 * time.Millisecond
+)
+
+// pmpResultCodes are NAT-PMP result code descriptions.
+var pmpResultCodes = map[uint16]string{
+	1: "unsupported version",
+	2: "not authorized/refused",
+	3: "network failure",
+	4: "out of resources",
+	5: "unsupported opcode",
+}
+
+// pmp is a NAT-PMP client.
+type pmp struct {
+	gw *net.UDPAddr
+	// lock serializes requests as responses are not matched against them.
+	lock sync.Mutex
+}
+
+func discoverPMP(gateway string, timeout time.Duration) (*pmp, error) {
+	var ip net.IP
+	if gateway != "" {
+		ip = net.ParseIP(gateway)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid gateway address %q", gateway)
+		}
+	} else {
+		var err error
+		ip, err = defaultGateway()
+		if err != nil {
+			return nil, err
+		}
+	}
+	p := &pmp{gw: &net.UDPAddr{IP: ip, Port: pmpPort}}
+	if _, err := p.externalIP(timeout); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// String implements Interface.
+func (p *pmp) String() string {
+	return "NAT-PMP(" + p.gw.IP.String() + ")"
+}
+
+// ExternalIP implements Interface.
+func (p *pmp) ExternalIP() (net.IP, error) {
+	return p.externalIP(pmpInitialWait << pmpRetries)
+}
+
+func (p *pmp) externalIP(timeout time.Duration) (net.IP, error) {
+	resp, err := p.call([]byte{pmpVersion, pmpOpExternal}, 12, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(resp[8], resp[9], resp[10], resp[11]), nil
+}
+
+// AddMapping implements Interface.
+func (p *pmp) AddMapping(protocol string, extPort, intPort uint16, _ string, lifetime time.Duration) (uint16, error) {
+	op, err := pmpMapOp(protocol)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.call(pmpMapRequest(op, extPort, intPort, lifetime), 16, pmpInitialWait<<pmpRetries)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(resp[10:]), nil
+}
+
+// DeleteMapping implements Interface.
+func (p *pmp) DeleteMapping(protocol string, _, intPort uint16) error {
+	op, err := pmpMapOp(protocol)
+	if err != nil {
+		return err
+	}
+	// Zero lifetime and external port delete the mapping.
+	_, err = p.call(pmpMapRequest(op, 0, intPort, 0), 16, pmpInitialWait<<pmpRetries)
+	return err
+}
+
+func pmpMapOp(protocol string) (byte, error) {
+	switch protocol {
+	case "tcp":
+		return pmpOpMapTCP, nil
+	case "udp":
+		return pmpOpMapUDP, nil
+	default:
+		return 0, fmt.Errorf("unsupported protocol %q", protocol)
+	}
+}
+
+func pmpMapRequest(op byte, extPort, intPort uint16, lifetime time.Duration) []byte {
+	req := make([]byte, 12)
+	req[0] = pmpVersion
+	req[1] = op
+	binary.BigEndian.PutUint16(req[4:], intPort)
+	binary.BigEndian.PutUint16(req[6:], extPort)
+	binary.BigEndian.PutUint32(req[8:], uint32(lifetime/time.Second))
+	return req
+}
+
+// call sends the request to the gateway retransmitting it with exponentially
+// growing interval until the response of the given size is received or
+// timeout expires.
+func (p *pmp) call(req []byte, respSize int, timeout time.Duration) ([]byte, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	conn, err := net.DialUDP("udp", nil, p.gw)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var (
+		deadline = time.Now().Add(timeout)
+		wait     = pmpInitialWait
+		buf      = make([]byte, 16)
+	)
+	for time.Now().Before(deadline) {
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+		d := time.Now().Add(wait)
+		if d.After(deadline) {
+			d = deadline
+		}
+		_ = conn.SetReadDeadline(d)
+		n, err := conn.Read(buf)
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				wait *= 2
+				continue
+			}
+			return nil, err
+		}
+		if n < respSize || buf[0] != pmpVersion || buf[1] != req[1]|pmpResponseBit {
+			continue
+		}
+		if code := binary.BigEndian.Uint16(buf[2:]); code != 0 {
+			if desc, ok := pmpResultCodes[code]; ok {
+				return nil, fmt.Errorf("NAT-PMP error: %s", desc)
+			}
+			return nil, fmt.Errorf("NAT-PMP error: result code %d", code)
+		}
+		return buf[:n], nil
+	}
+	return nil, errors.New("NAT-PMP gateway doesn't respond")
+}
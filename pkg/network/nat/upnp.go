@@ -0,0 +1,255 @@
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ssdpAddr     = "239.255.255.250:1900"
+	upnpTimeout  = 5 * time.Second
+	maxUPnPReply = 1 << 20
+)
+
+// upnpServiceTypes are service types providing port mapping in the order of
+// preference.
+var upnpServiceTypes = []string{
+	"urn:schemas-upnp-org:service:WANIPConnection:2",
+	"urn:schemas-upnp-org:service:WANIPConnection:1",
+	"urn:schemas-upnp-org:service:WANPPPConnection:1",
+}
+
+// upnp is a UPnP IGD client.
+type upnp struct {
+	controlURL  string
+	serviceType string
+	localIP     net.IP
+	client      *http.Client
+}
+
+// upnpDevice is a part of UPnP device description we're interested in.
+type upnpDevice struct {
+	Services []upnpService `xml:"serviceList>service"`
+	Devices  []upnpDevice  `xml:"deviceList>device"`
+}
+
+type upnpService struct {
+	ServiceType string `xml:"serviceType"`
+	ControlURL  string `xml:"controlURL"`
+}
+
+type upnpRoot struct {
+	URLBase string     `xml:"URLBase"`
+	Device  upnpDevice `xml:"device"`
+}
+
+func discoverUPnP(timeout time.Duration) (*upnp, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	dst, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		return nil, err
+	}
+	req := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpAddr + "\r\n" +
+		"ST: urn:schemas-upnp-org:device:InternetGatewayDevice:1\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n\r\n"
+	if _, err := conn.WriteTo([]byte(req), dst); err != nil {
+		return nil, err
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+
+	var (
+		buf           = make([]byte, 2048)
+		lastErr error = errors.New("no UPnP gateway found")
+		client        = &http.Client{Timeout: upnpTimeout}
+	)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			return nil, lastErr
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		loc := resp.Header.Get("Location")
+		if loc == "" {
+			continue
+		}
+		u, err := newUPnP(client, loc)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		u.localIP, err = localAddrFor(from.(*net.UDPAddr).IP)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return u, nil
+	}
+}
+
+// newUPnP creates UPnP client from the device description located at the
+// given URL.
+func newUPnP(client *http.Client, location string) (*upnp, error) {
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device description: unexpected status %s", resp.Status)
+	}
+	var root upnpRoot
+	if err := xml.NewDecoder(http.MaxBytesReader(nil, resp.Body, maxUPnPReply)).Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid device description: %w", err)
+	}
+	base, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if root.URLBase != "" {
+		if base, err = url.Parse(root.URLBase); err != nil {
+			return nil, err
+		}
+	}
+	for _, st := range upnpServiceTypes {
+		if s := findService(&root.Device, st); s != nil {
+			ctrl, err := base.Parse(s.ControlURL)
+			if err != nil {
+				return nil, err
+			}
+			return &upnp{
+				controlURL:  ctrl.String(),
+				serviceType: s.ServiceType,
+				client:      client,
+			}, nil
+		}
+	}
+	return nil, errors.New("device doesn't provide WAN connection service")
+}
+
+func findService(d *upnpDevice, serviceType string) *upnpService {
+	for i := range d.Services {
+		if d.Services[i].ServiceType == serviceType {
+			return &d.Services[i]
+		}
+	}
+	for i := range d.Devices {
+		if s := findService(&d.Devices[i], serviceType); s != nil {
+			return s
+		}
+	}
+	return nil
+}
+
+// String implements Interface.
+func (u *upnp) String() string {
+	return "UPnP(" + u.controlURL + ")"
+}
+
+// ExternalIP implements Interface.
+func (u *upnp) ExternalIP() (net.IP, error) {
+	var resp struct {
+		IP string `xml:"Body>GetExternalIPAddressResponse>NewExternalIPAddress"`
+	}
+	if err := u.call("GetExternalIPAddress", nil, &resp); err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(resp.IP)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid external address %q", resp.IP)
+	}
+	return ip, nil
+}
+
+// AddMapping implements Interface.
+func (u *upnp) AddMapping(protocol string, extPort, intPort uint16, desc string, lifetime time.Duration) (uint16, error) {
+	err := u.call("AddPortMapping", [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(int(extPort))},
+		{"NewProtocol", strings.ToUpper(protocol)},
+		{"NewInternalPort", strconv.Itoa(int(intPort))},
+		{"NewInternalClient", u.localIP.String()},
+		{"NewEnabled", "1"},
+		{"NewPortMappingDescription", desc},
+		{"NewLeaseDuration", strconv.Itoa(int(lifetime / time.Second))},
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return extPort, nil
+}
+
+// DeleteMapping implements Interface.
+func (u *upnp) DeleteMapping(protocol string, extPort, _ uint16) error {
+	return u.call("DeletePortMapping", [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(int(extPort))},
+		{"NewProtocol", strings.ToUpper(protocol)},
+	}, nil)
+}
+
+// call performs SOAP action with the given (ordered) arguments and decodes
+// the response into res if it's not nil.
+func (u *upnp) call(action string, args [][2]string, res interface{}) error {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+		`<s:Body><u:` + action + ` xmlns:u="` + u.serviceType + `">`)
+	for _, a := range args {
+		body.WriteString("<" + a[0] + ">")
+		_ = xml.EscapeText(&body, []byte(a[1]))
+		body.WriteString("</" + a[0] + ">")
+	}
+	body.WriteString(`</u:` + action + `></s:Body></s:Envelope>`)
+
+	req, err := http.NewRequest(http.MethodPost, u.controlURL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", `"`+u.serviceType+"#"+action+`"`)
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(http.MaxBytesReader(nil, resp.Body, maxUPnPReply))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var fault struct {
+			Code        int    `xml:"Body>Fault>detail>UPnPError>errorCode"`
+			Description string `xml:"Body>Fault>detail>UPnPError>errorDescription"`
+		}
+		if xml.Unmarshal(data, &fault) == nil && fault.Code != 0 {
+			return fmt.Errorf("UPnP %s failed: %d %s", action, fault.Code, fault.Description)
+		}
+		return fmt.Errorf("UPnP %s failed: %s", action, resp.Status)
+	}
+	if res != nil {
+		if err := xml.Unmarshal(data, res); err != nil {
+			return fmt.Errorf("invalid UPnP %s response: %w", action, err)
+		}
+	}
+	return nil
+}
@@ -64,6 +64,21 @@ func (p *AddressAndTime) GetTCPAddress() (string, error) {
 	return net.JoinHostPort(netip.String(), strconv.Itoa(port)), nil
 }
 
+// GetWSAddress makes a WebSocket URL from IP and port specified in
+// WSCapability. It returns an error if there's no such capability.
+func (p *AddressAndTime) GetWSAddress() (string, error) {
+	var netip = make(net.IP, 16)
+
+	copy(netip, p.IP[:])
+	for _, cap := range p.Capabilities {
+		if cap.Type == capability.WSServer {
+			port := int(cap.Data.(*capability.Server).Port)
+			return "ws://" + net.JoinHostPort(netip.String(), strconv.Itoa(port)), nil
+		}
+	}
+	return "", errors.New("no WebSocket capability found")
+}
+
 // AddressList is a list with AddrAndTime.
 type AddressList struct {
 	Addrs []*AddressAndTime
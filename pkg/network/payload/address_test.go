@@ -91,3 +91,25 @@ func TestGetTCPAddress(t *testing.T) {
 		fmt.Println(s, err)
 	})
 }
+
+func TestGetWSAddress(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		p := &AddressAndTime{}
+		copy(p.IP[:], net.IPv4(1, 1, 1, 1))
+		p.Capabilities = append(p.Capabilities, capability.Capability{
+			Type: capability.TCPServer,
+			Data: &capability.Server{Port: 123},
+		}, capability.Capability{
+			Type: capability.WSServer,
+			Data: &capability.Server{Port: 124},
+		})
+		s, err := p.GetWSAddress()
+		require.NoError(t, err)
+		require.Equal(t, "ws://1.1.1.1:124", s)
+	})
+	t.Run("bad, no capability", func(t *testing.T) {
+		p := &AddressAndTime{}
+		_, err := p.GetWSAddress()
+		require.Error(t, err)
+	})
+}
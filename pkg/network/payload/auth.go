@@ -0,0 +1,23 @@
+package payload
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// Auth is the payload of version acknowledgement used for authenticated
+// handshakes. It contains the signature of the challenge received in the
+// version message proving possession of the node key.
+type Auth struct {
+	Signature [keys.SignatureLen]byte
+}
+
+// DecodeBinary implements Serializable interface.
+func (a *Auth) DecodeBinary(br *io.BinReader) {
+	br.ReadBytes(a.Signature[:])
+}
+
+// EncodeBinary implements Serializable interface.
+func (a *Auth) EncodeBinary(bw *io.BinWriter) {
+	bw.WriteBytes(a.Signature[:])
+}
@@ -0,0 +1,17 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthEncodeDecode(t *testing.T) {
+	a := &Auth{Signature: [64]byte{1, 2, 3}}
+	testserdes.EncodeDecodeBinary(t, a, new(Auth))
+
+	data, err := testserdes.EncodeBinary(a)
+	require.NoError(t, err)
+	require.Error(t, testserdes.DecodeBinary(data[:10], new(Auth)))
+}
@@ -0,0 +1,57 @@
+// +build gofuzz
+
+package payload
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// Entry points for go-fuzz (and oss-fuzz), each of them decodes arbitrary data
+// as a particular payload. Use -func to pick one of them when building, like:
+//
+//	go-fuzz-build -func FuzzVersion github.com/nspcc-dev/neo-go/pkg/network/payload
+
+// FuzzVersion decodes data as Version payload.
+func FuzzVersion(data []byte) int { return fuzzPayload(&Version{}, data) }
+
+// FuzzAddressList decodes data as AddressList payload.
+func FuzzAddressList(data []byte) int { return fuzzPayload(&AddressList{}, data) }
+
+// FuzzInventory decodes data as Inventory payload.
+func FuzzInventory(data []byte) int { return fuzzPayload(&Inventory{}, data) }
+
+// FuzzGetBlocks decodes data as GetBlocks payload.
+func FuzzGetBlocks(data []byte) int { return fuzzPayload(&GetBlocks{}, data) }
+
+// FuzzGetBlockByIndex decodes data as GetBlockByIndex payload.
+func FuzzGetBlockByIndex(data []byte) int { return fuzzPayload(&GetBlockByIndex{}, data) }
+
+// FuzzHeaders decodes data as Headers payload.
+func FuzzHeaders(data []byte) int { return fuzzPayload(&Headers{}, data) }
+
+// FuzzMerkleBlock decodes data as MerkleBlock payload.
+func FuzzMerkleBlock(data []byte) int { return fuzzPayload(&MerkleBlock{}, data) }
+
+// FuzzExtensible decodes data as Extensible payload.
+func FuzzExtensible(data []byte) int { return fuzzPayload(NewExtensible(), data) }
+
+// FuzzP2PNotaryRequest decodes data as P2PNotaryRequest payload.
+func FuzzP2PNotaryRequest(data []byte) int { return fuzzPayload(&P2PNotaryRequest{}, data) }
+
+// FuzzPing decodes data as Ping payload.
+func FuzzPing(data []byte) int { return fuzzPayload(&Ping{}, data) }
+
+// fuzzPayload decodes data into p returning 1 if it's decoded successfully and
+// there is no trailing data and 0 otherwise.
+func fuzzPayload(p io.Serializable, data []byte) int {
+	r := io.NewBinReaderFromBuf(data)
+	p.DecodeBinary(r)
+	if r.Err != nil {
+		return 0
+	}
+	_ = r.ReadB()
+	if r.Err == nil {
+		return 0
+	}
+	return 1
+}
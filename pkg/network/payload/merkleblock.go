@@ -21,14 +21,18 @@ func (m *MerkleBlock) DecodeBinary(br *io.BinReader) {
 	m.Header = &block.Header{}
 	m.Header.DecodeBinary(br)
 
-	txCount := int(br.ReadVarUint())
-	if txCount > block.MaxTransactionsPerBlock {
+	count := br.ReadVarUint()
+	if br.Err != nil {
+		return
+	}
+	if count > block.MaxTransactionsPerBlock {
 		br.Err = block.ErrMaxContentsPerBlock
 		return
 	}
+	txCount := int(count)
 	m.TxCount = txCount
 	br.ReadArray(&m.Hashes, m.TxCount)
-	if txCount != len(m.Hashes) {
+	if br.Err == nil && txCount != len(m.Hashes) {
 		br.Err = errors.New("invalid tx count")
 	}
 	m.Flags = br.ReadVarBytes((txCount + 7) / 8)
@@ -42,3 +46,11 @@ func (m *MerkleBlock) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteArray(m.Hashes)
 	bw.WriteVarBytes(m.Flags)
 }
+
+// SerializedSize implements io.Sizer interface, it must be defined for
+// MerkleBlock explicitly, otherwise Header.SerializedSize would be used.
+func (m *MerkleBlock) SerializedSize() int {
+	return m.Header.SerializedSize() + io.GetVarSize(m.TxCount) +
+		io.GetVarSize(len(m.Hashes)) + len(m.Hashes)*util.Uint256Size +
+		io.GetVarSize(m.Flags)
+}
@@ -2,6 +2,7 @@ package payload
 
 import (
 	"errors"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
@@ -28,6 +30,17 @@ func newDumbBlock() *block.Header {
 	}
 }
 
+func TestMerkleBlock_Size(t *testing.T) {
+	m := &MerkleBlock{
+		Header:  newDumbBlock(),
+		TxCount: 3,
+		Hashes:  []util.Uint256{{1}, {2}, {3}},
+		Flags:   []byte{5},
+	}
+	require.Equal(t, io.GetEncodedSize(m), m.SerializedSize())
+	require.Equal(t, m.SerializedSize(), io.GetVarSize(m))
+}
+
 func TestMerkleBlock_EncodeDecodeBinary(t *testing.T) {
 	t.Run("positive", func(t *testing.T) {
 		b := newDumbBlock()
@@ -55,6 +68,15 @@ func TestMerkleBlock_EncodeDecodeBinary(t *testing.T) {
 		require.True(t, errors.Is(block.ErrMaxContentsPerBlock, testserdes.DecodeBinary(data, new(MerkleBlock))))
 	})
 
+	t.Run("huge contents count", func(t *testing.T) {
+		b := newDumbBlock()
+		w := io.NewBufBinWriter()
+		b.EncodeBinary(w.BinWriter)
+		w.WriteVarUint(math.MaxUint64)
+		require.NoError(t, w.Err)
+		require.True(t, errors.Is(testserdes.DecodeBinary(w.Bytes(), new(MerkleBlock)), block.ErrMaxContentsPerBlock))
+	})
+
 	t.Run("bad flags size", func(t *testing.T) {
 		b := newDumbBlock()
 		_ = b.Hash()
@@ -5,8 +5,10 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVersionEncodeDecode(t *testing.T) {
@@ -16,6 +18,8 @@ func TestVersionEncodeDecode(t *testing.T) {
 	var id uint32 = 13337
 	useragent := "/NEO:0.0.1/"
 	var height uint32 = 100500
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
 	var capabilities = []capability.Capability{
 		{
 			Type: capability.TCPServer,
@@ -35,6 +39,13 @@ func TestVersionEncodeDecode(t *testing.T) {
 				StartHeight: height,
 			},
 		},
+		{
+			Type: capability.NodeKey,
+			Data: &capability.Key{
+				PublicKey: priv.PublicKey(),
+				Challenge: [capability.ChallengeSize]byte{1, 2, 3},
+			},
+		},
 	}
 
 	version := NewVersion(magic, id, useragent, capabilities)
@@ -46,3 +57,16 @@ func TestVersionEncodeDecode(t *testing.T) {
 	assert.Equal(t, versionDecoded.UserAgent, []byte(useragent))
 	assert.Equal(t, version, versionDecoded)
 }
+
+func TestVersionDecodeTooManyCapabilities(t *testing.T) {
+	caps := make([]capability.Capability, capability.MaxCapabilities+1)
+	for i := range caps {
+		caps[i] = capability.Capability{
+			Type: capability.FullNode,
+			Data: &capability.Node{StartHeight: uint32(i)},
+		}
+	}
+	data, err := testserdes.EncodeBinary(NewVersion(netmode.UnitTestNet, 1, "/NEO:0.0.1/", caps))
+	require.NoError(t, err)
+	require.Error(t, testserdes.DecodeBinary(data, new(Version)))
+}
@@ -17,6 +17,9 @@ type Peer interface {
 	// to connect to it. It's only valid after the handshake is completed,
 	// before that it returns the same address as RemoteAddr.
 	PeerAddr() net.Addr
+	// IsInbound returns true if the connection was initiated by the remote
+	// node and false if it was established by us.
+	IsInbound() bool
 	Disconnect(error)
 
 	// EnqueueMessage is a temporary wrapper that sends a message via
@@ -66,6 +69,9 @@ type Peer interface {
 	StartProtocol()
 	HandleVersion(*payload.Version) error
 	HandleVersionAck() error
+	// AuthChallenge returns the challenge sent to the peer in the version
+	// message for authenticated handshake (it's nil if they're disabled).
+	AuthChallenge() []byte
 
 	// HandlePing checks ping contents against Peer's state and updates it.
 	HandlePing(ping *payload.Ping) error
@@ -0,0 +1,100 @@
+package network
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultNATDiscoveryTimeout is the time spent looking for the NAT
+	// gateway if it's not configured.
+	defaultNATDiscoveryTimeout = 5 * time.Second
+	// natMappingLifetime is the lifetime of the port mapping requested
+	// from the gateway.
+	natMappingLifetime = 20 * time.Minute
+	// natRenewInterval is the interval between port mapping renewals.
+	natRenewInterval = natMappingLifetime / 2
+)
+
+// runPortMapping discovers NAT gateway, maps P2P port on it and keeps this
+// mapping alive until the server is shut down. Mapped external port is
+// announced to other nodes in the version message.
+func (s *Server) runPortMapping() {
+	timeout := s.NATCfg.DiscoveryTimeout
+	if timeout <= 0 {
+		timeout = defaultNATDiscoveryTimeout
+	}
+	gw, err := s.natDiscover(s.NATCfg.Method, s.NATCfg.Gateway, timeout)
+	if err != nil {
+		s.log.Warn("NAT gateway discovery failed", zap.Error(err))
+		return
+	}
+	intPort, err := s.natInternalPort()
+	if err != nil {
+		s.log.Warn("can't get P2P port for mapping", zap.Error(err))
+		return
+	}
+
+	extPort := intPort
+	mapPort := func() {
+		p, err := gw.AddMapping("tcp", extPort, intPort, "neo-go P2P", natMappingLifetime)
+		if err != nil {
+			s.log.Warn("failed to map P2P port",
+				zap.Stringer("gateway", gw),
+				zap.Uint16("port", intPort),
+				zap.Error(err))
+			return
+		}
+		extPort = p
+		s.natPort.Store(uint32(p))
+		ip, err := gw.ExternalIP()
+		if err != nil {
+			s.log.Info("P2P port mapped",
+				zap.Stringer("gateway", gw),
+				zap.Uint16("port", p),
+				zap.NamedError("externalIPError", err))
+			return
+		}
+		s.log.Info("P2P port mapped",
+			zap.Stringer("gateway", gw),
+			zap.String("externalAddress", net.JoinHostPort(ip.String(), strconv.Itoa(int(p)))))
+	}
+
+	mapPort()
+	t := time.NewTicker(natRenewInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.quit:
+			if s.natPort.Load() != 0 {
+				if err := gw.DeleteMapping("tcp", extPort, intPort); err != nil {
+					s.log.Warn("failed to delete P2P port mapping", zap.Error(err))
+				}
+			}
+			return
+		case <-t.C:
+			mapPort()
+		}
+	}
+}
+
+// natInternalPort returns the P2P port to be mapped on the NAT gateway. It's
+// the configured one, the port transport is bound to is only used if the
+// configured port is zero (random port is used by the node then).
+func (s *Server) natInternalPort() (uint16, error) {
+	if s.ServerConfig.Port != 0 {
+		return s.ServerConfig.Port, nil
+	}
+	port, err := s.listenPort()
+	if err != nil {
+		return 0, err
+	}
+	if port == 0 {
+		return 0, errors.New("P2P transport is not bound yet")
+	}
+	return port, nil
+}
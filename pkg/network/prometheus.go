@@ -38,6 +38,22 @@ var (
 			Namespace: "neogo",
 		},
 	)
+
+	invCacheSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of recently requested inventory hashes remembered",
+			Name:      "inventory_cache_size",
+			Namespace: "neogo",
+		},
+	)
+
+	invDuplicates = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of announced inventory hashes not requested because of recent requests",
+			Name:      "inventory_duplicates",
+			Namespace: "neogo",
+		},
+	)
 )
 
 func init() {
@@ -46,6 +62,8 @@ func init() {
 		servAndNodeVersion,
 		poolCount,
 		blockQueueLength,
+		invCacheSize,
+		invDuplicates,
 	)
 }
 
@@ -53,6 +71,11 @@ func updateBlockQueueLenMetric(bqLen int) {
 	blockQueueLength.Set(float64(bqLen))
 }
 
+func updateInvCacheMetrics(size int, dups int) {
+	invCacheSize.Set(float64(size))
+	invDuplicates.Add(float64(dups))
+}
+
 func updatePoolCountMetric(pCount int) {
 	poolCount.Set(float64(pCount))
 }
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -19,7 +20,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/nspcc-dev/neo-go/pkg/network/extpool"
+	"github.com/nspcc-dev/neo-go/pkg/network/nat"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/balances"
 	"github.com/nspcc-dev/neo-go/pkg/services/notary"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/stateroot"
@@ -43,6 +46,10 @@ var (
 	errIdenticalID      = errors.New("identical node id")
 	errInvalidNetwork   = errors.New("invalid network")
 	errMaxPeers         = errors.New("max peers reached")
+	errMaxPeersPerIP    = errors.New("max peers per IP reached")
+	errMaxPeersPerNet   = errors.New("max peers per subnet reached")
+	errMaxInbound       = errors.New("max inbound peers reached")
+	errMaxOutbound      = errors.New("max outbound peers reached")
 	errServerShutdown   = errors.New("server shutdown")
 	errInvalidInvType   = errors.New("invalid inventory type")
 )
@@ -61,6 +68,8 @@ type (
 		network netmode.Magic
 		// stateRootInHeader specifies if block header contain state root.
 		stateRootInHeader bool
+		// headersOnly specifies if node only synchronizes block headers.
+		headersOnly bool
 
 		transport         Transporter
 		discovery         Discoverer
@@ -74,6 +83,16 @@ type (
 
 		lock  sync.RWMutex
 		peers map[Peer]bool
+		// peerInv keeps inventory hashes recently announced by peers to
+		// avoid announcing them back.
+		peerInv map[Peer]*hashCache
+
+		// invRequested keeps inventory hashes recently requested from
+		// peers to avoid requesting them from several peers at once.
+		invRequested *hashCache
+		// peerInvSize and peerInvTTL are parameters of peerInv caches.
+		peerInvSize int
+		peerInvTTL  time.Duration
 
 		// lastRequestedHeight contains last requested height.
 		lastRequestedHeight atomic.Uint32
@@ -86,8 +105,22 @@ type (
 
 		syncReached *atomic.Bool
 
-		oracle    *oracle.Oracle
-		stateRoot stateroot.Service
+		oracle         *oracle.Oracle
+		stateRoot      stateroot.Service
+		balanceTracker *balances.Tracker
+
+		// auth is used for authenticated handshakes, nil if they're disabled.
+		auth *nodeAuth
+
+		// bans keeps misbehaving peers scores and bans, nil if banning is
+		// disabled.
+		bans *banList
+
+		// natPort is the external P2P port mapped on the NAT gateway, zero
+		// if there is no mapping.
+		natPort atomic.Uint32
+		// natDiscover looks for the NAT gateway, it's replaceable for tests.
+		natDiscover func(method, gateway string, timeout time.Duration) (nat.Interface, error)
 
 		log *zap.Logger
 	}
@@ -107,7 +140,14 @@ func randomID() uint32 {
 // NewServer returns a new Server, initialized with the given configuration.
 func NewServer(config ServerConfig, chain blockchainer.Blockchainer, log *zap.Logger) (*Server, error) {
 	return newServerFromConstructors(config, chain, log, func(s *Server) Transporter {
-		return NewTCPTransport(s, net.JoinHostPort(s.ServerConfig.Address, strconv.Itoa(int(s.ServerConfig.Port))), s.log)
+		var wsAddr string
+		if s.ServerConfig.WSPort != 0 {
+			wsAddr = net.JoinHostPort(s.ServerConfig.Address, strconv.Itoa(int(s.ServerConfig.WSPort)))
+		}
+		return &multiTransport{
+			tcp: NewTCPTransport(s, net.JoinHostPort(s.ServerConfig.Address, strconv.Itoa(int(s.ServerConfig.Port))), s.log),
+			ws:  NewWSTransport(s, wsAddr, s.log),
+		}
 	}, consensus.NewService, newDefaultDiscovery)
 }
 
@@ -132,14 +172,22 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 		id:                randomID(),
 		network:           chain.GetConfig().Magic,
 		stateRootInHeader: chain.GetConfig().StateRootInHeader,
+		headersOnly:       chain.GetConfig().HeadersOnly,
 		quit:              make(chan struct{}),
 		register:          make(chan Peer),
 		unregister:        make(chan peerDrop),
 		peers:             make(map[Peer]bool),
+		peerInv:           make(map[Peer]*hashCache),
 		syncReached:       atomic.NewBool(false),
 		extensiblePool:    extpool.New(chain, config.ExtensiblePoolSize),
 		log:               log,
 		transactions:      make(chan *transaction.Transaction, 64),
+		natDiscover:       nat.Discover,
+	}
+	s.invRequested, s.peerInvSize, s.peerInvTTL = newInvCaches(config.InventoryCacheCfg)
+	if s.headersOnly && (config.Wallet != nil || config.OracleCfg.Enabled ||
+		config.P2PNotaryCfg.Enabled || config.StateRootCfg.Enabled) {
+		return nil, errors.New("consensus, oracle, notary and state root services can't be used in headers-only mode")
 	}
 	if chain.P2PSigExtensionsEnabled() {
 		s.notaryFeer = NewNotaryFeer(chain)
@@ -170,6 +218,20 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 	} else if config.P2PNotaryCfg.Enabled {
 		return nil, errors.New("P2PSigExtensions are disabled, but Notary service is enable")
 	}
+	if config.P2PAuthCfg.Enabled {
+		a, err := newNodeAuth(config.P2PAuthCfg)
+		if err != nil {
+			return nil, fmt.Errorf("can't initialize P2P authentication: %w", err)
+		}
+		s.auth = a
+	}
+	if !config.PeerBanCfg.Disabled {
+		b, err := newBanList(config.PeerBanCfg)
+		if err != nil {
+			return nil, fmt.Errorf("can't initialize peer ban list: %w", err)
+		}
+		s.bans = b
+	}
 	s.bQueue = newBlockQueue(maxBlockBatch, chain, log, func(b *block.Block) {
 		if !s.syncReached.Load() {
 			s.tryStartServices()
@@ -208,8 +270,16 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 		chain.SetOracle(orc)
 	}
 
+	if config.BalanceTrackerCfg.Enabled {
+		bt, err := balances.New(config.BalanceTrackerCfg, chain, log)
+		if err != nil {
+			return nil, fmt.Errorf("can't initialize balance tracker: %w", err)
+		}
+		s.balanceTracker = bt
+	}
+
 	srv, err := newConsensus(consensus.Config{
-		Logger:                log,
+		Logger:                log.Named("consensus"),
 		Broadcast:             s.handleNewPayload,
 		Chain:                 chain,
 		ProtocolConfiguration: chain.GetConfig(),
@@ -266,6 +336,9 @@ func (s *Server) Start(errChan chan error) {
 		zap.Uint32("blockHeight", s.chain.BlockHeight()),
 		zap.Uint32("headerHeight", s.chain.HeaderHeight()))
 
+	if s.balanceTracker != nil {
+		s.balanceTracker.Start()
+	}
 	s.tryStartServices()
 	s.initStaleMemPools()
 
@@ -273,6 +346,9 @@ func (s *Server) Start(errChan chan error) {
 	go s.relayBlocksLoop()
 	go s.bQueue.run()
 	go s.transport.Accept()
+	if s.NATCfg.Enabled {
+		go s.runPortMapping()
+	}
 	setServerAndNodeVersions(s.UserAgent, strconv.FormatUint(uint64(s.id), 10))
 	s.run()
 }
@@ -293,6 +369,9 @@ func (s *Server) Shutdown() {
 	if s.oracle != nil {
 		s.oracle.Shutdown()
 	}
+	if s.balanceTracker != nil {
+		s.balanceTracker.Shutdown()
+	}
 	if s.notaryModule != nil {
 		s.notaryModule.Stop()
 		s.notaryRequestPool.StopSubscriptions()
@@ -300,11 +379,67 @@ func (s *Server) Shutdown() {
 	close(s.quit)
 }
 
+// UpdateConsensusWallet makes consensus service use the wallet specified by
+// the given configuration starting with the next block (see
+// consensus.Service.UpdateWallet). It can only be used if the node was
+// started with consensus enabled.
+func (s *Server) UpdateConsensusWallet(w *config.Wallet) error {
+	if s.Wallet == nil {
+		return errors.New("consensus is not enabled")
+	}
+	return s.consensus.UpdateWallet(w)
+}
+
+// GetConsensusState returns the current consensus process state (see
+// consensus.Service.GetState). It can only be used if the node was started
+// with consensus enabled.
+func (s *Server) GetConsensusState() (*consensus.State, error) {
+	if s.Wallet == nil {
+		return nil, errors.New("consensus is not enabled")
+	}
+	return s.consensus.GetState()
+}
+
+// ReloadConfig updates runtime-tunable parameters of the server (peer limits
+// and quotas, relaying) with the ones from the given configuration, changes
+// of other parameters require restart. Existing connections are not affected,
+// new limits are applied to the new ones.
+func (s *Server) ReloadConfig(cfg ServerConfig) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if cfg.MinPeers >= 0 {
+		s.MinPeers = cfg.MinPeers
+	}
+	if cfg.MaxPeers > 0 {
+		s.MaxPeers = cfg.MaxPeers
+	}
+	if cfg.AttemptConnPeers > 0 {
+		s.AttemptConnPeers = cfg.AttemptConnPeers
+	}
+	s.MaxPeersPerIP = cfg.MaxPeersPerIP
+	s.MaxPeersPerSubnet = cfg.MaxPeersPerSubnet
+	s.MaxInboundPeers = cfg.MaxInboundPeers
+	s.MaxOutboundPeers = cfg.MaxOutboundPeers
+	s.Relay = cfg.Relay
+}
+
 // GetOracle returns oracle module instance.
 func (s *Server) GetOracle() *oracle.Oracle {
 	return s.oracle
 }
 
+// GetBalanceTracker returns balance tracker service instance (nil if it's
+// disabled).
+func (s *Server) GetBalanceTracker() *balances.Tracker {
+	return s.balanceTracker
+}
+
+// GetNotaryPool returns P2PNotaryRequest payload pool (nil if
+// P2PSigExtensions are disabled).
+func (s *Server) GetNotaryPool() *mempool.Pool {
+	return s.notaryRequestPool
+}
+
 // GetStateRoot returns state root service instance.
 func (s *Server) GetStateRoot() stateroot.Service {
 	return s.stateRoot
@@ -321,6 +456,15 @@ func (s *Server) BadPeers() []string {
 	return s.discovery.BadPeers()
 }
 
+// BannedPeers returns IP addresses of peers banned for misbehavior along with
+// the time their bans expire.
+func (s *Server) BannedPeers() map[string]time.Time {
+	if s.bans == nil {
+		return nil
+	}
+	return s.bans.banned()
+}
+
 // ConnectedPeers returns a list of currently connected peers.
 func (s *Server) ConnectedPeers() []string {
 	s.lock.RLock()
@@ -339,8 +483,13 @@ func (s *Server) ConnectedPeers() []string {
 func (s *Server) run() {
 	go s.runProto()
 	for {
-		if s.PeerCount() < s.MinPeers {
-			s.discovery.RequestRemote(s.AttemptConnPeers)
+		s.lock.RLock()
+		minPeers, maxPeers := s.MinPeers, s.MaxPeers
+		s.lock.RUnlock()
+		if s.PeerCount() < minPeers {
+			if n := s.outboundSlots(); n > 0 {
+				s.discovery.RequestRemote(n)
+			}
 		}
 		if s.discovery.PoolCount() < minPoolCount {
 			s.broadcastHPMessage(NewMessage(CMDGetAddr, payload.NewNullPayload()))
@@ -350,11 +499,18 @@ func (s *Server) run() {
 			return
 		case p := <-s.register:
 			s.lock.Lock()
+			rejectErr := s.checkPeerQuotas(p)
+			if s.isBanned(p) {
+				rejectErr = errBanned
+			}
 			s.peers[p] = true
 			s.lock.Unlock()
 			peerCount := s.PeerCount()
 			s.log.Info("new peer connected", zap.Stringer("addr", p.RemoteAddr()), zap.Int("peerCount", peerCount))
-			if peerCount > s.MaxPeers {
+			if rejectErr != nil {
+				// It will send us unregister signal.
+				go p.Disconnect(rejectErr)
+			} else if peerCount > maxPeers {
 				s.lock.RLock()
 				// Pick a random peer and drop connection to it.
 				for peer := range s.peers {
@@ -370,14 +526,20 @@ func (s *Server) run() {
 			s.lock.Lock()
 			if s.peers[drop.peer] {
 				delete(s.peers, drop.peer)
+				delete(s.peerInv, drop.peer)
 				s.lock.Unlock()
+				s.invRequested.removePeer(drop.peer)
 				s.log.Warn("peer disconnected",
 					zap.Stringer("addr", drop.peer.RemoteAddr()),
 					zap.String("reason", drop.reason.Error()),
 					zap.Int("peerCount", s.PeerCount()))
 				addr := drop.peer.PeerAddr().String()
-				if drop.reason == errIdenticalID {
+				if drop.reason == errIdenticalID || s.penalize(drop.peer, drop.reason) {
 					s.discovery.RegisterBadAddr(addr)
+				} else if isQuotaError(drop.reason) {
+					// Not a peer problem, but there is no point in
+					// reconnecting to it right now.
+					s.discovery.UnregisterConnectedAddr(addr)
 				} else if drop.reason == errAlreadyConnected {
 					// There is a race condition when peer can be disconnected twice for the this reason
 					// which can lead to no connections to peer at all. Here we check for such a possibility.
@@ -413,16 +575,151 @@ func (s *Server) run() {
 	}
 }
 
+// outboundSlots returns the number of new outbound connections to request
+// from discoverer taking MaxOutboundPeers limit into account.
+func (s *Server) outboundSlots() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.MaxOutboundPeers <= 0 {
+		return s.AttemptConnPeers
+	}
+	var outbound int
+	for p := range s.peers {
+		if !p.IsInbound() {
+			outbound++
+		}
+	}
+	n := s.MaxOutboundPeers - outbound
+	if n > s.AttemptConnPeers {
+		n = s.AttemptConnPeers
+	}
+	return n
+}
+
+// peerSubnet returns the /24 (IPv4) or /64 (IPv6) subnet of the given IP.
+func peerSubnet(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return ip.Mask(net.CIDRMask(64, 128)).String()
+}
+
+// peerIP returns the IP address of the peer or nil if it can't be determined.
+func peerIP(p Peer) net.IP {
+	addr := p.RemoteAddr()
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		return tcp.IP
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
+
+// checkPeerQuotas checks whether the new peer fits into per-IP, per-subnet,
+// inbound and outbound limits given already connected peers. It must be
+// called with s.lock held.
+func (s *Server) checkPeerQuotas(p Peer) error {
+	var (
+		ip                = peerIP(p)
+		subnet            string
+		sameIP, sameNet   int
+		inbound, outbound int
+	)
+	if ip != nil {
+		subnet = peerSubnet(ip)
+	}
+	for peer := range s.peers {
+		if peer.IsInbound() {
+			inbound++
+		} else {
+			outbound++
+		}
+		if ip == nil {
+			continue
+		}
+		if peerIP := peerIP(peer); peerIP != nil {
+			if peerIP.Equal(ip) {
+				sameIP++
+			}
+			if peerSubnet(peerIP) == subnet {
+				sameNet++
+			}
+		}
+	}
+	switch {
+	case s.MaxPeersPerIP > 0 && sameIP >= s.MaxPeersPerIP:
+		return errMaxPeersPerIP
+	case s.MaxPeersPerSubnet > 0 && sameNet >= s.MaxPeersPerSubnet:
+		return errMaxPeersPerNet
+	case p.IsInbound() && s.MaxInboundPeers > 0 && inbound >= s.MaxInboundPeers:
+		return errMaxInbound
+	case !p.IsInbound() && s.MaxOutboundPeers > 0 && outbound >= s.MaxOutboundPeers:
+		return errMaxOutbound
+	}
+	return nil
+}
+
+// isQuotaError returns true if the error is a result of checkPeerQuotas.
+func isQuotaError(err error) bool {
+	return err == errMaxPeersPerIP || err == errMaxPeersPerNet ||
+		err == errMaxInbound || err == errMaxOutbound
+}
+
+// isBanned returns true if the peer's IP address is banned.
+func (s *Server) isBanned(p Peer) bool {
+	if s.bans == nil {
+		return false
+	}
+	ip := peerIP(p)
+	return ip != nil && s.bans.isBanned(ip.String())
+}
+
+// penalize accounts for the peer misbehavior that caused its disconnection
+// and returns true if the peer is banned.
+func (s *Server) penalize(p Peer, reason error) bool {
+	if s.bans == nil {
+		return false
+	}
+	if errors.Is(reason, errBanned) {
+		return true
+	}
+	points := misbehaviorScore(reason)
+	ip := peerIP(p)
+	if points == 0 || ip == nil {
+		return false
+	}
+	banned, err := s.bans.misbehave(ip.String(), points)
+	if err != nil {
+		s.log.Warn("can't store banned peers", zap.Error(err))
+	}
+	if banned {
+		s.log.Warn("peer banned",
+			zap.Stringer("ip", ip),
+			zap.Duration("duration", s.bans.duration))
+	}
+	return banned
+}
+
+// handshakeTimeout returns the time a peer can spend doing the handshake.
+func (s *Server) handshakeTimeout() time.Duration {
+	if s.PeerBanCfg.HandshakeTimeout > 0 {
+		return s.PeerBanCfg.HandshakeTimeout
+	}
+	return defaultHandshakeTimeout
+}
+
 // runProto is a goroutine that manages server-wide protocol events.
 func (s *Server) runProto() {
 	pingTimer := time.NewTimer(s.PingInterval)
 	for {
-		prevHeight := s.chain.BlockHeight()
+		prevHeight := s.syncHeight()
 		select {
 		case <-s.quit:
 			return
 		case <-pingTimer.C:
-			if s.chain.BlockHeight() == prevHeight {
+			if s.syncHeight() == prevHeight {
 				// Get a copy of s.peers to avoid holding a lock while sending.
 				for peer := range s.Peers() {
 					_ = peer.SendPing(NewMessage(CMDPing, payload.NewPing(s.id, s.chain.HeaderHeight())))
@@ -494,8 +791,9 @@ func (s *Server) HandshakedPeersCount() int {
 	return count
 }
 
-// getVersionMsg returns current version message.
-func (s *Server) getVersionMsg() (*Message, error) {
+// getVersionMsg returns current version message, challenge is only used for
+// authenticated handshakes.
+func (s *Server) getVersionMsg(challenge []byte) (*Message, error) {
 	port, err := s.Port()
 	if err != nil {
 		return nil, err
@@ -509,7 +807,18 @@ func (s *Server) getVersionMsg() (*Message, error) {
 			},
 		},
 	}
-	if s.Relay {
+	if s.WSPort != 0 {
+		capabilities = append(capabilities, capability.Capability{
+			Type: capability.WSServer,
+			Data: &capability.Server{
+				Port: s.WSPort,
+			},
+		})
+	}
+	s.lock.RLock()
+	relay := s.Relay
+	s.lock.RUnlock()
+	if relay {
 		capabilities = append(capabilities, capability.Capability{
 			Type: capability.FullNode,
 			Data: &capability.Node{
@@ -517,6 +826,9 @@ func (s *Server) getVersionMsg() (*Message, error) {
 			},
 		})
 	}
+	if s.auth != nil {
+		capabilities = append(capabilities, s.auth.capability(challenge))
+	}
 	payload := payload.NewVersion(
 		s.Net,
 		s.id,
@@ -537,11 +849,14 @@ func (s *Server) IsInSync() bool {
 	var peersNumber int
 	var notHigher int
 
-	if s.MinPeers == 0 {
+	s.lock.RLock()
+	minPeers := s.MinPeers
+	s.lock.RUnlock()
+	if minPeers == 0 {
 		return true
 	}
 
-	ourLastBlock := s.chain.BlockHeight()
+	ourLastBlock := s.syncHeight()
 
 	s.lock.RLock()
 	for p := range s.peers {
@@ -556,7 +871,7 @@ func (s *Server) IsInSync() bool {
 
 	// Checking bQueue would also be nice, but it can be filled with garbage
 	// easily at the moment.
-	return peersNumber >= s.MinPeers && (3*notHigher > 2*peersNumber) // && s.bQueue.length() == 0
+	return peersNumber >= minPeers && (3*notHigher > 2*peersNumber) // && s.bQueue.length() == 0
 }
 
 // When a peer sends out his version we reply with verack after validating
@@ -574,6 +889,11 @@ func (s *Server) handleVersionCmd(p Peer, version *payload.Version) error {
 	if s.Net != version.Magic {
 		return errInvalidNetwork
 	}
+	if s.auth != nil {
+		if err := s.auth.checkVersion(version); err != nil {
+			return err
+		}
+	}
 	peerAddr := p.PeerAddr().String()
 	s.discovery.RegisterConnectedAddr(peerAddr)
 	s.lock.RLock()
@@ -589,21 +909,45 @@ func (s *Server) handleVersionCmd(p Peer, version *payload.Version) error {
 		}
 	}
 	s.lock.RUnlock()
+	if s.auth != nil {
+		return p.SendVersionAck(NewMessage(CMDVerack, s.auth.sign(s.Net, version)))
+	}
 	return p.SendVersionAck(NewMessage(CMDVerack, payload.NewNullPayload()))
 }
 
 // handleBlockCmd processes the received block received from its peer.
 func (s *Server) handleBlockCmd(p Peer, block *block.Block) error {
+	if s.headersOnly {
+		return nil
+	}
 	return s.bQueue.putBlock(block)
 }
 
+// handleHeadersCmd processes the headers received from its peer and requests
+// more of them if needed.
+func (s *Server) handleHeadersCmd(p Peer, h *payload.Headers) error {
+	if !s.headersOnly {
+		return nil
+	}
+	if err := s.chain.AddHeaders(h.Hdrs...); err != nil {
+		return err
+	}
+	if !s.syncReached.Load() {
+		s.tryStartServices()
+	}
+	if s.chain.HeaderHeight() < p.LastBlockIndex() {
+		return s.requestHeaders(p)
+	}
+	return nil
+}
+
 // handlePing processes ping request.
 func (s *Server) handlePing(p Peer, ping *payload.Ping) error {
 	err := p.HandlePing(ping)
 	if err != nil {
 		return err
 	}
-	if s.chain.BlockHeight() < ping.LastBlockIndex {
+	if s.syncHeight() < ping.LastBlockIndex {
 		err = s.requestBlocks(p)
 		if err != nil {
 			return err
@@ -618,7 +962,7 @@ func (s *Server) handlePong(p Peer, pong *payload.Ping) error {
 	if err != nil {
 		return err
 	}
-	if s.chain.BlockHeight() < pong.LastBlockIndex {
+	if s.syncHeight() < pong.LastBlockIndex {
 		return s.requestBlocks(p)
 	}
 	return nil
@@ -626,6 +970,14 @@ func (s *Server) handlePong(p Peer, pong *payload.Ping) error {
 
 // handleInvCmd processes the received inventory.
 func (s *Server) handleInvCmd(p Peer, inv *payload.Inventory) error {
+	if s.headersOnly {
+		switch inv.Type {
+		case payload.TXType:
+			return nil
+		case payload.BlockType:
+			return s.requestHeaders(p)
+		}
+	}
 	reqHashes := make([]util.Uint256, 0)
 	var typExists = map[payload.InventoryType]func(util.Uint256) bool{
 		payload.TXType:    s.chain.HasTransaction,
@@ -639,11 +991,24 @@ func (s *Server) handleInvCmd(p Peer, inv *payload.Inventory) error {
 		},
 	}
 	if exists := typExists[inv.Type]; exists != nil {
+		var (
+			known = s.peerInventory(p)
+			dups  int
+		)
 		for _, hash := range inv.Hashes {
-			if !exists(hash) {
-				reqHashes = append(reqHashes, hash)
+			known.add(hash)
+			if exists(hash) {
+				continue
 			}
+			// Extensible payloads are cheap and time-sensitive (consensus
+			// messages mostly), so they're requested from every announcer.
+			if inv.Type != payload.ExtensibleType && !s.invRequested.request(hash, p) {
+				dups++
+				continue
+			}
+			reqHashes = append(reqHashes, hash)
 		}
+		updateInvCacheMetrics(s.invRequested.len(), dups)
 	}
 	if len(reqHashes) > 0 {
 		msg := NewMessage(CMDGetData, payload.NewInventory(inv.Type, reqHashes))
@@ -659,6 +1024,39 @@ func (s *Server) handleInvCmd(p Peer, inv *payload.Inventory) error {
 	return nil
 }
 
+// peerInventory returns the cache of hashes announced by the given peer, it's
+// nil for unregistered peers.
+func (s *Server) peerInventory(p Peer) *hashCache {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.peers[p] {
+		return nil
+	}
+	c := s.peerInv[p]
+	if c == nil {
+		c = newHashCache(s.peerInvSize, s.peerInvTTL)
+		s.peerInv[p] = c
+	}
+	return c
+}
+
+// peerKnowsAll returns true if all of the given hashes were recently
+// announced by the peer.
+func (s *Server) peerKnowsAll(p Peer, hs []util.Uint256) bool {
+	s.lock.RLock()
+	c := s.peerInv[p]
+	s.lock.RUnlock()
+	if c == nil {
+		return false
+	}
+	for _, h := range hs {
+		if !c.has(h) {
+			return false
+		}
+	}
+	return true
+}
+
 // handleMempoolCmd handles getmempool command.
 func (s *Server) handleMempoolCmd(p Peer) error {
 	txs := s.chain.GetMemPool().GetVerifiedTransactions()
@@ -912,6 +1310,11 @@ func (s *Server) handleAddrCmd(p Peer, addrs *payload.AddressList) error {
 	dups := make(map[string]bool)
 	for _, a := range addrs.Addrs {
 		addr, err := a.GetTCPAddress()
+		if err != nil {
+			// Peers accepting only WebSocket connections can be
+			// dialed via WebSocket.
+			addr, err = a.GetWSAddress()
+		}
 		if err == nil && !dups[addr] {
 			dups[addr] = true
 			s.discovery.BackFill(addr)
@@ -947,6 +1350,9 @@ func (s *Server) handleGetAddrCmd(p Peer) error {
 // 2. Send requests for chunk in increasing order.
 // 3. After all requests were sent, request random height.
 func (s *Server) requestBlocks(p Peer) error {
+	if s.headersOnly {
+		return s.requestHeaders(p)
+	}
 	var currHeight = s.chain.BlockHeight()
 	var peerHeight = p.LastBlockIndex()
 	var needHeight uint32
@@ -976,6 +1382,22 @@ func (s *Server) requestBlocks(p Peer) error {
 	return p.EnqueueP2PMessage(NewMessage(CMDGetBlockByIndex, payload))
 }
 
+// requestHeaders sends a CMDGetHeaders message to the peer to sync up in
+// headers (used in headers-only mode instead of block requests).
+func (s *Server) requestHeaders(p Peer) error {
+	pl := payload.NewGetBlockByIndex(s.chain.HeaderHeight()+1, -1)
+	return p.EnqueueP2PMessage(NewMessage(CMDGetHeaders, pl))
+}
+
+// syncHeight returns the height of the synchronized part of the chain, that
+// is header height in headers-only mode and block height otherwise.
+func (s *Server) syncHeight() uint32 {
+	if s.headersOnly {
+		return s.chain.HeaderHeight()
+	}
+	return s.chain.BlockHeight()
+}
+
 // handleMessage processes the given message.
 func (s *Server) handleMessage(peer Peer, msg *Message) error {
 	s.log.Debug("got msg",
@@ -1007,6 +1429,9 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 		case CMDGetHeaders:
 			gh := msg.Payload.(*payload.GetBlockByIndex)
 			return s.handleGetHeadersCmd(peer, gh)
+		case CMDHeaders:
+			h := msg.Payload.(*payload.Headers)
+			return s.handleHeadersCmd(peer, h)
 		case CMDInv:
 			inventory := msg.Payload.(*payload.Inventory)
 			return s.handleInvCmd(peer, inventory)
@@ -1032,7 +1457,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 			pong := msg.Payload.(*payload.Ping)
 			return s.handlePong(peer, pong)
 		case CMDVersion, CMDVerack:
-			return fmt.Errorf("received '%s' after the handshake", msg.Command.String())
+			return fmt.Errorf("%w: received '%s' after the handshake", errProtocolViolation, msg.Command.String())
 		}
 	} else {
 		switch msg.Command {
@@ -1040,6 +1465,12 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 			version := msg.Payload.(*payload.Version)
 			return s.handleVersionCmd(peer, version)
 		case CMDVerack:
+			if s.auth != nil {
+				err := s.auth.verify(s.Net, peer.Version(), peer.AuthChallenge(), msg.Payload)
+				if err != nil {
+					return err
+				}
+			}
 			err := peer.HandleVersionAck()
 			if err != nil {
 				return err
@@ -1048,7 +1479,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 
 			s.tryStartServices()
 		default:
-			return fmt.Errorf("received '%s' during handshake", msg.Command.String())
+			return fmt.Errorf("%w: received '%s' during handshake", errProtocolViolation, msg.Command.String())
 		}
 	}
 	return nil
@@ -1170,11 +1601,12 @@ func (s *Server) relayBlocksLoop() {
 			s.chain.UnsubscribeFromBlocks(ch)
 			return
 		case b := <-ch:
-			msg := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}))
+			hs := []util.Uint256{b.Hash()}
+			msg := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, hs))
 			// Filter out nodes that are more current (avoid spamming the network
-			// during initial sync).
+			// during initial sync) or have announced this block to us.
 			s.iteratePeersWithSendMsg(msg, Peer.EnqueuePacket, func(p Peer) bool {
-				return p.Handshaked() && p.LastBlockIndex() < b.Index
+				return p.Handshaked() && p.LastBlockIndex() < b.Index && !s.peerKnowsAll(p, hs)
 			})
 			s.extensiblePool.RemoveStale(b.Index)
 		}
@@ -1207,9 +1639,12 @@ func (s *Server) broadcastTX(t *transaction.Transaction, _ interface{}) {
 func (s *Server) broadcastTxHashes(hs []util.Uint256) {
 	msg := NewMessage(CMDInv, payload.NewInventory(payload.TXType, hs))
 
-	// We need to filter out non-relaying nodes, so plain broadcast
-	// functions don't fit here.
-	s.iteratePeersWithSendMsg(msg, Peer.EnqueuePacket, Peer.IsFullNode)
+	// We need to filter out non-relaying nodes and the ones that have
+	// announced these transactions to us, so plain broadcast functions
+	// don't fit here.
+	s.iteratePeersWithSendMsg(msg, Peer.EnqueuePacket, func(p Peer) bool {
+		return p.IsFullNode() && !s.peerKnowsAll(p, hs)
+	})
 }
 
 // initStaleMemPools initializes mempools for stale tx/payload processing.
@@ -1285,11 +1720,21 @@ func (s *Server) broadcastTxLoop() {
 // Port returns a server port that should be used in P2P version exchange. In
 // case if `AnnouncedPort` is set in the server.Config, the announced node port
 // will be returned (e.g. consider the node running behind NAT). If `AnnouncedPort`
-// isn't set, the port returned may still differs from that of server.Config.
+// isn't set, but the port is mapped on the NAT gateway, the external mapped
+// port is returned. Otherwise the port returned may still differs from that of
+// server.Config.
 func (s *Server) Port() (uint16, error) {
 	if s.AnnouncedPort != 0 {
 		return s.ServerConfig.AnnouncedPort, nil
 	}
+	if p := s.natPort.Load(); p != 0 {
+		return uint16(p), nil
+	}
+	return s.listenPort()
+}
+
+// listenPort returns the port P2P transport is bound to.
+func (s *Server) listenPort() (uint16, error) {
 	var port uint16
 	_, portStr, err := net.SplitHostPort(s.transport.Address())
 	if err != nil {
@@ -25,6 +25,22 @@ type (
 		// be connected to the server.
 		MaxPeers int
 
+		// MaxPeersPerIP is the maximum number of peers connected from
+		// the same IP address, 0 means no limit.
+		MaxPeersPerIP int
+
+		// MaxPeersPerSubnet is the maximum number of peers connected from
+		// the same /24 (IPv4) or /64 (IPv6) subnet, 0 means no limit.
+		MaxPeersPerSubnet int
+
+		// MaxInboundPeers is the maximum number of peers that connected
+		// to us, 0 means no limit (other than MaxPeers).
+		MaxInboundPeers int
+
+		// MaxOutboundPeers is the maximum number of peers we've connected
+		// to, 0 means no limit (other than MaxPeers).
+		MaxOutboundPeers int
+
 		// The user agent of the server.
 		UserAgent string
 
@@ -37,6 +53,10 @@ type (
 		// Port is the actual node port it is bound to. Example: 20332.
 		Port uint16
 
+		// WSPort is the port P2P connections over WebSocket are accepted
+		// on, 0 means they're not accepted.
+		WSPort uint16
+
 		// The network mode the server will operate on.
 		// ModePrivNet docker private network.
 		// ModeTestNet NEO test network.
@@ -76,9 +96,24 @@ type (
 		// P2PNotaryCfg is notary module configuration.
 		P2PNotaryCfg config.P2PNotary
 
+		// P2PAuthCfg is authenticated handshake configuration.
+		P2PAuthCfg config.P2PAuth
+
 		// StateRootCfg is stateroot module configuration.
 		StateRootCfg config.StateRoot
 
+		// BalanceTrackerCfg is balance tracker service configuration.
+		BalanceTrackerCfg config.BalanceTracker
+
+		// NATCfg is NAT port mapping configuration.
+		NATCfg config.NAT
+
+		// PeerBanCfg is misbehaving peers banning configuration.
+		PeerBanCfg config.PeerBan
+
+		// InventoryCacheCfg is inventory hashes caching configuration.
+		InventoryCacheCfg config.InventoryCache
+
 		// ExtensiblePoolSize is size of the pool for extensible payloads from a single sender.
 		ExtensiblePoolSize int
 	}
@@ -100,6 +135,7 @@ func NewServerConfig(cfg config.Config) ServerConfig {
 		Address:            appConfig.Address,
 		AnnouncedPort:      appConfig.AnnouncedNodePort,
 		Port:               appConfig.NodePort,
+		WSPort:             appConfig.NodeWSPort,
 		Net:                protoConfig.Magic,
 		Relay:              appConfig.Relay,
 		Seeds:              protoConfig.SeedList,
@@ -110,11 +146,20 @@ func NewServerConfig(cfg config.Config) ServerConfig {
 		MaxPeers:           appConfig.MaxPeers,
 		AttemptConnPeers:   appConfig.AttemptConnPeers,
 		MinPeers:           appConfig.MinPeers,
+		MaxPeersPerIP:      appConfig.MaxPeersPerIP,
+		MaxPeersPerSubnet:  appConfig.MaxPeersPerSubnet,
+		MaxInboundPeers:    appConfig.MaxInboundPeers,
+		MaxOutboundPeers:   appConfig.MaxOutboundPeers,
 		Wallet:             wc,
 		TimePerBlock:       time.Duration(protoConfig.SecondsPerBlock) * time.Second,
 		OracleCfg:          appConfig.Oracle,
 		P2PNotaryCfg:       appConfig.P2PNotary,
+		P2PAuthCfg:         appConfig.P2PAuth,
 		StateRootCfg:       appConfig.StateRoot,
+		BalanceTrackerCfg:  appConfig.BalanceTracker,
+		NATCfg:             appConfig.NAT,
+		PeerBanCfg:         appConfig.PeerBan,
+		InventoryCacheCfg:  appConfig.InventoryCache,
 		ExtensiblePoolSize: appConfig.ExtensiblePoolSize,
 	}
 }
@@ -2,6 +2,7 @@ package network
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"net"
 	"strconv"
@@ -18,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/nat"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -32,6 +34,7 @@ type fakeConsensus struct {
 	stopped  atomic.Bool
 	payloads []*payload.Extensible
 	txs      []*transaction.Transaction
+	wallet   *config.Wallet
 }
 
 var _ consensus.Service = (*fakeConsensus)(nil)
@@ -44,6 +47,8 @@ func (f *fakeConsensus) Shutdown()                                     { f.stopp
 func (f *fakeConsensus) OnPayload(p *payload.Extensible)               { f.payloads = append(f.payloads, p) }
 func (f *fakeConsensus) OnTransaction(tx *transaction.Transaction)     { f.txs = append(f.txs, tx) }
 func (f *fakeConsensus) GetPayload(h util.Uint256) *payload.Extensible { panic("implement me") }
+func (f *fakeConsensus) UpdateWallet(w *config.Wallet) error           { f.wallet = w; return nil }
+func (f *fakeConsensus) GetState() (*consensus.State, error)           { return new(consensus.State), nil }
 
 func TestNewServer(t *testing.T) {
 	bc := &fakechain.FakeChain{}
@@ -126,6 +131,128 @@ func TestServerStartAndShutdown(t *testing.T) {
 	})
 }
 
+type fakeNAT struct {
+	mapped  atomic.Uint32
+	deleted atomic.Bool
+}
+
+func (f *fakeNAT) ExternalIP() (net.IP, error) { return net.IPv4(203, 0, 113, 7), nil }
+func (f *fakeNAT) AddMapping(_ string, ext, _ uint16, _ string, _ time.Duration) (uint16, error) {
+	f.mapped.Store(uint32(ext) + 1)
+	return ext + 1, nil
+}
+func (f *fakeNAT) DeleteMapping(string, uint16, uint16) error { f.deleted.Store(true); return nil }
+func (f *fakeNAT) String() string                             { return "fake" }
+
+func TestServerPortMapping(t *testing.T) {
+	t.Run("discovery failure", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{Port: 20333, NATCfg: config.NAT{Enabled: true}})
+		s.natDiscover = func(string, string, time.Duration) (nat.Interface, error) {
+			return nil, errors.New("no gateway")
+		}
+		s.runPortMapping()
+		port, err := s.Port()
+		require.NoError(t, err)
+		require.Equal(t, uint16(20333), port)
+	})
+	t.Run("mapped", func(t *testing.T) {
+		gw := new(fakeNAT)
+		s := newTestServer(t, ServerConfig{Port: 20333, NATCfg: config.NAT{Enabled: true}})
+		s.natDiscover = func(string, string, time.Duration) (nat.Interface, error) {
+			return gw, nil
+		}
+
+		ch := startWithChannel(s)
+		require.Eventually(t, func() bool { return gw.mapped.Load() != 0 }, time.Second, time.Millisecond*10)
+		port, err := s.Port()
+		require.NoError(t, err)
+		require.Equal(t, uint16(20334), port)
+
+		s.AnnouncedPort = 30333
+		port, err = s.Port()
+		require.NoError(t, err)
+		require.Equal(t, uint16(30333), port)
+
+		s.Shutdown()
+		<-ch
+		require.Eventually(t, gw.deleted.Load, time.Second, time.Millisecond*10)
+	})
+}
+
+func TestServerUpdateConsensusWallet(t *testing.T) {
+	w := &config.Wallet{Path: "wallet.json"}
+	t.Run("no consensus", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{})
+		require.Error(t, s.UpdateConsensusWallet(w))
+		require.Nil(t, s.consensus.(*fakeConsensus).wallet)
+	})
+	t.Run("with consensus", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{Wallet: new(config.Wallet)})
+		require.NoError(t, s.UpdateConsensusWallet(w))
+		require.Equal(t, w, s.consensus.(*fakeConsensus).wallet)
+	})
+}
+
+func TestServerPeerQuotas(t *testing.T) {
+	newPeer := func(s *Server, ip string, inbound bool) *localPeer {
+		p := newLocalPeer(t, s)
+		p.netaddr.IP = net.ParseIP(ip)
+		p.inbound = inbound
+		return p
+	}
+	s := newTestServer(t, ServerConfig{
+		MaxPeersPerIP:     2,
+		MaxPeersPerSubnet: 3,
+		MaxInboundPeers:   4,
+		MaxOutboundPeers:  2,
+		AttemptConnPeers:  5,
+	})
+	require.Equal(t, 2, s.outboundSlots())
+	for _, p := range []*localPeer{
+		newPeer(s, "10.0.0.1", true),
+		newPeer(s, "10.0.0.1", false),
+		newPeer(s, "10.0.0.2", true),
+		newPeer(s, "192.168.0.1", true),
+	} {
+		require.NoError(t, s.checkPeerQuotas(p))
+		s.peers[p] = true
+	}
+	require.Equal(t, 1, s.outboundSlots())
+
+	require.Equal(t, errMaxPeersPerIP, s.checkPeerQuotas(newPeer(s, "10.0.0.1", true)))
+	require.Equal(t, errMaxPeersPerNet, s.checkPeerQuotas(newPeer(s, "10.0.0.3", true)))
+	require.NoError(t, s.checkPeerQuotas(newPeer(s, "10.0.1.1", false)))
+	require.NoError(t, s.checkPeerQuotas(newPeer(s, "2001:db8::1", true)))
+
+	s.peers[newPeer(s, "172.16.0.1", true)] = true
+	require.Equal(t, errMaxInbound, s.checkPeerQuotas(newPeer(s, "172.16.1.1", true)))
+	s.peers[newPeer(s, "172.17.0.1", false)] = true
+	require.Equal(t, errMaxOutbound, s.checkPeerQuotas(newPeer(s, "172.18.0.1", false)))
+	require.Equal(t, 0, s.outboundSlots())
+}
+
+func TestServerReloadConfig(t *testing.T) {
+	s := newTestServer(t, ServerConfig{MaxPeers: 10, MinPeers: 3, Relay: true})
+	s.ReloadConfig(ServerConfig{
+		MaxPeers:         20,
+		MinPeers:         -1,
+		MaxPeersPerIP:    1,
+		MaxOutboundPeers: 1,
+	})
+	require.Equal(t, 20, s.MaxPeers)
+	require.Equal(t, 3, s.MinPeers)
+	require.Equal(t, 1, s.MaxPeersPerIP)
+	require.Equal(t, 1, s.outboundSlots())
+	require.False(t, s.Relay)
+
+	p := newLocalPeer(t, s)
+	p.netaddr.IP = net.ParseIP("10.0.0.1")
+	s.peers[p] = true
+	p2 := newLocalPeer(t, s)
+	p2.netaddr.IP = net.ParseIP("10.0.0.1")
+	require.Equal(t, errMaxPeersPerIP, s.checkPeerQuotas(p2))
+}
+
 func TestServerRegisterPeer(t *testing.T) {
 	const peerCount = 3
 
@@ -180,6 +307,40 @@ func TestServerRegisterPeer(t *testing.T) {
 	}, time.Second, time.Millisecond*50)
 }
 
+func TestServerBanPeer(t *testing.T) {
+	s := newTestServer(t, ServerConfig{PeerBanCfg: config.PeerBan{BanScore: 100}})
+	ch := startWithChannel(s)
+	t.Cleanup(func() {
+		s.Shutdown()
+		<-ch
+	})
+
+	newPeer := func(port int) *localPeer {
+		p := newLocalPeer(t, s)
+		p.netaddr.IP = net.ParseIP("10.0.0.1")
+		p.netaddr.Port = port
+		s.register <- p
+		return p
+	}
+	for i := 1; i <= 2; i++ {
+		p := newPeer(i)
+		require.Eventually(t, func() bool {
+			s.lock.RLock()
+			defer s.lock.RUnlock()
+			return s.peers[p]
+		}, time.Second, time.Millisecond*10)
+		require.Nil(t, p.droppedWith.Load())
+		p.Disconnect(fmt.Errorf("%w: test", errMalformedMessage))
+	}
+	require.Eventually(t, func() bool { return len(s.BannedPeers()) == 1 }, time.Second, time.Millisecond*10)
+	require.Contains(t, s.BannedPeers(), "10.0.0.1")
+
+	// Banned peer is dropped right after registration.
+	p := newPeer(3)
+	require.Eventually(t, func() bool { return p.droppedWith.Load() != nil }, time.Second, time.Millisecond*10)
+	require.True(t, errors.Is(p.droppedWith.Load().(error), errBanned))
+}
+
 func TestGetBlocksByIndex(t *testing.T) {
 	s := newTestServer(t, ServerConfig{Port: 0, UserAgent: "/test/"})
 	ps := make([]*localPeer, 10)
@@ -669,6 +830,52 @@ func TestGetHeaders(t *testing.T) {
 	})
 }
 
+func TestHeadersOnly(t *testing.T) {
+	chain := fakechain.NewFakeChain()
+	chain.HeadersOnly = true
+
+	_, err := newServerFromConstructors(ServerConfig{Wallet: new(config.Wallet)}, chain,
+		zaptest.NewLogger(t), newFakeTransp, newFakeConsensus, newTestDiscovery)
+	require.Error(t, err)
+
+	s, err := newServerFromConstructors(ServerConfig{}, chain, zaptest.NewLogger(t),
+		newFakeTransp, newFakeConsensus, newTestDiscovery)
+	require.NoError(t, err)
+	t.Cleanup(s.discovery.Close)
+
+	var actual *payload.GetBlockByIndex
+	p := newLocalPeer(t, s)
+	p.handshaked = true
+	p.lastBlockIndex = 10
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		if msg.Command == CMDGetHeaders {
+			actual = msg.Payload.(*payload.GetBlockByIndex)
+		}
+	}
+
+	t.Run("block", func(t *testing.T) {
+		s.testHandleMessage(t, p, CMDBlock, newDummyBlock(1, 0))
+		require.Equal(t, uint32(0), chain.BlockHeight())
+	})
+	t.Run("headers", func(t *testing.T) {
+		actual = nil
+		b1, b2 := newDummyBlock(1, 0), newDummyBlock(2, 0)
+		s.testHandleMessage(t, p, CMDHeaders, &payload.Headers{Hdrs: []*block.Header{&b1.Header, &b2.Header}})
+		require.Equal(t, uint32(2), chain.HeaderHeight())
+		require.NotNil(t, actual)
+		require.Equal(t, uint32(3), actual.IndexStart)
+	})
+	t.Run("block inventory", func(t *testing.T) {
+		actual = nil
+		s.testHandleMessage(t, p, CMDInv, &payload.Inventory{
+			Type:   payload.BlockType,
+			Hashes: []util.Uint256{random.Uint256()},
+		})
+		require.NotNil(t, actual)
+		require.Equal(t, uint32(3), actual.IndexStart)
+	})
+}
+
 func TestInv(t *testing.T) {
 	s := startTestServer(t)
 	s.chain.(*fakechain.FakeChain).UtilityTokenBalance = big.NewInt(10000000)
@@ -701,6 +908,56 @@ func TestInv(t *testing.T) {
 			Hashes: hs,
 		})
 		require.Equal(t, []util.Uint256{hs[0], hs[2]}, actual)
+
+		t.Run("duplicate", func(t *testing.T) {
+			actual = nil
+			s.testHandleMessage(t, p, CMDInv, &payload.Inventory{
+				Type:   payload.TXType,
+				Hashes: hs,
+			})
+			require.Nil(t, actual)
+
+			newHash := random.Uint256()
+			s.testHandleMessage(t, p, CMDInv, &payload.Inventory{
+				Type:   payload.TXType,
+				Hashes: append(hs, newHash),
+			})
+			require.Equal(t, []util.Uint256{newHash}, actual)
+		})
+		t.Run("retry", func(t *testing.T) {
+			var p2Actual []util.Uint256
+			p2 := newLocalPeer(t, s)
+			p2.handshaked = true
+			p2.messageHandler = func(t *testing.T, msg *Message) {
+				if msg.Command == CMDGetData {
+					p2Actual = msg.Payload.(*payload.Inventory).Hashes
+				}
+			}
+			hs := []util.Uint256{random.Uint256()}
+			actual = nil
+			s.testHandleMessage(t, p, CMDInv, &payload.Inventory{Type: payload.TXType, Hashes: hs})
+			require.Equal(t, hs, actual)
+
+			// Still in flight.
+			s.testHandleMessage(t, p2, CMDInv, &payload.Inventory{Type: payload.TXType, Hashes: hs})
+			require.Nil(t, p2Actual)
+
+			now := time.Now().Add(defaultInvRequestTimeout)
+			s.invRequested.now = func() time.Time { return now }
+			t.Cleanup(func() { s.invRequested.now = time.Now })
+			s.testHandleMessage(t, p2, CMDInv, &payload.Inventory{Type: payload.TXType, Hashes: hs})
+			require.Equal(t, hs, p2Actual)
+
+			t.Run("peer drop", func(t *testing.T) {
+				actual = nil
+				s.testHandleMessage(t, p, CMDInv, &payload.Inventory{Type: payload.TXType, Hashes: hs})
+				require.Nil(t, actual)
+
+				s.invRequested.removePeer(p2)
+				s.testHandleMessage(t, p, CMDInv, &payload.Inventory{Type: payload.TXType, Hashes: hs})
+				require.Equal(t, hs, actual)
+			})
+		})
 	})
 	t.Run("extensible", func(t *testing.T) {
 		ep := payload.NewExtensible()
@@ -713,6 +970,18 @@ func TestInv(t *testing.T) {
 			Type:   payload.ExtensibleType,
 			Hashes: []util.Uint256{ep.Hash()},
 		})
+
+		t.Run("not deduplicated", func(t *testing.T) {
+			hs := []util.Uint256{random.Uint256()}
+			for i := 0; i < 2; i++ {
+				actual = nil
+				s.testHandleMessage(t, p, CMDInv, &payload.Inventory{
+					Type:   payload.ExtensibleType,
+					Hashes: hs,
+				})
+				require.Equal(t, hs, actual)
+			}
+		})
 	})
 	t.Run("p2pNotaryRequest", func(t *testing.T) {
 		fallbackTx := transaction.New(random.Bytes(100), 123)
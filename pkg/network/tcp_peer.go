@@ -43,8 +43,12 @@ type TCPPeer struct {
 	conn net.Conn
 	// The server this peer belongs to.
 	server *Server
+	// Whether the connection was accepted by us.
+	inbound bool
 	// The version of the peer.
 	version *payload.Version
+	// challenge is the challenge sent to the peer for authenticated handshake.
+	challenge []byte
 	// Index of the last block.
 	lastBlockIndex uint32
 
@@ -66,11 +70,13 @@ type TCPPeer struct {
 	pingTimer *time.Timer
 }
 
-// NewTCPPeer returns a TCPPeer structure based on the given connection.
-func NewTCPPeer(conn net.Conn, s *Server) *TCPPeer {
+// NewTCPPeer returns a TCPPeer structure based on the given connection,
+// inbound is true for accepted connections.
+func NewTCPPeer(conn net.Conn, s *Server, inbound bool) *TCPPeer {
 	return &TCPPeer{
 		conn:     conn,
 		server:   s,
+		inbound:  inbound,
 		done:     make(chan struct{}),
 		sendQ:    make(chan []byte, requestQueueSize),
 		p2pSendQ: make(chan []byte, p2pMsgQueueSize),
@@ -157,6 +163,13 @@ func (p *TCPPeer) handleConn() {
 
 	p.server.register <- p
 
+	handshakeTimer := time.AfterFunc(p.server.handshakeTimeout(), func() {
+		if !p.Handshaked() {
+			p.Disconnect(errHandshakeTimeout)
+		}
+	})
+	defer handshakeTimer.Stop()
+
 	go p.handleQueues()
 	// When a new peer is connected we send out our version immediately.
 	err = p.SendVersion()
@@ -170,6 +183,11 @@ func (p *TCPPeer) handleConn() {
 				p.server.log.Warn("not all headers were processed")
 				r.Err = nil
 			} else if err != nil {
+				// Connection errors are not peer's fault, but
+				// anything else is a garbage it has sent.
+				if r.Err == nil {
+					err = fmt.Errorf("%w: %v", errMalformedMessage, err)
+				}
 				break
 			}
 			if err = p.server.handleMessage(p, msg); err != nil {
@@ -304,7 +322,11 @@ func (p *TCPPeer) IsFullNode() bool {
 
 // SendVersion checks for the handshake state and sends a message to the peer.
 func (p *TCPPeer) SendVersion() error {
-	msg, err := p.server.getVersionMsg()
+	var challenge []byte
+	if p.server.auth != nil {
+		challenge = newAuthChallenge()
+	}
+	msg, err := p.server.getVersionMsg(challenge)
 	if err != nil {
 		return err
 	}
@@ -316,10 +338,18 @@ func (p *TCPPeer) SendVersion() error {
 	err = p.writeMsg(msg)
 	if err == nil {
 		p.handShake |= versionSent
+		p.challenge = challenge
 	}
 	return err
 }
 
+// AuthChallenge implements the Peer interface.
+func (p *TCPPeer) AuthChallenge() []byte {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.challenge
+}
+
 // HandleVersion checks for the handshake state and version message contents.
 func (p *TCPPeer) HandleVersion(version *payload.Version) error {
 	p.lock.Lock()
@@ -383,6 +413,11 @@ func (p *TCPPeer) RemoteAddr() net.Addr {
 	return p.conn.RemoteAddr()
 }
 
+// IsInbound implements the Peer interface.
+func (p *TCPPeer) IsInbound() bool {
+	return p.inbound
+}
+
 // PeerAddr implements the Peer interface.
 func (p *TCPPeer) PeerAddr() net.Addr {
 	remote := p.conn.RemoteAddr()
@@ -394,13 +429,20 @@ func (p *TCPPeer) PeerAddr() net.Addr {
 	if err != nil {
 		return p.RemoteAddr()
 	}
-	var port uint16
+	var port, wsPort uint16
 	for _, cap := range p.version.Capabilities {
-		if cap.Type == capability.TCPServer {
+		switch cap.Type {
+		case capability.TCPServer:
 			port = cap.Data.(*capability.Server).Port
+		case capability.WSServer:
+			wsPort = cap.Data.(*capability.Server).Port
 		}
 	}
 	if port == 0 {
+		if wsPort != 0 {
+			// The peer can only be dialed via WebSocket.
+			return wsAddr(wsScheme + net.JoinHostPort(host, strconv.Itoa(int(wsPort))))
+		}
 		return p.RemoteAddr()
 	}
 	addrString := net.JoinHostPort(host, strconv.Itoa(int(port)))
@@ -36,11 +36,20 @@ func (t *TCPTransport) Dial(addr string, timeout time.Duration) error {
 	if err != nil {
 		return err
 	}
-	p := NewTCPPeer(conn, t.server)
+	p := NewTCPPeer(conn, t.server, false)
 	go p.handleConn()
 	return nil
 }
 
+// Probe implements the Transporter interface.
+func (t *TCPTransport) Probe(addr string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 // Accept implements the Transporter interface.
 func (t *TCPTransport) Accept() {
 	l, err := net.Listen("tcp", t.bindAddr)
@@ -62,7 +71,7 @@ func (t *TCPTransport) Accept() {
 			}
 			continue
 		}
-		p := NewTCPPeer(conn, t.server)
+		p := NewTCPPeer(conn, t.server, true)
 		go p.handleConn()
 	}
 }
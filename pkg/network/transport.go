@@ -6,6 +6,9 @@ import "time"
 // any form of communication between the server and its peers.
 type Transporter interface {
 	Dial(addr string, timeout time.Duration) error
+	// Probe checks whether there is anyone listening on the given address
+	// without establishing a peer connection.
+	Probe(addr string, timeout time.Duration) error
 	Accept()
 	Proto() string
 	Address() string
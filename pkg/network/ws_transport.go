@@ -0,0 +1,285 @@
+package network
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"go.uber.org/zap"
+)
+
+// WebSocket address schemes, addresses without them are dialed via TCP.
+const (
+	wsScheme  = "ws://"
+	wssScheme = "wss://"
+)
+
+// wsReadLimit is the maximum size of incoming WebSocket message, every
+// message contains a single P2P message which has 2 bytes of flags and
+// command, up to 9 bytes of payload length and the payload itself.
+const wsReadLimit = 2 + 9 + payload.MaxSize
+
+// WSTransport allows network communication over WebSocket connections, which
+// can pass through HTTP proxies and load balancers not allowing raw TCP. Every
+// P2P message is sent as a separate binary WebSocket message.
+type WSTransport struct {
+	log      *zap.Logger
+	server   *Server
+	bindAddr string
+	lock     sync.RWMutex
+	listener net.Listener
+	http     *http.Server
+}
+
+// NewWSTransport returns a new WSTransport that will listen for new incoming
+// peer connections on the given address. It only dials peers if bindAddr is
+// empty.
+func NewWSTransport(s *Server, bindAddr string, log *zap.Logger) *WSTransport {
+	return &WSTransport{
+		log:      log,
+		server:   s,
+		bindAddr: bindAddr,
+	}
+}
+
+// isWSAddress checks whether the address should be dialed via WebSocket.
+func isWSAddress(addr string) bool {
+	return strings.HasPrefix(addr, wsScheme) || strings.HasPrefix(addr, wssScheme)
+}
+
+// Dial implements the Transporter interface. Address can be given either as
+// ws:// (wss://) URL or as host:port pair.
+func (t *WSTransport) Dial(addr string, timeout time.Duration) error {
+	if !isWSAddress(addr) {
+		addr = wsScheme + addr
+	}
+	dialer := websocket.Dialer{HandshakeTimeout: timeout}
+	ws, _, err := dialer.Dial(addr, nil)
+	if err != nil {
+		return err
+	}
+	p := NewTCPPeer(newWSConn(ws), t.server, false)
+	go p.handleConn()
+	return nil
+}
+
+// Probe implements the Transporter interface, it makes a WebSocket handshake
+// with the given address.
+func (t *WSTransport) Probe(addr string, timeout time.Duration) error {
+	if !isWSAddress(addr) {
+		addr = wsScheme + addr
+	}
+	dialer := websocket.Dialer{HandshakeTimeout: timeout}
+	ws, _, err := dialer.Dial(addr, nil)
+	if err != nil {
+		return err
+	}
+	return ws.Close()
+}
+
+// Accept implements the Transporter interface.
+func (t *WSTransport) Accept() {
+	if t.bindAddr == "" {
+		return
+	}
+	l, err := net.Listen("tcp", t.bindAddr)
+	if err != nil {
+		t.log.Panic("WebSocket listen error", zap.Error(err))
+		return
+	}
+	upgrader := websocket.Upgrader{
+		// Peers are not browsers, so there is no need to check origin.
+		CheckOrigin: func(*http.Request) bool { return true },
+	}
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ws, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.log.Warn("WebSocket upgrade error", zap.Error(err))
+				return
+			}
+			p := NewTCPPeer(newWSConn(ws), t.server, true)
+			go p.handleConn()
+		}),
+	}
+
+	t.lock.Lock()
+	t.listener = l
+	t.http = srv
+	t.lock.Unlock()
+
+	err = srv.Serve(l)
+	if err != http.ErrServerClosed {
+		t.log.Warn("WebSocket accept error", zap.Error(err))
+	}
+}
+
+// Close implements the Transporter interface.
+func (t *WSTransport) Close() {
+	t.lock.Lock()
+	if t.http != nil {
+		t.http.Close()
+	}
+	t.lock.Unlock()
+}
+
+// Proto implements the Transporter interface.
+func (t *WSTransport) Proto() string {
+	return "ws"
+}
+
+// Address implements the Transporter interface.
+func (t *WSTransport) Address() string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	if t.listener != nil {
+		return t.listener.Addr().String()
+	}
+	return ""
+}
+
+// wsConn is a net.Conn over WebSocket connection, it allows to use the same
+// peer implementation for both TCP and WebSocket transports.
+type wsConn struct {
+	ws *websocket.Conn
+	// reader is the reader of the current incoming message.
+	reader io.Reader
+	// wLock protects from concurrent writes not supported by websocket.Conn.
+	wLock sync.Mutex
+}
+
+func newWSConn(ws *websocket.Conn) *wsConn {
+	ws.SetReadLimit(wsReadLimit)
+	return &wsConn{ws: ws}
+}
+
+// Read implements net.Conn interface, incoming messages are read as a stream.
+func (c *wsConn) Read(b []byte) (int, error) {
+	for {
+		if c.reader == nil {
+			typ, r, err := c.ws.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			if typ != websocket.BinaryMessage {
+				continue
+			}
+			c.reader = r
+		}
+		n, err := c.reader.Read(b)
+		if err == io.EOF {
+			c.reader = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+// Write implements net.Conn interface, every call sends a separate message.
+func (c *wsConn) Write(b []byte) (int, error) {
+	c.wLock.Lock()
+	defer c.wLock.Unlock()
+	if err := c.ws.WriteMessage(websocket.BinaryMessage, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close implements net.Conn interface.
+func (c *wsConn) Close() error {
+	return c.ws.Close()
+}
+
+// LocalAddr implements net.Conn interface.
+func (c *wsConn) LocalAddr() net.Addr {
+	return c.ws.LocalAddr()
+}
+
+// RemoteAddr implements net.Conn interface.
+func (c *wsConn) RemoteAddr() net.Addr {
+	return c.ws.RemoteAddr()
+}
+
+// SetDeadline implements net.Conn interface.
+func (c *wsConn) SetDeadline(t time.Time) error {
+	if err := c.ws.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.ws.SetWriteDeadline(t)
+}
+
+// SetReadDeadline implements net.Conn interface.
+func (c *wsConn) SetReadDeadline(t time.Time) error {
+	return c.ws.SetReadDeadline(t)
+}
+
+// SetWriteDeadline implements net.Conn interface.
+func (c *wsConn) SetWriteDeadline(t time.Time) error {
+	return c.ws.SetWriteDeadline(t)
+}
+
+// wsAddr is the address of the peer accepting WebSocket connections.
+type wsAddr string
+
+// Network implements net.Addr interface.
+func (a wsAddr) Network() string {
+	return "ws"
+}
+
+// String implements net.Addr interface.
+func (a wsAddr) String() string {
+	return string(a)
+}
+
+// multiTransport accepts connections via all of its transports and dials
+// WebSocket addresses via WebSocket transport and others via TCP.
+type multiTransport struct {
+	tcp Transporter
+	ws  Transporter
+}
+
+// Dial implements the Transporter interface.
+func (t *multiTransport) Dial(addr string, timeout time.Duration) error {
+	if isWSAddress(addr) {
+		return t.ws.Dial(addr, timeout)
+	}
+	return t.tcp.Dial(addr, timeout)
+}
+
+// Probe implements the Transporter interface.
+func (t *multiTransport) Probe(addr string, timeout time.Duration) error {
+	if isWSAddress(addr) {
+		return t.ws.Probe(addr, timeout)
+	}
+	return t.tcp.Probe(addr, timeout)
+}
+
+// Accept implements the Transporter interface.
+func (t *multiTransport) Accept() {
+	go t.ws.Accept()
+	t.tcp.Accept()
+}
+
+// Close implements the Transporter interface.
+func (t *multiTransport) Close() {
+	t.ws.Close()
+	t.tcp.Close()
+}
+
+// Proto implements the Transporter interface.
+func (t *multiTransport) Proto() string {
+	return t.tcp.Proto()
+}
+
+// Address implements the Transporter interface, it returns TCP address.
+func (t *multiTransport) Address() string {
+	return t.tcp.Address()
+}
@@ -0,0 +1,91 @@
+package network
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWSConn(t *testing.T) {
+	srvConns := make(chan *wsConn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		srvConns <- newWSConn(ws)
+	}))
+	defer srv.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	client := newWSConn(ws)
+	defer client.Close()
+	server := <-srvConns
+	defer server.Close()
+	require.NoError(t, server.SetDeadline(time.Now().Add(time.Second)))
+
+	// Messages are read as a continuous stream, text messages are skipped.
+	_, err = client.Write([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte("text")))
+	_, err = client.Write([]byte{4, 5})
+	require.NoError(t, err)
+
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(server, buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 4, 5}, buf)
+
+	require.NoError(t, client.Close())
+	_, err = server.Read(buf)
+	require.Error(t, err)
+}
+
+func TestWSTransport(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	tr := NewWSTransport(s, "127.0.0.1:0", s.log)
+	go tr.Accept()
+	defer tr.Close()
+	require.Eventually(t, func() bool { return tr.Address() != "" }, time.Second, 10*time.Millisecond)
+	require.Equal(t, "ws", tr.Proto())
+
+	require.NoError(t, tr.Dial(tr.Address(), time.Second))
+	require.NoError(t, tr.Dial("ws://"+tr.Address(), time.Second))
+	require.Error(t, tr.Dial("ws://127.0.0.1:1", time.Second))
+
+	require.NoError(t, tr.Probe(tr.Address(), time.Second))
+	require.NoError(t, tr.Probe("ws://"+tr.Address(), time.Second))
+	require.Error(t, tr.Probe("ws://127.0.0.1:1", time.Second))
+}
+
+func TestPeerAddrWS(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	p := NewTCPPeer(conn, newTestServer(t, ServerConfig{}), false)
+	p.version = &payload.Version{Capabilities: capability.Capabilities{{
+		Type: capability.WSServer,
+		Data: &capability.Server{Port: 20335},
+	}}}
+	require.Equal(t, "ws://127.0.0.1:20335", p.PeerAddr().String())
+
+	p.version.Capabilities = append(p.version.Capabilities, capability.Capability{
+		Type: capability.TCPServer,
+		Data: &capability.Server{Port: 20333},
+	})
+	require.Equal(t, "127.0.0.1:20333", p.PeerAddr().String())
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// Actor creates, signs and sends transactions using the given set of
+// accounts. The first one is the sender paying fees for all transactions,
+// every account must be able to sign (be unlocked or be a contract-based one
+// without parameters). Actor is also an Invoker with the same signers, so it
+// can be used for test invocations as well.
+type Actor struct {
+	Invoker
+
+	accounts     []*wallet.Account
+	waitInterval time.Duration
+}
+
+// NewActor creates new Actor using the given client and signers. Client must
+// be initialized, because its network is used for signing.
+func NewActor(c *Client, signers []SignerAccount) (*Actor, error) {
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	if len(signers) == 0 {
+		return nil, errors.New("at least one signer (sender) is required")
+	}
+	var (
+		txSigners = make([]transaction.Signer, len(signers))
+		accounts  = make([]*wallet.Account, len(signers))
+	)
+	for i := range signers {
+		if signers[i].Account == nil {
+			return nil, fmt.Errorf("no account for signer #%d", i)
+		}
+		h, err := address.StringToUint160(signers[i].Account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("bad account address for signer #%d: %w", i, err)
+		}
+		if !h.Equals(signers[i].Signer.Account) {
+			return nil, fmt.Errorf("signer #%d (%s) doesn't match its account (%s)", i,
+				address.Uint160ToString(signers[i].Signer.Account), signers[i].Account.Address)
+		}
+		txSigners[i] = signers[i].Signer
+		accounts[i] = signers[i].Account
+	}
+	return &Actor{
+		Invoker:      Invoker{client: c, signers: txSigners},
+		accounts:     accounts,
+		waitInterval: defaultWaitInterval,
+	}, nil
+}
+
+// NewSimpleActor creates new Actor with the only account signing with
+// CalledByEntry scope.
+func NewSimpleActor(c *Client, acc *wallet.Account) (*Actor, error) {
+	h, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("bad account address: %w", err)
+	}
+	return NewActor(c, []SignerAccount{{
+		Signer: transaction.Signer{
+			Account: h,
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}})
+}
+
+// Sender returns the sender (the first signer) of transactions.
+func (a *Actor) Sender() util.Uint160 {
+	return a.signers[0].Account
+}
+
+// MakeCall creates a signed transaction calling the method of the contract
+// with the given parameters (see MakeRun).
+func (a *Actor) MakeCall(contract util.Uint160, method string, params ...interface{}) (*transaction.Transaction, error) {
+	script, err := CallScript(contract, method, params...)
+	if err != nil {
+		return nil, err
+	}
+	return a.MakeRun(script)
+}
+
+// MakeRun creates a signed transaction with the given script. System fee is
+// taken from the test invocation of the script that must end in HALT state,
+// network fee is calculated for the signers and ValidUntilBlock is set to
+// the maximum possible value.
+func (a *Actor) MakeRun(script []byte) (*transaction.Transaction, error) {
+	res, err := a.Run(script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test-invoke: %w", err)
+	}
+	if res.State != "HALT" {
+		return nil, fmt.Errorf("script failed (%s state) due to an error: %s", res.State, res.FaultException)
+	}
+	tx, err := a.MakeUnsignedRun(script, res.GasConsumed)
+	if err != nil {
+		return nil, err
+	}
+	return tx, a.Sign(tx)
+}
+
+// MakeUnsignedRun creates an unsigned transaction with the given script and
+// system fee. Network fee and ValidUntilBlock are calculated the same way
+// MakeRun does.
+func (a *Actor) MakeUnsignedRun(script []byte, sysFee int64) (*transaction.Transaction, error) {
+	tx := transaction.New(script, sysFee)
+	tx.Signers = a.Signers()
+
+	var err error
+	tx.ValidUntilBlock, err = a.client.CalculateValidUntilBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate validUntilBlock: %w", err)
+	}
+	err = a.client.AddNetworkFee(tx, 0, a.accounts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add network fee: %w", err)
+	}
+	return tx, nil
+}
+
+// Sign adds witnesses of all Actor accounts to the transaction. Transaction
+// signers must match Actor's signers.
+func (a *Actor) Sign(tx *transaction.Transaction) error {
+	if len(tx.Signers) != len(a.accounts) {
+		return fmt.Errorf("transaction has %d signers, but actor has %d accounts", len(tx.Signers), len(a.accounts))
+	}
+	for i, acc := range a.accounts {
+		if !tx.Signers[i].Account.Equals(a.signers[i].Account) {
+			return fmt.Errorf("signer #%d (%s) doesn't match actor's account", i,
+				address.Uint160ToString(tx.Signers[i].Account))
+		}
+		if err := acc.SignTx(a.client.GetNetwork(), tx); err != nil {
+			return fmt.Errorf("failed to add witness for signer #%d (%s): %w", i, acc.Address, err)
+		}
+	}
+	return nil
+}
+
+// Send sends the transaction to the network, it returns transaction hash and
+// ValidUntilBlock value that can be passed to Wait.
+func (a *Actor) Send(tx *transaction.Transaction) (util.Uint256, uint32, error) {
+	h, err := a.client.SendRawTransaction(tx)
+	if err != nil {
+		return h, tx.ValidUntilBlock, fmt.Errorf("failed to send tx: %w", err)
+	}
+	if !h.Equals(tx.Hash()) {
+		return h, tx.ValidUntilBlock, fmt.Errorf("sent and actual tx hashes mismatch:\n\tsent: %v\n\tactual: %v",
+			tx.Hash().StringLE(), h.StringLE())
+	}
+	return h, tx.ValidUntilBlock, nil
+}
+
+// SendCall creates a transaction calling the method of the contract (see
+// MakeCall) and sends it to the network.
+func (a *Actor) SendCall(contract util.Uint160, method string, params ...interface{}) (util.Uint256, uint32, error) {
+	tx, err := a.MakeCall(contract, method, params...)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return a.Send(tx)
+}
+
+// SendRun creates a transaction with the given script (see MakeRun) and sends
+// it to the network.
+func (a *Actor) SendRun(script []byte) (util.Uint256, uint32, error) {
+	tx, err := a.MakeRun(script)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return a.Send(tx)
+}
+
+// Wait waits for the transaction with the given hash to be included in a
+// block and returns its application log. It accepts the results of Send*
+// methods as is, so that it can be used like
+//
+//	log, err := a.Wait(a.SendCall(contract, "method"))
+//
+// See Client.WaitForTransaction for details, the context of the underlying
+// client is used for waiting.
+func (a *Actor) Wait(h util.Uint256, vub uint32, err error) (*result.ApplicationLog, error) {
+	if err != nil {
+		return nil, err
+	}
+	return a.client.waitForTransaction(a.client.ctx, h, vub, a.waitInterval)
+}
@@ -11,6 +11,19 @@ Some of the methods also allow to pass a verbose bool. This will
 return a more pretty printed response from the server instead of
 a raw hex string.
 
+WaitForTransaction can be used to wait for the transaction sent to be
+included into the chain, it returns the application log of the transaction
+or ErrTxNotAccepted if it has expired. Waiting can also be interrupted via
+the context passed to it.
+
+Invoker and Actor
+
+Invoker wraps the client to perform test invocations of scripts and contract
+methods with a fixed set of signers. Actor extends it with the ability to
+create, sign and send transactions on behalf of the given wallet accounts
+(calculating fees and ValidUntilBlock automatically) and to wait for their
+inclusion into the chain.
+
 TODO:
 	Add missing methods to client.
 	Allow client to connect using client cert.
@@ -37,6 +50,7 @@ Supported methods
 	getunclaimedgas
 	getvalidators
 	getversion
+	getwalletbalance
 	invoke
 	invokefunction
 	invokescript
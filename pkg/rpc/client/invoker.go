@@ -0,0 +1,73 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+)
+
+// Invoker performs test invocations of scripts and contract methods using
+// the given set of signers (which can be empty). It doesn't change the chain
+// state and doesn't require any keys, so it can be used for read-only calls.
+type Invoker struct {
+	client  *Client
+	signers []transaction.Signer
+}
+
+// NewInvoker creates new Invoker using the given client and signers. Signers
+// are used for every invocation (CheckWitness calls in contracts depend on
+// them), nil is fine for methods not checking witnesses.
+func NewInvoker(c *Client, signers []transaction.Signer) *Invoker {
+	return &Invoker{client: c, signers: signers}
+}
+
+// Signers returns a copy of the signers used by Invoker.
+func (v *Invoker) Signers() []transaction.Signer {
+	if v.signers == nil {
+		return nil
+	}
+	res := make([]transaction.Signer, len(v.signers))
+	copy(res, v.signers)
+	return res
+}
+
+// Call invokes the method of the contract with the given parameters (see
+// CallScript for supported types) and returns the result of invocation. The
+// state of result should be checked by the caller.
+func (v *Invoker) Call(contract util.Uint160, method string, params ...interface{}) (*result.Invoke, error) {
+	script, err := CallScript(contract, method, params...)
+	if err != nil {
+		return nil, err
+	}
+	return v.Run(script)
+}
+
+// Run invokes the given script and returns the result of invocation.
+func (v *Invoker) Run(script []byte) (*result.Invoke, error) {
+	return v.client.InvokeScript(script, v.signers)
+}
+
+// Verify invokes `verify` method of the contract with the given parameters
+// and witnesses (see InvokeContractVerify) using Invoker signers.
+func (v *Invoker) Verify(contract util.Uint160, witnesses []transaction.Witness, params ...smartcontract.Parameter) (*result.Invoke, error) {
+	return v.client.InvokeContractVerify(contract, params, v.signers, witnesses...)
+}
+
+// CallScript returns a script calling the method of the contract with all call
+// flags and the given parameters. Parameters can be of any type supported by
+// emit.Array.
+func CallScript(contract util.Uint160, method string, params ...interface{}) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, contract, method, callflag.All, params...)
+	script, err := emit.Script(w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create script for %s: %w", method, err)
+	}
+	return script, nil
+}
@@ -31,6 +31,16 @@ func (c *Client) GetNNSPrice() (int64, error) {
 	return c.invokeNativeGetMethod(nnsHash, "getPrice")
 }
 
+// GetMinimumDeploymentFee invokes `getMinimumDeploymentFee` method on a native
+// ContractManagement contract.
+func (c *Client) GetMinimumDeploymentFee() (int64, error) {
+	mgmtHash, err := c.GetNativeContractHash(nativenames.Management)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get native ContractManagement hash: %w", err)
+	}
+	return c.invokeNativeGetMethod(mgmtHash, "getMinimumDeploymentFee")
+}
+
 // GetGasPerBlock invokes `getGasPerBlock` method on a native NEO contract.
 func (c *Client) GetGasPerBlock() (int64, error) {
 	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
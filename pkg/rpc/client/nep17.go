@@ -100,8 +100,12 @@ func (c *Client) CreateNEP17MultiTransferTx(acc *wallet.Account, gas int64,
 // CreateTxFromScript creates transaction and properly sets cosigners and NetworkFee.
 // If sysFee <= 0, it is determined via result of `invokescript` RPC. You should
 // initialize network magic with Init before calling CreateTxFromScript.
+// Scripts exceeding emit.MaxScriptSize are rejected.
 func (c *Client) CreateTxFromScript(script []byte, acc *wallet.Account, sysFee, netFee int64,
 	cosigners []SignerAccount) (*transaction.Transaction, error) {
+	if err := emit.CheckScriptSize(script); err != nil {
+		return nil, err
+	}
 	signers, accounts, err := getSigners(acc, cosigners)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct tx signers: %w", err)
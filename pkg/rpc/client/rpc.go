@@ -1,9 +1,11 @@
 package client
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -28,6 +30,14 @@ import (
 
 var errNetworkNotInitialized = errors.New("RPC client network is not initialized")
 
+// ErrTxNotAccepted is returned by WaitForTransaction when transaction wasn't
+// included in any block before its ValidUntilBlock.
+var ErrTxNotAccepted = errors.New("transaction was not accepted to the chain")
+
+// defaultWaitInterval is the default interval between application log
+// requests in WaitForTransaction.
+const defaultWaitInterval = time.Second
+
 // CalculateNetworkFee calculates network fee for transaction. The transaction may
 // have empty witnesses for contract signers and may have only verification scripts
 // filled for standard sig/multisig signers.
@@ -42,6 +52,30 @@ func (c *Client) CalculateNetworkFee(tx *transaction.Transaction) (int64, error)
 	return resp, nil
 }
 
+// EstimateNetworkFee returns network fee per byte paid by the given percent
+// of transactions in the given number of the latest blocks and the memory
+// pool. Zero values mean node defaults (50 percent and 10 blocks). It's only
+// supported by neo-go nodes.
+func (c *Client) EstimateNetworkFee(percentile, blocks int) (*result.NetworkFeeEstimate, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.NetworkFeeEstimate)
+	)
+	if percentile != 0 || blocks != 0 {
+		if percentile == 0 {
+			percentile = 50
+		}
+		params.Values = append(params.Values, percentile)
+		if blocks != 0 {
+			params.Values = append(params.Values, blocks)
+		}
+	}
+	if err := c.performRequest("estimatenetworkfee", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetApplicationLog returns the contract log based on the specified txid.
 func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
 	var (
@@ -151,10 +185,20 @@ func (c *Client) GetBlockHash(index uint32) (util.Uint256, error) {
 // according to the specified script hash. You should initialize network magic
 // // with Init before calling GetBlockHeader.
 func (c *Client) GetBlockHeader(hash util.Uint256) (*block.Header, error) {
+	return c.getBlockHeader(request.NewRawParams(hash.StringLE()))
+}
+
+// GetBlockHeaderByIndex returns the header of the block with the given index.
+// You should initialize network magic with Init before calling
+// GetBlockHeaderByIndex.
+func (c *Client) GetBlockHeaderByIndex(index uint32) (*block.Header, error) {
+	return c.getBlockHeader(request.NewRawParams(index))
+}
+
+func (c *Client) getBlockHeader(params request.RawParams) (*block.Header, error) {
 	var (
-		params = request.NewRawParams(hash.StringLE())
-		resp   []byte
-		h      *block.Header
+		resp []byte
+		h    *block.Header
 	)
 	if !c.initDone {
 		return nil, errNetworkNotInitialized
@@ -183,11 +227,35 @@ func (c *Client) GetBlockHeaderCount() (uint32, error) {
 // GetBlockHeaderVerbose returns the corresponding block header information from Json format string
 // according to the specified script hash.
 func (c *Client) GetBlockHeaderVerbose(hash util.Uint256) (*result.Header, error) {
+	return c.getBlockHeaderVerbose(request.NewRawParams(hash.StringLE(), 1))
+}
+
+// GetBlockHeaderByIndexVerbose returns the header of the block with the given
+// index in JSON format.
+func (c *Client) GetBlockHeaderByIndexVerbose(index uint32) (*result.Header, error) {
+	return c.getBlockHeaderVerbose(request.NewRawParams(index, 1))
+}
+
+func (c *Client) getBlockHeaderVerbose(params request.RawParams) (*result.Header, error) {
+	var resp = &result.Header{}
+	if err := c.performRequest("getblockheader", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBlockNotifications returns all notifications emitted during processing of
+// the block with the given hash, filter is optional and allows to choose
+// notifications of a particular contract and/or with a particular name.
+func (c *Client) GetBlockNotifications(hash util.Uint256, filter *request.NotificationFilter) (*result.BlockNotifications, error) {
 	var (
-		params = request.NewRawParams(hash.StringLE(), 1)
-		resp   = &result.Header{}
+		params = request.NewRawParams(hash.StringLE())
+		resp   = new(result.BlockNotifications)
 	)
-	if err := c.performRequest("getblockheader", params, resp); err != nil {
+	if filter != nil {
+		params.Values = append(params.Values, *filter)
+	}
+	if err := c.performRequest("getblocknotifications", params, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
@@ -217,6 +285,19 @@ func (c *Client) GetConnectionCount() (int, error) {
 	return resp, nil
 }
 
+// GetCandidates returns all NEO candidates with their votes, registration
+// status and next block validator flag.
+func (c *Client) GetCandidates() ([]result.Candidate, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new([]result.Candidate)
+	)
+	if err := c.performRequest("getcandidates", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
 // GetCommittee returns the current public keys of NEO nodes in committee.
 func (c *Client) GetCommittee() (keys.PublicKeys, error) {
 	var (
@@ -229,6 +310,19 @@ func (c *Client) GetCommittee() (keys.PublicKeys, error) {
 	return *resp, nil
 }
 
+// GetConsensusState returns the state of the node's consensus process (only
+// available if it's enabled in the node's RPC configuration).
+func (c *Client) GetConsensusState() (*result.ConsensusState, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.ConsensusState)
+	)
+	if err := c.performRequest("getconsensusstate", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetContractStateByHash queries contract information, according to the contract script hash.
 func (c *Client) GetContractStateByHash(hash util.Uint160) (*state.Contract, error) {
 	return c.getContractState(hash.StringLE())
@@ -256,6 +350,31 @@ func (c *Client) getContractState(param interface{}) (*state.Contract, error) {
 	return resp, nil
 }
 
+// GetInterops returns a list of all syscalls supported by the node along
+// with their prices.
+func (c *Client) GetInterops() ([]result.Interop, error) {
+	var (
+		params = request.NewRawParams()
+		resp   []result.Interop
+	)
+	if err := c.performRequest("getinterops", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetInteropPrice returns the price of the syscall with the given name.
+func (c *Client) GetInteropPrice(name string) (*result.Interop, error) {
+	var (
+		params = request.NewRawParams(name)
+		resp   = new(result.Interop)
+	)
+	if err := c.performRequest("getinteropprice", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNativeContracts queries information about native contracts.
 func (c *Client) GetNativeContracts() ([]state.NativeContract, error) {
 	var (
@@ -278,12 +397,40 @@ func (c *Client) GetNEP17Balances(address util.Uint160) (*result.NEP17Balances,
 	return resp, nil
 }
 
+// GetWalletBalance is a wrapper for getwalletbalance RPC (neo-go extension).
+// It requires balance tracker service to be enabled on the server with the
+// given address in its list of accounts.
+func (c *Client) GetWalletBalance(address util.Uint160) (*result.WalletBalance, error) {
+	params := request.NewRawParams(address.StringLE())
+	resp := new(result.WalletBalance)
+	if err := c.performRequest("getwalletbalance", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNEP17Transfers is a wrapper for getnep17transfers RPC. Address parameter
 // is mandatory, while all the others are optional. Start and stop parameters
 // are supported since neo-go 0.77.0 and limit and page since neo-go 0.78.0.
 // These parameters are positional in the JSON-RPC call, you can't specify limit
-// and not specify start/stop for example.
+// and not specify start/stop for example. Start and stop are timestamps in
+// milliseconds, use GetNEP17TransfersMs to pass ones not fitting into uint32.
 func (c *Client) GetNEP17Transfers(address string, start, stop *uint32, limit, page *int) (*result.NEP17Transfers, error) {
+	var start64, stop64 *uint64
+	if start != nil {
+		v := uint64(*start)
+		start64 = &v
+	}
+	if stop != nil {
+		v := uint64(*stop)
+		stop64 = &v
+	}
+	return c.GetNEP17TransfersMs(address, start64, stop64, limit, page)
+}
+
+// GetNEP17TransfersMs is similar to GetNEP17Transfers, but accepts start and
+// stop as uint64 millisecond timestamps (like the ones used in blocks).
+func (c *Client) GetNEP17TransfersMs(address string, start, stop *uint64, limit, page *int) (*result.NEP17Transfers, error) {
 	params := request.NewRawParams(address)
 	if start != nil {
 		params.Values = append(params.Values, *start)
@@ -334,6 +481,34 @@ func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	return *resp, nil
 }
 
+// GetRawMemPoolVerbose returns the list of unconfirmed transactions in memory
+// along with their details (sender, size, fees and the time of their
+// addition). Transaction details are only provided by neo-go nodes.
+func (c *Client) GetRawMemPoolVerbose() (*result.RawMempool, error) {
+	var (
+		params = request.NewRawParams(true)
+		resp   = new(result.RawMempool)
+	)
+	if err := c.performRequest("getrawmempool", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRawNotaryPool returns hashes of main and fallback transactions of the
+// P2PNotaryRequest payloads currently present in the node's notary pool.
+// It's only supported by nodes with P2PSigExtensions enabled.
+func (c *Client) GetRawNotaryPool() (*result.RawNotaryPool, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.RawNotaryPool)
+	)
+	if err := c.performRequest("getrawnotarypool", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawTransaction returns a transaction by hash. You should initialize network magic
 // with Init before calling GetRawTransaction.
 func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error) {
@@ -374,6 +549,19 @@ func (c *Client) GetRawTransactionVerbose(hash util.Uint256) (*result.Transactio
 	return resp, nil
 }
 
+// GetStateDiff returns contract storage changes made by the block with the
+// given index (node must have SaveStateDiffs or MaxReorgDepth setting enabled).
+func (c *Client) GetStateDiff(index uint32) (*result.StateDiff, error) {
+	var (
+		params = request.NewRawParams(index)
+		resp   = new(result.StateDiff)
+	)
+	if err := c.performRequest("getstatediff", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetStorageByID returns the stored value, according to the contract ID and the stored key.
 func (c *Client) GetStorageByID(id int32, key []byte) ([]byte, error) {
 	return c.getStorage(request.NewRawParams(id, base64.StdEncoding.EncodeToString(key)))
@@ -447,6 +635,21 @@ func (c *Client) InvokeScript(script []byte, signers []transaction.Signer) (*res
 	return c.invokeSomething("invokescript", p, signers)
 }
 
+// InvokeScriptWithTrace is similar to InvokeScript, but it also requests
+// instruction-level execution trace returned in the Trace field of the result.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithTrace(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var resp = new(result.Invoke)
+	if signers == nil {
+		signers = []transaction.Signer{}
+	}
+	var p = request.NewRawParams(script, signers, true)
+	if err := c.performRequest("invokescript", p, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // InvokeFunction returns the results after calling the smart contract scripthash
 // with the given operation and parameters.
 // NOTE: this is test invoke and will not affect the blockchain.
@@ -802,6 +1005,46 @@ func (c *Client) CalculateValidUntilBlock() (uint32, error) {
 	return blockCount + validatorsCount + 1, nil
 }
 
+// WaitForTransaction waits for the transaction with the given hash to be
+// included in a block and returns its application log. The node is polled
+// every second until the log is available, the chain grows beyond vub
+// (transaction's ValidUntilBlock) or ctx is done. ErrTxNotAccepted is returned
+// in the second case and ctx.Err() in the third one. WSClient does the same
+// polling, because subscription events are delivered to its Notifications
+// channel owned by the user.
+func (c *Client) WaitForTransaction(ctx context.Context, h util.Uint256, vub uint32) (*result.ApplicationLog, error) {
+	return c.waitForTransaction(ctx, h, vub, defaultWaitInterval)
+}
+
+func (c *Client) waitForTransaction(ctx context.Context, h util.Uint256, vub uint32, interval time.Duration) (*result.ApplicationLog, error) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		log, err := c.GetApplicationLog(h, nil)
+		if err == nil {
+			return log, nil
+		}
+		count, err := c.GetBlockCount()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block count: %w", err)
+		}
+		if count > vub+1 {
+			// Blocks up to vub are persisted, but there was no log
+			// a moment ago, so check it one more time.
+			if log, err := c.GetApplicationLog(h, nil); err == nil {
+				return log, nil
+			}
+			return nil, ErrTxNotAccepted
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			timer.Reset(interval)
+		}
+	}
+}
+
 // AddNetworkFee adds network fee for each witness script and optional extra
 // network fee to transaction. `accs` is an array signer's accounts.
 func (c *Client) AddNetworkFee(tx *transaction.Transaction, extraFee int64, accs ...*wallet.Account) error {
@@ -863,12 +1106,23 @@ func (c *Client) StateRootInHeader() bool {
 	return c.stateRootInHeader
 }
 
-// GetNativeContractHash returns native contract hash by its name.
+// GetNativeContractHash returns native contract hash by its name. Hashes of
+// all native contracts are fetched and cached with a single getnativecontracts
+// request, getcontractstate is used if the server doesn't support it.
 func (c *Client) GetNativeContractHash(name string) (util.Uint160, error) {
 	hash, ok := c.cache.nativeHashes[name]
 	if ok {
 		return hash, nil
 	}
+	natives, err := c.GetNativeContracts()
+	if err == nil {
+		for i := range natives {
+			c.cache.nativeHashes[natives[i].Manifest.Name] = natives[i].Hash
+		}
+		if hash, ok := c.cache.nativeHashes[name]; ok {
+			return hash, nil
+		}
+	}
 	cs, err := c.GetContractStateByAddressOrName(name)
 	if err != nil {
 		return util.Uint160{}, err
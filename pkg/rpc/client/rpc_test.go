@@ -28,6 +28,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -110,6 +111,24 @@ func getTxMoveNeo() *result.TransactionOutputRaw {
 // published in official C# JSON-RPC API v2.10.3 reference
 // (see https://docs.neo.org/docs/en-us/reference/rpc/latest-version/api.html)
 var rpcClientTestCases = map[string][]rpcClientTestCase{
+	"estimatenetworkfee": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.EstimateNetworkFee(90, 20)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"percentile":90,"blocks":20,"transactions":35,"feeperbyte":"5210","minfeeperbyte":"1000"}}`,
+			result: func(c *Client) interface{} {
+				return &result.NetworkFeeEstimate{
+					Percentile:    90,
+					Blocks:        20,
+					Transactions:  35,
+					FeePerByte:    5210,
+					MinFeePerByte: 1000,
+				}
+			},
+		},
+	},
 	"getapplicationlog": {
 		{
 			name: "positive",
@@ -251,6 +270,40 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return &b.Header
 			},
 		},
+		{
+			name: "byIndex_positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetBlockHeaderByIndex(1)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":"` + base64Header1 + `"}`,
+			result: func(c *Client) interface{} {
+				b := getResultBlock1()
+				return &b.Header
+			},
+		},
+		{
+			name: "byIndex_verbose_positive",
+			invoke: func(c *Client) (i interface{}, err error) {
+				return c.GetBlockHeaderByIndexVerbose(1)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":` + header1Verbose + `}`,
+			result: func(c *Client) interface{} {
+				b := getResultBlock1()
+				return &result.Header{
+					Hash:          b.Hash(),
+					Size:          449,
+					Version:       b.Version,
+					NextBlockHash: b.NextBlockHash,
+					PrevBlockHash: b.PrevHash,
+					MerkleRoot:    b.MerkleRoot,
+					Timestamp:     b.Timestamp,
+					Index:         b.Index,
+					NextConsensus: address.Uint160ToString(b.NextConsensus),
+					Witnesses:     []transaction.Witness{b.Script},
+					Confirmations: b.Confirmations,
+				}
+			},
+		},
 		{
 			name: "verbose_positive",
 			invoke: func(c *Client) (i interface{}, err error) {
@@ -291,6 +344,42 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getblocknotifications": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetBlockNotifications(util.Uint256{}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"blockhash":"0x773dd2dae4a9c9275290f89b56e67d7363ea4826dfd4fc13cc01cf73a44b0d0e","onpersist":[],"transactions":[{"txid":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","notifications":[{"contract":"0xd2a4cff31913016155e38e474a2c06d08be276cf","eventname":"Transfer","state":{"type":"Array","value":[{"type":"Integer","value":"1"}]}}]}],"postpersist":[]}}`,
+			result: func(c *Client) interface{} {
+				blockHash, err := util.Uint256DecodeStringLE("773dd2dae4a9c9275290f89b56e67d7363ea4826dfd4fc13cc01cf73a44b0d0e")
+				if err != nil {
+					panic(err)
+				}
+				txHash, err := util.Uint256DecodeStringLE("17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521")
+				if err != nil {
+					panic(err)
+				}
+				contract, err := util.Uint160DecodeStringLE("d2a4cff31913016155e38e474a2c06d08be276cf")
+				if err != nil {
+					panic(err)
+				}
+				return &result.BlockNotifications{
+					BlockHash: blockHash,
+					OnPersist: []state.NotificationEvent{},
+					Transactions: []result.TxNotifications{{
+						TxHash: txHash,
+						Notifications: []state.NotificationEvent{{
+							ScriptHash: contract,
+							Name:       "Transfer",
+							Item:       stackitem.NewArray([]stackitem.Item{stackitem.NewBigInteger(big.NewInt(1))}),
+						}},
+					}},
+					PostPersist: []state.NotificationEvent{},
+				}
+			},
+		},
+	},
 	"getblocksysfee": {
 		{
 			name: "positive",
@@ -303,6 +392,55 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getcandidates": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCandidates()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"publickey":"02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e","votes":"100500","active":true,"registered":true},{"publickey":"03d90c07df63e690ce77912e10ab51acc944b66860237b608c4f8f8309e71ee699","votes":"42","active":false,"registered":false}]}`,
+			result: func(c *Client) interface{} {
+				k1, err := keys.NewPublicKeyFromString("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e")
+				if err != nil {
+					panic(fmt.Errorf("failed to decode public key: %w", err))
+				}
+				k2, err := keys.NewPublicKeyFromString("03d90c07df63e690ce77912e10ab51acc944b66860237b608c4f8f8309e71ee699")
+				if err != nil {
+					panic(fmt.Errorf("failed to decode public key: %w", err))
+				}
+				return []result.Candidate{
+					{PublicKey: *k1, Votes: 100500, Active: true, Registered: true},
+					{PublicKey: *k2, Votes: 42},
+				}
+			},
+		},
+	},
+	"getconsensusstate": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetConsensusState()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":42,"view":1,"primary":2,"myindex":0,"validators":4,"phase":"commitSent","preparations":3,"commits":2,"changeviews":0,"timer":{"height":42,"view":1,"deadline":1616059782001}}}`,
+			result: func(c *Client) interface{} {
+				return &result.ConsensusState{
+					Height:       42,
+					View:         1,
+					Primary:      2,
+					MyIndex:      0,
+					Validators:   4,
+					Phase:        "commitSent",
+					Preparations: 3,
+					Commits:      2,
+					Timer: result.Timer{
+						Height:   42,
+						View:     1,
+						Deadline: 1616059782001,
+					},
+				}
+			},
+		},
+	},
 	"getcommittee": {
 		{
 			name: "positive",
@@ -466,6 +604,18 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getMinimumDeploymentFee": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetMinimumDeploymentFee()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"Integer","value":"1000000000"}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				return int64(1000000000)
+			},
+		},
+	},
 	"getGasPerBlock": {
 		{
 			name: "positive",
@@ -584,6 +734,57 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, milliseconds",
+			invoke: func(c *Client) (interface{}, error) {
+				var start, stop uint64 = 1555651816000, 1655651816000
+				return c.GetNEP17TransfersMs("AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF", &start, &stop, nil, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"sent":[],"received":[],"address":"AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF"}}`,
+			result: func(c *Client) interface{} {
+				return &result.NEP17Transfers{
+					Sent:     []result.NEP17Transfer{},
+					Received: []result.NEP17Transfer{},
+					Address:  "AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF",
+				}
+			},
+		},
+	},
+	"getinterops": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetInterops()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"id":3484127462,"name":"System.Runtime.Log","price":32768,"executionfee":983040,"paramcount":1,"requiredflags":8}]}`,
+			result: func(c *Client) interface{} {
+				return []result.Interop{{
+					ID:            3484127462,
+					Name:          "System.Runtime.Log",
+					Price:         32768,
+					ExecutionFee:  983040,
+					ParamCount:    1,
+					RequiredFlags: callflag.AllowNotify,
+				}}
+			},
+		},
+	},
+	"getinteropprice": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetInteropPrice("System.Runtime.GasLeft")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"id":3460373454,"name":"System.Runtime.GasLeft","price":16,"executionfee":480,"paramcount":0,"requiredflags":0}}`,
+			result: func(c *Client) interface{} {
+				return &result.Interop{
+					ID:           3460373454,
+					Name:         "System.Runtime.GasLeft",
+					Price:        16,
+					ExecutionFee: 480,
+				}
+			},
+		},
 	},
 	"getpeers": {
 		{
@@ -631,6 +832,55 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return []util.Uint256{hash}
 			},
 		},
+		{
+			name: "verbose",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetRawMemPoolVerbose()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":5,"verified":["0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e"],"unverified":[],"transactions":[{"hash":"0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e","sender":"NiXgSLtGUjBoFeQ8jQAkyd4tjxEAdeDrMD","size":250,"sysfee":"100","netfee":"1230610","height":4,"time":1612000000000}]}}`,
+			result: func(c *Client) interface{} {
+				hash, err := util.Uint256DecodeStringLE("9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e")
+				if err != nil {
+					panic(err)
+				}
+				return &result.RawMempool{
+					Height:     5,
+					Verified:   []util.Uint256{hash},
+					Unverified: []util.Uint256{},
+					Transactions: []result.MempoolTx{{
+						Hash:       hash,
+						Sender:     "NiXgSLtGUjBoFeQ8jQAkyd4tjxEAdeDrMD",
+						Size:       250,
+						SystemFee:  100,
+						NetworkFee: 1230610,
+						Height:     4,
+						Time:       1612000000000,
+					}},
+				}
+			},
+		},
+	},
+	"getrawnotarypool": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetRawNotaryPool()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hashes":{"0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e":["0xf5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275"]}}}`,
+			result: func(c *Client) interface{} {
+				main, err := util.Uint256DecodeStringLE("9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e")
+				if err != nil {
+					panic(err)
+				}
+				fallback, err := util.Uint256DecodeStringLE("f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275")
+				if err != nil {
+					panic(err)
+				}
+				return &result.RawNotaryPool{
+					Hashes: map[util.Uint256][]util.Uint256{main: {fallback}},
+				}
+			},
+		},
 	},
 	"getrawtransaction": {
 		{
@@ -668,6 +918,24 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getstatediff": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateDiff(5)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"index":5,"changes":[{"id":1,"key":"AQ==","oldvalue":null,"newvalue":"Ag=="},{"id":-6,"key":"Aw==","oldvalue":"BA==","newvalue":null}]}}`,
+			result: func(c *Client) interface{} {
+				return &result.StateDiff{
+					Index: 5,
+					Changes: []result.StorageChange{
+						{ID: 1, Key: []byte{1}, NewValue: []byte{2}},
+						{ID: -6, Key: []byte{3}, OldValue: []byte{4}},
+					},
+				}
+			},
+		},
+	},
 	"getstorage": {
 		{
 			name: "by hash, positive",
@@ -894,6 +1162,27 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, with trace",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.InvokeScriptWithTrace([]byte{byte(opcode.PUSH1)}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"EQ==","state":"HALT","gasconsumed":"60","stack":[{"type":"Integer","value":"1"}],"trace":{"entries":[{"scripthash":"0x0000000000000000000000000000000000000000","ip":0,"opcode":"PUSH1","gasconsumed":"0","stack":[]},{"scripthash":"0x0000000000000000000000000000000000000000","ip":1,"opcode":"RET","gasconsumed":"30","stack":[{"type":"Integer","value":"1"}]}],"truncated":false}}}`,
+			result: func(c *Client) interface{} {
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 60,
+					Script:      []byte{byte(opcode.PUSH1)},
+					Stack:       []stackitem.Item{stackitem.Make(1)},
+					Trace: &result.InvokeTrace{
+						Entries: []vm.TraceEntry{
+							{Opcode: opcode.PUSH1, Stack: []stackitem.Item{}},
+							{IP: 1, Opcode: opcode.RET, GasConsumed: 30, Stack: []stackitem.Item{stackitem.Make(1)}},
+						},
+					},
+				}
+			},
+		},
 	},
 	"invokecontractverify": {
 		{
@@ -1204,6 +1493,13 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 				return c.GetNEP17Transfers("Nhfg3TbpwogLvDGVvAvqyThbsHgoSUKwtn", &start, &stop, nil, &page)
 			},
 		},
+		{
+			name: "getnep17transfersms_invalid_params_error",
+			invoke: func(c *Client) (interface{}, error) {
+				var stop uint64
+				return c.GetNEP17TransfersMs("Nhfg3TbpwogLvDGVvAvqyThbsHgoSUKwtn", nil, &stop, nil, nil)
+			},
+		},
 		{
 			name: "getrawtransaction_invalid_params_error",
 			invoke: func(c *Client) (interface{}, error) {
@@ -1593,9 +1889,9 @@ func wrapInitResponse(r *request.In, resp string) string {
 		name, _ := p.ValueWithType(0, request.StringT).GetString()
 		switch name {
 		case "NeoToken":
-			response = `{"id":1,"jsonrpc":"2.0","result":{"id":-1,"script":"DANORU9Ba2d4Cw==","manifest":{"name":"NEO","abi":{"hash":"0xde5f57d430d3dece511cf975a8d37848cb9e0525","methods":[{"name":"name","offset":0,"parameters":null,"returntype":"String"},{"name":"symbol","offset":0,"parameters":null,"returntype":"String"},{"name":"decimals","offset":0,"parameters":null,"returntype":"Integer"},{"name":"totalSupply","offset":0,"parameters":null,"returntype":"Integer"},{"name":"balanceOf","offset":0,"parameters":[{"name":"account","type":"Hash160"}],"returntype":"Integer"},{"name":"transfer","offset":0,"parameters":[{"name":"from","type":"Hash160"},{"name":"to","type":"Hash160"},{"name":"amount","type":"Integer"}],"returntype":"Boolean"},{"name":"onPersist","offset":0,"parameters":null,"returntype":"Void"},{"name":"postPersist","offset":0,"parameters":null,"returntype":"Void"},{"name":"unclaimedGas","offset":0,"parameters":[{"name":"account","type":"Hash160"},{"name":"end","type":"Integer"}],"returntype":"Integer"},{"name":"registerCandidate","offset":0,"parameters":[{"name":"pubkey","type":"PublicKey"}],"returntype":"Boolean"},{"name":"unregisterCandidate","offset":0,"parameters":[{"name":"pubkey","type":"PublicKey"}],"returntype":"Boolean"},{"name":"vote","offset":0,"parameters":[{"name":"account","type":"Hash160"},{"name":"pubkey","type":"PublicKey"}],"returntype":"Boolean"},{"name":"getCandidates","offset":0,"parameters":null,"returntype":"Array"},{"name":"getСommittee","offset":0,"parameters":null,"returntype":"Array"},{"name":"getNextBlockValidators","offset":0,"parameters":null,"returntype":"Array"},{"name":"getGasPerBlock","offset":0,"parameters":null,"returntype":"Integer"},{"name":"setGasPerBlock","offset":0,"parameters":[{"name":"gasPerBlock","type":"Integer"}],"returntype":"Boolean"}],"events":[{"name":"Transfer","parameters":null}]},"groups":[],"permissions":[{"contract":"*","methods":"*"}],"supportedstandards":["NEP-5"],"trusts":[],"safemethods":["name","symbol","decimals","totalSupply","balanceOf","unclaimedGas","getCandidates","getСommittee","getNextBlockValidators"],"extra":null},"hash":"0xde5f57d430d3dece511cf975a8d37848cb9e0525"}}`
+			response = `{"id":1,"jsonrpc":"2.0","result":{"id":-1,"script":"DANORU9Ba2d4Cw==","manifest":{"name":"NEO","abi":{"hash":"0xde5f57d430d3dece511cf975a8d37848cb9e0525","methods":[{"name":"name","offset":0,"parameters":null,"returntype":"String"},{"name":"symbol","offset":0,"parameters":null,"returntype":"String"},{"name":"decimals","offset":0,"parameters":null,"returntype":"Integer"},{"name":"totalSupply","offset":0,"parameters":null,"returntype":"Integer"},{"name":"balanceOf","offset":0,"parameters":[{"name":"account","type":"Hash160"}],"returntype":"Integer"},{"name":"transfer","offset":0,"parameters":[{"name":"from","type":"Hash160"},{"name":"to","type":"Hash160"},{"name":"amount","type":"Integer"},{"name":"data","type":"Any"}],"returntype":"Boolean"},{"name":"onPersist","offset":0,"parameters":null,"returntype":"Void"},{"name":"postPersist","offset":0,"parameters":null,"returntype":"Void"},{"name":"unclaimedGas","offset":0,"parameters":[{"name":"account","type":"Hash160"},{"name":"end","type":"Integer"}],"returntype":"Integer"},{"name":"registerCandidate","offset":0,"parameters":[{"name":"pubkey","type":"PublicKey"}],"returntype":"Boolean"},{"name":"unregisterCandidate","offset":0,"parameters":[{"name":"pubkey","type":"PublicKey"}],"returntype":"Boolean"},{"name":"vote","offset":0,"parameters":[{"name":"account","type":"Hash160"},{"name":"pubkey","type":"PublicKey"}],"returntype":"Boolean"},{"name":"getCandidates","offset":0,"parameters":null,"returntype":"Array"},{"name":"getСommittee","offset":0,"parameters":null,"returntype":"Array"},{"name":"getNextBlockValidators","offset":0,"parameters":null,"returntype":"Array"},{"name":"getGasPerBlock","offset":0,"parameters":null,"returntype":"Integer"},{"name":"setGasPerBlock","offset":0,"parameters":[{"name":"gasPerBlock","type":"Integer"}],"returntype":"Boolean"}],"events":[{"name":"Transfer","parameters":null}]},"groups":[],"permissions":[{"contract":"*","methods":"*"}],"supportedstandards":["NEP-17"],"trusts":[],"safemethods":["name","symbol","decimals","totalSupply","balanceOf","unclaimedGas","getCandidates","getСommittee","getNextBlockValidators"],"extra":null},"hash":"0xde5f57d430d3dece511cf975a8d37848cb9e0525"}}`
 		case "GasToken":
-			response = `{"id":1,"jsonrpc":"2.0","result":{"id":-2,"script":"DANHQVNBa2d4Cw==","manifest":{"name":"GAS","abi":{"hash":"0x668e0c1f9d7b70a99dd9e06eadd4c784d641afbc","methods":[{"name":"name","offset":0,"parameters":null,"returntype":"String"},{"name":"symbol","offset":0,"parameters":null,"returntype":"String"},{"name":"decimals","offset":0,"parameters":null,"returntype":"Integer"},{"name":"totalSupply","offset":0,"parameters":null,"returntype":"Integer"},{"name":"balanceOf","offset":0,"parameters":[{"name":"account","type":"Hash160"}],"returntype":"Integer"},{"name":"transfer","offset":0,"parameters":[{"name":"from","type":"Hash160"},{"name":"to","type":"Hash160"},{"name":"amount","type":"Integer"}],"returntype":"Boolean"},{"name":"onPersist","offset":0,"parameters":null,"returntype":"Void"},{"name":"postPersist","offset":0,"parameters":null,"returntype":"Void"}],"events":[{"name":"Transfer","parameters":null}]},"groups":[],"permissions":[{"contract":"*","methods":"*"}],"supportedstandards":["NEP-5"],"trusts":[],"safemethods":["name","symbol","decimals","totalSupply","balanceOf"],"extra":null},"hash":"0x668e0c1f9d7b70a99dd9e06eadd4c784d641afbc"}}`
+			response = `{"id":1,"jsonrpc":"2.0","result":{"id":-2,"script":"DANHQVNBa2d4Cw==","manifest":{"name":"GAS","abi":{"hash":"0x668e0c1f9d7b70a99dd9e06eadd4c784d641afbc","methods":[{"name":"name","offset":0,"parameters":null,"returntype":"String"},{"name":"symbol","offset":0,"parameters":null,"returntype":"String"},{"name":"decimals","offset":0,"parameters":null,"returntype":"Integer"},{"name":"totalSupply","offset":0,"parameters":null,"returntype":"Integer"},{"name":"balanceOf","offset":0,"parameters":[{"name":"account","type":"Hash160"}],"returntype":"Integer"},{"name":"transfer","offset":0,"parameters":[{"name":"from","type":"Hash160"},{"name":"to","type":"Hash160"},{"name":"amount","type":"Integer"},{"name":"data","type":"Any"}],"returntype":"Boolean"},{"name":"onPersist","offset":0,"parameters":null,"returntype":"Void"},{"name":"postPersist","offset":0,"parameters":null,"returntype":"Void"}],"events":[{"name":"Transfer","parameters":null}]},"groups":[],"permissions":[{"contract":"*","methods":"*"}],"supportedstandards":["NEP-17"],"trusts":[],"safemethods":["name","symbol","decimals","totalSupply","balanceOf"],"extra":null},"hash":"0x668e0c1f9d7b70a99dd9e06eadd4c784d641afbc"}}`
 		case "PolicyContract":
 			response = `{"id":1,"jsonrpc":"2.0","result":{"id":-3,"updatecounter":0,"hash":"0xac593e6183643940a9193f87c64ccf55ef19c529","script":"DAZQb2xpY3lBGvd7Zw==","manifest":{"name":"Policy","abi":{"methods":[{"name":"getMaxTransactionsPerBlock","offset":0,"parameters":null,"returntype":"Integer"},{"name":"getMaxBlockSize","offset":0,"parameters":null,"returntype":"Integer"},{"name":"getFeePerByte","offset":0,"parameters":null,"returntype":"Integer"},{"name":"isBlocked","offset":0,"parameters":[{"name":"account","type":"Hash160"}],"returntype":"Boolean"},{"name":"getMaxBlockSystemFee","offset":0,"parameters":null,"returntype":"Integer"},{"name":"setMaxBlockSize","offset":0,"parameters":[{"name":"value","type":"Integer"}],"returntype":"Boolean"},{"name":"setMaxTransactionsPerBlock","offset":0,"parameters":[{"name":"value","type":"Integer"}],"returntype":"Boolean"},{"name":"setFeePerByte","offset":0,"parameters":[{"name":"value","type":"Integer"}],"returntype":"Boolean"},{"name":"setMaxBlockSystemFee","offset":0,"parameters":[{"name":"value","type":"Integer"}],"returntype":"Boolean"},{"name":"blockAccount","offset":0,"parameters":[{"name":"account","type":"Hash160"}],"returntype":"Boolean"},{"name":"unblockAccount","offset":0,"parameters":[{"name":"account","type":"Hash160"}],"returntype":"Boolean"}],"events":[]},"groups":[],"permissions":[{"contract":"*","methods":"*"}],"supportedstandards":[],"trusts":[],"safemethods":["getMaxTransactionsPerBlock","getMaxBlockSize","getFeePerByte","isBlocked","getMaxBlockSystemFee"],"extra":null}}}`
 		default:
@@ -1653,6 +1949,51 @@ func TestCalculateValidUntilBlock(t *testing.T) {
 	assert.Equal(t, 1, getValidatorsCalled)
 }
 
+func TestGetNativeContractHash(t *testing.T) {
+	natives := []state.NativeContract{
+		{ContractBase: state.ContractBase{ID: -10, Hash: util.Uint160{1, 2, 3}, Manifest: *manifest.NewManifest("Notary")}},
+		{ContractBase: state.ContractBase{ID: -11, Hash: util.Uint160{4, 5, 6}, Manifest: *manifest.NewManifest("NameService")}},
+	}
+	res, err := json.Marshal(natives)
+	require.NoError(t, err)
+
+	var getNativesCalled, getStateCalled int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var response string
+		switch r.In.Method {
+		case "getnativecontracts":
+			getNativesCalled++
+			response = `{"id":1,"jsonrpc":"2.0","result":` + string(res) + `}`
+		case "getcontractstate":
+			getStateCalled++
+			response = `{"id":1,"jsonrpc":"2.0","error":{"code":-100,"message":"Unknown contract"}}`
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+	getStateCalled = 0 // Init resolves NEO, GAS and Policy hashes itself.
+
+	for _, n := range natives {
+		h, err := c.GetNativeContractHash(n.Manifest.Name)
+		require.NoError(t, err)
+		require.Equal(t, n.Hash, h)
+	}
+	require.Equal(t, 1, getNativesCalled)
+	require.Equal(t, 0, getStateCalled)
+
+	_, err = c.GetNativeContractHash("Unknown")
+	require.Error(t, err)
+	require.Equal(t, 2, getNativesCalled)
+	require.Equal(t, 1, getStateCalled)
+}
+
 func TestGetNetwork(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		r := request.NewRequest()
@@ -135,7 +135,7 @@ readloop:
 			}
 			var slice []json.RawMessage
 			err = json.Unmarshal(rr.RawParams, &slice)
-			if err != nil || (event != response.MissedEventID && len(slice) != 1) {
+			if err != nil || (event != response.MissedEventID && len(slice) != 1) || len(slice) > 1 {
 				// Bad event received.
 				break
 			}
@@ -150,12 +150,15 @@ readloop:
 			case response.ExecutionEventID:
 				val = new(state.AppExecResult)
 			case response.MissedEventID:
-				// No value.
+				// Missed events count is optional.
+				if len(slice) == 1 {
+					val = new(response.MissedEvents)
+				}
 			default:
 				// Bad event received.
 				break readloop
 			}
-			if event != response.MissedEventID {
+			if val != nil {
 				err = json.Unmarshal(slice[0], val)
 				if err != nil {
 					// Bad event received.
@@ -290,12 +293,21 @@ func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160, na
 // be filtered by state (HALT/FAULT) to check for successful or failing
 // transactions, nil value means no filtering.
 func (c *WSClient) SubscribeForTransactionExecutions(state *string) (string, error) {
+	return c.SubscribeForTransactionExecutionsWithFilter(request.ExecutionFilter{State: state})
+}
+
+// SubscribeForTransactionExecutionsWithFilter is similar to
+// SubscribeForTransactionExecutions, but allows to specify full execution
+// filter: state (HALT/FAULT), container hash to wait for execution of a
+// particular transaction or block and contract that should emit notifications
+// during execution. Nil filter fields mean no filtering.
+func (c *WSClient) SubscribeForTransactionExecutionsWithFilter(filter request.ExecutionFilter) (string, error) {
 	params := request.NewRawParams("transaction_executed")
-	if state != nil {
-		if *state != "HALT" && *state != "FAULT" {
+	if filter.State != nil || filter.Container != nil || filter.Contract != nil {
+		if filter.State != nil && *filter.State != "HALT" && *filter.State != "FAULT" {
 			return "", errors.New("bad state parameter")
 		}
-		params.Values = append(params.Values, request.ExecutionFilter{State: *state})
+		params.Values = append(params.Values, filter)
 	}
 	return c.performSubscription(params)
 }
@@ -33,6 +33,12 @@ type (
 		Type  smartcontract.ParamType `json:"type"`
 		Value Param                   `json:"value"`
 	}
+	// FuncParamPair represents a key-value pair of the Map function
+	// argument parameter.
+	FuncParamPair struct {
+		Key   FuncParam `json:"key"`
+		Value FuncParam `json:"value"`
+	}
 	// BlockFilter is a wrapper structure for block event filter. The only
 	// allowed filter is primary index.
 	BlockFilter struct {
@@ -53,9 +59,13 @@ type (
 	}
 	// ExecutionFilter is a wrapper structure used for transaction execution
 	// events. It allows to choose failing or successful transactions based
-	// on their VM state.
+	// on their VM state, to wait for execution of a particular container
+	// (transaction or block) and to choose executions that emitted
+	// notifications of a particular contract. Nil fields mean no filtering.
 	ExecutionFilter struct {
-		State string `json:"state"`
+		State     *string       `json:"state,omitempty"`
+		Container *util.Uint256 `json:"container,omitempty"`
+		Contract  *util.Uint160 `json:"contract,omitempty"`
 	}
 	// SignerWithWitness represents transaction's signer with the corresponding witness.
 	SignerWithWitness struct {
@@ -72,6 +82,7 @@ const (
 	BooleanT
 	ArrayT
 	FuncParamT
+	FuncParamPairT
 	BlockFilterT
 	TxFilterT
 	NotificationFilterT
@@ -150,6 +161,33 @@ func (p *Param) GetUint256() (util.Uint256, error) {
 	return util.Uint256DecodeStringLE(strings.TrimPrefix(s, "0x"))
 }
 
+// GetBlockHashOrIndex returns block hash or index specified by the parameter.
+// Numbers and numeric strings shorter than hash are treated as indexes (isIndex
+// is set to true then), other strings are parsed as LE hashes.
+func (p *Param) GetBlockHashOrIndex() (hash util.Uint256, index int, isIndex bool, err error) {
+	if p == nil {
+		return hash, 0, false, errMissingParameter
+	}
+	switch p.Type {
+	case NumberT:
+		index, err = p.GetInt()
+		return hash, index, true, err
+	case StringT:
+		s, err := p.GetString()
+		if err != nil {
+			return hash, 0, false, err
+		}
+		if len(s) < 2*util.Uint256Size {
+			index, err = strconv.Atoi(s)
+			return hash, index, true, err
+		}
+		hash, err = p.GetUint256()
+		return hash, 0, false, err
+	default:
+		return hash, 0, false, errors.New("neither block hash nor index")
+	}
+}
+
 // GetUint160FromHex returns Uint160 value of the parameter encoded in hex.
 func (p *Param) GetUint160FromHex() (util.Uint160, error) {
 	s, err := p.GetString()
@@ -177,11 +215,11 @@ func (p *Param) GetUint160FromAddress() (util.Uint160, error) {
 // GetUint160FromAddressOrHex returns Uint160 value of the parameter that was
 // supplied either as raw hex or as an address.
 func (p *Param) GetUint160FromAddressOrHex() (util.Uint160, error) {
-	u, err := p.GetUint160FromHex()
-	if err == nil {
-		return u, err
+	s, err := p.GetString()
+	if err != nil {
+		return util.Uint160{}, err
 	}
-	return p.GetUint160FromAddress()
+	return address.ParseUint160(s)
 }
 
 // GetFuncParam returns current parameter as a function call parameter.
@@ -196,6 +234,36 @@ func (p *Param) GetFuncParam() (FuncParam, error) {
 	return fp, nil
 }
 
+// GetFuncParamPair returns current parameter as a key-value pair of the Map
+// function call parameter.
+func (p *Param) GetFuncParamPair() (FuncParamPair, error) {
+	if p == nil {
+		return FuncParamPair{}, errMissingParameter
+	}
+	fp, ok := p.Value.(FuncParamPair)
+	if !ok {
+		return FuncParamPair{}, errors.New("not a function parameter pair")
+	}
+	return fp, nil
+}
+
+// GetExecutionFilter returns ExecutionFilter value of the parameter. Filter
+// containing only contract hash has the same JSON representation as
+// NotificationFilter without name and is decoded as such, so it's converted
+// here.
+func (p *Param) GetExecutionFilter() (ExecutionFilter, error) {
+	switch p.Type {
+	case ExecutionFilterT:
+		return p.Value.(ExecutionFilter), nil
+	case NotificationFilterT:
+		nf := p.Value.(NotificationFilter)
+		if nf.Contract != nil && nf.Name == nil {
+			return ExecutionFilter{Contract: nf.Contract}, nil
+		}
+	}
+	return ExecutionFilter{}, errors.New("not an execution filter")
+}
+
 // GetBytesHex returns []byte value of the parameter if
 // it is a hex-encoded string.
 func (p *Param) GetBytesHex() ([]byte, error) {
@@ -273,6 +341,7 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 		{BooleanT, &b},
 		{StringT, &s},
 		{FuncParamT, &FuncParam{}},
+		{FuncParamPairT, &FuncParamPair{}},
 		{BlockFilterT, &BlockFilter{}},
 		{TxFilterT, &TxFilter{}},
 		{NotificationFilterT, &NotificationFilter{}},
@@ -302,6 +371,8 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 				p.Value = *val
 			case *FuncParam:
 				p.Value = *val
+			case *FuncParamPair:
+				p.Value = *val
 			case *BlockFilter:
 				p.Value = *val
 			case *TxFilter:
@@ -309,11 +380,10 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 			case *NotificationFilter:
 				p.Value = *val
 			case *ExecutionFilter:
-				if (*val).State == "HALT" || (*val).State == "FAULT" {
-					p.Value = *val
-				} else {
+				if !val.isValid() {
 					continue
 				}
+				p.Value = *val
 			case *signerWithWitnessAux:
 				aux := *val
 				p.Value = SignerWithWitness{
@@ -338,6 +408,15 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 	return errors.New("unknown type")
 }
 
+// isValid checks that the filter is not empty and that its VM state (if any)
+// is either HALT or FAULT.
+func (f *ExecutionFilter) isValid() bool {
+	if f.State == nil && f.Container == nil && f.Contract == nil {
+		return false
+	}
+	return f.State == nil || *f.State == "HALT" || *f.State == "FAULT"
+}
+
 // signerWithWitnessAux is an auxiluary struct for JSON marshalling. We need it because of
 // DisallowUnknownFields JSON marshaller setting.
 type signerWithWitnessAux struct {
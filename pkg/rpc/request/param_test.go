@@ -16,6 +16,7 @@ import (
 
 func TestParam_UnmarshalJSON(t *testing.T) {
 	msg := `["str1", 123, null, ["str2", 3], [{"type": "String", "value": "jajaja"}],
+                 [{"type": "Map", "value": [{"key": {"type": "String", "value": "k"}, "value": {"type": "Integer", "value": 1}}]}],
                  {"primary": 1},
                  {"sender": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
                  {"signer": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
@@ -24,11 +25,15 @@ func TestParam_UnmarshalJSON(t *testing.T) {
                  {"name": "my_pretty_notification"},
                  {"contract": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "name":"my_pretty_notification"},
                  {"state": "HALT"},
+                 {"state": "FAULT", "container": "0x2a14fc4d8e2c8ea0e2e2ff2a2d86f6b0a0d1296e2fac0ba9fef3b7ded7ee0af9", "contract": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
                  {"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569"},
                  [{"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "scopes": "Global"}]]`
 	contr, err := util.Uint160DecodeStringLE("f84d6a337fbc3d3a201d41da99e86b479e7a2554")
 	require.NoError(t, err)
 	name := "my_pretty_notification"
+	halt, fault := "HALT", "FAULT"
+	container, err := util.Uint256DecodeStringLE("2a14fc4d8e2c8ea0e2e2ff2a2d86f6b0a0d1296e2fac0ba9fef3b7ded7ee0af9")
+	require.NoError(t, err)
 	accountHash, err := util.Uint160DecodeStringLE("cadb3dc2faa3ef14a13b619c9a43124755aa2569")
 	require.NoError(t, err)
 	expected := Params{
@@ -71,6 +76,35 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			Type: ArrayT,
+			Value: []Param{
+				{
+					Type: FuncParamT,
+					Value: FuncParam{
+						Type: smartcontract.MapType,
+						Value: Param{
+							Type: ArrayT,
+							Value: []Param{
+								{
+									Type: FuncParamPairT,
+									Value: FuncParamPair{
+										Key: FuncParam{
+											Type:  smartcontract.StringType,
+											Value: Param{Type: StringT, Value: "k"},
+										},
+										Value: FuncParam{
+											Type:  smartcontract.IntegerType,
+											Value: Param{Type: NumberT, Value: 1},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Type:  BlockFilterT,
 			Value: BlockFilter{Primary: 1},
@@ -101,7 +135,11 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 		},
 		{
 			Type:  ExecutionFilterT,
-			Value: ExecutionFilter{State: "HALT"},
+			Value: ExecutionFilter{State: &halt},
+		},
+		{
+			Type:  ExecutionFilterT,
+			Value: ExecutionFilter{State: &fault, Container: &container, Contract: &contr},
 		},
 		{
 			Type: SignerWithWitnessT,
@@ -191,6 +229,39 @@ func TestParamGetUint256(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestParamGetBlockHashOrIndex(t *testing.T) {
+	gas := "f037308fa0ab18155bccfc08485468c112409ea5064595699e98c545f245f32d"
+	u256, _ := util.Uint256DecodeStringLE(gas)
+	testCases := []struct {
+		p       Param
+		hash    util.Uint256
+		index   int
+		isIndex bool
+		fail    bool
+	}{
+		{p: Param{Type: NumberT, Value: 42}, index: 42, isIndex: true},
+		{p: Param{Type: StringT, Value: "42"}, index: 42, isIndex: true},
+		{p: Param{Type: StringT, Value: gas}, hash: u256},
+		{p: Param{Type: StringT, Value: "0x" + gas}, hash: u256},
+		{p: Param{Type: StringT, Value: "notahash"}, fail: true},
+		{p: Param{Type: StringT, Value: gas[1:] + "x"}, fail: true},
+		{p: Param{Type: BooleanT, Value: true}, fail: true},
+	}
+	for _, tc := range testCases {
+		hash, index, isIndex, err := tc.p.GetBlockHashOrIndex()
+		if tc.fail {
+			require.Error(t, err, tc.p)
+			continue
+		}
+		require.NoError(t, err, tc.p)
+		require.Equal(t, tc.hash, hash)
+		require.Equal(t, tc.index, index)
+		require.Equal(t, tc.isIndex, isIndex)
+	}
+	_, _, _, err := (*Param)(nil).GetBlockHashOrIndex()
+	require.Error(t, err)
+}
+
 func TestParamGetUint160FromHex(t *testing.T) {
 	in := "50befd26fdf6e4d957c11e078b24ebce6291456f"
 	u160, _ := util.Uint160DecodeStringLE(in)
@@ -265,6 +336,30 @@ func TestParamGetFuncParam(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestParamGetExecutionFilter(t *testing.T) {
+	state := "HALT"
+	contract := util.Uint160{1, 2, 3}
+	ef := ExecutionFilter{State: &state, Contract: &contract}
+	p := Param{ExecutionFilterT, ef}
+	filt, err := p.GetExecutionFilter()
+	require.NoError(t, err)
+	require.Equal(t, ef, filt)
+
+	p = Param{NotificationFilterT, NotificationFilter{Contract: &contract}}
+	filt, err = p.GetExecutionFilter()
+	require.NoError(t, err)
+	require.Equal(t, ExecutionFilter{Contract: &contract}, filt)
+
+	name := "Transfer"
+	p = Param{NotificationFilterT, NotificationFilter{Contract: &contract, Name: &name}}
+	_, err = p.GetExecutionFilter()
+	require.Error(t, err)
+
+	p = Param{StringT, "HALT"}
+	_, err = p.GetExecutionFilter()
+	require.Error(t, err)
+}
+
 func TestParamGetBytesHex(t *testing.T) {
 	in := "602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7"
 	inb, _ := hex.DecodeString(in)
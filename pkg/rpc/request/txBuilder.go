@@ -93,6 +93,33 @@ func ExpandArrayIntoScript(script *io.BinWriter, slice []Param) error {
 			}
 			emit.Int(script, int64(len(val)))
 			emit.Opcodes(script, opcode.PACK)
+		case smartcontract.MapType:
+			val, err := fp.Value.GetArray()
+			if err != nil {
+				return err
+			}
+			emit.Opcodes(script, opcode.NEWMAP)
+			for i := range val {
+				pair, err := val[i].GetFuncParamPair()
+				if err != nil {
+					return err
+				}
+				emit.Opcodes(script, opcode.DUP)
+				// Key is pushed first, array is expanded in reverse order.
+				err = ExpandArrayIntoScript(script, []Param{
+					{Type: FuncParamT, Value: pair.Value},
+					{Type: FuncParamT, Value: pair.Key},
+				})
+				if err != nil {
+					return err
+				}
+				emit.Opcodes(script, opcode.SETITEM)
+			}
+		case smartcontract.AnyType:
+			if fp.Value.Type != defaultT {
+				return errors.New("Any parameter can only have null value")
+			}
+			emit.Opcodes(script, opcode.PUSHNULL)
 		default:
 			return fmt.Errorf("parameter type %v is not supported", fp.Type)
 		}
@@ -132,5 +159,5 @@ func CreateFunctionInvocationScript(contract util.Uint160, method string, params
 	}
 
 	emit.AppCallNoArgs(script.BinWriter, contract, method, callflag.All)
-	return script.Bytes(), nil
+	return emit.Script(script)
 }
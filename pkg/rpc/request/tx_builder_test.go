@@ -106,6 +106,17 @@ func TestExpandArrayIntoScript(t *testing.T) {
 			Input:    []Param{{Type: FuncParamT, Value: FuncParam{Type: smartcontract.ArrayType, Value: Param{Value: []Param{{Type: FuncParamT, Value: FuncParam{Type: smartcontract.StringType, Value: Param{Value: "a"}}}}}}}},
 			Expected: []byte{byte(opcode.PUSHDATA1), 1, byte('a'), byte(opcode.PUSH1), byte(opcode.PACK)},
 		},
+		{
+			Input:    []Param{{Type: FuncParamT, Value: FuncParam{Type: smartcontract.AnyType}}},
+			Expected: []byte{byte(opcode.PUSHNULL)},
+		},
+		{
+			Input: []Param{{Type: FuncParamT, Value: FuncParam{Type: smartcontract.MapType, Value: Param{Value: []Param{{Type: FuncParamPairT, Value: FuncParamPair{
+				Key:   FuncParam{Type: smartcontract.StringType, Value: Param{Value: "a"}},
+				Value: FuncParam{Type: smartcontract.IntegerType, Value: Param{Value: 1}},
+			}}}}}}},
+			Expected: []byte{byte(opcode.NEWMAP), byte(opcode.DUP), byte(opcode.PUSHDATA1), 1, byte('a'), byte(opcode.PUSH1), byte(opcode.SETITEM)},
+		},
 	}
 	for _, c := range testCases {
 		script := io.NewBufBinWriter()
@@ -120,6 +131,15 @@ func TestExpandArrayIntoScript(t *testing.T) {
 		{
 			{Type: FuncParamT, Value: FuncParam{Type: smartcontract.ArrayType, Value: Param{Value: []Param{{Type: FuncParamT, Value: nil}}}}},
 		},
+		{
+			{Type: FuncParamT, Value: FuncParam{Type: smartcontract.MapType, Value: Param{Value: []Param{{Type: FuncParamT, Value: FuncParam{Type: smartcontract.AnyType}}}}}},
+		},
+		{
+			{Type: FuncParamT, Value: FuncParam{Type: smartcontract.AnyType, Value: Param{Type: StringT, Value: "a"}}},
+		},
+		{
+			{Type: FuncParamT, Value: FuncParam{Type: smartcontract.InteropInterfaceType}},
+		},
 	}
 	for _, c := range errorCases {
 		script := io.NewBufBinWriter()
@@ -30,6 +30,12 @@ var (
 	ErrValidationFailed = NewSubmitError(-504, "Block or transaction validation failed.")
 	// ErrPolicyFail represents SubmitError with code -505.
 	ErrPolicyFail = NewSubmitError(-505, "One of the Policy filters failed.")
+	// ErrTxTooBig represents SubmitError with code -506.
+	ErrTxTooBig = NewSubmitError(-506, "Transaction exceeds the maximum size.")
+	// ErrTxTooManyAttributes represents SubmitError with code -507.
+	ErrTxTooManyAttributes = NewSubmitError(-507, "Transaction has too many signers or attributes.")
+	// ErrTxInvalidWitnesses represents SubmitError with code -508.
+	ErrTxInvalidWitnesses = NewSubmitError(-508, "Number of transaction witnesses doesn't match signers.")
 	// ErrUnknown represents SubmitError with code -500.
 	ErrUnknown = NewSubmitError(-500, "Unknown error.")
 )
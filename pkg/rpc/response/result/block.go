@@ -24,6 +24,21 @@ type (
 		Size          int           `json:"size"`
 		NextBlockHash *util.Uint256 `json:"nextblockhash,omitempty"`
 		Confirmations uint32        `json:"confirmations"`
+		Consensus     *Consensus    `json:"consensus,omitempty"`
+	}
+
+	// Consensus is the consensus metadata of the block that can be used to
+	// monitor view changes and primary nodes liveness.
+	Consensus struct {
+		// ViewNumber is the view number this block was accepted at (modulo
+		// the number of validators).
+		ViewNumber byte `json:"viewnumber"`
+		// Interval is the time passed since the previous block in
+		// milliseconds.
+		Interval uint64 `json:"interval"`
+		// Drift is the difference between Interval and configured
+		// SecondsPerBlock in milliseconds.
+		Drift int64 `json:"drift"`
 	}
 )
 
@@ -33,7 +48,7 @@ func NewBlock(b *block.Block, chain blockchainer.Blockchainer) Block {
 		Block: *b,
 		BlockMetadata: BlockMetadata{
 			Size:          io.GetVarSize(b),
-			Confirmations: chain.BlockHeight() - b.Index + 1,
+			Confirmations: confirmations(chain, b.Index),
 		},
 	}
 
@@ -42,6 +57,17 @@ func NewBlock(b *block.Block, chain blockchainer.Blockchainer) Block {
 		res.NextBlockHash = &hash
 	}
 
+	if b.Index != 0 {
+		prev, err := chain.GetHeader(b.PrevHash)
+		if err == nil {
+			cfg := chain.GetConfig()
+			res.Consensus = &Consensus{
+				ViewNumber: b.ViewNumber(cfg.ValidatorsCount),
+				Interval:   b.Timestamp - prev.Timestamp,
+				Drift:      int64(b.Timestamp-prev.Timestamp) - int64(cfg.SecondsPerBlock)*1000,
+			}
+		}
+	}
 	return res
 }
 
@@ -39,7 +39,7 @@ func NewHeader(h *block.Header, chain blockchainer.Blockchainer) Header {
 		Index:         h.Index,
 		NextConsensus: address.Uint160ToString(h.NextConsensus),
 		Witnesses:     []transaction.Witness{h.Script},
-		Confirmations: chain.BlockHeight() - h.Index + 1,
+		Confirmations: confirmations(chain, h.Index),
 	}
 
 	hash := chain.GetHeaderHash(int(h.Index) + 1)
@@ -48,3 +48,14 @@ func NewHeader(h *block.Header, chain blockchainer.Blockchainer) Header {
 	}
 	return res
 }
+
+// confirmations returns the number of confirmations of the block with the
+// given index, it's zero for blocks that are not yet processed (only their
+// headers are known).
+func confirmations(chain blockchainer.Blockchainer, index uint32) uint32 {
+	height := chain.BlockHeight()
+	if index > height {
+		return 0
+	}
+	return height - index + 1
+}
@@ -0,0 +1,19 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHeaderConfirmations(t *testing.T) {
+	chain := fakechain.NewFakeChain()
+	chain.Blockheight = 10
+
+	require.EqualValues(t, 1, NewHeader(&block.Header{Index: 10}, chain).Confirmations)
+	require.EqualValues(t, 6, NewHeader(&block.Header{Index: 5}, chain).Confirmations)
+	// Only header is known for the block.
+	require.EqualValues(t, 0, NewHeader(&block.Header{Index: 11}, chain).Confirmations)
+}
@@ -0,0 +1,21 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// BlockNotifications contains all notifications emitted during block
+// processing grouped by the execution they were emitted in.
+type BlockNotifications struct {
+	BlockHash    util.Uint256              `json:"blockhash"`
+	OnPersist    []state.NotificationEvent `json:"onpersist"`
+	Transactions []TxNotifications         `json:"transactions"`
+	PostPersist  []state.NotificationEvent `json:"postpersist"`
+}
+
+// TxNotifications contains notifications emitted by a single transaction.
+type TxNotifications struct {
+	TxHash        util.Uint256              `json:"txid"`
+	Notifications []state.NotificationEvent `json:"notifications"`
+}
@@ -0,0 +1,24 @@
+package result
+
+// ConsensusState is the state of the node's consensus process returned by
+// getconsensusstate call.
+type ConsensusState struct {
+	Height       uint32 `json:"height"`
+	View         byte   `json:"view"`
+	Primary      uint   `json:"primary"`
+	MyIndex      int    `json:"myindex"`
+	Validators   int    `json:"validators"`
+	Phase        string `json:"phase"`
+	Preparations int    `json:"preparations"`
+	Commits      int    `json:"commits"`
+	ChangeViews  int    `json:"changeviews"`
+	Timer        Timer  `json:"timer"`
+}
+
+// Timer is the consensus timer state. Deadline is the time it fires at in
+// milliseconds since Unix epoch (0 if the timer is stopped).
+type Timer struct {
+	Height   uint32 `json:"height"`
+	View     byte   `json:"view"`
+	Deadline int64  `json:"deadline"`
+}
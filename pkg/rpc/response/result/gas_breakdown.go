@@ -0,0 +1,60 @@
+package result
+
+import (
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// GasBreakdown shows how much GAS was consumed by every contract called and by
+// every syscall category during the invocation. Both lists are sorted by the
+// amount of GAS consumed in descending order.
+type GasBreakdown struct {
+	Contracts []ContractGas `json:"contracts"`
+	Syscalls  []SyscallGas  `json:"syscalls"`
+}
+
+// ContractGas is the amount of GAS consumed by the instructions of a single
+// contract (including syscalls made by it, but excluding other contracts
+// called).
+type ContractGas struct {
+	Hash        util.Uint160 `json:"hash"`
+	GasConsumed int64        `json:"gasconsumed,string"`
+}
+
+// SyscallGas is the amount of GAS consumed by syscalls of a single category
+// (like System.Storage).
+type SyscallGas struct {
+	Category    string `json:"category"`
+	GasConsumed int64  `json:"gasconsumed,string"`
+}
+
+// NewGasBreakdown returns GasBreakdown with the data from the given stats.
+func NewGasBreakdown(s *vm.GasStats) *GasBreakdown {
+	res := &GasBreakdown{
+		Contracts: make([]ContractGas, 0, len(s.Contracts)),
+		Syscalls:  make([]SyscallGas, 0, len(s.Syscalls)),
+	}
+	for h, gas := range s.Contracts {
+		res.Contracts = append(res.Contracts, ContractGas{Hash: h, GasConsumed: gas})
+	}
+	sort.Slice(res.Contracts, func(i, j int) bool {
+		a, b := res.Contracts[i], res.Contracts[j]
+		if a.GasConsumed != b.GasConsumed {
+			return a.GasConsumed > b.GasConsumed
+		}
+		return a.Hash.Less(b.Hash)
+	})
+	for c, gas := range s.Syscalls {
+		res.Syscalls = append(res.Syscalls, SyscallGas{Category: c, GasConsumed: gas})
+	}
+	sort.Slice(res.Syscalls, func(i, j int) bool {
+		a, b := res.Syscalls[i], res.Syscalls[j]
+		if a.GasConsumed != b.GasConsumed {
+			return a.GasConsumed > b.GasConsumed
+		}
+		return a.Category < b.Category
+	})
+	return res
+}
@@ -0,0 +1,40 @@
+package result
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGasBreakdown(t *testing.T) {
+	s := vm.NewGasStats()
+	s.Contracts[util.Uint160{3}] = 10
+	s.Contracts[util.Uint160{1}] = 10
+	s.Contracts[util.Uint160{2}] = 30
+	s.Syscalls["System.Storage"] = 5
+	s.Syscalls["System.Contract"] = 20
+	s.Syscalls["System.Runtime"] = 5
+
+	b := NewGasBreakdown(s)
+	require.Equal(t, []ContractGas{
+		{Hash: util.Uint160{2}, GasConsumed: 30},
+		{Hash: util.Uint160{1}, GasConsumed: 10},
+		{Hash: util.Uint160{3}, GasConsumed: 10},
+	}, b.Contracts)
+	require.Equal(t, []SyscallGas{
+		{Category: "System.Contract", GasConsumed: 20},
+		{Category: "System.Runtime", GasConsumed: 5},
+		{Category: "System.Storage", GasConsumed: 5},
+	}, b.Syscalls)
+
+	inv := &Invoke{State: "HALT", Stack: []stackitem.Item{}, GasBreakdown: b}
+	data, err := json.Marshal(inv)
+	require.NoError(t, err)
+	actual := new(Invoke)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, inv, actual)
+}
@@ -0,0 +1,32 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+)
+
+// Interop is a description of a syscall along with its price.
+type Interop struct {
+	ID   uint32 `json:"id"`
+	Name string `json:"name"`
+	// Price is a syscall price in execution fee units.
+	Price int64 `json:"price"`
+	// ExecutionFee is an amount of GAS charged for the syscall invocation,
+	// it's a Price multiplied by the current execution fee factor.
+	ExecutionFee  int64             `json:"executionfee"`
+	ParamCount    int               `json:"paramcount"`
+	RequiredFlags callflag.CallFlag `json:"requiredflags"`
+}
+
+// NewInterop creates Interop description of the given function using the
+// given execution fee factor.
+func NewInterop(f *interop.Function, execFeeFactor int64) Interop {
+	return Interop{
+		ID:            f.ID,
+		Name:          f.Name,
+		Price:         f.Price,
+		ExecutionFee:  f.Price * execFeeFactor,
+		ParamCount:    f.ParamCount,
+		RequiredFlags: f.RequiredFlags,
+	}
+}
@@ -19,6 +19,8 @@ type Invoke struct {
 	Stack                  []stackitem.Item
 	FaultException         string
 	Transaction            *transaction.Transaction
+	Trace                  *InvokeTrace
+	GasBreakdown           *GasBreakdown
 	maxIteratorResultItems int
 }
 
@@ -41,6 +43,8 @@ type invokeAux struct {
 	Stack          json.RawMessage `json:"stack"`
 	FaultException string          `json:"exception,omitempty"`
 	Transaction    []byte          `json:"tx,omitempty"`
+	Trace          *InvokeTrace    `json:"trace,omitempty"`
+	GasBreakdown   *GasBreakdown   `json:"gasbreakdown,omitempty"`
 }
 
 type iteratorAux struct {
@@ -110,6 +114,8 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 		Stack:          st,
 		FaultException: r.FaultException,
 		Transaction:    txbytes,
+		Trace:          r.Trace,
+		GasBreakdown:   r.GasBreakdown,
 	})
 }
 
@@ -165,5 +171,7 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	r.State = aux.State
 	r.FaultException = aux.FaultException
 	r.Transaction = tx
+	r.Trace = aux.Trace
+	r.GasBreakdown = aux.GasBreakdown
 	return nil
 }
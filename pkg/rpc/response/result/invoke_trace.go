@@ -0,0 +1,101 @@
+package result
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// InvokeTrace is an instruction-level trace of the script execution returned
+// by invoke* calls if tracing is enabled.
+type InvokeTrace struct {
+	Entries   []vm.TraceEntry
+	Truncated bool
+}
+
+type invokeTraceAux struct {
+	Entries   []traceEntryAux `json:"entries"`
+	Truncated bool            `json:"truncated"`
+}
+
+type traceEntryAux struct {
+	ScriptHash     util.Uint160      `json:"scripthash"`
+	IP             int               `json:"ip"`
+	Opcode         string            `json:"opcode"`
+	GasConsumed    int64             `json:"gasconsumed,string"`
+	Stack          []json.RawMessage `json:"stack"`
+	StackTruncated bool              `json:"stacktruncated,omitempty"`
+}
+
+// NewInvokeTrace returns InvokeTrace with the instructions recorded by the
+// given tracer.
+func NewInvokeTrace(t *vm.Tracer) *InvokeTrace {
+	return &InvokeTrace{
+		Entries:   t.Entries(),
+		Truncated: t.Truncated(),
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t InvokeTrace) MarshalJSON() ([]byte, error) {
+	aux := invokeTraceAux{
+		Entries:   make([]traceEntryAux, len(t.Entries)),
+		Truncated: t.Truncated,
+	}
+	for i, e := range t.Entries {
+		st := make([]json.RawMessage, len(e.Stack))
+		for j := range e.Stack {
+			data, err := stackitem.ToJSONWithTypes(e.Stack[j])
+			if err != nil {
+				data = []byte(`"error: recursive reference"`)
+			}
+			st[j] = data
+		}
+		aux.Entries[i] = traceEntryAux{
+			ScriptHash:     e.ScriptHash,
+			IP:             e.IP,
+			Opcode:         e.Opcode.String(),
+			GasConsumed:    e.GasConsumed,
+			Stack:          st,
+			StackTruncated: e.StackTruncated,
+		}
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *InvokeTrace) UnmarshalJSON(data []byte) error {
+	aux := new(invokeTraceAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	entries := make([]vm.TraceEntry, len(aux.Entries))
+	for i, e := range aux.Entries {
+		op, err := opcode.FromString(e.Opcode)
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+		st := make([]stackitem.Item, len(e.Stack))
+		for j := range e.Stack {
+			st[j], err = stackitem.FromJSONWithTypes(e.Stack[j])
+			if err != nil {
+				return fmt.Errorf("entry %d: invalid stack item: %w", i, err)
+			}
+		}
+		entries[i] = vm.TraceEntry{
+			ScriptHash:     e.ScriptHash,
+			IP:             e.IP,
+			Opcode:         op,
+			GasConsumed:    e.GasConsumed,
+			Stack:          st,
+			StackTruncated: e.StackTruncated,
+		}
+	}
+	t.Entries = entries
+	t.Truncated = aux.Truncated
+	return nil
+}
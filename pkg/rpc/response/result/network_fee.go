@@ -0,0 +1,18 @@
+package result
+
+// NetworkFeeEstimate represents a result of estimatenetworkfee RPC call.
+type NetworkFeeEstimate struct {
+	// Percentile is the percentile FeePerByte is calculated for.
+	Percentile int `json:"percentile"`
+	// Blocks is the number of the latest blocks analyzed.
+	Blocks int `json:"blocks"`
+	// Transactions is the number of transactions analyzed (from blocks and
+	// the memory pool).
+	Transactions int `json:"transactions"`
+	// FeePerByte is the network fee per byte of transaction size paid by
+	// Percentile percent of transactions analyzed (it's MinFeePerByte if
+	// there are no transactions).
+	FeePerByte int64 `json:"feeperbyte,string"`
+	// MinFeePerByte is the current Policy contract fee per byte.
+	MinFeePerByte int64 `json:"minfeeperbyte,string"`
+}
@@ -2,9 +2,28 @@ package result
 
 import "github.com/nspcc-dev/neo-go/pkg/util"
 
-// RawMempool represents a result of getrawmempool RPC call.
-type RawMempool struct {
-	Height     uint32         `json:"height"`
-	Verified   []util.Uint256 `json:"verified"`
-	Unverified []util.Uint256 `json:"unverified"`
-}
+type (
+	// RawMempool represents a result of getrawmempool RPC call.
+	RawMempool struct {
+		Height     uint32         `json:"height"`
+		Verified   []util.Uint256 `json:"verified"`
+		Unverified []util.Uint256 `json:"unverified"`
+		// Transactions contains details of verified transactions, it's
+		// a neo-go extension.
+		Transactions []MempoolTx `json:"transactions,omitempty"`
+	}
+
+	// MempoolTx contains details of a pooled transaction.
+	MempoolTx struct {
+		Hash       util.Uint256 `json:"hash"`
+		Sender     string       `json:"sender"`
+		Size       int          `json:"size"`
+		SystemFee  int64        `json:"sysfee,string"`
+		NetworkFee int64        `json:"netfee,string"`
+		// Height is the blockchain height at the moment of transaction
+		// addition.
+		Height uint32 `json:"height"`
+		// Time is the time of transaction addition in milliseconds.
+		Time uint64 `json:"time"`
+	}
+)
@@ -0,0 +1,10 @@
+package result
+
+import "github.com/nspcc-dev/neo-go/pkg/util"
+
+// RawNotaryPool represents a result of getrawnotarypool RPC call. Hashes
+// maps main transaction hashes to the hashes of fallback transactions of
+// the notary requests pooled for them.
+type RawNotaryPool struct {
+	Hashes map[util.Uint256][]util.Uint256 `json:"hashes"`
+}
@@ -0,0 +1,45 @@
+package result
+
+import (
+	"encoding/binary"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+)
+
+// StateDiff is a result of getstatediff RPC call, it contains contract
+// storage changes made by some block.
+type StateDiff struct {
+	Index   uint32          `json:"index"`
+	Changes []StorageChange `json:"changes"`
+}
+
+// StorageChange is a change of a single contract storage item. Old value is
+// nil for new items and new value is nil for deleted ones.
+type StorageChange struct {
+	ID       int32  `json:"id"`
+	Key      []byte `json:"key"`
+	OldValue []byte `json:"oldvalue"`
+	NewValue []byte `json:"newvalue"`
+}
+
+// NewStateDiff creates StateDiff from the given block DB changes journal
+// leaving only contract storage changes.
+func NewStateDiff(d *state.StateDiff) StateDiff {
+	res := StateDiff{
+		Index:   d.Index,
+		Changes: make([]StorageChange, 0, len(d.Changes)),
+	}
+	for _, c := range d.Changes {
+		if len(c.Key) < 5 || c.Key[0] != byte(storage.STStorage) {
+			continue
+		}
+		res.Changes = append(res.Changes, StorageChange{
+			ID:       int32(binary.LittleEndian.Uint32(c.Key[1:])),
+			Key:      c.Key[5:],
+			OldValue: c.Old,
+			NewValue: c.New,
+		})
+	}
+	return res
+}
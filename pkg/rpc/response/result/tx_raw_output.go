@@ -26,7 +26,8 @@ type TransactionMetadata struct {
 	VMState       string       `json:"vmstate,omitempty"`
 }
 
-// NewTransactionOutputRaw returns a new ransactionOutputRaw object.
+// NewTransactionOutputRaw returns a new ransactionOutputRaw object. VM state
+// is omitted if appExecResult is nil.
 func NewTransactionOutputRaw(tx *transaction.Transaction, header *block.Header, appExecResult *state.AppExecResult, chain blockchainer.Blockchainer) TransactionOutputRaw {
 	result := TransactionOutputRaw{
 		Transaction: *tx,
@@ -40,7 +41,9 @@ func NewTransactionOutputRaw(tx *transaction.Transaction, header *block.Header,
 		Blockhash:     header.Hash(),
 		Confirmations: confirmations,
 		Timestamp:     header.Timestamp,
-		VMState:       appExecResult.VMState.String(),
+	}
+	if appExecResult != nil {
+		result.TransactionMetadata.VMState = appExecResult.VMState.String()
 	}
 	return result
 }
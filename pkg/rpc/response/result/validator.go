@@ -11,3 +11,14 @@ type Validator struct {
 	Votes     int64          `json:"votes,string"`
 	Active    bool           `json:"active"`
 }
+
+// Candidate represents NEO candidate state returned by getcandidates call.
+type Candidate struct {
+	PublicKey keys.PublicKey `json:"publickey"`
+	Votes     int64          `json:"votes,string"`
+	// Active is true for candidates that are next block validators.
+	Active bool `json:"active"`
+	// Registered is false for candidates that were unregistered, but
+	// still have some votes.
+	Registered bool `json:"registered"`
+}
@@ -0,0 +1,19 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// WalletBalance is a result for the getwalletbalance RPC call.
+type WalletBalance struct {
+	Address string `json:"address"`
+	// Height is the index of the last block balances are actual for.
+	Height   uint32             `json:"height"`
+	Balances []WalletAssetValue `json:"balance"`
+}
+
+// WalletAssetValue represents balance of the single token contract.
+type WalletAssetValue struct {
+	Asset  util.Uint160 `json:"assethash"`
+	Amount string       `json:"amount"`
+}
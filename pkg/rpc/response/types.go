@@ -64,3 +64,9 @@ type Notification struct {
 	Event   EventID       `json:"method"`
 	Payload []interface{} `json:"params"`
 }
+
+// MissedEvents is a payload of `event_missed` notification, it contains the
+// number of events that were not delivered to the client.
+type MissedEvents struct {
+	Count int `json:"count"`
+}
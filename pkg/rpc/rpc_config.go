@@ -1,23 +1,113 @@
 package rpc
 
 import (
+	"time"
+
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 )
 
+// Websocket subscriber queue overflow policies.
+const (
+	// OverflowDropNewest drops new events until there is some space in the
+	// queue.
+	OverflowDropNewest = "drop-newest"
+	// OverflowDropOldest drops the oldest queued events to make space for
+	// the new ones.
+	OverflowDropOldest = "drop-oldest"
+	// OverflowDisconnect disconnects the client.
+	OverflowDisconnect = "disconnect"
+	// OverflowBlock waits for some space in the queue (stalling event
+	// dispatch for all clients) for BlockTimeout and drops the new event
+	// if there is still no space.
+	OverflowBlock = "block"
+)
+
 type (
 	// Config is an RPC service configuration information.
 	Config struct {
-		Address              string `yaml:"Address"`
-		Enabled              bool   `yaml:"Enabled"`
-		EnableCORSWorkaround bool   `yaml:"EnableCORSWorkaround"`
+		Address              string      `yaml:"Address"`
+		Audit                AuditConfig `yaml:"Audit"`
+		Enabled              bool        `yaml:"Enabled"`
+		EnableCORSWorkaround bool        `yaml:"EnableCORSWorkaround"`
+		// EnableConsensusState enables getconsensusstate debug call, it's
+		// the same as listing it in EnabledMethods.
+		EnableConsensusState bool `yaml:"EnableConsensusState"`
+		// DisabledMethods is a list of RPC methods that are not served by
+		// the node, methodNotFound error is returned for them.
+		DisabledMethods []string `yaml:"DisabledMethods"`
+		// EnabledMethods is a list of unsafe (debug-only) RPC methods
+		// enabled on the node, they're disabled by default.
+		EnabledMethods []string `yaml:"EnabledMethods"`
+		// InvokePool limits concurrency and duration of invoke* calls.
+		InvokePool InvokePoolConfig `yaml:"InvokePool"`
+		// InvokeTrace configures instruction-level tracing of invoke*
+		// calls.
+		InvokeTrace InvokeTraceConfig `yaml:"InvokeTrace"`
 		// MaxGasInvoke is a maximum amount of gas which
 		// can be spent during RPC call.
 		MaxGasInvoke           fixedn.Fixed8 `yaml:"MaxGasInvoke"`
 		MaxIteratorResultItems int           `yaml:"MaxIteratorResultItems"`
 		Port                   uint16        `yaml:"Port"`
+		SubscriberQueue        QueueConfig   `yaml:"SubscriberQueue"`
 		TLSConfig              TLSConfig     `yaml:"TLSConfig"`
 	}
 
+	// QueueConfig describes event queue of a single websocket subscriber.
+	QueueConfig struct {
+		// Size is the maximum number of events queued for the client.
+		Size int `yaml:"Size"`
+		// OverflowPolicy is the action taken when the queue is full, it's
+		// one of Overflow* constants, OverflowDropNewest is the default.
+		OverflowPolicy string `yaml:"OverflowPolicy"`
+		// BlockTimeout is the maximum time to wait for space in the queue
+		// for OverflowBlock policy.
+		BlockTimeout time.Duration `yaml:"BlockTimeout"`
+	}
+
+	// InvokePoolConfig describes execution of invoke* calls.
+	InvokePoolConfig struct {
+		// Workers is the maximum number of invoke* calls executed
+		// concurrently (the number of CPUs by default), other calls wait
+		// for a free worker.
+		Workers int `yaml:"Workers"`
+		// Timeout is the maximum wall-clock time of a single invocation
+		// including waiting for a worker, zero means no limit.
+		Timeout time.Duration `yaml:"Timeout"`
+	}
+
+	// InvokeTraceConfig describes execution tracing of invoke* calls.
+	InvokeTraceConfig struct {
+		// Enabled makes all invoke* calls return execution trace, it's
+		// intended to be used on debugging nodes only.
+		Enabled bool `yaml:"Enabled"`
+		// AllowRequests allows to request execution trace for invokescript
+		// explicitly, such requests are rejected otherwise.
+		AllowRequests bool `yaml:"AllowRequests"`
+		// MaxEntries is the maximum number of instructions recorded
+		// (vm.DefaultTraceLimit is used by default).
+		MaxEntries int `yaml:"MaxEntries"`
+		// MaxStackItems is the maximum number of stack items recorded for
+		// every instruction (vm.DefaultTraceStackItems is used by default).
+		MaxStackItems int `yaml:"MaxStackItems"`
+		// MaxEntrySize is the maximum size of stack recorded for every
+		// instruction (vm.DefaultTraceEntrySize is used by default).
+		MaxEntrySize int `yaml:"MaxEntrySize"`
+		// MaxSize is the maximum size of stacks recorded for all
+		// instructions (vm.DefaultTraceSize is used by default).
+		MaxSize int `yaml:"MaxSize"`
+	}
+
+	// AuditConfig describes audit log of RPC calls changing node state
+	// (sendrawtransaction, submitblock and submitoracleresponse).
+	AuditConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// Path is the file JSON records are appended to.
+		Path string `yaml:"Path"`
+		// Syslog enables sending records to the system logger instead of
+		// file.
+		Syslog bool `yaml:"Syslog"`
+	}
+
 	// TLSConfig describes SSL/TLS configuration.
 	TLSConfig struct {
 		Address  string `yaml:"Address"`
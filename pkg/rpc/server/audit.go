@@ -0,0 +1,172 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	nio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+type (
+	// auditLog writes records of state-changing RPC calls.
+	auditLog struct {
+		lock sync.Mutex
+		w    io.WriteCloser
+	}
+
+	// auditRecord is a single audit log entry.
+	auditRecord struct {
+		Time   time.Time     `json:"time"`
+		Method string        `json:"method"`
+		Origin string        `json:"origin"`
+		Hash   *util.Uint256 `json:"hash,omitempty"`
+		Result string        `json:"result"`
+		Error  string        `json:"error,omitempty"`
+	}
+)
+
+// Audit record results.
+const (
+	auditResultOK    = "ok"
+	auditResultError = "error"
+)
+
+// auditedMethods contains methods recorded to the audit log along with
+// functions calculating their payload hash.
+var auditedMethods = map[string]func(*Server, request.Params) (util.Uint256, bool){
+	"sendrawtransaction":   (*Server).auditTxHash,
+	"submitblock":          (*Server).auditBlockHash,
+	"submitoracleresponse": (*Server).auditOracleResponseHash,
+}
+
+// newAuditLog opens audit log according to the configuration.
+func newAuditLog(cfg rpc.AuditConfig) (*auditLog, error) {
+	var (
+		w   io.WriteCloser
+		err error
+	)
+	switch {
+	case cfg.Syslog:
+		w, err = newSyslogWriter()
+	case cfg.Path != "":
+		if err = nio.MakeDirForFile(cfg.Path, "RPC audit"); err != nil {
+			return nil, err
+		}
+		w, err = os.OpenFile(cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	default:
+		return nil, errors.New("RPC audit log path is not set")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &auditLog{w: w}, nil
+}
+
+// write writes the record as a single JSON line.
+func (a *auditLog) write(rec *auditRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	_, err = a.w.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes underlying writer.
+func (a *auditLog) Close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.w.Close()
+}
+
+// auditCall records the result of the call to the audit log if the method
+// is audited.
+func (s *Server) auditCall(method string, origin string, params request.Params, resErr *response.Error) {
+	getHash, ok := auditedMethods[method]
+	if !ok || s.audit == nil {
+		return
+	}
+	rec := &auditRecord{
+		Time:   time.Now().UTC(),
+		Method: method,
+		Origin: origin,
+		Result: auditResultOK,
+	}
+	if h, ok := getHash(s, params); ok {
+		rec.Hash = &h
+	}
+	if resErr != nil {
+		rec.Result = auditResultError
+		rec.Error = resErr.Error()
+	}
+	if err := s.audit.write(rec); err != nil {
+		s.log.Error("failed to write RPC audit record", zap.Error(err))
+	}
+}
+
+// originFromAddr returns IP address from the remote address of the request.
+func originFromAddr(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+func (s *Server) auditTxHash(ps request.Params) (util.Uint256, bool) {
+	b, err := ps.Value(0).GetBytesBase64()
+	if err != nil {
+		return util.Uint256{}, false
+	}
+	tx, err := transaction.NewTransactionFromBytes(b)
+	if err != nil {
+		return hash.Sha256(b), true
+	}
+	return tx.Hash(), true
+}
+
+func (s *Server) auditBlockHash(ps request.Params) (util.Uint256, bool) {
+	b, err := ps.Value(0).GetBytesBase64()
+	if err != nil {
+		return util.Uint256{}, false
+	}
+	blk := block.New(s.stateRootEnabled)
+	r := nio.NewBinReaderFromBuf(b)
+	blk.DecodeBinary(r)
+	if r.Err != nil {
+		return hash.Sha256(b), true
+	}
+	return blk.Hash(), true
+}
+
+func (s *Server) auditOracleResponseHash(ps request.Params) (util.Uint256, bool) {
+	pub, err := ps.Value(0).GetBytesBase64()
+	if err != nil {
+		return util.Uint256{}, false
+	}
+	reqID, err := ps.Value(1).GetInt()
+	if err != nil {
+		return util.Uint256{}, false
+	}
+	txSig, err := ps.Value(2).GetBytesBase64()
+	if err != nil {
+		return util.Uint256{}, false
+	}
+	return hash.Sha256(broadcaster.GetMessage(pub, uint64(reqID), txSig)), true
+}
@@ -0,0 +1,14 @@
+// +build windows plan9
+
+package server
+
+import (
+	"errors"
+	"io"
+)
+
+// newSyslogWriter returns an error as there is no system logger on this
+// platform.
+func newSyslogWriter() (io.WriteCloser, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
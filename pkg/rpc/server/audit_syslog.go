@@ -0,0 +1,13 @@
+// +build !windows,!plan9
+
+package server
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// newSyslogWriter connects to the system logger.
+func newSyslogWriter() (io.WriteCloser, error) {
+	return syslog.New(syslog.LOG_NOTICE|syslog.LOG_DAEMON, "neo-go")
+}
@@ -3,9 +3,14 @@ package server
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nnsrecords"
@@ -14,7 +19,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -695,6 +702,194 @@ func TestCreateTxFromScript(t *testing.T) {
 	})
 }
 
+func TestInvokerActor(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+
+	priv := testchain.PrivateKeyByID(0)
+	acc := wallet.NewAccountFromPrivateKey(priv)
+	_, err = client.NewSimpleActor(c, acc)
+	require.Error(t, err) // not initialized
+	require.NoError(t, c.Init())
+
+	gasHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+
+	t.Run("invoker", func(t *testing.T) {
+		signers := []transaction.Signer{{Account: priv.GetScriptHash()}}
+		inv := client.NewInvoker(c, signers)
+		require.Equal(t, signers, inv.Signers())
+
+		res, err := inv.Call(gasHash, "symbol")
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.Equal(t, 1, len(res.Stack))
+		require.Equal(t, []byte("GAS"), res.Stack[0].Value())
+
+		res, err = inv.Call(gasHash, "unknown")
+		require.NoError(t, err)
+		require.Equal(t, "FAULT", res.State)
+	})
+	t.Run("bad signers", func(t *testing.T) {
+		_, err := client.NewActor(c, nil)
+		require.Error(t, err)
+		_, err = client.NewActor(c, []client.SignerAccount{{
+			Signer:  transaction.Signer{Account: util.Uint160{1, 2, 3}},
+			Account: acc,
+		}})
+		require.Error(t, err)
+	})
+
+	a, err := client.NewSimpleActor(c, acc)
+	require.NoError(t, err)
+	require.Equal(t, priv.GetScriptHash(), a.Sender())
+
+	t.Run("fault", func(t *testing.T) {
+		_, err := a.MakeCall(gasHash, "unknown")
+		require.Error(t, err)
+	})
+	t.Run("send and wait", func(t *testing.T) {
+		tx, err := a.MakeCall(gasHash, "transfer", a.Sender(), util.Uint160{1, 2, 3}, int64(1000), nil)
+		require.NoError(t, err)
+		require.Equal(t, []transaction.Signer{{
+			Account: a.Sender(),
+			Scopes:  transaction.CalledByEntry,
+		}}, tx.Signers)
+		require.True(t, tx.ValidUntilBlock > chain.BlockHeight())
+		require.NoError(t, chain.VerifyTx(tx))
+
+		h, vub, err := a.Send(tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), h)
+		require.Equal(t, tx.ValidUntilBlock, vub)
+
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+		log, err := a.Wait(h, vub, nil)
+		require.NoError(t, err)
+		require.Equal(t, h, log.Container)
+		require.Equal(t, 1, len(log.Executions))
+		require.Equal(t, vm.HaltState, log.Executions[0].VMState)
+	})
+	t.Run("wait, not accepted", func(t *testing.T) {
+		_, err := a.Wait(util.Uint256{1, 2, 3}, chain.BlockHeight()-2, nil)
+		require.True(t, errors.Is(err, client.ErrTxNotAccepted))
+	})
+	t.Run("wait, error", func(t *testing.T) {
+		testErr := errors.New("some error")
+		_, err := a.Wait(util.Uint256{}, 0, testErr)
+		require.True(t, errors.Is(err, testErr))
+	})
+}
+
+func TestClient_WaitForTransaction(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	gasHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+	a, err := client.NewSimpleActor(c, wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0)))
+	require.NoError(t, err)
+
+	t.Run("accepted", func(t *testing.T) {
+		tx, err := a.MakeCall(gasHash, "transfer", a.Sender(), util.Uint160{1, 2, 3}, int64(1000), nil)
+		require.NoError(t, err)
+		h, vub, err := a.Send(tx)
+		require.NoError(t, err)
+
+		errCh := make(chan error, 1)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			errCh <- chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx))
+		}()
+		log, err := c.WaitForTransaction(context.Background(), h, vub)
+		require.NoError(t, err)
+		require.NoError(t, <-errCh)
+		require.Equal(t, h, log.Container)
+		require.Equal(t, vm.HaltState, log.Executions[0].VMState)
+	})
+	t.Run("not accepted", func(t *testing.T) {
+		_, err := c.WaitForTransaction(context.Background(), util.Uint256{1, 2, 3}, chain.BlockHeight()-2)
+		require.True(t, errors.Is(err, client.ErrTxNotAccepted))
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := c.WaitForTransaction(ctx, util.Uint256{1, 2, 3}, chain.BlockHeight()+100)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func TestClient_GetWalletBalance(t *testing.T) {
+	chain, _, cfg, logger := getUnitTestChain(t, false, false)
+	defer chain.Close()
+	cfg.ApplicationConfiguration.BalanceTracker = config.BalanceTracker{
+		Enabled:  true,
+		Accounts: []string{testchain.MultisigAddress()},
+	}
+	netSrv, err := network.NewServer(network.NewServerConfig(cfg), chain, logger)
+	require.NoError(t, err)
+	bt := netSrv.GetBalanceTracker()
+	require.NotNil(t, bt)
+	bt.Start()
+	defer bt.Shutdown()
+
+	rpcSrv, err := New(chain, cfg.ApplicationConfiguration.RPC, netSrv, nil, logger)
+	require.NoError(t, err)
+	rpcSrv.Start(make(chan error, 2))
+	defer func() { _ = rpcSrv.Shutdown() }()
+	httpSrv := httptest.NewServer(http.HandlerFunc(rpcSrv.handleHTTPRequest))
+	defer httpSrv.Close()
+
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	require.Eventually(t, func() bool {
+		_, h, _ := bt.Balances(testchain.MultisigScriptHash())
+		return h == chain.BlockHeight()
+	}, time.Second, 10*time.Millisecond)
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	res, err := c.GetWalletBalance(testchain.MultisigScriptHash())
+	require.NoError(t, err)
+	require.Equal(t, testchain.MultisigAddress(), res.Address)
+	require.Equal(t, chain.BlockHeight(), res.Height)
+
+	nep17, err := c.GetNEP17Balances(testchain.MultisigScriptHash())
+	require.NoError(t, err)
+	expected := make([]result.WalletAssetValue, 0, len(nep17.Balances))
+	for _, b := range nep17.Balances {
+		expected = append(expected, result.WalletAssetValue{Asset: b.Asset, Amount: b.Amount})
+	}
+	require.ElementsMatch(t, expected, res.Balances)
+
+	_, err = c.GetWalletBalance(util.Uint160{1, 2, 3})
+	require.Error(t, err)
+
+	t.Run("disabled", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer func() { _ = rpcSrv.Shutdown() }()
+
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+		_, err = c.GetWalletBalance(testchain.MultisigScriptHash())
+		require.Error(t, err)
+	})
+}
+
 func TestCreateNEP17TransferTx(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -773,6 +968,14 @@ func TestClient_GetNativeContracts(t *testing.T) {
 	cs, err := c.GetNativeContracts()
 	require.NoError(t, err)
 	require.Equal(t, chain.GetNatives(), cs)
+
+	for _, n := range cs {
+		h, err := c.GetNativeContractHash(n.Manifest.Name)
+		require.NoError(t, err)
+		require.Equal(t, n.Hash, h)
+	}
+	_, err = c.GetNativeContractHash("unknown")
+	require.Error(t, err)
 }
 
 func TestClient_NEP11(t *testing.T) {
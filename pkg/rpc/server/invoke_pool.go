@@ -0,0 +1,74 @@
+package server
+
+import (
+	"errors"
+	"runtime"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// deadlineCheckInterval is the number of VM instructions executed between
+// invocation deadline checks.
+const deadlineCheckInterval = 1024
+
+var (
+	errInvokeBusy    = errors.New("no free invocation worker")
+	errInvokeTimeout = errors.New("invocation timeout")
+)
+
+// invokePool limits the number of concurrently running test invocations and
+// their execution time.
+type invokePool struct {
+	slots   chan struct{}
+	timeout time.Duration
+}
+
+// newInvokePool creates a new invokePool according to the configuration.
+func newInvokePool(cfg rpc.InvokePoolConfig) *invokePool {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &invokePool{
+		slots:   make(chan struct{}, workers),
+		timeout: cfg.Timeout,
+	}
+}
+
+// run waits for a free worker and runs the loaded VM in it. Execution is
+// stopped with errInvokeTimeout if it takes longer than the timeout configured,
+// errInvokeBusy is returned if there is no free worker during this time.
+// Errors of the script itself are returned as is.
+func (p *invokePool) run(v *vm.VM) error {
+	var (
+		deadline time.Time
+		expired  <-chan time.Time
+	)
+	if p.timeout > 0 {
+		deadline = time.Now().Add(p.timeout)
+		t := time.NewTimer(p.timeout)
+		defer t.Stop()
+		expired = t.C
+	}
+	select {
+	case p.slots <- struct{}{}:
+	case <-expired:
+		return errInvokeBusy
+	}
+	defer func() { <-p.slots }()
+
+	if p.timeout <= 0 || !v.Ready() {
+		return v.Run()
+	}
+	for i := 0; !v.HasStopped(); i++ {
+		if i%deadlineCheckInterval == 0 && time.Now().After(deadline) {
+			return errInvokeTimeout
+		}
+		if err := v.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvokePool(t *testing.T) {
+	newVM := func(script ...opcode.Opcode) *vm.VM {
+		prog := make([]byte, len(script))
+		for i := range script {
+			prog[i] = byte(script[i])
+		}
+		v := vm.New()
+		v.LoadScript(prog)
+		return v
+	}
+	// NOP; JMP -1.
+	loop := []opcode.Opcode{opcode.NOP, opcode.JMP, 0xff}
+
+	t.Run("default", func(t *testing.T) {
+		p := newInvokePool(rpc.InvokePoolConfig{})
+		require.Equal(t, runtime.NumCPU(), cap(p.slots))
+
+		v := newVM(opcode.PUSH1, opcode.RET)
+		require.NoError(t, p.run(v))
+		require.True(t, v.HasHalted())
+		require.Equal(t, 0, len(p.slots))
+	})
+	t.Run("fault", func(t *testing.T) {
+		p := newInvokePool(rpc.InvokePoolConfig{Timeout: time.Second})
+		v := newVM(opcode.ABORT)
+		require.Error(t, p.run(v))
+		require.True(t, v.HasFailed())
+		require.Equal(t, 0, len(p.slots))
+	})
+	t.Run("timeout", func(t *testing.T) {
+		p := newInvokePool(rpc.InvokePoolConfig{Timeout: 50 * time.Millisecond})
+		require.Equal(t, errInvokeTimeout, p.run(newVM(loop...)))
+		require.Equal(t, 0, len(p.slots))
+	})
+	t.Run("busy", func(t *testing.T) {
+		p := newInvokePool(rpc.InvokePoolConfig{Workers: 1, Timeout: 50 * time.Millisecond})
+		p.slots <- struct{}{}
+		require.Equal(t, errInvokeBusy, p.run(newVM(opcode.RET)))
+		<-p.slots
+		require.NoError(t, p.run(newVM(opcode.RET)))
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -39,6 +41,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"go.uber.org/zap"
 )
@@ -54,6 +57,9 @@ type (
 		coreServer       *network.Server
 		oracle           *oracle.Oracle
 		log              *zap.Logger
+		audit            *auditLog
+		invokes          *invokePool
+		disabledMethods  map[string]bool
 		https            *http.Server
 		shutdown         chan struct{}
 
@@ -90,10 +96,17 @@ const (
 
 	// Maximum number of elements for get*transfers requests.
 	maxTransfersLimit = 1000
+
+	// Default parameters of estimatenetworkfee requests and the maximum
+	// number of blocks analyzed.
+	defaultFeeEstimatePercentile = 50
+	defaultFeeEstimateBlocks     = 10
+	maxFeeEstimateBlocks         = 100
 )
 
 var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *response.Error){
 	"calculatenetworkfee":    (*Server).calculateNetworkFee,
+	"estimatenetworkfee":     (*Server).estimateNetworkFee,
 	"getapplicationlog":      (*Server).getApplicationLog,
 	"getbestblockhash":       (*Server).getBestBlockHash,
 	"getblock":               (*Server).getBlock,
@@ -101,24 +114,32 @@ var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *respon
 	"getblockhash":           (*Server).getBlockHash,
 	"getblockheader":         (*Server).getBlockHeader,
 	"getblockheadercount":    (*Server).getBlockHeaderCount,
+	"getblocknotifications":  (*Server).getBlockNotifications,
 	"getblocksysfee":         (*Server).getBlockSysFee,
+	"getcandidates":          (*Server).getCandidates,
 	"getcommittee":           (*Server).getCommittee,
 	"getconnectioncount":     (*Server).getConnectionCount,
+	"getconsensusstate":      (*Server).getConsensusState,
 	"getcontractstate":       (*Server).getContractState,
+	"getinteropprice":        (*Server).getInteropPrice,
+	"getinterops":            (*Server).getInterops,
 	"getnativecontracts":     (*Server).getNativeContracts,
 	"getnep17balances":       (*Server).getNEP17Balances,
 	"getnep17transfers":      (*Server).getNEP17Transfers,
 	"getpeers":               (*Server).getPeers,
 	"getproof":               (*Server).getProof,
 	"getrawmempool":          (*Server).getRawMempool,
+	"getrawnotarypool":       (*Server).getRawNotaryPool,
 	"getrawtransaction":      (*Server).getrawtransaction,
 	"getstateheight":         (*Server).getStateHeight,
+	"getstatediff":           (*Server).getStateDiff,
 	"getstateroot":           (*Server).getStateRoot,
 	"getstorage":             (*Server).getStorage,
 	"gettransactionheight":   (*Server).getTransactionHeight,
 	"getunclaimedgas":        (*Server).getUnclaimedGas,
 	"getnextblockvalidators": (*Server).getNextBlockValidators,
 	"getversion":             (*Server).getVersion,
+	"getwalletbalance":       (*Server).getWalletBalance,
 	"invokefunction":         (*Server).invokeFunction,
 	"invokescript":           (*Server).invokescript,
 	"invokecontractverify":   (*Server).invokeContractVerify,
@@ -130,6 +151,25 @@ var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *respon
 	"verifyproof":            (*Server).verifyProof,
 }
 
+// unsafeMethods is a set of debug-only methods that are disabled unless
+// explicitly enabled in the configuration.
+var unsafeMethods = map[string]bool{
+	"getconsensusstate": true,
+}
+
+// headersOnlyMethods is a set of methods that can be served by the node
+// running in headers-only mode, all of them don't need contract storage.
+var headersOnlyMethods = map[string]bool{
+	"getblockhash":        true,
+	"getblockheader":      true,
+	"getblockheadercount": true,
+	"getconnectioncount":  true,
+	"getpeers":            true,
+	"getversion":          true,
+	"validateaddress":     true,
+	"verifyproof":         true,
+}
+
 var rpcWsHandlers = map[string]func(*Server, request.Params, *subscriber) (interface{}, *response.Error){
 	"subscribe":   (*Server).subscribe,
 	"unsubscribe": (*Server).unsubscribe,
@@ -143,9 +183,14 @@ var invalidBlockHeightError = func(index int, height int) *response.Error {
 // doesn't set any Error function.
 var upgrader = websocket.Upgrader{}
 
-// New creates a new Server struct.
+// New creates a new Server struct. It returns an error if configuration
+// references unknown RPC methods.
 func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.Server,
-	orc *oracle.Oracle, log *zap.Logger) Server {
+	orc *oracle.Oracle, log *zap.Logger) (*Server, error) {
+	disabled, err := disabledMethods(conf)
+	if err != nil {
+		return nil, err
+	}
 	httpServer := &http.Server{
 		Addr: conf.Address + ":" + strconv.FormatUint(uint64(conf.Port), 10),
 	}
@@ -160,7 +205,7 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 	if orc != nil {
 		orc.SetBroadcaster(broadcaster.New(orc.MainCfg, log))
 	}
-	return Server{
+	return &Server{
 		Server:           httpServer,
 		chain:            chain,
 		config:           conf,
@@ -169,6 +214,8 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		coreServer:       coreServer,
 		log:              log,
 		oracle:           orc,
+		invokes:          newInvokePool(conf.InvokePool),
+		disabledMethods:  disabled,
 		https:            tlsServer,
 		shutdown:         make(chan struct{}),
 
@@ -178,7 +225,7 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		executionCh:    make(chan *state.AppExecResult),
 		notificationCh: make(chan *state.NotificationEvent),
 		transactionCh:  make(chan *transaction.Transaction),
-	}
+	}, nil
 }
 
 // Start creates a new JSON-RPC server listening on the configured port. It's
@@ -189,6 +236,14 @@ func (s *Server) Start(errChan chan error) {
 		s.log.Info("RPC server is not enabled")
 		return
 	}
+	if cfg := s.config.Audit; cfg.Enabled {
+		audit, err := newAuditLog(cfg)
+		if err != nil {
+			errChan <- fmt.Errorf("failed to open RPC audit log: %w", err)
+			return
+		}
+		s.audit = audit
+	}
 	s.Handler = http.HandlerFunc(s.handleHTTPRequest)
 	s.log.Info("starting rpc-server", zap.String("endpoint", s.Addr))
 
@@ -244,6 +299,12 @@ func (s *Server) Shutdown() error {
 	// Wait for handleSubEvents to finish.
 	<-s.executionCh
 
+	if s.audit != nil {
+		if auditErr := s.audit.Close(); auditErr != nil {
+			s.log.Error("failed to close RPC audit log", zap.Error(auditErr))
+		}
+	}
+
 	if err == nil {
 		return httpsErr
 	}
@@ -275,12 +336,11 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 			return
 		}
 		resChan := make(chan response.AbstractResult) // response.Abstract or response.AbstractBatch
-		subChan := make(chan *websocket.PreparedMessage, notificationBufSize)
-		subscr := &subscriber{writer: subChan, ws: ws}
+		subscr := &subscriber{queue: newEventQueue(s.config.SubscriberQueue), ws: ws}
 		s.subsLock.Lock()
 		s.subscribers[subscr] = true
 		s.subsLock.Unlock()
-		go s.handleWsWrites(ws, resChan, subChan)
+		go s.handleWsWrites(ws, resChan, subscr.queue)
 		s.handleWsReads(ws, resChan, subscr)
 		return
 	}
@@ -302,22 +362,24 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
-	resp := s.handleRequest(req, nil)
+	resp := s.handleRequest(req, nil, originFromAddr(httpRequest.RemoteAddr))
 	s.writeHTTPServerResponse(req, w, resp)
 }
 
-func (s *Server) handleRequest(req *request.Request, sub *subscriber) response.AbstractResult {
+// handleRequest processes the request received from the given origin IP
+// address.
+func (s *Server) handleRequest(req *request.Request, sub *subscriber, origin string) response.AbstractResult {
 	if req.In != nil {
-		return s.handleIn(req.In, sub)
+		return s.handleIn(req.In, sub, origin)
 	}
 	resp := make(response.AbstractBatch, len(req.Batch))
 	for i, in := range req.Batch {
-		resp[i] = s.handleIn(&in, sub)
+		resp[i] = s.handleIn(&in, sub, origin)
 	}
 	return resp
 }
 
-func (s *Server) handleIn(req *request.In, sub *subscriber) response.Abstract {
+func (s *Server) handleIn(req *request.In, sub *subscriber, origin string) response.Abstract {
 	var res interface{}
 	var resErr *response.Error
 	if req.JSONRPC != request.JSONRPCVersion {
@@ -333,12 +395,20 @@ func (s *Server) handleIn(req *request.In, sub *subscriber) response.Abstract {
 		zap.String("method", req.Method),
 		zap.Stringer("params", reqParams))
 
+	resErr = response.NewMethodNotFoundError(fmt.Sprintf("Method '%s' not supported", req.Method), nil)
+	if s.disabledMethods[req.Method] {
+		return s.packResponse(req, nil, resErr)
+	}
 	incCounter(req.Method)
 
-	resErr = response.NewMethodNotFoundError(fmt.Sprintf("Method '%s' not supported", req.Method), nil)
+	if s.chain.GetConfig().HeadersOnly && !headersOnlyMethods[req.Method] {
+		return s.packResponse(req, nil, response.NewInvalidRequestError(
+			fmt.Sprintf("Method '%s' is not supported in headers-only mode", req.Method), nil))
+	}
 	handler, ok := rpcHandlers[req.Method]
 	if ok {
 		res, resErr = handler(s, *reqParams)
+		s.auditCall(req.Method, origin, *reqParams, resErr)
 	} else if sub != nil {
 		handler, ok := rpcWsHandlers[req.Method]
 		if ok {
@@ -348,22 +418,60 @@ func (s *Server) handleIn(req *request.In, sub *subscriber) response.Abstract {
 	return s.packResponse(req, res, resErr)
 }
 
-func (s *Server) handleWsWrites(ws *websocket.Conn, resChan <-chan response.AbstractResult, subChan <-chan *websocket.PreparedMessage) {
+// disabledMethods returns a set of methods that are not served according to
+// the given configuration. Unknown methods in configuration are an error.
+func disabledMethods(conf rpc.Config) (map[string]bool, error) {
+	res := make(map[string]bool)
+	for m := range unsafeMethods {
+		res[m] = true
+	}
+	if conf.EnableConsensusState {
+		delete(res, "getconsensusstate")
+	}
+	for _, m := range conf.EnabledMethods {
+		if !isKnownMethod(m) {
+			return nil, fmt.Errorf("unknown method in EnabledMethods: %s", m)
+		}
+		delete(res, m)
+	}
+	for _, m := range conf.DisabledMethods {
+		if !isKnownMethod(m) {
+			return nil, fmt.Errorf("unknown method in DisabledMethods: %s", m)
+		}
+		res[m] = true
+	}
+	return res, nil
+}
+
+// isKnownMethod checks whether the given method is served by RPC server via
+// HTTP or WebSocket.
+func isKnownMethod(m string) bool {
+	if _, ok := rpcHandlers[m]; ok {
+		return true
+	}
+	_, ok := rpcWsHandlers[m]
+	return ok
+}
+
+func (s *Server) handleWsWrites(ws *websocket.Conn, resChan <-chan response.AbstractResult, queue *eventQueue) {
 	pingTicker := time.NewTicker(wsPingPeriod)
 eventloop:
 	for {
 		select {
 		case <-s.shutdown:
 			break eventloop
-		case event, ok := <-subChan:
-			if !ok {
-				break eventloop
-			}
-			if err := ws.SetWriteDeadline(time.Now().Add(wsWriteLimit)); err != nil {
-				break eventloop
-			}
-			if err := ws.WritePreparedMessage(event); err != nil {
-				break eventloop
+		case <-queue.ready:
+			for {
+				event, ok := queue.pop()
+				if !ok {
+					break
+				}
+				if err := ws.SetWriteDeadline(time.Now().Add(wsWriteLimit)); err != nil {
+					break eventloop
+				}
+				if err := ws.WritePreparedMessage(event); err != nil {
+					break eventloop
+				}
 			}
 		case res, ok := <-resChan:
 			if !ok {
@@ -386,25 +494,16 @@ eventloop:
 	}
 	ws.Close()
 	pingTicker.Stop()
-	// Drain notification channel as there might be some goroutines blocked
-	// on it.
-drainloop:
-	for {
-		select {
-		case _, ok := <-subChan:
-			if !ok {
-				break drainloop
-			}
-		default:
-			break drainloop
-		}
-	}
+	// Drop queued events, event dispatcher might also be waiting for some
+	// space in the queue.
+	queue.close()
 }
 
 func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- response.AbstractResult, subscr *subscriber) {
 	ws.SetReadLimit(wsReadLimit)
 	err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 	ws.SetPongHandler(func(string) error { return ws.SetReadDeadline(time.Now().Add(wsPongLimit)) })
+	origin := originFromAddr(ws.RemoteAddr().String())
 requestloop:
 	for err == nil {
 		req := request.NewRequest()
@@ -412,7 +511,7 @@ requestloop:
 		if err != nil {
 			break
 		}
-		res := s.handleRequest(req, subscr)
+		res := s.handleRequest(req, subscr, origin)
 		res.RunForErrors(func(jsonErr *response.Error) {
 			s.logRequestError(req, jsonErr)
 		})
@@ -451,27 +550,15 @@ func (s *Server) getConnectionCount(_ request.Params) (interface{}, *response.Er
 }
 
 func (s *Server) blockHashFromParam(param *request.Param) (util.Uint256, *response.Error) {
-	var hash util.Uint256
-
-	if param == nil {
+	hash, index, isIndex, err := param.GetBlockHashOrIndex()
+	if err != nil {
 		return hash, response.ErrInvalidParams
 	}
-
-	switch param.Type {
-	case request.StringT:
-		var err error
-		hash, err = param.GetUint256()
-		if err != nil {
+	if isIndex {
+		if index < 0 || index > int(s.chain.BlockHeight()) {
 			return hash, response.ErrInvalidParams
 		}
-	case request.NumberT:
-		num, err := s.blockHeightFromParam(param)
-		if err != nil {
-			return hash, response.ErrInvalidParams
-		}
-		hash = s.chain.GetHeaderHash(num)
-	default:
-		return hash, response.ErrInvalidParams
+		hash = s.chain.GetHeaderHash(index)
 	}
 	return hash, nil
 }
@@ -485,6 +572,9 @@ func (s *Server) getBlock(reqParams request.Params) (interface{}, *response.Erro
 
 	block, err := s.chain.GetBlock(hash)
 	if err != nil {
+		if errors.Is(err, core.ErrOnlyHeaderFound) {
+			return nil, response.NewRPCError("Block is not processed yet", "only header is available, use getblockheader", err)
+		}
 		return nil, response.NewInternalServerError(fmt.Sprintf("Problem locating block with hash: %s", hash), err)
 	}
 
@@ -534,17 +624,51 @@ func (s *Server) getPeers(_ request.Params) (interface{}, *response.Error) {
 func (s *Server) getRawMempool(reqParams request.Params) (interface{}, *response.Error) {
 	verbose := reqParams.Value(0).GetBoolean()
 	mp := s.chain.GetMemPool()
-	hashList := make([]util.Uint256, 0)
-	for _, item := range mp.GetVerifiedTransactions() {
-		hashList = append(hashList, item.Hash())
-	}
 	if !verbose {
+		hashList := make([]util.Uint256, 0)
+		for _, item := range mp.GetVerifiedTransactions() {
+			hashList = append(hashList, item.Hash())
+		}
 		return hashList, nil
 	}
-	return result.RawMempool{
-		Height:   s.chain.BlockHeight(),
-		Verified: hashList,
-	}, nil
+	pooled := mp.GetVerifiedPooledTxs()
+	res := result.RawMempool{
+		Height:       s.chain.BlockHeight(),
+		Verified:     make([]util.Uint256, 0, len(pooled)),
+		Transactions: make([]result.MempoolTx, 0, len(pooled)),
+	}
+	for _, p := range pooled {
+		res.Verified = append(res.Verified, p.Tx.Hash())
+		res.Transactions = append(res.Transactions, result.MempoolTx{
+			Hash:       p.Tx.Hash(),
+			Sender:     address.Uint160ToString(p.Tx.Sender()),
+			Size:       p.Tx.Size(),
+			SystemFee:  p.Tx.SystemFee,
+			NetworkFee: p.Tx.NetworkFee,
+			Height:     p.Height,
+			Time:       uint64(p.Timestamp.UnixNano() / int64(time.Millisecond)),
+		})
+	}
+	return res, nil
+}
+
+// getRawNotaryPool returns hashes of main and fallback transactions of the
+// pooled P2PNotaryRequest payloads.
+func (s *Server) getRawNotaryPool(_ request.Params) (interface{}, *response.Error) {
+	if !s.chain.P2PSigExtensionsEnabled() {
+		return nil, response.NewInternalServerError("P2PSignatureExtensions are disabled", nil)
+	}
+	pool := s.coreServer.GetNotaryPool()
+	res := result.RawNotaryPool{Hashes: make(map[util.Uint256][]util.Uint256)}
+	for _, fallback := range pool.GetVerifiedTransactions() {
+		data, ok := pool.TryGetData(fallback.Hash())
+		if !ok {
+			continue
+		}
+		main := data.(*payload.P2PNotaryRequest).MainTransaction.Hash()
+		res.Hashes[main] = append(res.Hashes[main], fallback.Hash())
+	}
+	return res, nil
 }
 
 func (s *Server) validateAddress(reqParams request.Params) (interface{}, *response.Error) {
@@ -588,7 +712,7 @@ func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *re
 		}
 		if verificationScript == nil { // then it still might be a contract-based verification
 			verificationErr := fmt.Sprintf("contract verification for signer #%d failed", i)
-			res, respErr := s.runScriptInVM(trigger.Verification, tx.Scripts[i].InvocationScript, signer.Account, tx)
+			res, respErr := s.runScriptInVM(trigger.Verification, tx.Scripts[i].InvocationScript, signer.Account, tx, false)
 			if respErr != nil && errors.Is(respErr.Cause, core.ErrUnknownVerificationContract) {
 				// it's neither a contract-based verification script nor a standard witness attached to
 				// the tx, so the user did not provide enough data to calculate fee for that witness =>
@@ -633,6 +757,77 @@ func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *re
 	return netFee, nil
 }
 
+// estimateNetworkFee implements the `estimatenetworkfee` RPC call returning
+// network fee per byte percentile over transactions of the latest blocks and
+// the memory pool.
+func (s *Server) estimateNetworkFee(reqParams request.Params) (interface{}, *response.Error) {
+	var (
+		percentile = defaultFeeEstimatePercentile
+		blocks     = defaultFeeEstimateBlocks
+	)
+	if p := reqParams.Value(0); p != nil {
+		v, err := p.GetInt()
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+		}
+		if v < 1 || v > 100 {
+			return nil, response.NewInvalidParamsError("percentile should be in [1, 100] range", nil)
+		}
+		percentile = v
+	}
+	if p := reqParams.Value(1); p != nil {
+		v, err := p.GetInt()
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+		}
+		if v < 1 || v > maxFeeEstimateBlocks {
+			return nil, response.NewInvalidParamsError(fmt.Sprintf("number of blocks should be in [1, %d] range", maxFeeEstimateBlocks), nil)
+		}
+		blocks = v
+	}
+
+	var (
+		fees   []int64
+		height = int(s.chain.BlockHeight())
+		res    = result.NetworkFeeEstimate{
+			Percentile:    percentile,
+			MinFeePerByte: s.chain.GetPolicer().FeePerByte(),
+		}
+	)
+	for ; res.Blocks < blocks && res.Blocks <= height; res.Blocks++ {
+		b, err := s.chain.GetBlock(s.chain.GetHeaderHash(height - res.Blocks))
+		if err != nil {
+			return nil, response.NewInternalServerError("can't get block", err)
+		}
+		for _, tx := range b.Transactions {
+			fees = append(fees, tx.FeePerByte())
+		}
+	}
+	for _, tx := range s.chain.GetMemPool().GetVerifiedTransactions() {
+		fees = append(fees, tx.FeePerByte())
+	}
+	res.Transactions = len(fees)
+	res.FeePerByte = feePercentile(fees, percentile)
+	if res.FeePerByte < res.MinFeePerByte {
+		res.FeePerByte = res.MinFeePerByte
+	}
+	return res, nil
+}
+
+// feePercentile returns the nearest-rank percentile of the given fees (which
+// are reordered), it's zero for an empty slice.
+func feePercentile(fees []int64, percentile int) int64 {
+	if len(fees) == 0 {
+		return 0
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+	rank := (len(fees)*percentile + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return fees[rank-1]
+}
+
 // getApplicationLog returns the contract log based on the specified txid or blockid.
 func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *response.Error) {
 	hash, err := reqParams.Value(0).GetUint256()
@@ -652,6 +847,9 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 		}
 	}
 
+	if s.chain.GetConfig().SkipApplicationLogs {
+		return nil, response.NewInvalidRequestError("'getapplicationlog' is not supported", errSkipApplicationLogs)
+	}
 	appExecResults, err := s.chain.GetAppExecResults(hash, trigger.All)
 	if err != nil {
 		return nil, response.NewRPCError("Unknown transaction or block", "", err)
@@ -659,6 +857,73 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 	return result.NewApplicationLog(hash, appExecResults, trig), nil
 }
 
+// getBlockNotifications returns all notifications emitted during processing
+// of the block specified by its hash or index, they can be filtered by contract
+// hash and event name.
+func (s *Server) getBlockNotifications(reqParams request.Params) (interface{}, *response.Error) {
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	var filter request.NotificationFilter
+	if len(reqParams) > 1 {
+		p := reqParams.ValueWithType(1, request.NotificationFilterT)
+		if p == nil {
+			return nil, response.ErrInvalidParams
+		}
+		filter = p.Value.(request.NotificationFilter)
+	}
+
+	if s.chain.GetConfig().SkipApplicationLogs {
+		return nil, response.NewInvalidRequestError("'getblocknotifications' is not supported", errSkipApplicationLogs)
+	}
+	block, err := s.chain.GetBlock(hash)
+	if err != nil {
+		return nil, response.NewRPCError("Unknown block", "", err)
+	}
+	filterEvents := func(events []state.NotificationEvent) []state.NotificationEvent {
+		res := make([]state.NotificationEvent, 0, len(events))
+		for _, e := range events {
+			if (filter.Contract == nil || e.ScriptHash.Equals(*filter.Contract)) &&
+				(filter.Name == nil || e.Name == *filter.Name) {
+				res = append(res, e)
+			}
+		}
+		return res
+	}
+
+	res := &result.BlockNotifications{
+		BlockHash:    hash,
+		OnPersist:    []state.NotificationEvent{},
+		Transactions: make([]result.TxNotifications, 0, len(block.Transactions)),
+		PostPersist:  []state.NotificationEvent{},
+	}
+	aers, err := s.chain.GetAppExecResults(hash, trigger.All)
+	if err != nil {
+		return nil, response.NewInternalServerError("failed to get block application logs", err)
+	}
+	for _, aer := range aers {
+		switch aer.Trigger {
+		case trigger.OnPersist:
+			res.OnPersist = filterEvents(aer.Events)
+		case trigger.PostPersist:
+			res.PostPersist = filterEvents(aer.Events)
+		}
+	}
+	for _, tx := range block.Transactions {
+		aers, err := s.chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		if err != nil {
+			return nil, response.NewInternalServerError(fmt.Sprintf("failed to get application log for %s", tx.Hash().StringLE()), err)
+		}
+		tn := result.TxNotifications{TxHash: tx.Hash(), Notifications: []state.NotificationEvent{}}
+		if len(aers) > 0 {
+			tn.Notifications = filterEvents(aers[0].Events)
+		}
+		res.Transactions = append(res.Transactions, tn)
+	}
+	return res, nil
+}
+
 func (s *Server) getNEP17Balances(ps request.Params) (interface{}, *response.Error) {
 	u, err := ps.Value(0).GetUint160FromAddressOrHex()
 	if err != nil {
@@ -687,6 +952,35 @@ func (s *Server) getNEP17Balances(ps request.Params) (interface{}, *response.Err
 	return bs, nil
 }
 
+func (s *Server) getWalletBalance(ps request.Params) (interface{}, *response.Error) {
+	if s.coreServer == nil || s.coreServer.GetBalanceTracker() == nil {
+		return nil, response.NewInternalServerError("balance tracker is not enabled", nil)
+	}
+	u, err := ps.Value(0).GetUint160FromAddressOrHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	balances, height, err := s.coreServer.GetBalanceTracker().Balances(u)
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+	res := &result.WalletBalance{
+		Address:  address.Uint160ToString(u),
+		Height:   height,
+		Balances: make([]result.WalletAssetValue, 0, len(balances)),
+	}
+	for h, amount := range balances {
+		res.Balances = append(res.Balances, result.WalletAssetValue{
+			Asset:  h,
+			Amount: amount.String(),
+		})
+	}
+	sort.Slice(res.Balances, func(i, j int) bool {
+		return res.Balances[i].Asset.Less(res.Balances[j].Asset)
+	})
+	return res, nil
+}
+
 func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, int, error) {
 	var start, end uint64
 	var limit, page int
@@ -738,6 +1032,9 @@ func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, i
 }
 
 func (s *Server) getNEP17Transfers(ps request.Params) (interface{}, *response.Error) {
+	if s.chain.GetConfig().SkipNEP17TransferLogs {
+		return nil, response.NewInvalidRequestError("'getnep17transfers' is not supported", errSkipNEP17TransferLogs)
+	}
 	u, err := ps.Value(0).GetUint160FromAddressOrHex()
 	if err != nil {
 		return nil, response.ErrInvalidParams
@@ -755,12 +1052,7 @@ func (s *Server) getNEP17Transfers(ps request.Params) (interface{}, *response.Er
 	}
 	cache := make(map[int32]util.Uint160)
 	var resCount, frameCount int
-	err = s.chain.ForEachNEP17Transfer(u, func(tr *state.NEP17Transfer) (bool, error) {
-		// Iterating from newest to oldest, not yet reached required
-		// time frame, continue looping.
-		if tr.Timestamp > end {
-			return true, nil
-		}
+	err = s.chain.ForEachNEP17Transfer(u, end, 0, func(tr *state.NEP17Transfer) (bool, error) {
 		// Iterating from newest to oldest, moved past required
 		// time frame, stop looping.
 		if tr.Timestamp < start {
@@ -901,7 +1193,32 @@ func makeStorageKey(id int32, key []byte) []byte {
 	return skey
 }
 
-var errKeepOnlyLatestState = errors.New("'KeepOnlyLatestState' setting is enabled")
+var (
+	errKeepOnlyLatestState   = errors.New("'KeepOnlyLatestState' setting is enabled")
+	errSkipApplicationLogs   = errors.New("'SkipApplicationLogs' setting is enabled")
+	errSkipNEP17TransferLogs = errors.New("'SkipNEP17TransferLogs' setting is enabled")
+	errNoStateDiffs          = errors.New("neither 'SaveStateDiffs' nor 'MaxReorgDepth' setting is enabled")
+)
+
+func (s *Server) getStateDiff(reqParams request.Params) (interface{}, *response.Error) {
+	cfg := s.chain.GetConfig()
+	if !cfg.SaveStateDiffs && cfg.MaxReorgDepth == 0 {
+		return nil, response.NewInvalidRequestError("'getstatediff' is not supported", errNoStateDiffs)
+	}
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	header, err := s.chain.GetHeader(hash)
+	if err != nil {
+		return nil, response.NewRPCError("Unknown block", "", err)
+	}
+	d, err := s.chain.GetStateDiff(header.Index)
+	if err != nil {
+		return nil, response.NewRPCError("Unknown state diff", "", err)
+	}
+	return result.NewStateDiff(d), nil
+}
 
 func (s *Server) getProof(ps request.Params) (interface{}, *response.Error) {
 	if s.chain.GetConfig().KeepOnlyLatestState {
@@ -1025,7 +1342,11 @@ func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *resp
 	}
 	tx, height, err := s.chain.GetTransaction(txHash)
 	if err != nil {
-		err = fmt.Errorf("invalid transaction %s: %w", txHash, err)
+		if conflicting, h, cErr := s.chain.GetTransactionConflict(txHash); cErr == nil {
+			err = fmt.Errorf("transaction %s conflicts with transaction %s included in block %d", txHash, conflicting, h)
+		} else {
+			err = fmt.Errorf("invalid transaction %s: %w", txHash, err)
+		}
 		return nil, response.NewRPCError("Unknown transaction", err.Error(), err)
 	}
 	if reqParams.Value(1).GetBoolean() {
@@ -1037,6 +1358,9 @@ func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *resp
 		if err != nil {
 			return nil, response.NewRPCError("Failed to get header for the transaction", err.Error(), err)
 		}
+		if s.chain.GetConfig().SkipApplicationLogs {
+			return result.NewTransactionOutputRaw(tx, header, nil, s.chain), nil
+		}
 		aers, err := s.chain.GetAppExecResults(txHash, trigger.Application)
 		if err != nil {
 			return nil, response.NewRPCError("Failed to get application log for the transaction", err.Error(), err)
@@ -1081,19 +1405,66 @@ func (s *Server) getNativeContracts(_ request.Params) (interface{}, *response.Er
 	return s.chain.GetNatives(), nil
 }
 
-// getBlockSysFee returns the system fees of the block, based on the specified index.
-func (s *Server) getBlockSysFee(reqParams request.Params) (interface{}, *response.Error) {
-	param := reqParams.ValueWithType(0, request.NumberT)
+// interopsGetter is implemented by chains allowing custom interops (like
+// core.Blockchain).
+type interopsGetter interface {
+	GetInterops() []interop.Function
+}
+
+// interops returns interop functions registered for the chain, standard ones
+// are returned if the chain doesn't provide them.
+func (s *Server) interops() []interop.Function {
+	if g, ok := s.chain.(interopsGetter); ok {
+		return g.GetInterops()
+	}
+	return core.Interops()
+}
+
+// getInterops returns a list of all syscalls with their prices.
+func (s *Server) getInterops(_ request.Params) (interface{}, *response.Error) {
+	fee := s.chain.GetPolicer().GetBaseExecFee()
+	fs := s.interops()
+	res := make([]result.Interop, len(fs))
+	for i := range fs {
+		res[i] = result.NewInterop(&fs[i], fee)
+	}
+	return res, nil
+}
+
+// getInteropPrice returns the price of a syscall specified by its name or ID.
+func (s *Server) getInteropPrice(reqParams request.Params) (interface{}, *response.Error) {
+	param := reqParams.Value(0)
 	if param == nil {
-		return 0, response.ErrInvalidParams
+		return nil, response.ErrInvalidParams
 	}
+	fs := s.interops()
+	name, err := param.GetString()
+	id, idErr := param.GetInt()
+	if err != nil && idErr != nil {
+		return nil, response.ErrInvalidParams
+	}
+	for i := range fs {
+		if (err == nil && fs[i].Name == name) || (idErr == nil && int64(fs[i].ID) == int64(id)) {
+			return result.NewInterop(&fs[i], s.chain.GetPolicer().GetBaseExecFee()), nil
+		}
+	}
+	return nil, response.NewRPCError("Unknown interop", "", nil)
+}
 
-	num, err := s.blockHeightFromParam(param)
+// getBlockSysFee returns the system fees of the block, based on the specified
+// index or hash.
+func (s *Server) getBlockSysFee(reqParams request.Params) (interface{}, *response.Error) {
+	headerHash, index, isIndex, err := reqParams.Value(0).GetBlockHashOrIndex()
 	if err != nil {
-		return 0, response.NewRPCError("Invalid height", "", nil)
+		return 0, response.ErrInvalidParams
+	}
+	if isIndex {
+		if index < 0 || index > int(s.chain.BlockHeight()) {
+			return 0, response.NewRPCError("Invalid height", "", nil)
+		}
+		headerHash = s.chain.GetHeaderHash(index)
 	}
 
-	headerHash := s.chain.GetHeaderHash(num)
 	block, errBlock := s.chain.GetBlock(headerHash)
 	if errBlock != nil {
 		return 0, response.NewRPCError(errBlock.Error(), "", nil)
@@ -1179,6 +1550,58 @@ func (s *Server) getNextBlockValidators(_ request.Params) (interface{}, *respons
 	return res, nil
 }
 
+// getConsensusState returns the state of the node's consensus process.
+func (s *Server) getConsensusState(_ request.Params) (interface{}, *response.Error) {
+	st, err := s.coreServer.GetConsensusState()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't get consensus state", err)
+	}
+	res := result.ConsensusState{
+		Height:       st.Height,
+		View:         st.View,
+		Primary:      st.Primary,
+		MyIndex:      st.MyIndex,
+		Validators:   st.Validators,
+		Phase:        st.Phase,
+		Preparations: st.Preparations,
+		Commits:      st.Commits,
+		ChangeViews:  st.ChangeViews,
+		Timer: result.Timer{
+			Height: st.TimerHeight,
+			View:   st.TimerView,
+		},
+	}
+	if !st.Deadline.IsZero() {
+		res.Timer.Deadline = st.Deadline.UnixNano() / int64(time.Millisecond)
+	}
+	return res, nil
+}
+
+// getCandidates returns all NEO candidates with their votes, registration
+// status and next block validator flag.
+func (s *Server) getCandidates(_ request.Params) (interface{}, *response.Error) {
+	var validators keys.PublicKeys
+
+	validators, err := s.chain.GetNextBlockValidators()
+	if err != nil {
+		return nil, response.NewRPCError("can't get next block validators", "", err)
+	}
+	candidates, err := s.chain.GetAllCandidates()
+	if err != nil {
+		return nil, response.NewRPCError("can't get candidates", "", err)
+	}
+	var res = make([]result.Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		res = append(res, result.Candidate{
+			PublicKey:  *c.Key,
+			Votes:      c.Votes.Int64(),
+			Active:     validators.Contains(c.Key),
+			Registered: c.Registered,
+		})
+	}
+	return res, nil
+}
+
 // getCommittee returns the current list of NEO committee members.
 func (s *Server) getCommittee(_ request.Params) (interface{}, *response.Error) {
 	keys, err := s.chain.GetCommittee()
@@ -1212,7 +1635,7 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 		return nil, response.NewInternalServerError("can't create invocation script", err)
 	}
 	tx.Script = script
-	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx)
+	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx, false)
 }
 
 // invokescript implements the `invokescript` RPC call.
@@ -1238,7 +1661,12 @@ func (s *Server) invokescript(reqParams request.Params) (interface{}, *response.
 		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.None}}
 	}
 	tx.Script = script
-	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx)
+	// Third parameter enables execution tracing.
+	trace := len(reqParams) > 2 && reqParams[2].Type == request.BooleanT && reqParams[2].GetBoolean()
+	if trace && !s.config.InvokeTrace.AllowRequests && !s.config.InvokeTrace.Enabled {
+		return nil, response.NewInvalidParamsError("execution tracing is not allowed", nil)
+	}
+	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx, trace)
 }
 
 // invokeContractVerify implements the `invokecontractverify` RPC call.
@@ -1276,15 +1704,17 @@ func (s *Server) invokeContractVerify(reqParams request.Params) (interface{}, *r
 		tx.Scripts = []transaction.Witness{{InvocationScript: invocationScript, VerificationScript: []byte{}}}
 	}
 
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, false)
 }
 
 // runScriptInVM runs given script in a new test VM and returns the invocation
 // result. The script is either a simple script in case of `application` trigger
 // witness invocation script in case of `verification` trigger (it pushes `verify`
 // arguments on stack before verification). In case of contract verification
-// contractScriptHash should be specified.
-func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction) (*result.Invoke, *response.Error) {
+// contractScriptHash should be specified. Instruction-level execution trace is
+// returned if trace is set or tracing is enabled in the configuration, GAS
+// consumption breakdown is always returned.
+func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, trace bool) (*result.Invoke, *response.Error) {
 	// When transferring funds, script execution does no auto GAS claim,
 	// because it depends on persisting tx height.
 	// This is why we provide block here.
@@ -1296,8 +1726,20 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 	}
 	b.Timestamp = hdr.Timestamp + uint64(s.chain.GetConfig().SecondsPerBlock*int(time.Second/time.Millisecond))
 
+	gasStats := vm.NewGasStats()
+	var tracer *vm.Tracer
+	if trace || s.config.InvokeTrace.Enabled {
+		tracer = vm.NewTracer(vm.TraceLimits{
+			MaxEntries:    s.config.InvokeTrace.MaxEntries,
+			MaxStackItems: s.config.InvokeTrace.MaxStackItems,
+			MaxEntrySize:  s.config.InvokeTrace.MaxEntrySize,
+			MaxSize:       s.config.InvokeTrace.MaxSize,
+		})
+	}
 	vm := s.chain.GetTestVM(t, tx, b)
 	vm.GasLimit = int64(s.config.MaxGasInvoke)
+	vm.SetTracer(tracer)
+	vm.SetGasStats(gasStats)
 	if t == trigger.Verification {
 		// We need this special case because witnesses verification is not the simple System.Contract.Call,
 		// and we need to define exactly the amount of gas consumed for a contract witness verification.
@@ -1316,15 +1758,26 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		if err != nil {
 			return nil, response.NewInternalServerError("can't prepare verification VM", err)
 		}
+		// Contract verification is not supposed to change anything, so
+		// untrusted scripts are not allowed to use other syscalls.
+		vm.RestrictSyscalls(core.ReadOnlySyscalls())
 	} else {
 		vm.LoadScriptWithFlags(script, callflag.All)
 	}
-	err = vm.Run()
+	err = s.invokes.run(vm)
+	if errors.Is(err, errInvokeBusy) || errors.Is(err, errInvokeTimeout) {
+		return nil, response.NewRPCError("Invocation is not completed", err.Error(), err)
+	}
 	var faultException string
 	if err != nil {
 		faultException = err.Error()
 	}
-	return result.NewInvoke(vm, script, faultException, s.config.MaxIteratorResultItems), nil
+	res := result.NewInvoke(vm, script, faultException, s.config.MaxIteratorResultItems)
+	if tracer != nil {
+		res.Trace = result.NewInvokeTrace(tracer)
+	}
+	res.GasBreakdown = result.NewGasBreakdown(gasStats)
+	return res, nil
 }
 
 // submitBlock broadcasts a raw block over the NEO network.
@@ -1386,9 +1839,26 @@ func getRelayResult(err error, hash util.Uint256) (interface{}, *response.Error)
 		return nil, response.WrapErrorWithData(response.ErrOutOfMemory, err)
 	case errors.Is(err, core.ErrPolicy):
 		return nil, response.WrapErrorWithData(response.ErrPolicyFail, err)
-	default:
-		return nil, response.WrapErrorWithData(response.ErrValidationFailed, err)
 	}
+	if respErr := getTxLimitsError(err); respErr != nil {
+		return nil, respErr
+	}
+	return nil, response.WrapErrorWithData(response.ErrValidationFailed, err)
+}
+
+// getTxLimitsError returns specific submit error for transactions violating
+// size or attribute/witness count limits and nil for any other error.
+func getTxLimitsError(err error) *response.Error {
+	switch {
+	case errors.Is(err, transaction.ErrTooBig):
+		return response.WrapErrorWithData(response.ErrTxTooBig, err)
+	case errors.Is(err, transaction.ErrTooManySigners),
+		errors.Is(err, transaction.ErrTooManyAttributes):
+		return response.WrapErrorWithData(response.ErrTxTooManyAttributes, err)
+	case errors.Is(err, transaction.ErrInvalidWitnessNum):
+		return response.WrapErrorWithData(response.ErrTxInvalidWitnesses, err)
+	}
+	return nil
 }
 
 func (s *Server) submitOracleResponse(ps request.Params) (interface{}, *response.Error) {
@@ -1433,6 +1903,9 @@ func (s *Server) sendrawtransaction(reqParams request.Params) (interface{}, *res
 	}
 	tx, err := transaction.NewTransactionFromBytes(byteTx)
 	if err != nil {
+		if respErr := getTxLimitsError(err); respErr != nil {
+			return nil, respErr
+		}
 		return nil, response.NewInvalidParamsError("can't decode transaction", err)
 	}
 	return getRelayResult(s.coreServer.RelayTxn(tx), tx.Hash())
@@ -1465,9 +1938,11 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 				return nil, response.ErrInvalidParams
 			}
 		case response.ExecutionEventID:
-			if p.Type != request.ExecutionFilterT {
+			filt, err := p.GetExecutionFilter()
+			if err != nil {
 				return nil, response.ErrInvalidParams
 			}
+			p = &request.Param{Type: request.ExecutionFilterT, Value: filt}
 		}
 		filter = p.Value
 	}
@@ -1569,20 +2044,6 @@ func (s *Server) unsubscribeFromChannel(event response.EventID) {
 }
 
 func (s *Server) handleSubEvents() {
-	b, err := json.Marshal(response.Notification{
-		JSONRPC: request.JSONRPCVersion,
-		Event:   response.MissedEventID,
-		Payload: make([]interface{}, 0),
-	})
-	if err != nil {
-		s.log.Error("fatal: failed to marshal overflow event", zap.Error(err))
-		return
-	}
-	overflowMsg, err := websocket.NewPreparedMessage(websocket.TextMessage, b)
-	if err != nil {
-		s.log.Error("fatal: failed to prepare overflow message", zap.Error(err))
-		return
-	}
 chloop:
 	for {
 		var resp = response.Notification{
@@ -1609,13 +2070,10 @@ chloop:
 		s.subsLock.RLock()
 	subloop:
 		for sub := range s.subscribers {
-			if sub.overflown.Load() {
-				continue
-			}
 			for i := range sub.feeds {
 				if sub.feeds[i].Matches(&resp) {
 					if msg == nil {
-						b, err = json.Marshal(resp)
+						b, err := json.Marshal(resp)
 						if err != nil {
 							s.log.Error("failed to marshal notification",
 								zap.Error(err),
@@ -1630,15 +2088,12 @@ chloop:
 							break subloop
 						}
 					}
-					select {
-					case sub.writer <- msg:
-					default:
-						sub.overflown.Store(true)
-						// MissedEvent is to be delivered eventually.
-						go func(sub *subscriber) {
-							sub.writer <- overflowMsg
-							sub.overflown.Store(false)
-						}(sub)
+					if err := sub.queue.push(msg); err != nil {
+						s.log.Info("disconnecting slow websocket client",
+							zap.Stringer("addr", sub.ws.RemoteAddr()),
+							zap.Error(err))
+						// It will make reader routine unsubscribe the client.
+						go sub.ws.Close()
 					}
 					// The message is sent only once per subscriber.
 					break
@@ -29,10 +29,17 @@ const (
 )
 
 func getUnitTestChain(t *testing.T, enableOracle bool, enableNotary bool) (*core.Blockchain, *oracle.Oracle, config.Config, *zap.Logger) {
+	return getUnitTestChainWithCustomConfig(t, enableOracle, enableNotary, nil)
+}
+
+func getUnitTestChainWithCustomConfig(t *testing.T, enableOracle bool, enableNotary bool, customCfg func(*config.Config)) (*core.Blockchain, *oracle.Oracle, config.Config, *zap.Logger) {
 	net := netmode.UnitTestNet
 	configPath := "../../../config"
 	cfg, err := config.Load(configPath, net)
 	require.NoError(t, err, "could not load config")
+	if customCfg != nil {
+		customCfg(&cfg)
+	}
 
 	memoryStore := storage.NewMemoryStore()
 	logger := zaptest.NewLogger(t)
@@ -99,18 +106,27 @@ func getTestBlocks(t *testing.T) []*block.Block {
 
 func initClearServerWithServices(t *testing.T, needOracle bool, needNotary bool) (*core.Blockchain, *Server, *httptest.Server) {
 	chain, orc, cfg, logger := getUnitTestChain(t, needOracle, needNotary)
+	return wrapUnitTestChain(t, chain, orc, cfg, logger)
+}
 
+func initClearServerWithCustomConfig(t *testing.T, customCfg func(*config.Config)) (*core.Blockchain, *Server, *httptest.Server) {
+	chain, orc, cfg, logger := getUnitTestChainWithCustomConfig(t, false, false, customCfg)
+	return wrapUnitTestChain(t, chain, orc, cfg, logger)
+}
+
+func wrapUnitTestChain(t *testing.T, chain *core.Blockchain, orc *oracle.Oracle, cfg config.Config, logger *zap.Logger) (*core.Blockchain, *Server, *httptest.Server) {
 	serverConfig := network.NewServerConfig(cfg)
 	server, err := network.NewServer(serverConfig, chain, logger)
 	require.NoError(t, err)
-	rpcServer := New(chain, cfg.ApplicationConfiguration.RPC, server, orc, logger)
+	rpcServer, err := New(chain, cfg.ApplicationConfiguration.RPC, server, orc, logger)
+	require.NoError(t, err)
 	errCh := make(chan error, 2)
 	rpcServer.Start(errCh)
 
 	handler := http.HandlerFunc(rpcServer.handleHTTPRequest)
 	srv := httptest.NewServer(handler)
 
-	return chain, &rpcServer, srv
+	return chain, rpcServer, srv
 }
 
 func initClearServerWithInMemoryChain(t *testing.T) (*core.Blockchain, *Server, *httptest.Server) {
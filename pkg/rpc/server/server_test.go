@@ -9,6 +9,8 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
@@ -19,18 +21,23 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	rpc2 "github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -207,6 +214,11 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `["notahex"]`,
 			fail:   true,
 		},
+		{
+			name:   "too big numeric string height",
+			params: `["1000000"]`,
+			fail:   true,
+		},
 	},
 
 	"getnep17balances": {
@@ -314,6 +326,67 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"estimatenetworkfee": {
+		{
+			name:   "defaults",
+			params: `[]`,
+			result: func(_ *executor) interface{} { return new(result.NetworkFeeEstimate) },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				est, ok := res.(*result.NetworkFeeEstimate)
+				require.True(t, ok)
+				require.Equal(t, 50, est.Percentile)
+				require.Equal(t, 10, est.Blocks)
+				require.Equal(t, e.chain.GetPolicer().FeePerByte(), est.MinFeePerByte)
+				require.True(t, est.FeePerByte >= est.MinFeePerByte)
+			},
+		},
+		{
+			name:   "maximum",
+			params: `[100, 100]`,
+			result: func(_ *executor) interface{} { return new(result.NetworkFeeEstimate) },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				est, ok := res.(*result.NetworkFeeEstimate)
+				require.True(t, ok)
+
+				var (
+					count int
+					max   = e.chain.GetPolicer().FeePerByte()
+				)
+				addFees := func(txs []*transaction.Transaction) {
+					for _, tx := range txs {
+						count++
+						if fee := tx.FeePerByte(); fee > max {
+							max = fee
+						}
+					}
+				}
+				for i := 0; i <= int(e.chain.BlockHeight()) && i < 100; i++ {
+					b, err := e.chain.GetBlock(e.chain.GetHeaderHash(int(e.chain.BlockHeight()) - i))
+					require.NoError(t, err)
+					addFees(b.Transactions)
+				}
+				addFees(e.chain.GetMemPool().GetVerifiedTransactions())
+				require.Equal(t, 100, est.Percentile)
+				require.Equal(t, count, est.Transactions)
+				require.Equal(t, max, est.FeePerByte)
+			},
+		},
+		{
+			name:   "invalid percentile",
+			params: `[101]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid blocks",
+			params: `[50, 0]`,
+			fail:   true,
+		},
+		{
+			name:   "string percentile",
+			params: `["high"]`,
+			fail:   true,
+		},
+	},
 	"getstateheight": {
 		{
 			name:   "positive",
@@ -328,6 +401,13 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getstatediff": {
+		{
+			name:   "not supported",
+			params: `[1]`,
+			fail:   true,
+		},
+	},
 	"getstateroot": {
 		{
 			name:   "no params",
@@ -404,6 +484,13 @@ var rpcTestCases = map[string][]rpcTestCase{
 				require.NoErrorf(t, err, "could not get block")
 
 				assert.Equal(t, block.Hash(), res.Hash())
+				prev, err := e.chain.GetHeader(block.PrevHash)
+				require.NoError(t, err)
+				require.NotNil(t, res.Consensus)
+				cfg := e.chain.GetConfig()
+				require.Equal(t, block.ViewNumber(cfg.ValidatorsCount), res.Consensus.ViewNumber)
+				require.Equal(t, block.Timestamp-prev.Timestamp, res.Consensus.Interval)
+				require.Equal(t, int64(res.Consensus.Interval)-int64(cfg.SecondsPerBlock)*1000, res.Consensus.Drift)
 				for i, tx := range res.Transactions {
 					actualTx := block.Transactions[i]
 					require.True(t, ok)
@@ -500,6 +587,75 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getblocknotifications": {
+		{
+			name:   "positive, by index",
+			params: "[1]",
+			result: func(e *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				b, err := e.chain.GetBlock(e.chain.GetHeaderHash(1))
+				require.NoError(t, err)
+				require.Equal(t, b.Hash(), res.BlockHash)
+				require.Equal(t, len(b.Transactions), len(res.Transactions))
+				var count int
+				for i := range b.Transactions {
+					require.Equal(t, b.Transactions[i].Hash(), res.Transactions[i].TxHash)
+					aers, err := e.chain.GetAppExecResults(b.Transactions[i].Hash(), trigger.Application)
+					require.NoError(t, err)
+					require.Equal(t, len(aers[0].Events), len(res.Transactions[i].Notifications))
+					count += len(aers[0].Events)
+				}
+				require.True(t, count > 0)
+			},
+		},
+		{
+			name:   "positive, filter by name",
+			params: `[1, {"name": "Transfer"}]`,
+			result: func(e *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				events := append(res.OnPersist, res.PostPersist...)
+				for _, tn := range res.Transactions {
+					events = append(events, tn.Notifications...)
+				}
+				require.NotEqual(t, 0, len(events))
+				for _, e := range events {
+					require.Equal(t, "Transfer", e.Name)
+				}
+			},
+		},
+		{
+			name:   "positive, filter by contract",
+			params: `["` + genesisBlockHash + `", {"contract": "` + testContractHash + `"}]`,
+			result: func(e *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				require.Equal(t, genesisBlockHash, res.BlockHash.StringLE())
+				require.Equal(t, 0, len(res.OnPersist))
+				require.Equal(t, 0, len(res.PostPersist))
+				require.Equal(t, 0, len(res.Transactions))
+			},
+		},
+		{
+			name:   "invalid filter",
+			params: `[1, "Transfer"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown block",
+			params: `["a6e526375a780335112299f2262501e5e9574c3ba61b16bbc1e282b344f6c141"]`,
+			fail:   true,
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+	},
 	"getblocksysfee": {
 		{
 			name:   "positive",
@@ -514,6 +670,24 @@ var rpcTestCases = map[string][]rpcTestCase{
 				return &expectedBlockSysFee
 			},
 		},
+		{
+			name:   "numeric string",
+			params: `["1"]`,
+			result: func(e *executor) interface{} {
+				block, _ := e.chain.GetBlock(e.chain.GetHeaderHash(1))
+
+				var expectedBlockSysFee int64
+				for _, tx := range block.Transactions {
+					expectedBlockSysFee += tx.SystemFee
+				}
+				return &expectedBlockSysFee
+			},
+		},
+		{
+			name:   "unknown block hash",
+			params: `["a6e526375a780335112299f2262501e5e9574c3ba61b16bbc1e282b344f6c141"]`,
+			fail:   true,
+		},
 		{
 			name:   "no params",
 			params: `[]`,
@@ -530,6 +704,22 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"getcandidates": {
+		{
+			params: "[]",
+			result: func(*executor) interface{} {
+				// no one is registered in the test chain
+				return &[]result.Candidate{}
+			},
+		},
+	},
+	"getconsensusstate": {
+		{
+			name:   "disabled",
+			params: "[]",
+			fail:   true,
+		},
+	},
 	"getcommittee": {
 		{
 			params: "[]",
@@ -550,6 +740,54 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getinterops": {
+		{
+			params: "[]",
+			result: func(e *executor) interface{} {
+				return new([]result.Interop)
+			},
+			check: func(t *testing.T, e *executor, res interface{}) {
+				lst := res.(*[]result.Interop)
+				fs := core.Interops()
+				require.Equal(t, len(fs), len(*lst))
+				for i := range fs {
+					require.Equal(t, result.NewInterop(&fs[i], e.chain.GetPolicer().GetBaseExecFee()), (*lst)[i])
+				}
+			},
+		},
+	},
+	"getinteropprice": {
+		{
+			name:   "by name",
+			params: `["System.Runtime.Log"]`,
+			result: func(e *executor) interface{} { return new(result.Interop) },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				f := res.(*result.Interop)
+				require.Equal(t, interopnames.ToID([]byte(interopnames.SystemRuntimeLog)), f.ID)
+				require.EqualValues(t, 1<<15, f.Price)
+				require.Equal(t, f.Price*e.chain.GetPolicer().GetBaseExecFee(), f.ExecutionFee)
+				require.Equal(t, callflag.AllowNotify, f.RequiredFlags)
+			},
+		},
+		{
+			name:   "by ID",
+			params: fmt.Sprintf(`[%d]`, interopnames.ToID([]byte(interopnames.SystemRuntimeGasLeft))),
+			result: func(e *executor) interface{} { return new(result.Interop) },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				require.Equal(t, interopnames.SystemRuntimeGasLeft, res.(*result.Interop).Name)
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown",
+			params: `["System.Unknown"]`,
+			fail:   true,
+		},
+	},
 	"getnativecontracts": {
 		{
 			params: "[]",
@@ -702,6 +940,17 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.NotNil(t, res.Script)
 				assert.NotEqual(t, "", res.State)
 				assert.NotEqual(t, 0, res.GasConsumed)
+				require.NotNil(t, res.GasBreakdown)
+				var (
+					total     int64
+					hasScript bool
+				)
+				for _, c := range res.GasBreakdown.Contracts {
+					total += c.GasConsumed
+					hasScript = hasScript || c.Hash == hash.Hash160(res.Script)
+				}
+				require.Equal(t, res.GasConsumed, total)
+				require.True(t, hasScript)
 			},
 		},
 		{
@@ -736,6 +985,26 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.NotEqual(t, "", res.Script)
 				assert.NotEqual(t, "", res.State)
 				assert.NotEqual(t, 0, res.GasConsumed)
+				assert.Nil(t, res.Trace)
+			},
+		},
+		{
+			name:   "positive, with trace",
+			params: fmt.Sprintf(`["%s",[],true]`, invokescriptContractAVM),
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State)
+				require.NotNil(t, res.Trace)
+				require.True(t, len(res.Trace.Entries) > 0)
+				require.Equal(t, 0, res.Trace.Entries[0].IP)
+				require.Equal(t, 0, len(res.Trace.Entries[0].Stack))
+				require.Equal(t, int64(0), res.Trace.Entries[0].GasConsumed)
+				last := res.Trace.Entries[len(res.Trace.Entries)-1]
+				if !res.Trace.Truncated {
+					require.Equal(t, opcode.RET, last.Opcode)
+				}
 			},
 		},
 		{
@@ -1007,6 +1276,176 @@ func TestRPC(t *testing.T) {
 	})
 }
 
+func TestGetStateDiff(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ProtocolConfiguration.SaveStateDiffs = true
+	})
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+	cs := chain.GetContractState(h)
+	require.NotNil(t, cs)
+
+	// Find the block storing testkey/testvalue pair.
+	var expected *result.StateDiff
+	for i := uint32(1); i <= chain.BlockHeight() && expected == nil; i++ {
+		d, err := chain.GetStateDiff(i)
+		require.NoError(t, err)
+		res := result.NewStateDiff(d)
+		for _, c := range res.Changes {
+			if c.ID == cs.ID && string(c.Key) == "testkey" {
+				require.Nil(t, c.OldValue)
+				require.Equal(t, []byte("testvalue"), c.NewValue)
+				expected = &res
+			}
+		}
+	}
+	require.NotNil(t, expected)
+
+	checkDiff := func(t *testing.T, param string) {
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getstatediff", "params": [%s]}`, param)
+		body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+		rawRes := checkErrGetResult(t, body, false)
+		res := new(result.StateDiff)
+		require.NoError(t, json.Unmarshal(rawRes, res))
+		require.Equal(t, expected, res)
+	}
+	t.Run("ByHeight", func(t *testing.T) { checkDiff(t, strconv.FormatInt(int64(expected.Index), 10)) })
+	t.Run("ByHash", func(t *testing.T) { checkDiff(t, `"`+chain.GetHeaderHash(int(expected.Index)).StringLE()+`"`) })
+	t.Run("unknown block", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getstatediff", "params": [100500]}`
+		checkErrGetResult(t, doRPCCallOverHTTP(rpc, httpSrv.URL, t), true)
+	})
+}
+
+func TestRPCAudit(t *testing.T) {
+	d, err := ioutil.TempDir("", "neogo-audit")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+	auditPath := filepath.Join(d, "audit.log")
+
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.Audit = rpc.AuditConfig{
+			Enabled: true,
+			Path:    auditPath,
+		}
+	})
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	b := testchain.NewBlock(t, chain, 1, 0)
+	body := doRPCCallOverHTTP(fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "submitblock", "params": ["%s"]}`,
+		encodeBlock(t, b)), httpSrv.URL, t)
+	checkErrGetResult(t, body, false)
+	body = doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "sendrawtransaction", "params": ["not base64"]}`, httpSrv.URL, t)
+	checkErrGetResult(t, body, true)
+	body = doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`, httpSrv.URL, t)
+	checkErrGetResult(t, body, false)
+
+	data, err := ioutil.ReadFile(auditPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, 2, len(lines))
+
+	var rec auditRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &rec))
+	require.Equal(t, "submitblock", rec.Method)
+	require.Equal(t, "127.0.0.1", rec.Origin)
+	require.NotNil(t, rec.Hash)
+	require.Equal(t, b.Hash(), *rec.Hash)
+	require.Equal(t, auditResultOK, rec.Result)
+	require.Empty(t, rec.Error)
+
+	rec = auditRecord{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &rec))
+	require.Equal(t, "sendrawtransaction", rec.Method)
+	require.Equal(t, "127.0.0.1", rec.Origin)
+	require.Nil(t, rec.Hash)
+	require.Equal(t, auditResultError, rec.Result)
+	require.NotEmpty(t, rec.Error)
+}
+
+func TestRPCMethodToggles(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.DisabledMethods = []string{"getversion"}
+		c.ApplicationConfiguration.RPC.EnabledMethods = []string{"getconsensusstate"}
+	})
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	getErrCode := func(t *testing.T, method string) int64 {
+		body := doRPCCallOverHTTP(fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": []}`, method), httpSrv.URL, t)
+		var resp response.Raw
+		require.NoError(t, json.Unmarshal(body, &resp))
+		if resp.Error == nil {
+			return 0
+		}
+		return resp.Error.Code
+	}
+	require.EqualValues(t, -32601, getErrCode(t, "getversion"))
+	require.EqualValues(t, 0, getErrCode(t, "getblockcount"))
+	// Enabled, but the node has no consensus service.
+	code := getErrCode(t, "getconsensusstate")
+	require.NotZero(t, code)
+	require.NotEqual(t, int64(-32601), code)
+
+	t.Run("unsafe methods are disabled by default", func(t *testing.T) {
+		res, err := disabledMethods(rpc.Config{})
+		require.NoError(t, err)
+		require.True(t, res["getconsensusstate"])
+		res, err = disabledMethods(rpc.Config{EnableConsensusState: true})
+		require.NoError(t, err)
+		require.False(t, res["getconsensusstate"])
+	})
+	t.Run("unknown methods", func(t *testing.T) {
+		_, err := disabledMethods(rpc.Config{DisabledMethods: []string{"getversion", "nosuchmethod"}})
+		require.Error(t, err)
+		_, err = disabledMethods(rpc.Config{EnabledMethods: []string{"nosuchmethod"}})
+		require.Error(t, err)
+		_, err = disabledMethods(rpc.Config{DisabledMethods: []string{"subscribe"}})
+		require.NoError(t, err)
+		_, err = New(chain, rpc.Config{DisabledMethods: []string{"nosuchmethod"}}, nil, nil, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestInvokeTraceNotAllowed(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.InvokeTrace.AllowRequests = false
+	})
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s",[],true]}`, invokescriptContractAVM)
+	body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+	var resp response.Raw
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.NotNil(t, resp.Error)
+	require.EqualValues(t, -32602, resp.Error.Code)
+}
+
+func TestGetBlockSysFeeInvalidHeight(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	for _, height := range []string{"-2", `"1000"`} {
+		req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getblocksysfee", "params": [%s]}`, height)
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		var resp response.Raw
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.NotNil(t, resp.Error)
+		require.EqualValues(t, -100, resp.Error.Code)
+		require.Equal(t, "Invalid height", resp.Error.Message)
+	}
+}
+
 func TestSubmitOracle(t *testing.T) {
 	chain, rpcSrv, httpSrv := initClearServerWithServices(t, true, false)
 	defer chain.Close()
@@ -1146,9 +1585,52 @@ func TestSubmitNotaryRequest(t *testing.T) {
 		require.NoError(t, err)
 		str := fmt.Sprintf(`"%s"`, base64.StdEncoding.EncodeToString(bytes))
 		runCase(t, false, str)(t)
+
+		req := `{"jsonrpc": "2.0", "id": 1, "method": "getrawnotarypool", "params": []}`
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		res := checkErrGetResult(t, body, false)
+		actual := new(result.RawNotaryPool)
+		require.NoError(t, json.Unmarshal(res, actual))
+		require.Equal(t, map[util.Uint256][]util.Uint256{mainTx.Hash(): {fallbackTx.Hash()}}, actual.Hashes)
 	})
 }
 
+func TestGetRawNotaryPool(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithServices(t, false, false)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+	req := `{"jsonrpc": "2.0", "id": 1, "method": "getrawnotarypool", "params": []}`
+	body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+	res := checkErrGetResult(t, body, false)
+	actual := new(result.RawNotaryPool)
+	require.NoError(t, json.Unmarshal(res, actual))
+	require.Equal(t, 0, len(actual.Hashes))
+}
+
+func TestHeadersOnly(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ProtocolConfiguration.HeadersOnly = true
+	})
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	blocks := getTestBlocks(t)
+	for _, b := range blocks {
+		require.NoError(t, chain.AddHeaders(&b.Header))
+	}
+
+	req := `{"jsonrpc": "2.0", "id": 1, "method": "getblockheadercount", "params": []}`
+	body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+	res := checkErrGetResult(t, body, false)
+	var count uint32
+	require.NoError(t, json.Unmarshal(res, &count))
+	require.Equal(t, uint32(len(blocks)+1), count)
+
+	req = `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+	body = doRPCCallOverHTTP(req, httpSrv.URL, t)
+	checkErrGetResult(t, body, true)
+}
+
 // testRPCProtocol runs a full set of tests using given callback to make actual
 // calls. Some tests change the chain state, thus we reinitialize the chain from
 // scratch here.
@@ -1440,6 +1922,10 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			t.Run("by number", func(t *testing.T) {
 				runCase(t, fmt.Sprintf(rpc, `[1]`), &encoded, new(string))
 			})
+
+			t.Run("by numeric string", func(t *testing.T) {
+				runCase(t, fmt.Sprintf(rpc, `["1"]`), &encoded, new(string))
+			})
 		})
 
 		t.Run("verbose != 0", func(t *testing.T) {
@@ -1486,6 +1972,29 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		require.NoErrorf(t, err, "could not parse response: %s", res)
 
 		assert.ElementsMatch(t, expected, actual)
+
+		t.Run("verbose", func(t *testing.T) {
+			rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getrawmempool", "params": [true]}`
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			res := checkErrGetResult(t, body, false)
+
+			var actual result.RawMempool
+			err := json.Unmarshal(res, &actual)
+			require.NoErrorf(t, err, "could not parse response: %s", res)
+			require.Equal(t, chain.BlockHeight(), actual.Height)
+			assert.ElementsMatch(t, expected, actual.Verified)
+			require.Equal(t, len(expected), len(actual.Transactions))
+			for i, tx := range actual.Transactions {
+				require.Equal(t, actual.Verified[i], tx.Hash)
+				pooled, ok := mp.TryGetValue(tx.Hash)
+				require.True(t, ok)
+				require.Equal(t, address.Uint160ToString(pooled.Sender()), tx.Sender)
+				require.Equal(t, pooled.Size(), tx.Size)
+				require.Equal(t, pooled.SystemFee, tx.SystemFee)
+				require.Equal(t, pooled.NetworkFee, tx.NetworkFee)
+				require.NotZero(t, tx.Time)
+			}
+		})
 	})
 
 	t.Run("getnep17transfers", func(t *testing.T) {
@@ -1930,3 +2439,13 @@ func checkNep17TransfersAux(t *testing.T, e *executor, acc interface{}, sent, rc
 	}
 	require.Equal(t, arr, res.Received)
 }
+
+func TestFeePercentile(t *testing.T) {
+	require.Equal(t, int64(0), feePercentile(nil, 50))
+	fees := []int64{5, 1, 4, 2, 3}
+	require.Equal(t, int64(1), feePercentile(fees, 1))
+	require.Equal(t, int64(1), feePercentile(fees, 20))
+	require.Equal(t, int64(2), feePercentile(fees, 21))
+	require.Equal(t, int64(3), feePercentile(fees, 50))
+	require.Equal(t, int64(5), feePercentile(fees, 100))
+}
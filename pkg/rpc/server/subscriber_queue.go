@@ -0,0 +1,219 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+)
+
+// errQueueOverflow is returned from eventQueue.push when the queue is full
+// and its policy is to disconnect the client.
+var errQueueOverflow = errors.New("subscriber event queue overflow")
+
+type (
+	// eventQueue is a bounded queue of events prepared for a single
+	// subscriber. It's filled by the event dispatching routine and drained by
+	// the subscriber's writer routine. When the queue is full the action
+	// taken depends on the overflow policy, dropped events are accounted for
+	// and the client is notified of them with `event_missed` event placed
+	// where these events should've been.
+	eventQueue struct {
+		size    int
+		policy  string
+		timeout time.Duration
+
+		lock    sync.Mutex
+		entries []queueEntry
+		// queued is the number of events (not missed event markers) in
+		// entries.
+		queued int
+		closed bool
+		// ready signals writer that there are some entries in the queue.
+		ready chan struct{}
+		// space signals pusher that some entries were popped from the
+		// queue.
+		space chan struct{}
+	}
+	// queueEntry is either an event or a missed events marker (if msg is
+	// nil).
+	queueEntry struct {
+		msg    *websocket.PreparedMessage
+		missed int
+	}
+)
+
+func newEventQueue(cfg rpc.QueueConfig) *eventQueue {
+	q := &eventQueue{
+		size:    cfg.Size,
+		policy:  cfg.OverflowPolicy,
+		timeout: cfg.BlockTimeout,
+		ready:   make(chan struct{}, 1),
+		space:   make(chan struct{}, 1),
+	}
+	if q.size <= 0 {
+		q.size = notificationBufSize
+	}
+	if q.policy == "" {
+		q.policy = rpc.OverflowDropNewest
+	}
+	return q
+}
+
+// push adds the event to the queue applying overflow policy if it's full.
+// errQueueOverflow is returned if the client is to be disconnected.
+func (q *eventQueue) push(msg *websocket.PreparedMessage) error {
+	q.lock.Lock()
+	if q.closed {
+		q.lock.Unlock()
+		return nil
+	}
+	if q.queued >= q.size && q.policy == rpc.OverflowBlock {
+		q.lock.Unlock()
+		if !q.waitForSpace() {
+			q.lock.Lock()
+			q.dropNewest()
+			q.lock.Unlock()
+			return nil
+		}
+		q.lock.Lock()
+		if q.closed {
+			q.lock.Unlock()
+			return nil
+		}
+	}
+	defer q.lock.Unlock()
+	if q.queued >= q.size {
+		switch q.policy {
+		case rpc.OverflowDisconnect:
+			q.closed = true
+			q.entries = nil
+			return errQueueOverflow
+		case rpc.OverflowDropOldest:
+			q.dropOldest()
+		default:
+			q.dropNewest()
+			return nil
+		}
+	}
+	q.entries = append(q.entries, queueEntry{msg: msg})
+	q.queued++
+	q.signal(q.ready)
+	return nil
+}
+
+// waitForSpace waits for some entries to be popped from the queue for the
+// configured timeout and returns true if there is some space in the queue.
+func (q *eventQueue) waitForSpace() bool {
+	t := time.NewTimer(q.timeout)
+	defer t.Stop()
+	for {
+		select {
+		case <-q.space:
+			q.lock.Lock()
+			hasSpace := q.closed || q.queued < q.size
+			q.lock.Unlock()
+			if hasSpace {
+				return true
+			}
+		case <-t.C:
+			return false
+		}
+	}
+}
+
+// dropNewest accounts for a new event dropped, it must be called with the
+// lock held.
+func (q *eventQueue) dropNewest() {
+	if n := len(q.entries); n > 0 && q.entries[n-1].msg == nil {
+		q.entries[n-1].missed++
+		return
+	}
+	q.entries = append(q.entries, queueEntry{missed: 1})
+	q.signal(q.ready)
+}
+
+// dropOldest removes the oldest event from the queue, it must be called with
+// the lock held.
+func (q *eventQueue) dropOldest() {
+	if q.entries[0].msg == nil {
+		q.entries[0].missed++
+		q.entries = append(q.entries[:1], q.entries[2:]...)
+	} else {
+		q.entries[0] = queueEntry{missed: 1}
+	}
+	q.queued--
+}
+
+// pop removes the first entry from the queue and returns message for it,
+// false is returned if the queue is empty.
+func (q *eventQueue) pop() (*websocket.PreparedMessage, bool) {
+	for {
+		e, ok := q.popEntry()
+		if !ok {
+			return nil, false
+		}
+		if e.msg != nil {
+			return e.msg, true
+		}
+		msg, err := missedEventsMessage(e.missed)
+		if err == nil {
+			return msg, true
+		}
+	}
+}
+
+// popEntry removes the first entry from the queue and returns it.
+func (q *eventQueue) popEntry() (queueEntry, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.entries) == 0 {
+		return queueEntry{}, false
+	}
+	e := q.entries[0]
+	q.entries[0] = queueEntry{}
+	q.entries = q.entries[1:]
+	if e.msg != nil {
+		q.queued--
+		q.signal(q.space)
+	}
+	return e, true
+}
+
+// close makes the queue drop all events, it's used when the client is
+// disconnected.
+func (q *eventQueue) close() {
+	q.lock.Lock()
+	q.closed = true
+	q.entries = nil
+	q.queued = 0
+	q.signal(q.space)
+	q.lock.Unlock()
+}
+
+// signal does a non-blocking send to the given channel.
+func (q *eventQueue) signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// missedEventsMessage prepares `event_missed` event for the given number of
+// events.
+func missedEventsMessage(count int) (*websocket.PreparedMessage, error) {
+	b, err := json.Marshal(response.Notification{
+		JSONRPC: request.JSONRPCVersion,
+		Event:   response.MissedEventID,
+		Payload: []interface{}{response.MissedEvents{Count: count}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return websocket.NewPreparedMessage(websocket.TextMessage, b)
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMessages(t *testing.T, n int) []*websocket.PreparedMessage {
+	msgs := make([]*websocket.PreparedMessage, n)
+	for i := range msgs {
+		var err error
+		msgs[i], err = websocket.NewPreparedMessage(websocket.TextMessage, []byte(strconv.Itoa(i)))
+		require.NoError(t, err)
+	}
+	return msgs
+}
+
+// checkQueue pops all entries from the queue and checks them against expected
+// ones, nil message in expected entries means missed events marker.
+func checkQueue(t *testing.T, q *eventQueue, expected ...queueEntry) {
+	for _, exp := range expected {
+		e, ok := q.popEntry()
+		require.True(t, ok)
+		require.Equal(t, exp, e)
+	}
+	_, ok := q.popEntry()
+	require.False(t, ok)
+}
+
+func TestEventQueue(t *testing.T) {
+	msgs := newTestMessages(t, 5)
+	t.Run("default", func(t *testing.T) {
+		q := newEventQueue(rpc.QueueConfig{})
+		require.Equal(t, notificationBufSize, q.size)
+		require.Equal(t, rpc.OverflowDropNewest, q.policy)
+	})
+	t.Run("drop newest", func(t *testing.T) {
+		q := newEventQueue(rpc.QueueConfig{Size: 2})
+		for _, m := range msgs[:4] {
+			require.NoError(t, q.push(m))
+		}
+		checkQueue(t, q, queueEntry{msg: msgs[0]}, queueEntry{msg: msgs[1]}, queueEntry{missed: 2})
+
+		require.NoError(t, q.push(msgs[4]))
+		m, ok := q.pop()
+		require.True(t, ok)
+		require.Equal(t, msgs[4], m)
+	})
+	t.Run("drop oldest", func(t *testing.T) {
+		q := newEventQueue(rpc.QueueConfig{Size: 2, OverflowPolicy: rpc.OverflowDropOldest})
+		for _, m := range msgs {
+			require.NoError(t, q.push(m))
+		}
+		checkQueue(t, q, queueEntry{missed: 3}, queueEntry{msg: msgs[3]}, queueEntry{msg: msgs[4]})
+	})
+	t.Run("disconnect", func(t *testing.T) {
+		q := newEventQueue(rpc.QueueConfig{Size: 1, OverflowPolicy: rpc.OverflowDisconnect})
+		require.NoError(t, q.push(msgs[0]))
+		require.Equal(t, errQueueOverflow, q.push(msgs[1]))
+		require.NoError(t, q.push(msgs[2]))
+		checkQueue(t, q)
+	})
+	t.Run("block", func(t *testing.T) {
+		q := newEventQueue(rpc.QueueConfig{Size: 1, OverflowPolicy: rpc.OverflowBlock, BlockTimeout: 10 * time.Millisecond})
+		require.NoError(t, q.push(msgs[0]))
+		require.NoError(t, q.push(msgs[1]))
+		checkQueue(t, q, queueEntry{msg: msgs[0]}, queueEntry{missed: 1})
+
+		q = newEventQueue(rpc.QueueConfig{Size: 1, OverflowPolicy: rpc.OverflowBlock, BlockTimeout: time.Minute})
+		require.NoError(t, q.push(msgs[0]))
+		done := make(chan struct{})
+		go func() {
+			require.NoError(t, q.push(msgs[1]))
+			close(done)
+		}()
+		m, ok := q.pop()
+		require.True(t, ok)
+		require.Equal(t, msgs[0], m)
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("push is still blocked")
+		}
+		checkQueue(t, q, queueEntry{msg: msgs[1]})
+	})
+	t.Run("closed", func(t *testing.T) {
+		q := newEventQueue(rpc.QueueConfig{Size: 1, OverflowPolicy: rpc.OverflowBlock, BlockTimeout: time.Minute})
+		require.NoError(t, q.push(msgs[0]))
+		done := make(chan struct{})
+		go func() {
+			require.NoError(t, q.push(msgs[1]))
+			close(done)
+		}()
+		q.close()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("push is still blocked")
+		}
+		checkQueue(t, q)
+	})
+}
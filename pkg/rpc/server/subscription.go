@@ -7,15 +7,13 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
-	"go.uber.org/atomic"
 )
 
 type (
 	// subscriber is an event subscriber.
 	subscriber struct {
-		writer    chan<- *websocket.PreparedMessage
-		ws        *websocket.Conn
-		overflown atomic.Bool
+		queue *eventQueue
+		ws    *websocket.Conn
 		// These work like slots as there is not a lot of them (it's
 		// cheaper doing it this way rather than creating a map),
 		// pointing to EventID is an obvious overkill at the moment, but
@@ -32,14 +30,14 @@ const (
 	// Maximum number of subscriptions per one client.
 	maxFeeds = 16
 
-	// This sets notification messages buffer depth, it may seem to be quite
-	// big, but there is a big gap in speed between internal event processing
-	// and networking communication that is combined with spiky nature of our
-	// event generation process, which leads to lots of events generated in
-	// short time and they will put some pressure to this buffer (consider
-	// ~500 invocation txs in one block with some notifications). At the same
-	// time this channel is about sending pointers, so it's doesn't cost
-	// a lot in terms of memory used.
+	// This sets default notification messages queue depth, it may seem to
+	// be quite big, but there is a big gap in speed between internal event
+	// processing and networking communication that is combined with spiky
+	// nature of our event generation process, which leads to lots of events
+	// generated in short time and they will put some pressure to this queue
+	// (consider ~500 invocation txs in one block with some notifications). At
+	// the same time this queue is about storing pointers, so it's doesn't
+	// cost a lot in terms of memory used.
 	notificationBufSize = 1024
 )
 
@@ -79,7 +77,13 @@ func (f *feed) Matches(r *response.Notification) bool {
 	case response.ExecutionEventID:
 		filt := f.filter.(request.ExecutionFilter)
 		applog := r.Payload[0].(*state.AppExecResult)
-		return applog.VMState.String() == filt.State
+		stateOk := filt.State == nil || applog.VMState.String() == *filt.State
+		containerOk := filt.Container == nil || applog.Container.Equals(*filt.Container)
+		contractOk := filt.Contract == nil
+		for i := 0; !contractOk && i < len(applog.Events); i++ {
+			contractOk = applog.Events[i].ScriptHash.Equals(*filt.Contract)
+		}
+		return stateOk && containerOk && contractOk
 	}
 	return false
 }
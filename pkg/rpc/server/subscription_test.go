@@ -219,6 +219,27 @@ func TestFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, "HALT", st)
 			},
 		},
+		"execution matching container": {
+			params: `["transaction_executed", {"container":"` + deploymentTxHash + `"}]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.ExecutionEventID, resp.Event)
+				c := rmap["container"].(string)
+				require.Equal(t, "0x"+deploymentTxHash, c)
+			},
+		},
+		"execution matching contract": {
+			params: `["transaction_executed", {"state":"HALT", "contract":"` + testContractHash + `"}]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.ExecutionEventID, resp.Event)
+				var found bool
+				for _, n := range rmap["notifications"].([]interface{}) {
+					found = found || n.(map[string]interface{})["contract"].(string) == "0x"+testContractHash
+				}
+				require.True(t, found)
+			},
+		},
 		"tx non-matching": {
 			params: `["transaction_added", {"sender":"00112233445566778899aabbccddeeff00112233"}]`,
 			check: func(t *testing.T, _ *response.Notification) {
@@ -231,6 +252,18 @@ func TestFilteredSubscriptions(t *testing.T) {
 				t.Fatal("unexpected match for contract 00112233445566778899aabbccddeeff00112233")
 			},
 		},
+		"execution non-matching container": {
+			params: `["transaction_executed", {"container":"0000000000000000000000000000000000000000000000000000000000000000"}]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for zero container")
+			},
+		},
+		"execution non-matching contract": {
+			params: `["transaction_executed", {"contract":"00112233445566778899aabbccddeeff00112233"}]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for contract 00112233445566778899aabbccddeeff00112233")
+			},
+		},
 		"execution non-matching": {
 			params: `["transaction_executed", {"state":"FAULT"}]`,
 			check: func(t *testing.T, _ *response.Notification) {
@@ -359,6 +392,7 @@ func TestBadSubUnsub(t *testing.T) {
 		"notification filter 2":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", "name"], "id": 1}`,
 		"execution filter 1":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", "FAULT"], "id": 1}`,
 		"execution filter 2":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"state": "STOP"}], "id": 1}`,
+		"execution filter 3":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"contract": "00112233445566778899aabbccddeeff00112233", "name": "Transfer"}], "id": 1}`,
 	}
 	var unsubCases = map[string]string{
 		"no params":         `{"jsonrpc": "2.0", "method": "unsubscribe", "params": [], "id": 1}`,
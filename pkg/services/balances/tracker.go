@@ -0,0 +1,392 @@
+/*
+Package balances implements wallet balance tracker service. It follows
+executions of all blocks and transactions and maintains NEP-17 (including
+NEO and GAS) balances of the configured set of accounts based on Transfer
+notifications. Balances can optionally be saved to a file after every block,
+otherwise they're synchronized with the chain on start.
+*/
+package balances
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+type (
+	// Tracker maintains NEP-17 balances of the configured accounts.
+	Tracker struct {
+		chain    blockchainer.Blockchainer
+		log      *zap.Logger
+		filePath string
+		accounts []util.Uint160
+		tracked  map[util.Uint160]bool
+
+		lock     sync.RWMutex
+		height   uint32
+		balances map[util.Uint160]map[util.Uint160]*big.Int
+
+		// pending contains balance changes from executions of the block
+		// that is being processed.
+		pending map[util.Uint160]map[util.Uint160]*big.Int
+
+		started *atomic.Bool
+		blockCh chan *block.Block
+		execCh  chan *state.AppExecResult
+		quit    chan struct{}
+		done    chan struct{}
+	}
+
+	// fileData is the format of the balances file.
+	fileData struct {
+		Height   uint32                       `json:"height"`
+		Balances map[string]map[string]string `json:"balances"`
+	}
+)
+
+// New creates new Tracker using the given configuration.
+func New(cfg config.BalanceTracker, chain blockchainer.Blockchainer, log *zap.Logger) (*Tracker, error) {
+	if len(cfg.Accounts) == 0 {
+		return nil, errors.New("no accounts to track")
+	}
+	t := &Tracker{
+		chain:    chain,
+		log:      log,
+		filePath: cfg.FilePath,
+		tracked:  make(map[util.Uint160]bool, len(cfg.Accounts)),
+		balances: make(map[util.Uint160]map[util.Uint160]*big.Int),
+		pending:  make(map[util.Uint160]map[util.Uint160]*big.Int),
+		started:  atomic.NewBool(false),
+		blockCh:  make(chan *block.Block),
+		execCh:   make(chan *state.AppExecResult),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, s := range cfg.Accounts {
+		u, err := address.ParseUint160(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account %s: %w", s, err)
+		}
+		if !t.tracked[u] {
+			t.tracked[u] = true
+			t.accounts = append(t.accounts, u)
+		}
+	}
+	return t, nil
+}
+
+// Start loads balances (from the file if it's actual or from the chain) and
+// starts processing new blocks.
+func (t *Tracker) Start() {
+	if !t.started.CAS(false, true) {
+		return
+	}
+	// Subscribe first to not miss any block, those processed during
+	// synchronization are skipped by height.
+	t.chain.SubscribeForExecutions(t.execCh)
+	t.chain.SubscribeForBlocks(t.blockCh)
+	go t.run()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.filePath != "" {
+		ok, err := t.load()
+		if err != nil {
+			t.log.Warn("can't load balances from file, synchronizing with the chain",
+				zap.String("path", t.filePath), zap.Error(err))
+		}
+		if ok {
+			return
+		}
+	}
+	t.syncWithChain()
+}
+
+// Shutdown stops the service.
+func (t *Tracker) Shutdown() {
+	if !t.started.Load() {
+		return
+	}
+	close(t.quit)
+	<-t.done
+}
+
+// Accounts returns the list of tracked accounts.
+func (t *Tracker) Accounts() []util.Uint160 {
+	res := make([]util.Uint160, len(t.accounts))
+	copy(res, t.accounts)
+	return res
+}
+
+// Balances returns a copy of token balances of the account (with token
+// contract hashes as keys) along with the height they're actual for. An
+// error is returned if the account is not tracked.
+func (t *Tracker) Balances(acc util.Uint160) (map[util.Uint160]*big.Int, uint32, error) {
+	if !t.tracked[acc] {
+		return nil, 0, fmt.Errorf("account %s is not tracked", address.Uint160ToString(acc))
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	res := make(map[util.Uint160]*big.Int, len(t.balances[acc]))
+	for token, b := range t.balances[acc] {
+		res[token] = new(big.Int).Set(b)
+	}
+	return res, t.height, nil
+}
+
+func (t *Tracker) run() {
+runloop:
+	for {
+		select {
+		case <-t.quit:
+			break runloop
+		case aer := <-t.execCh:
+			t.handleExecution(aer)
+		case b := <-t.blockCh:
+			t.handleBlock(b)
+		}
+	}
+	// Chain can be blocked sending an event to us, so channels are drained
+	// until unsubscription is completed.
+	unsubscribed := make(chan struct{})
+	go func() {
+		t.chain.UnsubscribeFromExecutions(t.execCh)
+		t.chain.UnsubscribeFromBlocks(t.blockCh)
+		close(unsubscribed)
+	}()
+drainloop:
+	for {
+		select {
+		case <-t.execCh:
+		case <-t.blockCh:
+		case <-unsubscribed:
+			break drainloop
+		}
+	}
+	close(t.done)
+}
+
+// handleExecution collects balance changes from Transfer notifications of
+// the successful execution.
+func (t *Tracker) handleExecution(aer *state.AppExecResult) {
+	if aer.VMState != vm.HaltState {
+		return
+	}
+	for i := range aer.Events {
+		from, to, amount, ok := parseTransfer(&aer.Events[i])
+		if !ok {
+			continue
+		}
+		token := aer.Events[i].ScriptHash
+		if from != nil && t.tracked[*from] {
+			t.addPending(*from, token, new(big.Int).Neg(amount))
+		}
+		if to != nil && t.tracked[*to] {
+			t.addPending(*to, token, amount)
+		}
+	}
+}
+
+func (t *Tracker) addPending(acc, token util.Uint160, amount *big.Int) {
+	if t.pending[acc] == nil {
+		t.pending[acc] = make(map[util.Uint160]*big.Int)
+	}
+	if t.pending[acc][token] == nil {
+		t.pending[acc][token] = new(big.Int)
+	}
+	t.pending[acc][token].Add(t.pending[acc][token], amount)
+}
+
+// handleBlock applies changes collected for the block (block event comes
+// after all of its executions) and saves balances.
+func (t *Tracker) handleBlock(b *block.Block) {
+	pending := t.pending
+	t.pending = make(map[util.Uint160]map[util.Uint160]*big.Int)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if b.Index <= t.height {
+		// Already included into synchronized balances.
+		return
+	}
+	for acc, tokens := range pending {
+		if t.balances[acc] == nil {
+			t.balances[acc] = make(map[util.Uint160]*big.Int)
+		}
+		for token, amount := range tokens {
+			if t.balances[acc][token] == nil {
+				t.balances[acc][token] = new(big.Int)
+			}
+			t.balances[acc][token].Add(t.balances[acc][token], amount)
+		}
+	}
+	t.height = b.Index
+	if t.filePath != "" {
+		if err := t.save(); err != nil {
+			t.log.Error("failed to save balances", zap.String("path", t.filePath), zap.Error(err))
+		}
+	}
+}
+
+// parseTransfer returns sender, receiver and amount of NEP-17 transfer, nil
+// sender means minting and nil receiver means burning.
+func parseTransfer(note *state.NotificationEvent) (*util.Uint160, *util.Uint160, *big.Int, bool) {
+	if note.Name != "Transfer" || note.Item == nil {
+		return nil, nil, nil, false
+	}
+	arr, ok := note.Item.Value().([]stackitem.Item)
+	if !ok || len(arr) != 3 {
+		return nil, nil, nil, false
+	}
+	from, ok := parseAccount(arr[0])
+	if !ok {
+		return nil, nil, nil, false
+	}
+	to, ok := parseAccount(arr[1])
+	if !ok {
+		return nil, nil, nil, false
+	}
+	amount, ok := arr[2].Value().(*big.Int)
+	if !ok {
+		bs, ok := arr[2].Value().([]byte)
+		if !ok || len(bs) > bigint.MaxBytesLen {
+			return nil, nil, nil, false
+		}
+		amount = bigint.FromBytes(bs)
+	}
+	return from, to, amount, true
+}
+
+func parseAccount(item stackitem.Item) (*util.Uint160, bool) {
+	if item.Value() == nil {
+		return nil, true
+	}
+	bs, ok := item.Value().([]byte)
+	if !ok {
+		return nil, false
+	}
+	u, err := util.Uint160DecodeBytesBE(bs)
+	if err != nil {
+		return nil, false
+	}
+	return &u, true
+}
+
+// syncWithChain replaces balances with the ones stored in the chain. It must
+// be called with the lock held.
+func (t *Tracker) syncWithChain() {
+	for {
+		height := t.chain.BlockHeight()
+		balances := make(map[util.Uint160]map[util.Uint160]*big.Int, len(t.accounts))
+		for _, acc := range t.accounts {
+			bs := t.chain.GetNEP17Balances(acc)
+			if bs == nil {
+				continue
+			}
+			balances[acc] = make(map[util.Uint160]*big.Int, len(bs.Trackers))
+			for id, tr := range bs.Trackers {
+				token, err := t.chain.GetContractScriptHash(id)
+				if err != nil {
+					t.log.Warn("unknown token contract", zap.Int32("id", id), zap.Error(err))
+					continue
+				}
+				balances[acc][token] = new(big.Int).Set(&tr.Balance)
+			}
+		}
+		// Retry if some block was added in the meantime.
+		if height == t.chain.BlockHeight() {
+			t.height = height
+			t.balances = balances
+			return
+		}
+	}
+}
+
+// load reads balances from the file, false is returned if there is no file
+// or it's not actual.
+func (t *Tracker) load() (bool, error) {
+	data, err := ioutil.ReadFile(t.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	fd := new(fileData)
+	if err := json.Unmarshal(data, fd); err != nil {
+		return false, err
+	}
+	if fd.Height != t.chain.BlockHeight() {
+		return false, nil
+	}
+	balances := make(map[util.Uint160]map[util.Uint160]*big.Int, len(fd.Balances))
+	for addr, tokens := range fd.Balances {
+		acc, err := address.StringToUint160(addr)
+		if err != nil {
+			return false, fmt.Errorf("invalid account %s: %w", addr, err)
+		}
+		if !t.tracked[acc] {
+			continue
+		}
+		balances[acc] = make(map[util.Uint160]*big.Int, len(tokens))
+		for s, v := range tokens {
+			token, err := util.Uint160DecodeStringLE(s)
+			if err != nil {
+				return false, fmt.Errorf("invalid token %s: %w", s, err)
+			}
+			amount, ok := new(big.Int).SetString(v, 10)
+			if !ok {
+				return false, fmt.Errorf("invalid amount %s", v)
+			}
+			balances[acc][token] = amount
+		}
+	}
+	for _, acc := range t.accounts {
+		if _, ok := fd.Balances[address.Uint160ToString(acc)]; !ok {
+			// New account was added to the configuration.
+			return false, nil
+		}
+	}
+	t.height = fd.Height
+	t.balances = balances
+	return true, nil
+}
+
+// save writes balances to the file. It must be called with the lock held.
+func (t *Tracker) save() error {
+	fd := fileData{
+		Height:   t.height,
+		Balances: make(map[string]map[string]string, len(t.accounts)),
+	}
+	for _, acc := range t.accounts {
+		tokens := make(map[string]string, len(t.balances[acc]))
+		for token, amount := range t.balances[acc] {
+			tokens[token.StringLE()] = amount.String()
+		}
+		fd.Balances[address.Uint160ToString(acc)] = tokens
+	}
+	data, err := json.Marshal(fd)
+	if err != nil {
+		return err
+	}
+	tmp := t.filePath + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, t.filePath)
+}
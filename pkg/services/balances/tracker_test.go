@@ -0,0 +1,142 @@
+package balances
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTestChain(t *testing.T) *core.Blockchain {
+	cfg, err := config.Load("../../../config", testchain.Network())
+	require.NoError(t, err)
+	bc, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	go bc.Run()
+	t.Cleanup(bc.Close)
+	return bc
+}
+
+// chainBalances returns balances of the account stored in the chain.
+func chainBalances(t *testing.T, bc *core.Blockchain, acc util.Uint160) map[util.Uint160]*big.Int {
+	res := make(map[util.Uint160]*big.Int)
+	bs := bc.GetNEP17Balances(acc)
+	if bs == nil {
+		return res
+	}
+	for id, tr := range bs.Trackers {
+		h, err := bc.GetContractScriptHash(id)
+		require.NoError(t, err)
+		res[h] = new(big.Int).Set(&tr.Balance)
+	}
+	return res
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(config.BalanceTracker{Enabled: true}, nil, nil)
+	require.Error(t, err)
+
+	_, err = New(config.BalanceTracker{Enabled: true, Accounts: []string{"bad"}}, nil, nil)
+	require.Error(t, err)
+
+	u := random.Uint160()
+	tr, err := New(config.BalanceTracker{
+		Enabled:  true,
+		Accounts: []string{address.Uint160ToString(u), u.StringLE()},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint160{u}, tr.Accounts())
+
+	_, _, err = tr.Balances(random.Uint160())
+	require.Error(t, err)
+}
+
+func TestTracker(t *testing.T) {
+	bc := newTestChain(t)
+	owner := testchain.MultisigScriptHash()
+	receiver := random.Uint160()
+	d, err := ioutil.TempDir("", "neogo-balances")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+	filePath := path.Join(d, "balances.json")
+
+	cfg := config.BalanceTracker{
+		Enabled:  true,
+		Accounts: []string{address.Uint160ToString(owner), address.Uint160ToString(receiver)},
+		FilePath: filePath,
+	}
+	tr, err := New(cfg, bc, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	tr.Start()
+
+	bs, height, err := tr.Balances(owner)
+	require.NoError(t, err)
+	require.Equal(t, bc.BlockHeight(), height)
+	require.Equal(t, chainBalances(t, bc, owner), bs)
+
+	tx, err := testchain.NewTransferFromOwner(bc, bc.UtilityTokenHash(), receiver, 42, 0, bc.BlockHeight()+1)
+	require.NoError(t, err)
+	require.NoError(t, bc.AddBlock(testchain.NewBlock(t, bc, 1, 0, tx)))
+	require.Eventually(t, func() bool {
+		_, height, _ := tr.Balances(owner)
+		return height == bc.BlockHeight()
+	}, time.Second, 10*time.Millisecond)
+
+	bs, _, err = tr.Balances(receiver)
+	require.NoError(t, err)
+	require.Equal(t, map[util.Uint160]*big.Int{bc.UtilityTokenHash(): big.NewInt(42)}, bs)
+	bs, _, err = tr.Balances(owner)
+	require.NoError(t, err)
+	require.Equal(t, chainBalances(t, bc, owner), bs)
+	tr.Shutdown()
+
+	data, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	fd := new(fileData)
+	require.NoError(t, json.Unmarshal(data, fd))
+	require.Equal(t, bc.BlockHeight(), fd.Height)
+	require.Equal(t, "42", fd.Balances[address.Uint160ToString(receiver)][bc.UtilityTokenHash().StringLE()])
+
+	t.Run("load from file", func(t *testing.T) {
+		fd.Balances[address.Uint160ToString(receiver)][bc.UtilityTokenHash().StringLE()] = "100500"
+		data, err := json.Marshal(fd)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filePath, data, 0644))
+
+		tr, err := New(cfg, bc, zaptest.NewLogger(t))
+		require.NoError(t, err)
+		tr.Start()
+		defer tr.Shutdown()
+		bs, _, err := tr.Balances(receiver)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100500), bs[bc.UtilityTokenHash()])
+	})
+	t.Run("outdated file", func(t *testing.T) {
+		fd.Height--
+		data, err := json.Marshal(fd)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filePath, data, 0644))
+
+		tr, err := New(cfg, bc, zaptest.NewLogger(t))
+		require.NoError(t, err)
+		tr.Start()
+		defer tr.Shutdown()
+		bs, height, err := tr.Balances(receiver)
+		require.NoError(t, err)
+		require.Equal(t, bc.BlockHeight(), height)
+		require.Equal(t, big.NewInt(42), bs[bc.UtilityTokenHash()])
+	})
+}
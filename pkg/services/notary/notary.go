@@ -85,7 +85,12 @@ type request struct {
 
 // NewNotary returns new Notary module.
 func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction func(tx *transaction.Transaction) error) (*Notary, error) {
-	w := cfg.MainCfg.UnlockWallet
+	w := &cfg.MainCfg.UnlockWallet
+	pass, err := w.ResolvePassword()
+	if err != nil {
+		return nil, err
+	}
+	w.Password = pass
 	wallet, err := wallet.NewWalletFromFile(w.Path)
 	if err != nil {
 		return nil, err
@@ -123,7 +123,10 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	}
 
 	var err error
-	w := cfg.MainCfg.UnlockWallet
+	w := &o.MainCfg.UnlockWallet
+	if w.Password, err = w.ResolvePassword(); err != nil {
+		return nil, err
+	}
 	if o.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
 		return nil, err
 	}
@@ -78,7 +78,10 @@ func New(cfg config.StateRoot, log *zap.Logger, bc blockchainer.Blockchainer, cb
 	s.MainCfg = cfg
 	if cfg.Enabled {
 		var err error
-		w := cfg.UnlockWallet
+		w := &s.MainCfg.UnlockWallet
+		if w.Password, err = w.ResolvePassword(); err != nil {
+			return nil, err
+		}
 		if s.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,194 @@
+/*
+Package binding contains helpers for calling contract methods via RPC and
+a generator of typed Go wrappers for contracts based on their manifests.
+Generated code uses Call, Transaction and Send along with result converters
+like BigInt or Uint160.
+*/
+package binding
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// errNoResult is returned when value is expected, but result stack is empty.
+var errNoResult = errors.New("result stack is empty")
+
+// Script returns a script calling the method of the contract with the given
+// hash and arguments. Arguments can be of any type supported by emit.Array,
+// *keys.PublicKey and int are also accepted.
+func Script(h util.Uint160, method string, args ...interface{}) ([]byte, error) {
+	return client.CallScript(h, method, convertArgs(args)...)
+}
+
+// convertArgs converts arguments not supported by emit.Array.
+func convertArgs(args []interface{}) []interface{} {
+	res := make([]interface{}, len(args))
+	for i := range args {
+		switch a := args[i].(type) {
+		case *keys.PublicKey:
+			if a != nil {
+				res[i] = a.Bytes()
+			}
+		case int:
+			res[i] = int64(a)
+		case []interface{}:
+			res[i] = convertArgs(a)
+		default:
+			res[i] = a
+		}
+	}
+	return res
+}
+
+// Call invokes the method of the contract with the given hash in test mode
+// (via invokescript RPC) and returns the top item of the resulting stack (nil
+// if it's empty). Invocation ending in any state other than HALT is an error.
+// It's intended to be used for safe methods.
+func Call(c *client.Client, h util.Uint160, method string, args ...interface{}) (stackitem.Item, error) {
+	script, err := Script(h, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.NewInvoker(c, nil).Run(script)
+	if err != nil {
+		return nil, err
+	}
+	if res.State != "HALT" {
+		return nil, fmt.Errorf("%s invocation failed: %s", method, res.FaultException)
+	}
+	if len(res.Stack) == 0 {
+		return nil, nil
+	}
+	return res.Stack[len(res.Stack)-1], nil
+}
+
+// Transaction creates a transaction calling the method of the contract with
+// the given hash. Account is used as a sender and the only signer, fees are
+// calculated, but the transaction is not signed.
+func Transaction(c *client.Client, acc *wallet.Account, h util.Uint160, method string, args ...interface{}) (*transaction.Transaction, error) {
+	script, err := Script(h, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateTxFromScript(script, acc, -1, 0, nil)
+}
+
+// Send creates a transaction calling the method of the contract with the given
+// hash (see Transaction), signs it with the given account and sends it to the
+// network. Transaction hash is returned.
+func Send(c *client.Client, acc *wallet.Account, h util.Uint160, method string, args ...interface{}) (util.Uint256, error) {
+	tx, err := Transaction(c, acc, h, method, args...)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return c.SignAndPushTx(tx, acc, nil)
+}
+
+// checkResult returns an error if there is one or if there is no item.
+func checkResult(item stackitem.Item, err error) error {
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return errNoResult
+	}
+	return nil
+}
+
+// Bool converts the result of Call to boolean.
+func Bool(item stackitem.Item, err error) (bool, error) {
+	if err := checkResult(item, err); err != nil {
+		return false, err
+	}
+	return item.TryBool()
+}
+
+// BigInt converts the result of Call to integer.
+func BigInt(item stackitem.Item, err error) (*big.Int, error) {
+	if err := checkResult(item, err); err != nil {
+		return nil, err
+	}
+	return item.TryInteger()
+}
+
+// Bytes converts the result of Call to byte slice.
+func Bytes(item stackitem.Item, err error) ([]byte, error) {
+	if err := checkResult(item, err); err != nil {
+		return nil, err
+	}
+	return item.TryBytes()
+}
+
+// String converts the result of Call to UTF-8 string.
+func String(item stackitem.Item, err error) (string, error) {
+	b, err := Bytes(item, err)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("not a UTF-8 string")
+	}
+	return string(b), nil
+}
+
+// Uint160 converts the result of Call to Uint160.
+func Uint160(item stackitem.Item, err error) (util.Uint160, error) {
+	b, err := Bytes(item, err)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return util.Uint160DecodeBytesBE(b)
+}
+
+// Uint256 converts the result of Call to Uint256.
+func Uint256(item stackitem.Item, err error) (util.Uint256, error) {
+	b, err := Bytes(item, err)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesBE(b)
+}
+
+// PublicKey converts the result of Call to public key.
+func PublicKey(item stackitem.Item, err error) (*keys.PublicKey, error) {
+	b, err := Bytes(item, err)
+	if err != nil {
+		return nil, err
+	}
+	return keys.NewPublicKeyFromBytes(b, elliptic.P256())
+}
+
+// Array converts the result of Call to a slice of items (it can be either
+// Array or Struct).
+func Array(item stackitem.Item, err error) ([]stackitem.Item, error) {
+	if err := checkResult(item, err); err != nil {
+		return nil, err
+	}
+	if t := item.Type(); t != stackitem.ArrayT && t != stackitem.StructT {
+		return nil, fmt.Errorf("not an array: %s", t)
+	}
+	return item.Value().([]stackitem.Item), nil
+}
+
+// Map converts the result of Call to Map.
+func Map(item stackitem.Item, err error) (*stackitem.Map, error) {
+	if err := checkResult(item, err); err != nil {
+		return nil, err
+	}
+	m, ok := item.(*stackitem.Map)
+	if !ok {
+		return nil, fmt.Errorf("not a map: %s", item.Type())
+	}
+	return m, nil
+}
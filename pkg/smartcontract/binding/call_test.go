@@ -0,0 +1,108 @@
+package binding
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScript(t *testing.T) {
+	pk, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	h := util.Uint160{1, 2, 3}
+
+	script, err := Script(h, "method", 1, pk.PublicKey(), []interface{}{2, "str"})
+	require.NoError(t, err)
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, h, "method", callflag.All,
+		int64(1), pk.PublicKey().Bytes(), []interface{}{int64(2), "str"})
+	require.NoError(t, w.Err)
+	require.Equal(t, w.Bytes(), script)
+
+	_, err = Script(h, "method", struct{}{})
+	require.Error(t, err)
+}
+
+func TestUnwrap(t *testing.T) {
+	testErr := errors.New("some error")
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Bool(stackitem.Make(true), testErr)
+		require.True(t, errors.Is(err, testErr))
+		_, err = BigInt(nil, nil)
+		require.True(t, errors.Is(err, errNoResult))
+	})
+	t.Run("Bool", func(t *testing.T) {
+		b, err := Bool(stackitem.Make(true), nil)
+		require.NoError(t, err)
+		require.True(t, b)
+	})
+	t.Run("BigInt", func(t *testing.T) {
+		i, err := BigInt(stackitem.Make(42), nil)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(42), i)
+
+		_, err = BigInt(stackitem.Make([]stackitem.Item{}), nil)
+		require.Error(t, err)
+	})
+	t.Run("String", func(t *testing.T) {
+		s, err := String(stackitem.Make("neo"), nil)
+		require.NoError(t, err)
+		require.Equal(t, "neo", s)
+
+		_, err = String(stackitem.Make([]byte{0xff}), nil)
+		require.Error(t, err)
+	})
+	t.Run("Uint160", func(t *testing.T) {
+		u := util.Uint160{1, 2, 3}
+		res, err := Uint160(stackitem.Make(u.BytesBE()), nil)
+		require.NoError(t, err)
+		require.Equal(t, u, res)
+
+		_, err = Uint160(stackitem.Make([]byte{1}), nil)
+		require.Error(t, err)
+	})
+	t.Run("Uint256", func(t *testing.T) {
+		u := util.Uint256{1, 2, 3}
+		res, err := Uint256(stackitem.Make(u.BytesBE()), nil)
+		require.NoError(t, err)
+		require.Equal(t, u, res)
+	})
+	t.Run("PublicKey", func(t *testing.T) {
+		pk, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		res, err := PublicKey(stackitem.Make(pk.PublicKey().Bytes()), nil)
+		require.NoError(t, err)
+		require.Equal(t, pk.PublicKey(), res)
+
+		_, err = PublicKey(stackitem.Make([]byte{1, 2, 3}), nil)
+		require.Error(t, err)
+	})
+	t.Run("Array", func(t *testing.T) {
+		items := []stackitem.Item{stackitem.Make(1)}
+		res, err := Array(stackitem.NewStruct(items), nil)
+		require.NoError(t, err)
+		require.Equal(t, items, res)
+
+		_, err = Array(stackitem.Make(1), nil)
+		require.Error(t, err)
+	})
+	t.Run("Map", func(t *testing.T) {
+		m := stackitem.NewMap()
+		res, err := Map(m, nil)
+		require.NoError(t, err)
+		require.Equal(t, m, res)
+
+		_, err = Map(stackitem.Make(1), nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,320 @@
+package binding
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Config contains parameters for the wrapper generator.
+type Config struct {
+	// Manifest is the manifest of the contract.
+	Manifest *manifest.Manifest
+	// Hash is the hash of the deployed contract.
+	Hash util.Uint160
+	// Package is the name of generated package, it's derived from the
+	// contract name if not set.
+	Package string
+}
+
+type (
+	goParam struct {
+		Name string
+		Type string
+	}
+
+	goMethod struct {
+		Name       string
+		ABIName    string
+		Params     []goParam
+		ReturnType string
+		Unwrap     string
+	}
+
+	templateData struct {
+		Package    string
+		Contract   string
+		Hash       string
+		StdImports []string
+		Imports    []string
+		Safe       []goMethod
+		Unsafe     []goMethod
+	}
+)
+
+const (
+	pkgBinding     = "github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	pkgClient      = "github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	pkgKeys        = "github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	pkgStackitem   = "github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	pkgTransaction = "github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	pkgUtil        = "github.com/nspcc-dev/neo-go/pkg/util"
+	pkgWallet      = "github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+var wrapperTemplate = template.Must(template.New("wrapper").Parse(`// Code generated by neo-go contract generate-wrapper. DO NOT EDIT.
+
+// Package {{.Package}} contains RPC wrappers for {{.Contract}} contract.
+package {{.Package}}
+
+import (
+{{- range .StdImports}}
+	"{{.}}"
+{{- end}}
+{{range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// Hash contains contract hash.
+var Hash = {{.Hash}}
+
+// Contract provides access to contract methods via RPC.
+type Contract struct {
+	client *client.Client
+	hash   util.Uint160
+}
+
+// New creates contract wrapper using the given RPC client.
+func New(c *client.Client) *Contract {
+	return &Contract{client: c, hash: Hash}
+}
+{{range .Safe}}
+// {{.Name}} invokes ` + "`{{.ABIName}}`" + ` method of contract.
+func (c *Contract) {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.Type}}{{end}}) {{if .ReturnType}}({{.ReturnType}}, error){{else}}error{{end}} {
+{{- if not .ReturnType}}
+	_, err := binding.Call(c.client, c.hash, "{{.ABIName}}"{{range .Params}}, {{.Name}}{{end}})
+	return err
+{{- else if .Unwrap}}
+	return binding.{{.Unwrap}}(binding.Call(c.client, c.hash, "{{.ABIName}}"{{range .Params}}, {{.Name}}{{end}}))
+{{- else}}
+	return binding.Call(c.client, c.hash, "{{.ABIName}}"{{range .Params}}, {{.Name}}{{end}})
+{{- end}}
+}
+{{end}}
+{{- range .Unsafe}}
+// {{.Name}}Transaction creates a transaction invoking ` + "`{{.ABIName}}`" + ` method of contract
+// with acc as a sender. Fees are calculated, but the transaction is not signed.
+func (c *Contract) {{.Name}}Transaction(acc *wallet.Account{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (*transaction.Transaction, error) {
+	return binding.Transaction(c.client, acc, c.hash, "{{.ABIName}}"{{range .Params}}, {{.Name}}{{end}})
+}
+
+// {{.Name}} creates a transaction invoking ` + "`{{.ABIName}}`" + ` method of contract,
+// signs it with acc and sends it to the network.
+func (c *Contract) {{.Name}}(acc *wallet.Account{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (util.Uint256, error) {
+	return binding.Send(c.client, acc, c.hash, "{{.ABIName}}"{{range .Params}}, {{.Name}}{{end}})
+}
+{{end}}`))
+
+// reservedNames can't be used as parameter names in generated methods.
+var reservedNames = map[string]bool{
+	"c": true, "acc": true, "err": true,
+	"big": true, "binding": true, "client": true, "keys": true,
+	"stackitem": true, "transaction": true, "util": true, "wallet": true,
+}
+
+// Generate writes Go package with typed wrappers for all methods of the
+// contract described by the manifest. Safe methods are invoked in test mode
+// and return converted results, for other ones two methods are generated:
+// one creating a transaction and one creating, signing and sending it.
+func Generate(cfg Config, w io.Writer) error {
+	if cfg.Manifest == nil {
+		return fmt.Errorf("no manifest")
+	}
+	data := templateData{
+		Package:  cfg.Package,
+		Contract: cfg.Manifest.Name,
+		Hash:     uint160Literal(cfg.Hash),
+	}
+	if data.Package == "" {
+		data.Package = strings.ToLower(identifier(cfg.Manifest.Name, "contract"))
+	}
+	if !token.IsIdentifier(data.Package) || token.IsKeyword(data.Package) {
+		return fmt.Errorf("invalid package name: %q", data.Package)
+	}
+
+	imports := map[string]bool{pkgBinding: true, pkgClient: true, pkgUtil: true}
+	names := make(map[string]int)
+	for _, m := range cfg.Manifest.ABI.Methods {
+		if strings.HasPrefix(m.Name, "_") {
+			continue
+		}
+		gm := goMethod{
+			Name:    exported(identifier(m.Name, "method")),
+			ABIName: m.Name,
+		}
+		names[gm.Name]++
+		if names[gm.Name] > 1 {
+			gm.Name += strconv.Itoa(len(m.Parameters))
+		}
+		paramNames := make(map[string]bool)
+		for i, p := range m.Parameters {
+			name := unexported(identifier(p.Name, "arg"+strconv.Itoa(i)))
+			if token.IsKeyword(name) || reservedNames[name] || paramNames[name] {
+				name += "Arg" + strconv.Itoa(i)
+			}
+			paramNames[name] = true
+			typ, imp := goType(p.Type)
+			if imp != "" {
+				imports[imp] = true
+			}
+			gm.Params = append(gm.Params, goParam{Name: name, Type: typ})
+		}
+		if m.Safe {
+			if m.ReturnType != smartcontract.VoidType {
+				var imp string
+				gm.ReturnType, imp = goType(m.ReturnType)
+				if imp != "" {
+					imports[imp] = true
+				}
+				gm.Unwrap = unwrapFunc(m.ReturnType)
+				if t, ok := unwrapTypes[gm.Unwrap]; ok {
+					imports[pkgStackitem] = true
+					gm.ReturnType = t
+				}
+			}
+			data.Safe = append(data.Safe, gm)
+		} else {
+			imports[pkgTransaction] = true
+			imports[pkgWallet] = true
+			data.Unsafe = append(data.Unsafe, gm)
+		}
+	}
+	for imp := range imports {
+		if strings.Contains(imp, ".") {
+			data.Imports = append(data.Imports, imp)
+		} else {
+			data.StdImports = append(data.StdImports, imp)
+		}
+	}
+	sort.Strings(data.StdImports)
+	sort.Strings(data.Imports)
+
+	buf := bytes.NewBuffer(nil)
+	if err := wrapperTemplate.Execute(buf, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// goType returns Go type used for the parameter of the given type and the
+// package it requires to be imported.
+func goType(t smartcontract.ParamType) (string, string) {
+	switch t {
+	case smartcontract.BoolType:
+		return "bool", ""
+	case smartcontract.IntegerType:
+		return "*big.Int", "math/big"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "[]byte", ""
+	case smartcontract.StringType:
+		return "string", ""
+	case smartcontract.Hash160Type:
+		return "util.Uint160", ""
+	case smartcontract.Hash256Type:
+		return "util.Uint256", ""
+	case smartcontract.PublicKeyType:
+		return "*keys.PublicKey", pkgKeys
+	case smartcontract.ArrayType:
+		return "[]interface{}", ""
+	default:
+		return "interface{}", ""
+	}
+}
+
+// unwrapFunc returns the name of binding function converting result of the
+// given type, empty string means stack item is returned as is.
+func unwrapFunc(t smartcontract.ParamType) string {
+	switch t {
+	case smartcontract.BoolType:
+		return "Bool"
+	case smartcontract.IntegerType:
+		return "BigInt"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "Bytes"
+	case smartcontract.StringType:
+		return "String"
+	case smartcontract.Hash160Type:
+		return "Uint160"
+	case smartcontract.Hash256Type:
+		return "Uint256"
+	case smartcontract.PublicKeyType:
+		return "PublicKey"
+	case smartcontract.ArrayType:
+		return "Array"
+	case smartcontract.MapType:
+		return "Map"
+	default:
+		return ""
+	}
+}
+
+// unwrapTypes contains Go types of results that differ from parameter types,
+// the key is unwrap function name (empty for raw stack items).
+var unwrapTypes = map[string]string{
+	"":      "stackitem.Item",
+	"Array": "[]stackitem.Item",
+	"Map":   "*stackitem.Map",
+}
+
+// identifier removes all characters not allowed in Go identifiers from s,
+// def is returned if nothing is left.
+func identifier(s string, def string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || r == '_' || unicode.IsDigit(r) && b.Len() != 0 {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return def
+	}
+	return b.String()
+}
+
+// exported makes the first letter of s upper-case.
+func exported(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	if !unicode.IsUpper(r[0]) {
+		return "M" + string(r)
+	}
+	return string(r)
+}
+
+// unexported makes the first letter of s lower-case.
+func unexported(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// uint160Literal returns Go expression for the given Uint160 value.
+func uint160Literal(u util.Uint160) string {
+	var b strings.Builder
+	b.WriteString("util.Uint160{")
+	for i := range u {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "0x%02x", u[i])
+	}
+	b.WriteString("}")
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package binding
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	m := manifest.NewManifest("Test-Token")
+	m.ABI.Methods = []manifest.Method{
+		{Name: "_deploy", Parameters: []manifest.Parameter{
+			manifest.NewParameter("data", smartcontract.AnyType),
+			manifest.NewParameter("isUpdate", smartcontract.BoolType),
+		}, ReturnType: smartcontract.VoidType},
+		{Name: "symbol", ReturnType: smartcontract.StringType, Safe: true},
+		{Name: "balanceOf", Parameters: []manifest.Parameter{
+			manifest.NewParameter("account", smartcontract.Hash160Type),
+		}, ReturnType: smartcontract.IntegerType, Safe: true},
+		{Name: "balanceOf", Parameters: []manifest.Parameter{
+			manifest.NewParameter("account", smartcontract.Hash160Type),
+			manifest.NewParameter("id", smartcontract.ByteArrayType),
+		}, ReturnType: smartcontract.IntegerType, Safe: true},
+		{Name: "getKeys", ReturnType: smartcontract.ArrayType, Safe: true},
+		{Name: "getData", Parameters: []manifest.Parameter{
+			manifest.NewParameter("type", smartcontract.StringType),
+			manifest.NewParameter("", smartcontract.PublicKeyType),
+		}, ReturnType: smartcontract.AnyType, Safe: true},
+		{Name: "check", ReturnType: smartcontract.VoidType, Safe: true},
+		{Name: "transfer", Parameters: []manifest.Parameter{
+			manifest.NewParameter("from", smartcontract.Hash160Type),
+			manifest.NewParameter("to", smartcontract.Hash160Type),
+			manifest.NewParameter("amount", smartcontract.IntegerType),
+			manifest.NewParameter("data", smartcontract.AnyType),
+		}, ReturnType: smartcontract.BoolType},
+	}
+	h := util.Uint160{1, 2, 3}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, Generate(Config{Manifest: m, Hash: h}, buf))
+	src := buf.String()
+	require.True(t, strings.HasPrefix(src, "// Code generated by neo-go contract generate-wrapper. DO NOT EDIT."))
+	require.Contains(t, src, "var Hash = util.Uint160{0x01, 0x02, 0x03, 0x00")
+	require.NotContains(t, src, "_deploy")
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	require.NoError(t, err)
+	require.Equal(t, "testtoken", f.Name.Name)
+
+	var imports []string
+	for _, imp := range f.Imports {
+		imports = append(imports, strings.Trim(imp.Path.Value, `"`))
+	}
+	require.Equal(t, []string{
+		"math/big",
+		pkgTransaction,
+		pkgKeys,
+		pkgClient,
+		pkgBinding,
+		pkgUtil,
+		pkgStackitem,
+		pkgWallet,
+	}, imports)
+
+	sigs := make(map[string]string)
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Recv == nil {
+			continue
+		}
+		b := bytes.NewBuffer(nil)
+		require.NoError(t, format.Node(b, fset, fd.Type))
+		sigs[fd.Name.Name] = b.String()
+	}
+	require.Equal(t, map[string]string{
+		"Symbol":              "func() (string, error)",
+		"BalanceOf":           "func(account util.Uint160) (*big.Int, error)",
+		"BalanceOf2":          "func(account util.Uint160, id []byte) (*big.Int, error)",
+		"GetKeys":             "func() ([]stackitem.Item, error)",
+		"GetData":             "func(typeArg0 string, arg1 *keys.PublicKey) (stackitem.Item, error)",
+		"Check":               "func() error",
+		"TransferTransaction": "func(acc *wallet.Account, from util.Uint160, to util.Uint160, amount *big.Int, data interface{}) (*transaction.Transaction, error)",
+		"Transfer":            "func(acc *wallet.Account, from util.Uint160, to util.Uint160, amount *big.Int, data interface{}) (util.Uint256, error)",
+	}, sigs)
+
+	t.Run("package name", func(t *testing.T) {
+		buf.Reset()
+		require.NoError(t, Generate(Config{Manifest: m, Package: "token"}, buf))
+		require.Contains(t, buf.String(), "package token\n")
+
+		require.Error(t, Generate(Config{Manifest: m, Package: "func"}, buf))
+		require.Error(t, Generate(Config{Manifest: m, Package: "1abc"}, buf))
+	})
+	t.Run("no manifest", func(t *testing.T) {
+		require.Error(t, Generate(Config{}, buf))
+	})
+}
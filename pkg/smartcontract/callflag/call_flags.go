@@ -1,5 +1,7 @@
 package callflag
 
+import "strings"
+
 // CallFlag represents call flag.
 type CallFlag byte
 
@@ -20,3 +22,36 @@ const (
 func (f CallFlag) Has(cf CallFlag) bool {
 	return f&cf == cf
 }
+
+// basicFlags contains names of all distinct flags in the order of their values.
+var basicFlags = []struct {
+	flag CallFlag
+	name string
+}{
+	{ReadStates, "ReadStates"},
+	{WriteStates, "WriteStates"},
+	{AllowCall, "AllowCall"},
+	{AllowNotify, "AllowNotify"},
+}
+
+// String implements fmt.Stringer interface. Flags combinations having their
+// own names are returned as is, others are listed separated by ", ".
+func (f CallFlag) String() string {
+	switch f {
+	case NoneFlag:
+		return "None"
+	case States:
+		return "States"
+	case ReadOnly:
+		return "ReadOnly"
+	case All:
+		return "All"
+	}
+	var names []string
+	for _, b := range basicFlags {
+		if f.Has(b.flag) {
+			names = append(names, b.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -12,3 +12,11 @@ func TestCallFlag_Has(t *testing.T) {
 	require.False(t, (AllowCall).Has(AllowCall|AllowNotify))
 	require.True(t, All.Has(ReadOnly))
 }
+
+func TestCallFlag_String(t *testing.T) {
+	require.Equal(t, "None", NoneFlag.String())
+	require.Equal(t, "All", All.String())
+	require.Equal(t, "ReadOnly", ReadOnly.String())
+	require.Equal(t, "AllowNotify", AllowNotify.String())
+	require.Equal(t, "ReadStates, AllowNotify", (ReadStates | AllowNotify).String())
+}
@@ -34,6 +34,23 @@ func TestCreateMultiSigRedeemScript(t *testing.T) {
 	assert.Equal(t, opcode.PUSH3, opcode.Opcode(br.ReadB()))
 	assert.Equal(t, opcode.SYSCALL, opcode.Opcode(br.ReadB()))
 	assert.Equal(t, interopnames.ToID([]byte(interopnames.SystemCryptoCheckMultisig)), br.ReadU32LE())
+
+	t.Run("bad m", func(t *testing.T) {
+		_, err := CreateMultiSigRedeemScript(0, validators)
+		require.Error(t, err)
+		_, err = CreateMultiSigRedeemScript(4, validators)
+		require.Error(t, err)
+	})
+	t.Run("too many keys", func(t *testing.T) {
+		pubs := make(keys.PublicKeys, 1025)
+		for i := range pubs {
+			pubs[i] = val1
+		}
+		_, err := CreateMultiSigRedeemScript(1, pubs)
+		require.Error(t, err)
+		_, err = CreateMultiSigRedeemScript(1, pubs[:1024])
+		require.NoError(t, err)
+	})
 }
 
 func TestCreateDefaultMultiSigRedeemScript(t *testing.T) {
@@ -1,9 +1,12 @@
 package smartcontract
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
@@ -70,3 +73,96 @@ func ParameterFromStackItem(i stackitem.Item, seen map[stackitem.Item]bool) Para
 		panic(fmt.Sprintf("unknown stack item type: %v", t))
 	}
 }
+
+// ToStackItem converts Parameter to stackitem.Item. Hashes, public keys and
+// signatures are converted to byte strings, Any and InteropInterface
+// parameters can only be converted if they have no value (to Null).
+func (p *Parameter) ToStackItem() (stackitem.Item, error) {
+	switch p.Type {
+	case AnyType, InteropInterfaceType:
+		if p.Value != nil {
+			return nil, fmt.Errorf("%s parameter can't have a value", p.Type)
+		}
+		return stackitem.Null{}, nil
+	case BoolType:
+		b, ok := p.Value.(bool)
+		if !ok {
+			return nil, errors.New("invalid Boolean value")
+		}
+		return stackitem.NewBool(b), nil
+	case IntegerType:
+		switch v := p.Value.(type) {
+		case int64:
+			return stackitem.NewBigInteger(big.NewInt(v)), nil
+		case *big.Int:
+			return stackitem.NewBigInteger(v), nil
+		default:
+			return nil, errors.New("invalid Integer value")
+		}
+	case ByteArrayType, SignatureType, PublicKeyType:
+		switch v := p.Value.(type) {
+		case []byte:
+			return stackitem.NewByteArray(v), nil
+		case *keys.PublicKey:
+			if p.Type == PublicKeyType {
+				return stackitem.NewByteArray(v.Bytes()), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid %s value", p.Type)
+	case StringType:
+		s, ok := p.Value.(string)
+		if !ok {
+			return nil, errors.New("invalid String value")
+		}
+		return stackitem.NewByteArray([]byte(s)), nil
+	case Hash160Type:
+		u, ok := p.Value.(util.Uint160)
+		if !ok {
+			return nil, errors.New("invalid Hash160 value")
+		}
+		return stackitem.NewByteArray(u.BytesBE()), nil
+	case Hash256Type:
+		u, ok := p.Value.(util.Uint256)
+		if !ok {
+			return nil, errors.New("invalid Hash256 value")
+		}
+		return stackitem.NewByteArray(u.BytesBE()), nil
+	case ArrayType:
+		ps, ok := p.Value.([]Parameter)
+		if !ok {
+			return nil, errors.New("invalid Array value")
+		}
+		items := make([]stackitem.Item, len(ps))
+		for i := range ps {
+			item, err := ps[i].ToStackItem()
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			items[i] = item
+		}
+		return stackitem.NewArray(items), nil
+	case MapType:
+		pairs, ok := p.Value.([]ParameterPair)
+		if !ok {
+			return nil, errors.New("invalid Map value")
+		}
+		m := stackitem.NewMap()
+		for i := range pairs {
+			k, err := pairs[i].Key.ToStackItem()
+			if err != nil {
+				return nil, fmt.Errorf("key %d: %w", i, err)
+			}
+			if err := stackitem.IsValidMapKey(k); err != nil {
+				return nil, fmt.Errorf("key %d: %w", i, err)
+			}
+			v, err := pairs[i].Value.ToStackItem()
+			if err != nil {
+				return nil, fmt.Errorf("value %d: %w", i, err)
+			}
+			m.Add(k, v)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("unsupported parameter type %s", p.Type)
+	}
+}
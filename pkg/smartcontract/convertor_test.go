@@ -4,8 +4,11 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var toContractParameterTestCases = []struct {
@@ -77,3 +80,63 @@ func TestToContractParameter(t *testing.T) {
 		assert.Equal(t, res, tc.result)
 	}
 }
+
+func TestParameter_ToStackItem(t *testing.T) {
+	for _, tc := range toContractParameterTestCases {
+		switch tc.input.(type) {
+		case *stackitem.Buffer, *stackitem.Struct, *stackitem.Interop:
+			continue
+		}
+		item, err := tc.result.ToStackItem()
+		require.NoError(t, err)
+		require.Equal(t, tc.input, item)
+	}
+
+	t.Run("other types", func(t *testing.T) {
+		pk, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		u160 := util.Uint160{1, 2, 3}
+		u256 := util.Uint256{4, 5, 6}
+		testCases := []struct {
+			param    Parameter
+			expected stackitem.Item
+		}{
+			{Parameter{Type: AnyType}, stackitem.Null{}},
+			{Parameter{Type: InteropInterfaceType}, stackitem.Null{}},
+			{Parameter{Type: StringType, Value: "str"}, stackitem.NewByteArray([]byte("str"))},
+			{Parameter{Type: Hash160Type, Value: u160}, stackitem.NewByteArray(u160.BytesBE())},
+			{Parameter{Type: Hash256Type, Value: u256}, stackitem.NewByteArray(u256.BytesBE())},
+			{Parameter{Type: PublicKeyType, Value: pk.PublicKey()}, stackitem.NewByteArray(pk.PublicKey().Bytes())},
+			{Parameter{Type: SignatureType, Value: []byte{1, 2}}, stackitem.NewByteArray([]byte{1, 2})},
+			{Parameter{Type: IntegerType, Value: big.NewInt(42)}, stackitem.NewBigInteger(big.NewInt(42))},
+		}
+		for _, tc := range testCases {
+			item, err := tc.param.ToStackItem()
+			require.NoError(t, err, tc.param.Type)
+			require.Equal(t, tc.expected, item, tc.param.Type)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		errCases := []Parameter{
+			{Type: AnyType, Value: 1},
+			{Type: InteropInterfaceType, Value: "value"},
+			{Type: BoolType, Value: "true"},
+			{Type: IntegerType, Value: "1"},
+			{Type: ByteArrayType, Value: "1"},
+			{Type: StringType, Value: []byte{1}},
+			{Type: Hash160Type, Value: util.Uint256{}},
+			{Type: Hash256Type, Value: util.Uint160{}},
+			{Type: ArrayType, Value: []Parameter{{Type: BoolType}}},
+			{Type: MapType, Value: []ParameterPair{{
+				Key:   Parameter{Type: ArrayType, Value: []Parameter{}},
+				Value: Parameter{Type: AnyType},
+			}}},
+			{Type: VoidType},
+		}
+		for _, p := range errCases {
+			_, err := p.ToStackItem()
+			require.Error(t, err, p.Type)
+		}
+	})
+}
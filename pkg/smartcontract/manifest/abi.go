@@ -50,6 +50,28 @@ func (a *ABI) GetEvent(name string) *Event {
 	return nil
 }
 
+// normalized returns a copy of ABI with all nil slices replaced by empty
+// ones, it's used for manifest hash calculation.
+func (a ABI) normalized() ABI {
+	res := ABI{
+		Methods: make([]Method, len(a.Methods)),
+		Events:  make([]Event, len(a.Events)),
+	}
+	copy(res.Methods, a.Methods)
+	copy(res.Events, a.Events)
+	for i := range res.Methods {
+		if res.Methods[i].Parameters == nil {
+			res.Methods[i].Parameters = []Parameter{}
+		}
+	}
+	for i := range res.Events {
+		if res.Events[i].Parameters == nil {
+			res.Events[i].Parameters = []Parameter{}
+		}
+	}
+	return res
+}
+
 // IsValid checks ABI consistency and correctness.
 func (a *ABI) IsValid() error {
 	if len(a.Methods) == 0 {
@@ -64,6 +64,17 @@ func (g Groups) AreValid(h util.Uint160) error {
 	return nil
 }
 
+// Contains returns true if there is a group with the given key and valid
+// signature of the given contract hash.
+func (g Groups) Contains(h util.Uint160, k *keys.PublicKey) bool {
+	for i := range g {
+		if g[i].PublicKey.Equal(k) && g[i].IsValid(h) == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // MarshalJSON implements json.Marshaler interface.
 func (g *Group) MarshalJSON() ([]byte, error) {
 	aux := &groupAux{
@@ -1,10 +1,12 @@
 package manifest
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"math"
 
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
@@ -67,8 +69,9 @@ func DefaultManifest(name string) *Manifest {
 	return m
 }
 
-// CanCall returns true is current contract is allowed to call
-// method of another contract with specified hash.
+// CanCall returns true if current contract is allowed to call method of the
+// contract with the given hash and manifest according to current contract's
+// permissions (see Permission.IsAllowed).
 func (m *Manifest) CanCall(hash util.Uint160, toCall *Manifest, method string) bool {
 	for i := range m.Permissions {
 		if m.Permissions[i].IsAllowed(hash, toCall, method) {
@@ -78,6 +81,67 @@ func (m *Manifest) CanCall(hash util.Uint160, toCall *Manifest, method string) b
 	return false
 }
 
+// MarshalJSON implements json.Marshaler interface. The output is canonical:
+// fields always go in the same order and implementation-defined JSON
+// (features and extra) is compacted with object keys sorted.
+func (m Manifest) MarshalJSON() ([]byte, error) {
+	type manifestAux Manifest
+	aux := manifestAux(m)
+	aux.Features = canonicalJSON(m.Features)
+	aux.Extra = canonicalJSON(m.Extra)
+	return json.Marshal(aux)
+}
+
+// Hash returns SHA256 hash of the canonical manifest JSON (see MarshalJSON)
+// with nil slices treated as empty ones, so it's the same for semantically
+// identical manifests.
+func (m *Manifest) Hash() (util.Uint256, error) {
+	data, err := json.Marshal(m.normalized())
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return hash.Sha256(data), nil
+}
+
+// normalized returns a copy of the manifest with all nil slices replaced by
+// empty ones and empty features replaced by an empty object.
+func (m *Manifest) normalized() Manifest {
+	res := *m
+	res.ABI = m.ABI.normalized()
+	if len(res.Features) == 0 {
+		res.Features = json.RawMessage("{}")
+	}
+	if res.Groups == nil {
+		res.Groups = []Group{}
+	}
+	if res.Permissions == nil {
+		res.Permissions = []Permission{}
+	}
+	if res.SupportedStandards == nil {
+		res.SupportedStandards = []string{}
+	}
+	return res
+}
+
+// canonicalJSON returns compact representation of the given JSON with
+// object keys sorted. Empty and invalid JSON is returned as is.
+func canonicalJSON(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 {
+		return data
+	}
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil || d.More() {
+		return data
+	}
+	res, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+	return res
+}
+
 // IsValid checks manifest internal consistency and correctness, one of the
 // checks is for group signature correctness, contract hash is passed for it.
 func (m *Manifest) IsValid(hash util.Uint160) error {
@@ -63,6 +63,19 @@ func TestManifest_CanCall(t *testing.T) {
 	man1 := DefaultManifest("Test1")
 	man2 := DefaultManifest("Test2")
 	require.True(t, man1.CanCall(util.Uint160{}, man2, "method1"))
+
+	t.Run("restricted", func(t *testing.T) {
+		man1.Permissions = []Permission{*NewPermission(PermissionHash, util.Uint160{1})}
+		man1.Permissions[0].Methods.Restrict()
+		man1.Permissions[0].Methods.Add("method1")
+		require.True(t, man1.CanCall(util.Uint160{1}, man2, "method1"))
+		require.False(t, man1.CanCall(util.Uint160{1}, man2, "method2"))
+		require.False(t, man1.CanCall(util.Uint160{2}, man2, "method1"))
+	})
+	t.Run("no permissions", func(t *testing.T) {
+		man1.Permissions = nil
+		require.False(t, man1.CanCall(util.Uint160{}, man2, "method1"))
+	})
 }
 
 func TestPermission_IsAllowed(t *testing.T) {
@@ -71,6 +84,13 @@ func TestPermission_IsAllowed(t *testing.T) {
 	t.Run("wildcard", func(t *testing.T) {
 		perm := NewPermission(PermissionWildcard)
 		require.True(t, perm.IsAllowed(util.Uint160{}, manifest, "AAA"))
+
+		t.Run("restrict methods", func(t *testing.T) {
+			perm.Methods.Restrict()
+			require.False(t, perm.IsAllowed(util.Uint160{}, manifest, "AAA"))
+			perm.Methods.Add("AAA")
+			require.True(t, perm.IsAllowed(util.Uint160{}, manifest, "AAA"))
+		})
 	})
 
 	t.Run("hash", func(t *testing.T) {
@@ -92,11 +112,44 @@ func TestPermission_IsAllowed(t *testing.T) {
 
 	priv, err := keys.NewPrivateKey()
 	require.NoError(t, err)
+
+	t.Run("group, no groups", func(t *testing.T) {
+		perm := NewPermission(PermissionGroup, priv.PublicKey())
+		require.False(t, perm.IsAllowed(util.Uint160{}, manifest, "AAA"))
+	})
+
 	manifest.Groups = []Group{{PublicKey: priv.PublicKey()}}
 
+	t.Run("group, invalid signature", func(t *testing.T) {
+		perm := NewPermission(PermissionGroup, priv.PublicKey())
+		require.False(t, perm.IsAllowed(util.Uint160{}, manifest, "AAA"))
+	})
+
+	manifest.Groups[0].Signature = priv.Sign(util.Uint160{}.BytesBE())
+
 	t.Run("group", func(t *testing.T) {
 		perm := NewPermission(PermissionGroup, priv.PublicKey())
 		require.True(t, perm.IsAllowed(util.Uint160{}, manifest, "AAA"))
+		require.False(t, perm.IsAllowed(util.Uint160{1}, manifest, "AAA"))
+
+		t.Run("restrict methods", func(t *testing.T) {
+			perm.Methods.Restrict()
+			perm.Methods.Add("BBB")
+			require.False(t, perm.IsAllowed(util.Uint160{}, manifest, "AAA"))
+			require.True(t, perm.IsAllowed(util.Uint160{}, manifest, "BBB"))
+		})
+	})
+
+	t.Run("group, multiple groups", func(t *testing.T) {
+		priv2, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		m := DefaultManifest("Test")
+		m.Groups = []Group{
+			{PublicKey: priv2.PublicKey(), Signature: priv2.Sign(util.Uint160{}.BytesBE())},
+			manifest.Groups[0],
+		}
+		perm := NewPermission(PermissionGroup, priv.PublicKey())
+		require.True(t, perm.IsAllowed(util.Uint160{}, m, "AAA"))
 	})
 
 	t.Run("invalid group", func(t *testing.T) {
@@ -395,3 +448,51 @@ func TestABI_FromStackItemErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestManifest_CanonicalJSON(t *testing.T) {
+	m := DefaultManifest("Test")
+	m.ABI.Methods = []Method{{Name: "method", ReturnType: smartcontract.BoolType}}
+	m.Groups = nil
+	m.SupportedStandards = nil
+	m.Extra = json.RawMessage(`{ "b": 1, "a": {"d": [1, 2], "c": 1.50} }`)
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Test","abi":{"methods":[{"name":"method","offset":0,"parameters":null,"returntype":"Boolean","safe":false}],"events":[]},`+
+		`"features":{},"groups":null,"permissions":[{"contract":"*","methods":"*"}],"supportedstandards":null,"trusts":[],`+
+		`"extra":{"a":{"c":1.50,"d":[1,2]},"b":1}}`, string(data))
+
+	t.Run("round trip", func(t *testing.T) {
+		m2 := new(Manifest)
+		require.NoError(t, json.Unmarshal(data, m2))
+		require.Equal(t, `{"a":{"c":1.50,"d":[1,2]},"b":1}`, string(m2.Extra))
+		m2.Extra = m.Extra
+		require.Equal(t, m, m2)
+	})
+	t.Run("hash", func(t *testing.T) {
+		h, err := m.Hash()
+		require.NoError(t, err)
+
+		m2 := new(Manifest)
+		require.NoError(t, json.Unmarshal(data, m2))
+		m2.ABI.Methods[0].Parameters = []Parameter{}
+		m2.Groups = []Group{}
+		m2.SupportedStandards = []string{}
+		m2.Features = nil
+		m2.Extra = json.RawMessage(`{"b":1,"a":{"c":1.50,"d":[1,2]}}`)
+		h2, err := m2.Hash()
+		require.NoError(t, err)
+		require.Equal(t, h, h2)
+
+		m2.Extra = json.RawMessage(`{"b":2,"a":{"c":1.50,"d":[1,2]}}`)
+		h2, err = m2.Hash()
+		require.NoError(t, err)
+		require.NotEqual(t, h, h2)
+	})
+	t.Run("invalid extra", func(t *testing.T) {
+		m := DefaultManifest("Test")
+		m.Extra = json.RawMessage(`not a json`)
+		_, err := m.Hash()
+		require.Error(t, err)
+	})
+}
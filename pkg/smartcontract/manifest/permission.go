@@ -158,21 +158,22 @@ func (ps Permissions) AreValid() error {
 	return nil
 }
 
-// IsAllowed checks if method is allowed to be executed.
+// IsAllowed checks if method of the contract with the given hash and manifest
+// is allowed to be executed. Contract restriction is checked first: wildcard
+// allows any contract, hash restriction allows the contract with this hash
+// only and group restriction allows contracts having this group with valid
+// signature in their manifest. Then the method is checked against the list of
+// allowed methods.
 func (p *Permission) IsAllowed(hash util.Uint160, m *Manifest, method string) bool {
 	switch p.Contract.Type {
 	case PermissionWildcard:
-		return true
 	case PermissionHash:
 		if !p.Contract.Hash().Equals(hash) {
 			return false
 		}
 	case PermissionGroup:
-		g := p.Contract.Group()
-		for i := range m.Groups {
-			if !g.Equal(m.Groups[i].PublicKey) {
-				return false
-			}
+		if !Groups(m.Groups).Contains(hash, p.Contract.Group()) {
+			return false
 		}
 	default:
 		panic(fmt.Sprintf("unexpected permission: %d", p.Contract.Type))
@@ -145,13 +145,14 @@ func (pt *ParamType) DecodeBinary(r *io.BinReader) {
 //     int, integer -> IntegerType
 //     hash160 -> Hash160Type
 //     hash256 -> Hash256Type
-//     bytes, bytearray, filebytes -> ByteArrayType
+//     bytes, bytearray, bytestring, buffer, filebytes -> ByteArrayType
 //     key, publickey -> PublicKeyType
 //     string -> StringType
 //     array, struct -> ArrayType
 //     map -> MapType
-//     interopinterface -> InteropInterfaceType
+//     interop, interopinterface -> InteropInterfaceType
 //     void -> VoidType
+//     any -> AnyType
 // anything else generates an error.
 func ParseParamType(typ string) (ParamType, error) {
 	switch strings.ToLower(typ) {
@@ -165,7 +166,7 @@ func ParseParamType(typ string) (ParamType, error) {
 		return Hash160Type, nil
 	case "hash256":
 		return Hash256Type, nil
-	case "bytes", "bytearray", "bytestring", fileBytesParamType:
+	case "bytes", "bytearray", "bytestring", "buffer", fileBytesParamType:
 		return ByteArrayType, nil
 	case "key", "publickey":
 		return PublicKeyType, nil
@@ -175,7 +176,7 @@ func ParseParamType(typ string) (ParamType, error) {
 		return ArrayType, nil
 	case "map":
 		return MapType, nil
-	case "interopinterface":
+	case "interop", "interopinterface":
 		return InteropInterfaceType, nil
 	case "void":
 		return VoidType, nil
@@ -53,6 +53,18 @@ func TestParseParamType(t *testing.T) {
 	}, {
 		in:  "interopinterface",
 		out: InteropInterfaceType,
+	}, {
+		in:  "interop",
+		out: InteropInterfaceType,
+	}, {
+		in:  "buffer",
+		out: ByteArrayType,
+	}, {
+		in:  "bytestring",
+		out: ByteArrayType,
+	}, {
+		in:  "any",
+		out: AnyType,
 	}, {
 		in:  "void",
 		out: VoidType,
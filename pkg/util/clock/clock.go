@@ -0,0 +1,63 @@
+/*
+Package clock provides time source abstraction used by the node services, so
+that real time can be replaced with a manually controlled one in tests.
+*/
+package clock
+
+import (
+	"time"
+)
+
+// Clock is a source of time and timers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a new Timer sending the current time to its channel
+	// after at least duration d.
+	NewTimer(d time.Duration) Timer
+	// AfterFunc waits for the duration to elapse and then calls f in its
+	// own goroutine. Returned Timer has nil channel.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a single event timer similar to time.Timer.
+type Timer interface {
+	// C returns the channel time is sent to when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing, it returns false if the timer
+	// has already expired or been stopped.
+	Stop() bool
+	// Reset changes the timer to expire after duration d, it returns true
+	// if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+type (
+	realClock struct{}
+	realTimer struct {
+		*time.Timer
+	}
+)
+
+// Real is the Clock using system time.
+var Real Clock = realClock{}
+
+// Now implements Clock interface.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer implements Clock interface.
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// AfterFunc implements Clock interface.
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+// C implements Timer interface.
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
@@ -0,0 +1,144 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Mock is a Clock that only changes its time when told to. Timers fire when
+// the time is moved past their deadlines with Add or Set, so tests can
+// fast-forward time instead of sleeping.
+type Mock struct {
+	lock   sync.Mutex
+	now    time.Time
+	timers []*mockTimer
+}
+
+// mockTimer is a Timer of Mock clock.
+type mockTimer struct {
+	clock    *Mock
+	deadline time.Time
+	ch       chan time.Time
+	f        func()
+}
+
+// NewMock returns a new Mock clock set to the given time.
+func NewMock(now time.Time) *Mock {
+	return &Mock{now: now}
+}
+
+// Now implements Clock interface.
+func (m *Mock) Now() time.Time {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.now
+}
+
+// NewTimer implements Clock interface.
+func (m *Mock) NewTimer(d time.Duration) Timer {
+	t := &mockTimer{clock: m, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// AfterFunc implements Clock interface.
+func (m *Mock) AfterFunc(d time.Duration, f func()) Timer {
+	t := &mockTimer{clock: m, f: f}
+	t.Reset(d)
+	return t
+}
+
+// Add moves the clock forward by the given duration firing all timers
+// expiring during this period.
+func (m *Mock) Add(d time.Duration) {
+	m.Set(m.Now().Add(d))
+}
+
+// Set sets the clock to the given time firing all timers expiring before
+// it. Time can't be moved backwards, so earlier times are ignored.
+func (m *Mock) Set(now time.Time) {
+	m.lock.Lock()
+	if now.Before(m.now) {
+		m.lock.Unlock()
+		return
+	}
+	m.now = now
+	var fired []*mockTimer
+	var i int
+	for _, t := range m.timers {
+		if t.deadline.After(now) {
+			m.timers[i] = t
+			i++
+		} else {
+			fired = append(fired, t)
+		}
+	}
+	for j := i; j < len(m.timers); j++ {
+		m.timers[j] = nil
+	}
+	m.timers = m.timers[:i]
+	m.lock.Unlock()
+
+	sort.Slice(fired, func(i, j int) bool {
+		return fired[i].deadline.Before(fired[j].deadline)
+	})
+	for _, t := range fired {
+		t.fire(now)
+	}
+}
+
+// fire makes expired timer send the time to its channel or call its
+// function.
+func (t *mockTimer) fire(now time.Time) {
+	if t.f != nil {
+		go t.f()
+		return
+	}
+	select {
+	case t.ch <- now:
+	default: // Previous value wasn't read, the same as for time.Timer.
+	}
+}
+
+// C implements Timer interface.
+func (t *mockTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// Stop implements Timer interface.
+func (t *mockTimer) Stop() bool {
+	m := t.clock
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.removeTimer(t)
+}
+
+// Reset implements Timer interface.
+func (t *mockTimer) Reset(d time.Duration) bool {
+	m := t.clock
+	m.lock.Lock()
+	active := m.removeTimer(t)
+	t.deadline = m.now.Add(d)
+	if d > 0 {
+		m.timers = append(m.timers, t)
+		m.lock.Unlock()
+		return active
+	}
+	now := m.now
+	m.lock.Unlock()
+	t.fire(now)
+	return active
+}
+
+// removeTimer removes the given timer from the list of active ones, it
+// returns true if it was there. It must be called with the lock held.
+func (m *Mock) removeTimer(t *mockTimer) bool {
+	for i := range m.timers {
+		if m.timers[i] == t {
+			m.timers = append(m.timers[:i], m.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
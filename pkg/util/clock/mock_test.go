@@ -0,0 +1,53 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockTimer(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	m := NewMock(start)
+	require.Equal(t, start, m.Now())
+
+	tm := m.NewTimer(time.Second)
+	m.Add(999 * time.Millisecond)
+	select {
+	case <-tm.C():
+		t.Fatal("timer fired too early")
+	default:
+	}
+	m.Add(time.Millisecond)
+	require.Equal(t, start.Add(time.Second), <-tm.C())
+	require.False(t, tm.Stop())
+
+	require.False(t, tm.Reset(time.Minute))
+	require.True(t, tm.Stop())
+	m.Add(time.Hour)
+	select {
+	case <-tm.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+
+	t.Run("backwards", func(t *testing.T) {
+		now := m.Now()
+		m.Set(now.Add(-time.Hour))
+		require.Equal(t, now, m.Now())
+	})
+}
+
+func TestMockAfterFunc(t *testing.T) {
+	m := NewMock(time.Unix(1600000000, 0))
+	ch := make(chan int, 2)
+	m.AfterFunc(2*time.Second, func() { ch <- 2 })
+	m.AfterFunc(time.Second, func() { ch <- 1 })
+	require.Nil(t, m.AfterFunc(time.Second, func() {}).C())
+
+	m.Add(time.Second)
+	require.Equal(t, 1, <-ch)
+	m.Add(time.Second)
+	require.Equal(t, 2, <-ch)
+}
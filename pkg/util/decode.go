@@ -0,0 +1,75 @@
+package util
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// Uint160DecodeStringsLE decodes the given little-endian hex strings into
+// Uint160 values, an optional "0x" prefix is allowed for each of them.
+func Uint160DecodeStringsLE(ss []string) ([]Uint160, error) {
+	res := make([]Uint160, len(ss))
+	for i, s := range ss {
+		u, err := Uint160DecodeStringLE(trimHexPrefix(s, Uint160Size))
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		res[i] = u
+	}
+	return res, nil
+}
+
+// Uint160DecodeBase64Strings decodes the given base64 strings of big-endian
+// bytes into Uint160 values.
+func Uint160DecodeBase64Strings(ss []string) ([]Uint160, error) {
+	res := make([]Uint160, len(ss))
+	for i, s := range ss {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err == nil {
+			res[i], err = Uint160DecodeBytesBE(b)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// Uint256DecodeStringsLE decodes the given little-endian hex strings into
+// Uint256 values, an optional "0x" prefix is allowed for each of them.
+func Uint256DecodeStringsLE(ss []string) ([]Uint256, error) {
+	res := make([]Uint256, len(ss))
+	for i, s := range ss {
+		u, err := Uint256DecodeStringLE(trimHexPrefix(s, Uint256Size))
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		res[i] = u
+	}
+	return res, nil
+}
+
+// Uint256DecodeBase64Strings decodes the given base64 strings of big-endian
+// bytes into Uint256 values.
+func Uint256DecodeBase64Strings(ss []string) ([]Uint256, error) {
+	res := make([]Uint256, len(ss))
+	for i, s := range ss {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err == nil {
+			res[i], err = Uint256DecodeBytesBE(b)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// trimHexPrefix removes "0x" prefix from the hex string of a value of the
+// given size.
+func trimHexPrefix(s string, size int) string {
+	if len(s) == 2*size+2 && s[0] == '0' && s[1] == 'x' {
+		return s[2:]
+	}
+	return s
+}
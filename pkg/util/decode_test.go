@@ -0,0 +1,52 @@
+package util
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint160DecodeStrings(t *testing.T) {
+	u1, u2 := Uint160{1, 2, 3}, Uint160{4, 5, 6}
+	us, err := Uint160DecodeStringsLE([]string{u1.StringLE(), "0x" + u2.StringLE()})
+	require.NoError(t, err)
+	require.Equal(t, []Uint160{u1, u2}, us)
+
+	_, err = Uint160DecodeStringsLE([]string{u1.StringLE(), "0x"})
+	require.Error(t, err)
+
+	us, err = Uint160DecodeBase64Strings([]string{
+		base64.StdEncoding.EncodeToString(u1.BytesBE()),
+		base64.StdEncoding.EncodeToString(u2.BytesBE()),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Uint160{u1, u2}, us)
+
+	_, err = Uint160DecodeBase64Strings([]string{"!"})
+	require.Error(t, err)
+	_, err = Uint160DecodeBase64Strings([]string{base64.StdEncoding.EncodeToString([]byte{1})})
+	require.Error(t, err)
+}
+
+func TestUint256DecodeStrings(t *testing.T) {
+	u1, u2 := Uint256{1, 2, 3}, Uint256{4, 5, 6}
+	us, err := Uint256DecodeStringsLE([]string{u1.StringLE(), "0x" + u2.StringLE()})
+	require.NoError(t, err)
+	require.Equal(t, []Uint256{u1, u2}, us)
+
+	_, err = Uint256DecodeStringsLE([]string{u1.StringLE(), "0x"})
+	require.Error(t, err)
+
+	us, err = Uint256DecodeBase64Strings([]string{
+		base64.StdEncoding.EncodeToString(u1.BytesBE()),
+		base64.StdEncoding.EncodeToString(u2.BytesBE()),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Uint256{u1, u2}, us)
+
+	_, err = Uint256DecodeBase64Strings([]string{"!"})
+	require.Error(t, err)
+	_, err = Uint256DecodeBase64Strings([]string{base64.StdEncoding.EncodeToString([]byte{1})})
+	require.Error(t, err)
+}
@@ -0,0 +1,77 @@
+// +build gofuzz
+
+package util
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// Entry points for go-fuzz (and oss-fuzz), each of them decodes arbitrary
+// newline-separated data with one of the batch decoders checking that the
+// result encodes back to the same values. Use -func to pick one of them when
+// building, like:
+//
+//	go-fuzz-build -func FuzzUint160DecodeStringsLE github.com/nspcc-dev/neo-go/pkg/util
+
+// FuzzUint160DecodeStringsLE decodes data with Uint160DecodeStringsLE.
+func FuzzUint160DecodeStringsLE(data []byte) int {
+	ss := strings.Split(string(data), "\n")
+	us, err := Uint160DecodeStringsLE(ss)
+	if err != nil {
+		return 0
+	}
+	for i := range us {
+		if strings.TrimPrefix(strings.ToLower(ss[i]), "0x") != us[i].StringLE() {
+			panic("bad Uint160 decoding")
+		}
+	}
+	return 1
+}
+
+// FuzzUint256DecodeStringsLE decodes data with Uint256DecodeStringsLE.
+func FuzzUint256DecodeStringsLE(data []byte) int {
+	ss := strings.Split(string(data), "\n")
+	us, err := Uint256DecodeStringsLE(ss)
+	if err != nil {
+		return 0
+	}
+	for i := range us {
+		if strings.TrimPrefix(strings.ToLower(ss[i]), "0x") != us[i].StringLE() {
+			panic("bad Uint256 decoding")
+		}
+	}
+	return 1
+}
+
+// FuzzUint160DecodeBase64Strings decodes data with Uint160DecodeBase64Strings.
+func FuzzUint160DecodeBase64Strings(data []byte) int {
+	ss := strings.Split(string(data), "\n")
+	us, err := Uint160DecodeBase64Strings(ss)
+	if err != nil {
+		return 0
+	}
+	for i := range us {
+		b, _ := base64.StdEncoding.DecodeString(ss[i])
+		if string(b) != string(us[i].BytesBE()) {
+			panic("bad Uint160 decoding")
+		}
+	}
+	return 1
+}
+
+// FuzzUint256DecodeBase64Strings decodes data with Uint256DecodeBase64Strings.
+func FuzzUint256DecodeBase64Strings(data []byte) int {
+	ss := strings.Split(string(data), "\n")
+	us, err := Uint256DecodeBase64Strings(ss)
+	if err != nil {
+		return 0
+	}
+	for i := range us {
+		b, _ := base64.StdEncoding.DecodeString(ss[i])
+		if string(b) != string(us[i].BytesBE()) {
+			panic("bad Uint256 decoding")
+		}
+	}
+	return 1
+}
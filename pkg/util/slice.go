@@ -0,0 +1,99 @@
+package util
+
+import (
+	"sort"
+)
+
+// Uint160Slice is a slice of Uint160 values implementing sort.Interface.
+// Search methods expect it to be sorted.
+type Uint160Slice []Uint160
+
+// Len implements sort.Interface.
+func (s Uint160Slice) Len() int { return len(s) }
+
+// Less implements sort.Interface.
+func (s Uint160Slice) Less(i, j int) bool { return s[i].Less(s[j]) }
+
+// Swap implements sort.Interface.
+func (s Uint160Slice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// Sort sorts the slice in place.
+func (s Uint160Slice) Sort() { sort.Sort(s) }
+
+// Unique sorts the slice and removes duplicates from it, it reuses the
+// underlying array and returns the resulting sorted set.
+func (s Uint160Slice) Unique() Uint160Slice {
+	if len(s) == 0 {
+		return s
+	}
+	s.Sort()
+	n := 1
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[n-1] {
+			s[n] = s[i]
+			n++
+		}
+	}
+	return s[:n]
+}
+
+// Index returns the index of u in the sorted slice or -1 if it's not there.
+func (s Uint160Slice) Index(u Uint160) int {
+	i := sort.Search(len(s), func(i int) bool { return !s[i].Less(u) })
+	if i < len(s) && s[i] == u {
+		return i
+	}
+	return -1
+}
+
+// Contains returns true if the sorted slice contains u.
+func (s Uint160Slice) Contains(u Uint160) bool {
+	return s.Index(u) >= 0
+}
+
+// Uint256Slice is a slice of Uint256 values implementing sort.Interface.
+// Search methods expect it to be sorted.
+type Uint256Slice []Uint256
+
+// Len implements sort.Interface.
+func (s Uint256Slice) Len() int { return len(s) }
+
+// Less implements sort.Interface.
+func (s Uint256Slice) Less(i, j int) bool { return s[i].CompareTo(s[j]) < 0 }
+
+// Swap implements sort.Interface.
+func (s Uint256Slice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// Sort sorts the slice in place.
+func (s Uint256Slice) Sort() { sort.Sort(s) }
+
+// Unique sorts the slice and removes duplicates from it, it reuses the
+// underlying array and returns the resulting sorted set.
+func (s Uint256Slice) Unique() Uint256Slice {
+	if len(s) == 0 {
+		return s
+	}
+	s.Sort()
+	n := 1
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[n-1] {
+			s[n] = s[i]
+			n++
+		}
+	}
+	return s[:n]
+}
+
+// Index returns the index of u in the sorted slice or -1 if it's not there.
+func (s Uint256Slice) Index(u Uint256) int {
+	i := sort.Search(len(s), func(i int) bool { return s[i].CompareTo(u) >= 0 })
+	if i < len(s) && s[i] == u {
+		return i
+	}
+	return -1
+}
+
+// Contains returns true if the sorted slice contains u.
+func (s Uint256Slice) Contains(u Uint256) bool {
+	return s.Index(u) >= 0
+}
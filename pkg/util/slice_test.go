@@ -0,0 +1,33 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint160Slice(t *testing.T) {
+	s := Uint160Slice{{3}, {1}, {2}, {1}, {3}}
+	s = s.Unique()
+	require.Equal(t, Uint160Slice{{1}, {2}, {3}}, s)
+	require.Equal(t, 1, s.Index(Uint160{2}))
+	require.True(t, s.Contains(Uint160{3}))
+	require.False(t, s.Contains(Uint160{0}))
+	require.False(t, s.Contains(Uint160{2, 1}))
+	require.False(t, s.Contains(Uint160{4}))
+	require.Equal(t, -1, Uint160Slice(nil).Index(Uint160{}))
+	require.Equal(t, 0, len(Uint160Slice{}.Unique()))
+}
+
+func TestUint256Slice(t *testing.T) {
+	s := Uint256Slice{{3}, {1}, {2}, {1}, {3}}
+	s = s.Unique()
+	require.Equal(t, Uint256Slice{{1}, {2}, {3}}, s)
+	require.Equal(t, 1, s.Index(Uint256{2}))
+	require.True(t, s.Contains(Uint256{3}))
+	require.False(t, s.Contains(Uint256{0}))
+	require.False(t, s.Contains(Uint256{2, 1}))
+	require.False(t, s.Contains(Uint256{4}))
+	require.Equal(t, -1, Uint256Slice(nil).Index(Uint256{}))
+	require.Equal(t, 0, len(Uint256Slice{}.Unique()))
+}
@@ -124,9 +124,7 @@ func (u *Uint160) UnmarshalJSON(data []byte) (err error) {
 	if err = json.Unmarshal(data, &js); err != nil {
 		return err
 	}
-	js = strings.TrimPrefix(js, "0x")
-	*u, err = Uint160DecodeStringLE(js)
-	return err
+	return u.UnmarshalText([]byte(js))
 }
 
 // MarshalJSON implements the json marshaller interface.
@@ -134,6 +132,19 @@ func (u Uint160) MarshalJSON() ([]byte, error) {
 	return []byte(`"0x` + u.StringLE() + `"`), nil
 }
 
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// little-endian hex string with an optional 0x prefix.
+func (u *Uint160) UnmarshalText(text []byte) (err error) {
+	*u, err = Uint160DecodeStringLE(strings.TrimPrefix(string(text), "0x"))
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, the result is
+// 0x-prefixed little-endian hex string (the same one MarshalJSON produces).
+func (u Uint160) MarshalText() ([]byte, error) {
+	return []byte("0x" + u.StringLE()), nil
+}
+
 // EncodeBinary implements Serializable interface.
 func (u *Uint160) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteBytes(u[:])
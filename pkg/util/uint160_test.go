@@ -2,6 +2,8 @@ package util
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"math/rand"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
@@ -25,6 +27,54 @@ func TestUint160UnmarshalJSON(t *testing.T) {
 	assert.Error(t, u2.UnmarshalJSON([]byte(`123`)))
 }
 
+func TestUint160MarshalText(t *testing.T) {
+	var u Uint160
+	for i := range u {
+		u[i] = byte(i)
+	}
+	text, err := u.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "0x"+u.StringLE(), string(text))
+
+	var actual Uint160
+	require.NoError(t, actual.UnmarshalText(text))
+	require.Equal(t, u, actual)
+	require.NoError(t, actual.UnmarshalText([]byte(u.StringLE())))
+	require.Equal(t, u, actual)
+
+	require.Error(t, actual.UnmarshalText([]byte("0x")))
+	require.Error(t, actual.UnmarshalText([]byte("0x0x"+u.StringLE())))
+	require.Error(t, actual.UnmarshalText(text[:len(text)-2]))
+
+	t.Run("map key", func(t *testing.T) {
+		m := map[Uint160]int{u: 1}
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		require.Equal(t, `{"`+string(text)+`":1}`, string(data))
+
+		actual := make(map[Uint160]int)
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, m, actual)
+	})
+}
+
+func TestUint160UnmarshalTextRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		var u, actual Uint160
+		rand.Read(u[:])
+		text, err := u.MarshalText()
+		require.NoError(t, err)
+		require.NoError(t, actual.UnmarshalText(text))
+		require.Equal(t, u, actual)
+
+		// Arbitrary input must never cause panic.
+		garbage := make([]byte, rand.Intn(2*Uint160Size+4))
+		rand.Read(garbage)
+		_ = actual.UnmarshalText(garbage)
+		_ = actual.UnmarshalJSON(garbage)
+	}
+}
+
 func TestUInt160DecodeString(t *testing.T) {
 	hexStr := "2d3b96ae1bcc5a585e075e3b81920210dec16302"
 	val, err := Uint160DecodeStringBE(hexStr)
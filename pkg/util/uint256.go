@@ -100,9 +100,7 @@ func (u *Uint256) UnmarshalJSON(data []byte) (err error) {
 	if err = json.Unmarshal(data, &js); err != nil {
 		return err
 	}
-	js = strings.TrimPrefix(js, "0x")
-	*u, err = Uint256DecodeStringLE(js)
-	return err
+	return u.UnmarshalText([]byte(js))
 }
 
 // MarshalJSON implements the json marshaller interface.
@@ -110,6 +108,19 @@ func (u Uint256) MarshalJSON() ([]byte, error) {
 	return []byte(`"0x` + u.StringLE() + `"`), nil
 }
 
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// little-endian hex string with an optional 0x prefix.
+func (u *Uint256) UnmarshalText(text []byte) (err error) {
+	*u, err = Uint256DecodeStringLE(strings.TrimPrefix(string(text), "0x"))
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, the result is
+// 0x-prefixed little-endian hex string (the same one MarshalJSON produces).
+func (u Uint256) MarshalText() ([]byte, error) {
+	return []byte("0x" + u.StringLE()), nil
+}
+
 // CompareTo compares two Uint256 with each other. Possible output: 1, -1, 0
 //  1 implies u > other.
 // -1 implies u < other.
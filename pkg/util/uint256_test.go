@@ -2,6 +2,8 @@ package util
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"math/rand"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
@@ -26,6 +28,54 @@ func TestUint256UnmarshalJSON(t *testing.T) {
 	assert.Error(t, u2.UnmarshalJSON([]byte("123")))
 }
 
+func TestUint256MarshalText(t *testing.T) {
+	var u Uint256
+	for i := range u {
+		u[i] = byte(i)
+	}
+	text, err := u.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "0x"+u.StringLE(), string(text))
+
+	var actual Uint256
+	require.NoError(t, actual.UnmarshalText(text))
+	require.Equal(t, u, actual)
+	require.NoError(t, actual.UnmarshalText([]byte(u.StringLE())))
+	require.Equal(t, u, actual)
+
+	require.Error(t, actual.UnmarshalText([]byte("0x")))
+	require.Error(t, actual.UnmarshalText([]byte("0x0x"+u.StringLE())))
+	require.Error(t, actual.UnmarshalText(text[:len(text)-2]))
+
+	t.Run("map key", func(t *testing.T) {
+		m := map[Uint256]int{u: 1}
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		require.Equal(t, `{"`+string(text)+`":1}`, string(data))
+
+		actual := make(map[Uint256]int)
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, m, actual)
+	})
+}
+
+func TestUint256UnmarshalTextRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		var u, actual Uint256
+		rand.Read(u[:])
+		text, err := u.MarshalText()
+		require.NoError(t, err)
+		require.NoError(t, actual.UnmarshalText(text))
+		require.Equal(t, u, actual)
+
+		// Arbitrary input must never cause panic.
+		garbage := make([]byte, rand.Intn(2*Uint256Size+4))
+		rand.Read(garbage)
+		_ = actual.UnmarshalText(garbage)
+		_ = actual.UnmarshalJSON(garbage)
+	}
+}
+
 func TestUint256DecodeString(t *testing.T) {
 	hexStr := "f037308fa0ab18155bccfc08485468c112409ea5064595699e98c545f245f32d"
 	val, err := Uint256DecodeStringLE(hexStr)
@@ -0,0 +1,162 @@
+package vm
+
+import (
+	"encoding/binary"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// ScriptInfo contains the results of static script analysis.
+type ScriptInfo struct {
+	// Syscalls contains all syscalls used by the script in order of their
+	// first usage.
+	Syscalls []SyscallUsage
+	// ContractCalls contains System.Contract.Call invocations with contract
+	// hash known statically (pushed right before the syscall).
+	ContractCalls []ContractCall
+	// Tokens contains NEF method token indexes used by CALLT instructions
+	// in order of their first usage.
+	Tokens []uint16
+	// Methods contains sorted offsets of internal functions (targets of
+	// CALL and CALLL instructions and PUSHA).
+	Methods []int
+	// JumpTargets contains sorted offsets of all jump, call and exception
+	// handling instructions' targets.
+	JumpTargets []int
+}
+
+// SyscallUsage describes a syscall used by the script.
+type SyscallUsage struct {
+	// ID is the syscall ID.
+	ID uint32
+	// Name is the syscall name, it's empty for unknown syscalls.
+	Name string
+	// Offsets contains offsets of all SYSCALL instructions using this ID.
+	Offsets []int
+}
+
+// ContractCall describes a statically determined call of another contract.
+type ContractCall struct {
+	// Offset is the offset of SYSCALL instruction.
+	Offset int
+	// Hash is the hash of the contract being called.
+	Hash util.Uint160
+	// Method is the name of the method being called, it's empty if it can't
+	// be determined statically.
+	Method string
+}
+
+// instrInfo is an already parsed instruction.
+type instrInfo struct {
+	op    opcode.Opcode
+	param []byte
+}
+
+var contractCallID = interopnames.ToID([]byte(interopnames.SystemContractCall))
+
+// Parse walks through all instructions of the script without executing it
+// and collects syscalls used, contracts called and jump targets. An error is
+// returned for scripts that can't be parsed (invalid opcodes, truncated
+// parameters or jumps out of script bounds).
+func Parse(script []byte) (*ScriptInfo, error) {
+	var (
+		info     = new(ScriptInfo)
+		syscalls = make(map[uint32]int)
+		tokens   = make(map[uint16]bool)
+		methods  = make(map[int]bool)
+		jumps    = make(map[int]bool)
+		prev     [2]instrInfo
+	)
+	ctx := NewContext(script)
+	for ctx.nextip < len(script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+			opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
+			opcode.CALL, opcode.ENDTRY, opcode.JMPL, opcode.JMPIFL,
+			opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+			opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL,
+			opcode.ENDTRYL, opcode.CALLL, opcode.PUSHA:
+			off, _, err := calcJumpOffset(ctx, param) // It does bounds checking.
+			if err != nil {
+				return nil, err
+			}
+			jumps[off] = true
+			if op == opcode.CALL || op == opcode.CALLL || op == opcode.PUSHA {
+				methods[off] = true
+			}
+		case opcode.TRY, opcode.TRYL:
+			catchP, finallyP := getTryParams(op, param)
+			for _, p := range [][]byte{catchP, finallyP} {
+				off, rOff, err := calcJumpOffset(ctx, p)
+				if err != nil {
+					return nil, err
+				}
+				if rOff != 0 { // Zero offset means there is no catch or finally block.
+					jumps[off] = true
+				}
+			}
+		case opcode.CALLT:
+			id := binary.LittleEndian.Uint16(param)
+			if !tokens[id] {
+				tokens[id] = true
+				info.Tokens = append(info.Tokens, id)
+			}
+		case opcode.SYSCALL:
+			id := binary.LittleEndian.Uint32(param)
+			i, ok := syscalls[id]
+			if !ok {
+				name, _ := interopnames.FromID(id)
+				i = len(info.Syscalls)
+				syscalls[id] = i
+				info.Syscalls = append(info.Syscalls, SyscallUsage{ID: id, Name: name})
+			}
+			info.Syscalls[i].Offsets = append(info.Syscalls[i].Offsets, ctx.ip)
+			if id == contractCallID {
+				if c, ok := getContractCall(prev); ok {
+					c.Offset = ctx.ip
+					info.ContractCalls = append(info.ContractCalls, c)
+				}
+			}
+		}
+		prev[1], prev[0] = prev[0], instrInfo{op: op, param: param}
+	}
+	info.Methods = sortedOffsets(methods)
+	info.JumpTargets = sortedOffsets(jumps)
+	return info, nil
+}
+
+// getContractCall extracts contract hash and method from the instructions
+// preceding System.Contract.Call syscall (prev[0] is the last one), this
+// instruction sequence is emitted by emit.AppCall and the compiler for
+// constant arguments.
+func getContractCall(prev [2]instrInfo) (ContractCall, bool) {
+	var c ContractCall
+	if prev[0].op != opcode.PUSHDATA1 || len(prev[0].param) != util.Uint160Size {
+		return c, false
+	}
+	c.Hash, _ = util.Uint160DecodeBytesBE(prev[0].param)
+	switch prev[1].op {
+	case opcode.PUSHDATA1, opcode.PUSHDATA2, opcode.PUSHDATA4:
+		if utf8.Valid(prev[1].param) {
+			c.Method = string(prev[1].param)
+		}
+	}
+	return c, true
+}
+
+func sortedOffsets(m map[int]bool) []int {
+	res := make([]int, 0, len(m))
+	for off := range m {
+		res = append(res, off)
+	}
+	sort.Ints(res)
+	return res
+}
@@ -0,0 +1,85 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	h := random.Uint160()
+	getTimeID := interopnames.ToID([]byte(interopnames.SystemRuntimeGetTime))
+
+	w := io.NewBufBinWriter()
+	emit.Instruction(w.BinWriter, opcode.CALL, []byte{0}) // Fixed below.
+	timeOff1 := w.Len()
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetTime)
+	emit.AppCallNoArgs(w.BinWriter, h, "transfer", callflag.All)
+	callOff := w.Len() - 5
+	timeOff2 := w.Len()
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetTime)
+	unknownOff := w.Len()
+	emit.Instruction(w.BinWriter, opcode.SYSCALL, []byte{0xef, 0xbe, 0xad, 0xde})
+	emit.Instruction(w.BinWriter, opcode.CALLT, []byte{1, 0})
+	emit.Instruction(w.BinWriter, opcode.CALLT, []byte{1, 0})
+	emit.Opcodes(w.BinWriter, opcode.RET)
+	methodOff := w.Len()
+	emit.Opcodes(w.BinWriter, opcode.RET)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+	script[1] = byte(methodOff)
+
+	info, err := Parse(script)
+	require.NoError(t, err)
+	require.Equal(t, []SyscallUsage{
+		{ID: getTimeID, Name: interopnames.SystemRuntimeGetTime, Offsets: []int{timeOff1, timeOff2}},
+		{ID: contractCallID, Name: interopnames.SystemContractCall, Offsets: []int{callOff}},
+		{ID: 0xdeadbeef, Offsets: []int{unknownOff}},
+	}, info.Syscalls)
+	require.Equal(t, []ContractCall{{Offset: callOff, Hash: h, Method: "transfer"}}, info.ContractCalls)
+	require.Equal(t, []uint16{1}, info.Tokens)
+	require.Equal(t, []int{methodOff}, info.Methods)
+	require.Equal(t, []int{methodOff}, info.JumpTargets)
+
+	t.Run("try and jumps", func(t *testing.T) {
+		script := []byte{
+			byte(opcode.TRY), 5, 0, // catch at 5, no finally
+			byte(opcode.JMP), 3,
+			byte(opcode.NOP),
+			byte(opcode.JMPIF), 0xfa, // back to 0
+			byte(opcode.RET),
+		}
+		info, err := Parse(script)
+		require.NoError(t, err)
+		require.Empty(t, info.Syscalls)
+		require.Empty(t, info.Methods)
+		require.Equal(t, []int{0, 5, 6}, info.JumpTargets)
+	})
+	t.Run("dynamic contract call", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Opcodes(w.BinWriter, opcode.LDARG0)
+		emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+		require.NoError(t, w.Err)
+		info, err := Parse(w.Bytes())
+		require.NoError(t, err)
+		require.Empty(t, info.ContractCalls)
+		require.Equal(t, 1, len(info.Syscalls))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, script := range [][]byte{
+			{0xff},
+			{byte(opcode.PUSHDATA1), 10, 1},
+			{byte(opcode.JMP), 10},
+			{byte(opcode.SYSCALL), 1, 2},
+		} {
+			_, err := Parse(script)
+			require.Error(t, err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"crypto/elliptic"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -16,6 +17,8 @@ import (
 
 	"github.com/abiosoft/readline"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -28,6 +31,7 @@ import (
 const (
 	vmKey       = "vm"
 	manifestKey = "manifest"
+	labelsKey   = "labels"
 	boolType    = "bool"
 	boolFalse   = "false"
 	boolTrue    = "true"
@@ -180,7 +184,7 @@ example:
 	{
 		Name:     "ops",
 		Help:     "Dump opcodes of the current loaded program",
-		LongHelp: "Dump opcodes of the current loaded program",
+		LongHelp: "Dump opcodes of the current loaded program with method names (if known) and jump arrows",
 		Func:     handleOps,
 	},
 }
@@ -215,6 +219,7 @@ func NewWithConfig(printLogo bool, onExit func(int), c *readline.Config) *VMCLI
 	}
 	vmcli.shell.Set(vmKey, vmcli.vm)
 	vmcli.shell.Set(manifestKey, new(manifest.Manifest))
+	vmcli.shell.Set(labelsKey, map[int]string(nil))
 	vmcli.shell.Set(exitFunc, onExit)
 	for _, c := range commands {
 		vmcli.shell.AddCmd(c)
@@ -236,6 +241,14 @@ func setManifestInContext(c *ishell.Context, m *manifest.Manifest) {
 	*old = *m
 }
 
+func getLabelsFromContext(c *ishell.Context) map[int]string {
+	return c.Get(labelsKey).(map[int]string)
+}
+
+func setLabelsInContext(c *ishell.Context, labels map[int]string) {
+	c.Set(labelsKey, labels)
+}
+
 func checkVMIsReady(c *ishell.Context) bool {
 	v := getVMFromContext(c)
 	if v == nil || !v.Ready() {
@@ -305,6 +318,7 @@ func handleLoadNEF(c *ishell.Context) {
 	}
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
 	setManifestInContext(c, m)
+	setLabelsInContext(c, vm.LabelsFromManifest(m))
 	changePrompt(c, v)
 }
 
@@ -320,6 +334,7 @@ func handleLoadBase64(c *ishell.Context) {
 		return
 	}
 	v.Load(b)
+	setLabelsInContext(c, nil)
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
 	changePrompt(c, v)
 }
@@ -336,6 +351,7 @@ func handleLoadHex(c *ishell.Context) {
 		return
 	}
 	v.Load(b)
+	setLabelsInContext(c, nil)
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
 	changePrompt(c, v)
 }
@@ -361,6 +377,7 @@ func handleLoadGo(c *ishell.Context) {
 	setManifestInContext(c, m)
 
 	v.Load(b)
+	setLabelsInContext(c, di.Labels())
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
 	changePrompt(c, v)
 }
@@ -514,7 +531,11 @@ func handleOps(c *ishell.Context) {
 	}
 	v := getVMFromContext(c)
 	out := bytes.NewBuffer(nil)
-	v.PrintOps(out)
+	_ = vm.Disassemble(out, v.Context().Program(), vm.DisasmOptions{
+		Labels: getLabelsFromContext(c),
+		Arrows: true,
+		Cursor: v.Context().IP(),
+	})
 	c.Println(out.String())
 }
 
@@ -557,14 +578,21 @@ func Parse(args []string) (string, error) {
 		buf.WriteString(fmt.Sprintf("Integer to Base64\t%s\n", base64.StdEncoding.EncodeToString(bs)))
 	}
 	noX := strings.TrimPrefix(arg, "0x")
+	var script []byte
 	if rawStr, err := hex.DecodeString(noX); err == nil {
 		if val, err := util.Uint160DecodeBytesBE(rawStr); err == nil {
 			buf.WriteString(fmt.Sprintf("BE ScriptHash to Address\t%s\n", address.Uint160ToString(val)))
 			buf.WriteString(fmt.Sprintf("LE ScriptHash to Address\t%s\n", address.Uint160ToString(val.Reverse())))
 		}
+		if pub, err := keys.NewPublicKeyFromBytes(rawStr, elliptic.P256()); err == nil && !pub.IsInfinity() {
+			buf.WriteString(fmt.Sprintf("Public Key to Address\t%s\n", pub.Address()))
+			buf.WriteString(fmt.Sprintf("Public Key to BE ScriptHash\t%s\n", pub.GetScriptHash()))
+		}
 		buf.WriteString(fmt.Sprintf("Hex to String\t%s\n", fmt.Sprintf("%q", string(rawStr))))
 		buf.WriteString(fmt.Sprintf("Hex to Integer\t%s\n", bigint.FromBytes(rawStr)))
+		buf.WriteString(fmt.Sprintf("Hex to Base64\t%s\n", base64.StdEncoding.EncodeToString(rawStr)))
 		buf.WriteString(fmt.Sprintf("Swap Endianness\t%s\n", hex.EncodeToString(util.ArrayReverse(rawStr))))
+		script = rawStr
 	}
 	if addr, err := address.StringToUint160(arg); err == nil {
 		buf.WriteString(fmt.Sprintf("Address to BE ScriptHash\t%s\n", addr))
@@ -573,8 +601,25 @@ func Parse(args []string) (string, error) {
 		buf.WriteString(fmt.Sprintf("Address to Base64 (LE)\t%s\n", base64.StdEncoding.EncodeToString(addr.BytesLE())))
 	}
 	if rawStr, err := base64.StdEncoding.DecodeString(arg); err == nil {
+		if val, err := util.Uint160DecodeBytesBE(rawStr); err == nil {
+			buf.WriteString(fmt.Sprintf("Base64 BE ScriptHash to Address\t%s\n", address.Uint160ToString(val)))
+			buf.WriteString(fmt.Sprintf("Base64 LE ScriptHash to Address\t%s\n", address.Uint160ToString(val.Reverse())))
+		}
 		buf.WriteString(fmt.Sprintf("Base64 to String\t%s\n", fmt.Sprintf("%q", string(rawStr))))
 		buf.WriteString(fmt.Sprintf("Base64 to BigInteger\t%s\n", bigint.FromBytes(rawStr)))
+		buf.WriteString(fmt.Sprintf("Base64 to Hex\t%s\n", hex.EncodeToString(rawStr)))
+		if script == nil {
+			script = rawStr
+		}
+	}
+	if vm.IsStandardContract(script) {
+		h := hash.Hash160(script)
+		buf.WriteString(fmt.Sprintf("Verification Script to Address\t%s\n", address.Uint160ToString(h)))
+		buf.WriteString(fmt.Sprintf("Verification Script to BE ScriptHash\t%s\n", h))
+	}
+	if priv, err := keys.NewPrivateKeyFromWIF(arg); err == nil {
+		buf.WriteString(fmt.Sprintf("WIF to Public Key\t%s\n", hex.EncodeToString(priv.PublicKey().Bytes())))
+		buf.WriteString(fmt.Sprintf("WIF to Address\t%s\n", priv.Address()))
 	}
 
 	buf.WriteString(fmt.Sprintf("String to Hex\t%s\n", hex.EncodeToString([]byte(arg))))
@@ -19,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -448,9 +449,11 @@ func TestParse(t *testing.T) {
 		e.checkNextLine(t, "Integer to Base64.*Cxo=")
 		e.checkNextLine(t, "Hex to String.*\"fg\"")
 		e.checkNextLine(t, "Hex to Integer.*26470")
+		e.checkNextLine(t, "Hex to Base64.*Zmc=")
 		e.checkNextLine(t, "Swap Endianness.*6766")
 		e.checkNextLine(t, "Base64 to String.*\"뮻\"")
 		e.checkNextLine(t, "Base64 to BigInteger.*-4477205")
+		e.checkNextLine(t, "Base64 to Hex.*ebaebb")
 		e.checkNextLine(t, "String to Hex.*36363637")
 		e.checkNextLine(t, "String to Base64.*NjY2Nw==")
 	})
@@ -474,12 +477,53 @@ func TestParse(t *testing.T) {
 		e.checkNextLine(t, "LE ScriptHash to Address.*NRxLN7apYwKJihzMt4eSSnU9BJ77dp2TNj")
 		e.checkNextLine(t, "Hex to String")
 		e.checkNextLine(t, "Hex to Integer.*378293464438118320046642359484100328446970822656")
+		e.checkNextLine(t, "Hex to Base64.*AAAAAAAAAAAAAAAAAAAAAABEQ0I=")
 		e.checkNextLine(t, "Swap Endianness.*4243440000000000000000000000000000000000")
 		e.checkNextLine(t, "Base64 to String.*")
 		e.checkNextLine(t, "Base64 to BigInteger.*376115185060690908522683414825349447309891933036899526770189324554358227")
+		e.checkNextLine(t, "Base64 to Hex.*d34d34.*e38e37e36")
 		e.checkNextLine(t, "String to Hex.*30303030303030303030303030303030303030303030303030303030303030303030343434333432")
 		e.checkNextLine(t, "String to Base64.*MDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDQ0NDM0Mg==")
 	})
+	t.Run("Base64 Uint160", func(t *testing.T) {
+		e := newTestVMCLI(t)
+		e.runProg(t, "parse qorPhZ1P5AKzTmc/IVaCF5akiOs=")
+		e.checkNextLine(t, "Base64 BE ScriptHash to Address.*NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc")
+		e.checkNextLine(t, "Base64 LE ScriptHash to Address")
+		e.checkNextLine(t, "Base64 to String")
+		e.checkNextLine(t, "Base64 to BigInteger")
+		e.checkNextLine(t, "Base64 to Hex.*aa8acf859d4fe402b34e673f2156821796a488eb")
+	})
+	t.Run("Public key and script", func(t *testing.T) {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		pub := priv.PublicKey()
+		addr := pub.Address()
+
+		e := newTestVMCLI(t)
+		e.runProg(t,
+			"parse "+hex.EncodeToString(pub.Bytes()),
+			"parse "+base64.StdEncoding.EncodeToString(pub.GetVerificationScript()),
+			"parse "+priv.WIF())
+		e.checkNextLine(t, "Public Key to Address.*"+addr)
+		e.checkNextLine(t, "Public Key to BE ScriptHash.*"+pub.GetScriptHash().StringBE())
+		for i := 0; i < 6; i++ { // Hex and strings.
+			e.checkNextLine(t, ".*")
+		}
+		for i := 0; i < 3; i++ {
+			e.checkNextLine(t, "Base64 to .*")
+		}
+		e.checkNextLine(t, "Verification Script to Address.*"+addr)
+		e.checkNextLine(t, "Verification Script to BE ScriptHash.*"+pub.GetScriptHash().StringBE())
+		for i := 0; i < 2; i++ {
+			e.checkNextLine(t, "String to .*")
+		}
+		for i := 0; i < 3; i++ { // WIF is a valid Base64 string.
+			e.checkNextLine(t, "Base64 to .*")
+		}
+		e.checkNextLine(t, "WIF to Public Key.*"+hex.EncodeToString(pub.Bytes()))
+		e.checkNextLine(t, "WIF to Address.*"+addr)
+	})
 }
 
 func TestPrintLogo(t *testing.T) {
@@ -47,6 +47,9 @@ type Context struct {
 	// Call flags this context was created with.
 	callFlag callflag.CallFlag
 
+	// Syscalls allowed to be used in this context, nil means all of them.
+	syscalls map[uint32]bool
+
 	// ParamCount specifies number of parameters.
 	ParamCount int
 	// RetCount specifies number of return values.
@@ -55,6 +58,12 @@ type Context struct {
 	NEF *nef.File
 }
 
+// isSyscallAllowed checks whether syscall with the given ID can be used in
+// this context.
+func (c *Context) isSyscallAllowed(id uint32) bool {
+	return c.syscalls == nil || c.syscalls[id]
+}
+
 // CheckReturnState represents possible states of stack after opcode.RET was processed.
 type CheckReturnState byte
 
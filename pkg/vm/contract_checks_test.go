@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -92,8 +93,16 @@ func TestIsMultiSigContract(t *testing.T) {
 	})
 
 	t.Run("too many keys", func(t *testing.T) {
-		prog := testMultisigContract(t, 1025, 1)
-		assert.False(t, IsMultiSigContract(prog))
+		// CreateMultiSigRedeemScript doesn't allow to create such script.
+		w := io.NewBufBinWriter()
+		emit.Int(w.BinWriter, 1)
+		for i := 0; i < 1025; i++ {
+			emit.Bytes(w.BinWriter, randomBytes(33))
+		}
+		emit.Int(w.BinWriter, 1025)
+		emit.Syscall(w.BinWriter, interopnames.SystemCryptoCheckMultisig)
+		require.NoError(t, w.Err)
+		assert.False(t, IsMultiSigContract(w.Bytes()))
 	})
 
 	t.Run("invalid interop ID", func(t *testing.T) {
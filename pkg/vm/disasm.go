@@ -0,0 +1,289 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// DisasmOptions contains parameters of script listing.
+type DisasmOptions struct {
+	// Labels maps script offsets to names (usually method names), labels
+	// are printed before instructions at these offsets and are used to
+	// describe jump targets.
+	Labels map[int]string
+	// Arrows enables jump arrows rendering on the left side of the listing.
+	Arrows bool
+	// Cursor is the offset of the instruction marked with "<<", negative
+	// value disables the mark.
+	Cursor int
+}
+
+// listingLine is a single instruction of the listing.
+type listingLine struct {
+	ip     int
+	op     opcode.Opcode
+	desc   string
+	err    error
+	target int // Jump target offset (-1 if none).
+}
+
+// jumpArrow is a jump between two listing lines.
+type jumpArrow struct {
+	from, to int // Line numbers.
+	lane     int
+}
+
+// Disassemble writes a readable listing of the script to out. Instruction
+// parameters are decoded where possible (syscall names, integers, jump
+// targets, etc). Listing stops at the first instruction that can't be
+// decoded, this error is printed and returned.
+func Disassemble(out io.Writer, script []byte, opts DisasmOptions) error {
+	var (
+		lines []listingLine
+		err   error
+		ctx   = NewContext(script)
+	)
+	for {
+		instr, parameter, e := ctx.Next()
+		line := listingLine{ip: ctx.ip, op: instr, err: e, target: -1}
+		if e != nil {
+			err = e
+			lines = append(lines, line)
+			break
+		}
+		if parameter != nil {
+			line.desc, line.target = describeParam(ctx, instr, parameter, opts.Labels)
+		}
+		lines = append(lines, line)
+		if ctx.nextip >= len(ctx.prog) {
+			break
+		}
+	}
+
+	var gutters []string
+	if opts.Arrows {
+		gutters = renderArrows(lines)
+	}
+	// Labels are written after formatting, empty rows are used in their place
+	// to keep columns aligned across the whole listing.
+	var (
+		buf    = bytes.NewBuffer(nil)
+		w      = tabwriter.NewWriter(buf, 0, 0, 4, ' ', 0)
+		labels = make(map[int]string)
+		row    = 1
+	)
+	if gutters != nil {
+		fmt.Fprint(w, "\t")
+	}
+	fmt.Fprintln(w, "INDEX\tOPCODE\tPARAMETER\t")
+	for i, l := range lines {
+		if name, ok := opts.Labels[l.ip]; ok {
+			labels[row] = name + ":"
+			if gutters != nil {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprintln(w, "\t\t\t")
+			row++
+		}
+		if gutters != nil {
+			fmt.Fprintf(w, "%s\t", gutters[i])
+		}
+		var cursor string
+		if l.ip == opts.Cursor {
+			cursor = "<<"
+		}
+		row++
+		if l.err != nil {
+			fmt.Fprintf(w, "%d\t%s\tERROR: %s\t%s\n", l.ip, l.op, l.err, cursor)
+			break
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", l.ip, l.op, l.desc, cursor)
+	}
+	w.Flush()
+	rows := strings.SplitAfter(buf.String(), "\n")
+	for i := range rows {
+		if label, ok := labels[i]; ok {
+			rows[i] = label + "\n"
+		}
+		if _, werr := io.WriteString(out, rows[i]); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return err
+}
+
+// describeParam returns human-readable description of the instruction
+// parameter and jump target offset (-1 for non-jump instructions).
+func describeParam(ctx *Context, instr opcode.Opcode, parameter []byte, labels map[int]string) (string, int) {
+	switch instr {
+	case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.CALL,
+		opcode.JMPEQ, opcode.JMPNE,
+		opcode.JMPGT, opcode.JMPGE, opcode.JMPLE, opcode.JMPLT,
+		opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.CALLL,
+		opcode.JMPEQL, opcode.JMPNEL,
+		opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLEL, opcode.JMPLTL,
+		opcode.PUSHA, opcode.ENDTRY, opcode.ENDTRYL:
+		return getOffsetDesc(ctx, parameter, labels)
+	case opcode.TRY, opcode.TRYL:
+		catchP, finallyP := getTryParams(instr, parameter)
+		catchDesc, _ := getOffsetDesc(ctx, catchP, labels)
+		finallyDesc, _ := getOffsetDesc(ctx, finallyP, labels)
+		return fmt.Sprintf("catch %s, finally %s", catchDesc, finallyDesc), -1
+	case opcode.INITSSLOT:
+		return fmt.Sprint(parameter[0]), -1
+	case opcode.CONVERT, opcode.ISTYPE:
+		typ := stackitem.Type(parameter[0])
+		return fmt.Sprintf("%s (%x)", typ, parameter[0]), -1
+	case opcode.INITSLOT:
+		return fmt.Sprintf("%d local, %d arg", parameter[0], parameter[1]), -1
+	case opcode.SYSCALL:
+		name, err := interopnames.FromID(GetInteropID(parameter))
+		if err != nil {
+			name = "not found"
+		}
+		return fmt.Sprintf("%s (%x)", name, parameter), -1
+	case opcode.PUSHINT8, opcode.PUSHINT16, opcode.PUSHINT32,
+		opcode.PUSHINT64, opcode.PUSHINT128, opcode.PUSHINT256:
+		val := bigint.FromBytes(parameter)
+		return fmt.Sprintf("%d (%x)", val, parameter), -1
+	case opcode.LDLOC, opcode.STLOC, opcode.LDARG, opcode.STARG, opcode.LDSFLD, opcode.STSFLD:
+		return fmt.Sprintf("%d (%x)", parameter[0], parameter), -1
+	default:
+		if utf8.Valid(parameter) {
+			return fmt.Sprintf("%x (%q)", parameter, parameter), -1
+		}
+		return fmt.Sprintf("%x", parameter), -1
+	}
+}
+
+func getOffsetDesc(ctx *Context, parameter []byte, labels map[int]string) (string, int) {
+	offset, rOffset, err := calcJumpOffset(ctx, parameter)
+	if err != nil {
+		return fmt.Sprintf("ERROR: %v", err), -1
+	}
+	desc := fmt.Sprintf("%d (%d/%x)", offset, rOffset, parameter)
+	if name, ok := labels[offset]; ok {
+		desc += " <" + name + ">"
+	}
+	return desc, offset
+}
+
+// renderArrows returns left-side listing parts with jump arrows for every
+// line. Shorter jumps are placed closer to instructions.
+func renderArrows(lines []listingLine) []string {
+	var (
+		arrows  []jumpArrow
+		lineNum = make(map[int]int, len(lines))
+	)
+	for i := range lines {
+		lineNum[lines[i].ip] = i
+	}
+	for i := range lines {
+		if lines[i].target < 0 {
+			continue
+		}
+		if to, ok := lineNum[lines[i].target]; ok && to != i {
+			arrows = append(arrows, jumpArrow{from: i, to: to})
+		}
+	}
+	if len(arrows) == 0 {
+		return make([]string, len(lines))
+	}
+	span := func(a jumpArrow) (int, int) {
+		if a.from < a.to {
+			return a.from, a.to
+		}
+		return a.to, a.from
+	}
+	sort.SliceStable(arrows, func(i, j int) bool {
+		si, ei := span(arrows[i])
+		sj, ej := span(arrows[j])
+		return ei-si < ej-sj
+	})
+	// Assign every arrow the innermost lane not used by overlapping arrows.
+	var lanes [][]jumpArrow
+	for i := range arrows {
+		s, e := span(arrows[i])
+		lane := 0
+		for ; lane < len(lanes); lane++ {
+			free := true
+			for _, a := range lanes[lane] {
+				as, ae := span(a)
+				if s <= ae && as <= e {
+					free = false
+					break
+				}
+			}
+			if free {
+				break
+			}
+		}
+		if lane == len(lanes) {
+			lanes = append(lanes, nil)
+		}
+		arrows[i].lane = lane
+		lanes[lane] = append(lanes[lane], arrows[i])
+	}
+
+	var (
+		width = len(lanes)
+		res   = make([]string, len(lines))
+		grid  = make([][]rune, len(lines))
+		tails = make([]rune, len(lines))
+	)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", width))
+		tails[i] = ' '
+	}
+	for _, a := range arrows {
+		col := width - 1 - a.lane
+		s, e := span(a)
+		for i := s + 1; i < e; i++ {
+			if grid[i][col] == ' ' {
+				grid[i][col] = '|'
+			}
+		}
+	}
+	for _, a := range arrows {
+		col := width - 1 - a.lane
+		for _, i := range []int{a.from, a.to} {
+			grid[i][col] = '+'
+			for c := col + 1; c < width; c++ {
+				if grid[i][c] == ' ' {
+					grid[i][c] = '-'
+				}
+			}
+		}
+		if tails[a.from] == ' ' {
+			tails[a.from] = '-'
+		}
+		tails[a.to] = '>'
+	}
+	for i := range grid {
+		res[i] = string(grid[i]) + string(tails[i])
+	}
+	return res
+}
+
+// LabelsFromManifest returns method labels for Disassemble based on the
+// offsets of manifest methods.
+func LabelsFromManifest(m *manifest.Manifest) map[int]string {
+	labels := make(map[int]string, len(m.ABI.Methods))
+	for _, md := range m.ABI.Methods {
+		if _, ok := labels[md.Offset]; !ok {
+			labels[md.Offset] = md.Name
+		}
+	}
+	return labels
+}
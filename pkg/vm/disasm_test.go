@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisassemble(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emit.Instruction(w.BinWriter, opcode.CALL, []byte{7})    // 0 -> 7
+	emit.Opcodes(w.BinWriter, opcode.PUSH1)                  // 2
+	emit.Instruction(w.BinWriter, opcode.JMPIF, []byte{4})   // 3 -> 7
+	emit.Opcodes(w.BinWriter, opcode.NOP, opcode.RET)        // 5, 6
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeLog) // 7
+	emit.Opcodes(w.BinWriter, opcode.RET)                    // 12
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	t.Run("plain", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, Disassemble(buf, script, DisasmOptions{Cursor: -1}))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Equal(t, 8, len(lines))
+		require.Regexp(t, "^INDEX +OPCODE +PARAMETER", lines[0])
+		require.Regexp(t, "^0 +CALL +7 \\(7/07\\)", lines[1])
+		require.Regexp(t, "^7 +SYSCALL +System\\.Runtime\\.Log", lines[6])
+		require.NotContains(t, buf.String(), "<<")
+	})
+	t.Run("labels and arrows", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, Disassemble(buf, script, DisasmOptions{
+			Labels: map[int]string{0: "main", 7: "log"},
+			Arrows: true,
+			Cursor: 2,
+		}))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Equal(t, 10, len(lines))
+		require.Equal(t, "main:", lines[1])
+		require.Regexp(t, "^ *\\+-- +0 +CALL +7 \\(7/07\\) <log>", lines[2])
+		require.Regexp(t, "^ *\\|  +2 +PUSH1 .*<<", lines[3])
+		require.Regexp(t, "^ *\\|\\+- +3 +JMPIF +7 \\(4/04\\) <log>", lines[4])
+		require.Regexp(t, "^ *\\|\\| +5 +NOP", lines[5])
+		require.Equal(t, "log:", lines[7])
+		require.Regexp(t, "^ *\\+\\+> +7 +SYSCALL", lines[8])
+		require.Regexp(t, "^ +12 +RET", lines[9])
+	})
+	t.Run("bad instruction", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		require.Error(t, Disassemble(buf, []byte{byte(opcode.PUSH1), 0xff}, DisasmOptions{Cursor: -1}))
+		require.Contains(t, buf.String(), "ERROR")
+	})
+}
+
+func TestLabelsFromManifest(t *testing.T) {
+	m := manifest.NewManifest("Test")
+	m.ABI.Methods = []manifest.Method{
+		{Name: "main", Offset: 0},
+		{Name: "sum", Offset: 10},
+		{Name: "sum", Offset: 10},
+	}
+	require.Equal(t, map[int]string{0: "main", 10: "sum"}, LabelsFromManifest(m))
+}
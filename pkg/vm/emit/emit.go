@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"math/bits"
 
@@ -16,6 +17,52 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
+// MaxScriptSize is the maximum size of the script that can be used in a
+// transaction, it's the same as transaction.MaxScriptLength.
+const MaxScriptSize = math.MaxUint16
+
+// ErrScriptTooBig is returned when the resulting script exceeds MaxScriptSize.
+var ErrScriptTooBig = errors.New("script is too big")
+
+// CheckScriptSize returns an error if the given script can't be used in a
+// transaction because of its size.
+func CheckScriptSize(script []byte) error {
+	if len(script) > MaxScriptSize {
+		return fmt.Errorf("%w: %d bytes (max %d)", ErrScriptTooBig, len(script), MaxScriptSize)
+	}
+	return nil
+}
+
+// Concat concatenates the given scripts checking the resulting size, so that
+// it's still usable in a transaction.
+func Concat(scripts ...[]byte) ([]byte, error) {
+	var size int
+	for _, s := range scripts {
+		size += len(s)
+	}
+	if size > MaxScriptSize {
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrScriptTooBig, size, MaxScriptSize)
+	}
+	res := make([]byte, 0, size)
+	for _, s := range scripts {
+		res = append(res, s...)
+	}
+	return res, nil
+}
+
+// Script returns the script emitted to the given writer, it returns an error
+// if there was an error emitting it or if it exceeds MaxScriptSize.
+func Script(w *io.BufBinWriter) ([]byte, error) {
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	script := w.Bytes()
+	if err := CheckScriptSize(script); err != nil {
+		return nil, err
+	}
+	return script, nil
+}
+
 // Instruction emits a VM Instruction with data to the given buffer.
 func Instruction(w *io.BinWriter, op opcode.Opcode, b []byte) {
 	w.WriteB(byte(op))
@@ -290,3 +290,39 @@ func TestEmitCall(t *testing.T) {
 	label := binary.LittleEndian.Uint16(result[1:3])
 	assert.Equal(t, label, uint16(100))
 }
+
+func TestCheckScriptSize(t *testing.T) {
+	require.NoError(t, CheckScriptSize(make([]byte, MaxScriptSize)))
+	require.True(t, errors.Is(CheckScriptSize(make([]byte, MaxScriptSize+1)), ErrScriptTooBig))
+}
+
+func TestConcat(t *testing.T) {
+	script, err := Concat([]byte{1, 2}, nil, []byte{3})
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, script)
+
+	_, err = Concat(make([]byte, MaxScriptSize), []byte{byte(opcode.RET)})
+	require.True(t, errors.Is(err, ErrScriptTooBig))
+}
+
+func TestScript(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		buf := io.NewBufBinWriter()
+		Opcodes(buf.BinWriter, opcode.PUSH1, opcode.RET)
+		script, err := Script(buf)
+		require.NoError(t, err)
+		require.Equal(t, []byte{byte(opcode.PUSH1), byte(opcode.RET)}, script)
+	})
+	t.Run("emit error", func(t *testing.T) {
+		buf := io.NewBufBinWriter()
+		Syscall(buf.BinWriter, "")
+		_, err := Script(buf)
+		require.Error(t, err)
+	})
+	t.Run("too big", func(t *testing.T) {
+		buf := io.NewBufBinWriter()
+		Bytes(buf.BinWriter, make([]byte, MaxScriptSize))
+		_, err := Script(buf)
+		require.True(t, errors.Is(err, ErrScriptTooBig))
+	})
+}
@@ -0,0 +1,63 @@
+package vm
+
+import (
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// unknownSyscallCategory is used for syscalls not known to interopnames.
+const unknownSyscallCategory = "unknown"
+
+// GasStats accounts GAS consumed by the VM per executed contract and per
+// syscall category.
+type GasStats struct {
+	// Contracts maps script hash to the amount of GAS consumed by the
+	// instructions of this script (including syscalls made by it, but not
+	// the instructions of other contracts called).
+	Contracts map[util.Uint160]int64
+	// Syscalls maps syscall category (interop name without method, like
+	// System.Storage) to the amount of GAS consumed by syscalls of this
+	// category.
+	Syscalls map[string]int64
+}
+
+// NewGasStats returns new empty GasStats.
+func NewGasStats() *GasStats {
+	return &GasStats{
+		Contracts: make(map[util.Uint160]int64),
+		Syscalls:  make(map[string]int64),
+	}
+}
+
+// SyscallCategory returns category of the syscall with the given name which
+// is its name without the method part.
+func SyscallCategory(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i > 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// account adds GAS consumed by the instruction to the stats.
+func (s *GasStats) account(ctx *Context, op opcode.Opcode, parameter []byte, gas int64) {
+	if gas == 0 {
+		return
+	}
+	s.Contracts[ctx.ScriptHash()] += gas
+	if op == opcode.SYSCALL && len(parameter) == 4 {
+		category := unknownSyscallCategory
+		if name, err := interopnames.FromID(GetInteropID(parameter)); err == nil {
+			category = SyscallCategory(name)
+		}
+		s.Syscalls[category] += gas
+	}
+}
+
+// SetGasStats makes VM account GAS consumed to the given GasStats, nil
+// disables accounting.
+func (v *VM) SetGasStats(s *GasStats) {
+	v.gasStats = s
+}
@@ -0,0 +1,38 @@
+package vm
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_GasStats(t *testing.T) {
+	prog := []byte{byte(opcode.PUSH1), byte(opcode.SYSCALL), 0, 0, 0, 0, byte(opcode.SYSCALL), 1, 2, 3, 4, byte(opcode.RET)}
+	binary.LittleEndian.PutUint32(prog[2:], interopnames.ToID([]byte(interopnames.SystemRuntimeLog)))
+
+	v := load(prog)
+	v.SetPriceGetter(func(opcode.Opcode, []byte) int64 { return 1 })
+	v.SyscallHandler = func(v *VM, id uint32) error {
+		v.AddGas(10)
+		return nil
+	}
+	stats := NewGasStats()
+	v.SetGasStats(stats)
+	require.NoError(t, v.Run())
+
+	require.Equal(t, map[util.Uint160]int64{hash.Hash160(prog): 24}, stats.Contracts)
+	require.Equal(t, map[string]int64{
+		"System.Runtime":       11,
+		unknownSyscallCategory: 11,
+	}, stats.Syscalls)
+}
+
+func TestSyscallCategory(t *testing.T) {
+	require.Equal(t, "System.Storage", SyscallCategory(interopnames.SystemStoragePut))
+	require.Equal(t, "name", SyscallCategory("name"))
+}
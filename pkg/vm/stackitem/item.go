@@ -689,7 +689,11 @@ type MapElement struct {
 // which should be fine for maps with less than 32 or so elements. Given that
 // our VM has quite low limit of overall stack items, it should be good enough,
 // but it can be extended with a real map for fast random access in the future
-// if need be.
+// if need be. Elements are kept in insertion order (replacing the value of an
+// existing key doesn't change its position, dropping an element doesn't
+// change the order of the others) the same way C# VM does, so iteration,
+// serialization and KEYS/VALUES output are deterministic. Maps are compared
+// by reference.
 type Map struct {
 	value []MapElement
 }
@@ -763,6 +767,39 @@ func (i *Map) Has(key Item) bool {
 	return i.Index(key) >= 0
 }
 
+// Get returns the value stored for the given key or nil if there is no such
+// key in the map.
+func (i *Map) Get(key Item) Item {
+	if k := i.Index(key); k >= 0 {
+		return i.value[k].Value
+	}
+	return nil
+}
+
+// ElementAt returns the key-value pair stored at the given index (no bounds
+// check done here), indexes follow insertion order.
+func (i *Map) ElementAt(index int) MapElement {
+	return i.value[index]
+}
+
+// Keys returns map keys in insertion order.
+func (i *Map) Keys() []Item {
+	keys := make([]Item, len(i.value))
+	for k := range i.value {
+		keys[k] = i.value[k].Key
+	}
+	return keys
+}
+
+// Values returns map values in insertion order.
+func (i *Map) Values() []Item {
+	values := make([]Item, len(i.value))
+	for k := range i.value {
+		values[k] = i.value[k].Value
+	}
+	return values
+}
+
 // Dup implements Item interface.
 func (i *Map) Dup() Item {
 	// reference type
@@ -522,3 +522,46 @@ func TestDeepCopy(t *testing.T) {
 		require.True(t, actual == actual.(*Map).value[0].Value)
 	})
 }
+
+func TestMapOrder(t *testing.T) {
+	m := NewMap()
+	m.Add(NewByteArray([]byte("b")), NewBigInteger(big.NewInt(1)))
+	m.Add(NewBigInteger(big.NewInt(1)), NewBigInteger(big.NewInt(2)))
+	m.Add(NewBool(true), NewBigInteger(big.NewInt(3)))
+	m.Add(NewByteArray([]byte("a")), NewBigInteger(big.NewInt(4)))
+
+	// Keys of different types are different even if their values are the same.
+	require.Equal(t, 4, m.Len())
+	// Replacing existing value keeps element position.
+	m.Add(NewBigInteger(big.NewInt(1)), NewBigInteger(big.NewInt(5)))
+	require.Equal(t, []Item{
+		NewByteArray([]byte("b")),
+		NewBigInteger(big.NewInt(1)),
+		NewBool(true),
+		NewByteArray([]byte("a")),
+	}, m.Keys())
+	require.Equal(t, []Item{
+		NewBigInteger(big.NewInt(1)),
+		NewBigInteger(big.NewInt(5)),
+		NewBigInteger(big.NewInt(3)),
+		NewBigInteger(big.NewInt(4)),
+	}, m.Values())
+
+	require.Equal(t, 2, m.Index(NewBool(true)))
+	require.Equal(t, MapElement{Key: NewBool(true), Value: NewBigInteger(big.NewInt(3))}, m.ElementAt(2))
+	require.Equal(t, NewBigInteger(big.NewInt(4)), m.Get(NewByteArray([]byte("a"))))
+	require.Nil(t, m.Get(NewByteArray([]byte("c"))))
+
+	// Dropping an element doesn't change the order of the others.
+	m.Drop(1)
+	require.Equal(t, []Item{
+		NewByteArray([]byte("b")),
+		NewBool(true),
+		NewByteArray([]byte("a")),
+	}, m.Keys())
+	require.Equal(t, -1, m.Index(NewBigInteger(big.NewInt(1))))
+
+	// Maps are compared by reference.
+	require.True(t, m.Equals(m))
+	require.False(t, m.Equals(NewMapWithValue(m.value)))
+}
@@ -9,6 +9,38 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 )
 
+// MaxDeserialized is the maximum number of items in a single serialized
+// item tree. It's the same as the maximum number of items VM can hold on
+// all of its stacks.
+const MaxDeserialized = 2048
+
+// ErrRecursive is returned on attempts to serialize some recursive stack item
+// (like array including an item with reference to the same array).
+var ErrRecursive = errors.New("recursive item")
+
+// ErrUnserializable is returned on attempt to serialize some item that can't
+// be serialized (like Interop item).
+var ErrUnserializable = errors.New("unserializable")
+
+// ErrTooBig is returned when item (or its serialized representation) exceeds
+// size limits.
+var ErrTooBig = errors.New("too big")
+
+// serContext is an internal serialization context.
+type serContext struct {
+	*io.BinWriter
+	allowInvalid bool
+	seen         map[Item]bool
+}
+
+// deserContext is an internal deserialization context.
+type deserContext struct {
+	*io.BinReader
+	allowInvalid bool
+	keepBuffers  bool
+	count        int
+}
+
 // SerializeItem encodes given Item into the byte slice.
 func SerializeItem(item Item) ([]byte, error) {
 	w := io.NewBufBinWriter()
@@ -16,6 +48,9 @@ func SerializeItem(item Item) ([]byte, error) {
 	if w.Err != nil {
 		return nil, w.Err
 	}
+	if w.Len() > MaxSize {
+		return nil, fmt.Errorf("%w: serialized item size %d exceeds %d", ErrTooBig, w.Len(), MaxSize)
+	}
 	return w.Bytes(), nil
 }
 
@@ -23,14 +58,23 @@ func SerializeItem(item Item) ([]byte, error) {
 // similar to io.Serializable's EncodeBinary, but works with Item
 // interface.
 func EncodeBinaryStackItem(item Item, w *io.BinWriter) {
-	serializeItemTo(item, w, false, make(map[Item]bool))
+	sc := serContext{
+		BinWriter: w,
+		seen:      make(map[Item]bool),
+	}
+	sc.serialize(item)
 }
 
 // EncodeBinaryStackItemAppExec encodes given Item into the given BinWriter. It's
 // similar to EncodeBinaryStackItem but allows to encode interop (only type, value is lost).
 func EncodeBinaryStackItemAppExec(item Item, w *io.BinWriter) {
 	bw := io.NewBufBinWriter()
-	serializeItemTo(item, bw.BinWriter, true, make(map[Item]bool))
+	sc := serContext{
+		BinWriter:    bw.BinWriter,
+		allowInvalid: true,
+		seen:         make(map[Item]bool),
+	}
+	sc.serialize(item)
 	if bw.Err != nil {
 		w.WriteBytes([]byte{byte(InvalidT)})
 		return
@@ -38,12 +82,15 @@ func EncodeBinaryStackItemAppExec(item Item, w *io.BinWriter) {
 	w.WriteBytes(bw.Bytes())
 }
 
-func serializeItemTo(item Item, w *io.BinWriter, allowInvalid bool, seen map[Item]bool) {
-	if seen[item] {
-		w.Err = errors.New("recursive structures can't be serialized")
+func (w *serContext) serialize(item Item) {
+	if w.Err != nil {
+		return
+	}
+	if w.seen[item] {
+		w.Err = fmt.Errorf("%w: recursive structures can't be serialized", ErrRecursive)
 		return
 	}
-	if item == nil && allowInvalid {
+	if item == nil && w.allowInvalid {
 		w.WriteBytes([]byte{byte(InvalidT)})
 		return
 	}
@@ -62,14 +109,28 @@ func serializeItemTo(item Item, w *io.BinWriter, allowInvalid bool, seen map[Ite
 		w.WriteBytes([]byte{byte(IntegerT)})
 		w.WriteVarBytes(bigint.ToBytes(t.Value().(*big.Int)))
 	case *Interop:
-		if allowInvalid {
+		if w.allowInvalid {
 			w.WriteBytes([]byte{byte(InteropT)})
 			return
 		}
-		w.Err = errors.New("interop item can't be serialized")
-	case *Array, *Struct:
-		seen[item] = true
+		w.Err = fmt.Errorf("%w: interop item can't be serialized", ErrUnserializable)
+	case *Array, *Struct, *Map:
+		w.seen[item] = true
+		w.serializeCompound(t)
+	case Null:
+		w.WriteB(byte(AnyT))
+	default:
+		if w.allowInvalid {
+			w.WriteBytes([]byte{byte(InvalidT)})
+		}
+		// Other items (like Pointer) are not written at all, that's how
+		// they've always been serialized and it affects execution results.
+	}
+}
 
+func (w *serContext) serializeCompound(item Item) {
+	switch t := item.(type) {
+	case *Array, *Struct:
 		_, isArray := t.(*Array)
 		if isArray {
 			w.WriteBytes([]byte{byte(ArrayT)})
@@ -80,23 +141,21 @@ func serializeItemTo(item Item, w *io.BinWriter, allowInvalid bool, seen map[Ite
 		arr := t.Value().([]Item)
 		w.WriteVarUint(uint64(len(arr)))
 		for i := range arr {
-			serializeItemTo(arr[i], w, allowInvalid, seen)
+			w.serialize(arr[i])
 		}
 	case *Map:
-		seen[item] = true
-
+		elems := t.Value().([]MapElement)
 		w.WriteBytes([]byte{byte(MapT)})
-		w.WriteVarUint(uint64(len(t.Value().([]MapElement))))
-		for i := range t.Value().([]MapElement) {
-			serializeItemTo(t.Value().([]MapElement)[i].Key, w, allowInvalid, seen)
-			serializeItemTo(t.Value().([]MapElement)[i].Value, w, allowInvalid, seen)
+		w.WriteVarUint(uint64(len(elems)))
+		for i := range elems {
+			w.serialize(elems[i].Key)
+			w.serialize(elems[i].Value)
 		}
-	case Null:
-		w.WriteB(byte(AnyT))
 	}
 }
 
-// DeserializeItem decodes Item from the given byte slice.
+// DeserializeItem decodes Item from the given byte slice. Buffer items are
+// decoded as ByteArray.
 func DeserializeItem(data []byte) (Item, error) {
 	r := io.NewBinReaderFromBuf(data)
 	item := DecodeBinaryStackItem(r)
@@ -106,6 +165,20 @@ func DeserializeItem(data []byte) (Item, error) {
 	return item, nil
 }
 
+// DeserializeItemStrict is similar to DeserializeItem, but Buffer items are
+// decoded as Buffer.
+func DeserializeItemStrict(data []byte) (Item, error) {
+	dc := deserContext{
+		BinReader:   io.NewBinReaderFromBuf(data),
+		keepBuffers: true,
+	}
+	item := dc.decode()
+	if dc.Err != nil {
+		return nil, dc.Err
+	}
+	return item, nil
+}
+
 // DecodeBinaryStackItem decodes previously serialized Item from the given
 // reader. It's similar to the io.Serializable's DecodeBinary(), but implemented
 // as a function because Item itself is an interface. Caveat: always check
@@ -121,15 +194,36 @@ func DecodeBinaryStackItemAppExec(r *io.BinReader) Item {
 }
 
 func decodeBinaryStackItem(r *io.BinReader, allowInvalid bool) Item {
+	dc := deserContext{
+		BinReader:    r,
+		allowInvalid: allowInvalid,
+	}
+	return dc.decode()
+}
+
+// decode performs actual item deserialization checking item count limit
+// along the way. Nesting level is limited by the item count.
+func (r *deserContext) decode() Item {
 	var t = Type(r.ReadB())
 	if r.Err != nil {
 		return nil
 	}
+	r.count++
+	if r.count > MaxDeserialized {
+		r.Err = fmt.Errorf("%w: more than %d items", ErrTooBig, MaxDeserialized)
+		return nil
+	}
 
 	switch t {
-	case ByteArrayT, BufferT:
+	case ByteArrayT:
 		data := r.ReadVarBytes(MaxSize)
 		return NewByteArray(data)
+	case BufferT:
+		data := r.ReadVarBytes(MaxSize)
+		if r.keepBuffers {
+			return NewBuffer(data)
+		}
+		return NewByteArray(data)
 	case BooleanT:
 		var b = r.ReadBool()
 		return NewBool(b)
@@ -137,41 +231,56 @@ func decodeBinaryStackItem(r *io.BinReader, allowInvalid bool) Item {
 		data := r.ReadVarBytes(bigint.MaxBytesLen)
 		num := bigint.FromBytes(data)
 		return NewBigInteger(num)
-	case ArrayT, StructT:
-		size := int(r.ReadVarUint())
+	case ArrayT, StructT, MapT:
+		u := r.ReadVarUint()
+		if r.Err != nil {
+			return nil
+		}
+		if u > MaxDeserialized {
+			r.Err = fmt.Errorf("%w: %d elements", ErrTooBig, u)
+			return nil
+		}
+		size := int(u)
+		if t == MapT {
+			return r.decodeMap(size)
+		}
 		arr := make([]Item, size)
 		for i := 0; i < size; i++ {
-			arr[i] = DecodeBinaryStackItem(r)
+			arr[i] = r.decode()
+			if r.Err != nil {
+				return nil
+			}
 		}
 
 		if t == ArrayT {
 			return NewArray(arr)
 		}
 		return NewStruct(arr)
-	case MapT:
-		size := int(r.ReadVarUint())
-		m := NewMap()
-		for i := 0; i < size; i++ {
-			key := DecodeBinaryStackItem(r)
-			value := DecodeBinaryStackItem(r)
-			if r.Err != nil {
-				break
-			}
-			m.Add(key, value)
-		}
-		return m
 	case AnyT:
 		return Null{}
 	case InteropT:
-		if allowInvalid {
+		if r.allowInvalid {
 			return NewInterop(nil)
 		}
 		fallthrough
 	default:
-		if t == InvalidT && allowInvalid {
+		if t == InvalidT && r.allowInvalid {
 			return nil
 		}
 		r.Err = fmt.Errorf("unknown type: %v", t)
 		return nil
 	}
 }
+
+func (r *deserContext) decodeMap(size int) Item {
+	m := NewMap()
+	for i := 0; i < size; i++ {
+		key := r.decode()
+		value := r.decode()
+		if r.Err != nil {
+			break
+		}
+		m.Add(key, value)
+	}
+	return m
+}
@@ -0,0 +1,100 @@
+package stackitem
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeDeserialize(t *testing.T) {
+	m := NewMap()
+	m.Add(Make(1), Make("value"))
+	items := []Item{
+		Make(42),
+		Make(true),
+		Make([]byte{1, 2, 3}),
+		Null{},
+		NewStruct([]Item{Make(1), Make([]Item{Make("nested")})}),
+		m,
+	}
+	var deep Item = NewArray([]Item{})
+	for i := 0; i < 100; i++ {
+		deep = NewArray([]Item{deep})
+	}
+	items = append(items, deep)
+	for _, item := range items {
+		data, err := SerializeItem(item)
+		require.NoError(t, err)
+		actual, err := DeserializeItem(data)
+		require.NoError(t, err)
+		require.Equal(t, item, actual)
+	}
+}
+
+func TestDeserializeBuffer(t *testing.T) {
+	data, err := SerializeItem(NewBuffer([]byte{1, 2, 3}))
+	require.NoError(t, err)
+
+	actual, err := DeserializeItem(data)
+	require.NoError(t, err)
+	require.Equal(t, NewByteArray([]byte{1, 2, 3}), actual)
+
+	actual, err = DeserializeItemStrict(data)
+	require.NoError(t, err)
+	require.Equal(t, NewBuffer([]byte{1, 2, 3}), actual)
+}
+
+func TestSerializeErrors(t *testing.T) {
+	t.Run("recursive", func(t *testing.T) {
+		arr := NewArray(nil)
+		arr.Append(arr)
+		_, err := SerializeItem(arr)
+		require.True(t, errors.Is(err, ErrRecursive), "got: %v", err)
+	})
+	t.Run("interop", func(t *testing.T) {
+		_, err := SerializeItem(NewInterop(42))
+		require.True(t, errors.Is(err, ErrUnserializable), "got: %v", err)
+	})
+	t.Run("pointer", func(t *testing.T) {
+		data, err := SerializeItem(NewPointer(0, []byte{0}))
+		require.NoError(t, err)
+		require.Equal(t, 0, len(data))
+	})
+	t.Run("too big", func(t *testing.T) {
+		arr := NewArray([]Item{NewByteArray(make([]byte, MaxSize/2)), NewByteArray(make([]byte, MaxSize/2))})
+		_, err := SerializeItem(arr)
+		require.True(t, errors.Is(err, ErrTooBig), "got: %v", err)
+	})
+}
+
+func TestDeserializeErrors(t *testing.T) {
+	t.Run("too many elements", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		w.WriteB(byte(ArrayT))
+		w.WriteVarUint(MaxDeserialized + 1)
+		_, err := DeserializeItem(w.Bytes())
+		require.True(t, errors.Is(err, ErrTooBig), "got: %v", err)
+	})
+	t.Run("too many items", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		w.WriteB(byte(ArrayT))
+		w.WriteVarUint(MaxDeserialized)
+		for i := 0; i < MaxDeserialized; i++ {
+			w.WriteB(byte(AnyT))
+		}
+		_, err := DeserializeItem(w.Bytes())
+		require.True(t, errors.Is(err, ErrTooBig), "got: %v", err)
+	})
+	t.Run("too deep", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		for i := 0; i < MaxDeserialized; i++ {
+			w.WriteB(byte(ArrayT))
+			w.WriteVarUint(1)
+		}
+		w.WriteB(byte(AnyT))
+		_, err := DeserializeItem(w.Bytes())
+		require.True(t, errors.Is(err, ErrTooBig), "got: %v", err)
+	})
+}
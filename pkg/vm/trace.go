@@ -0,0 +1,225 @@
+package vm
+
+import (
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Default Tracer limits used for zero TraceLimits fields.
+const (
+	// DefaultTraceLimit is the default number of instructions recorded.
+	DefaultTraceLimit = 1024
+	// DefaultTraceStackItems is the default number of evaluation stack
+	// items (from the top) recorded for every instruction.
+	DefaultTraceStackItems = 16
+	// DefaultTraceEntrySize is the default size of stack snapshot recorded
+	// for a single instruction.
+	DefaultTraceEntrySize = 4096
+	// DefaultTraceSize is the default size of all stack snapshots recorded.
+	DefaultTraceSize = 1024 * 1024
+)
+
+// TraceEntry describes a single instruction executed by the VM.
+type TraceEntry struct {
+	// ScriptHash is the hash of the script being executed.
+	ScriptHash util.Uint160
+	// IP is the instruction offset in the script.
+	IP int
+	// Opcode is the instruction executed.
+	Opcode opcode.Opcode
+	// GasConsumed is the amount of GAS consumed before the instruction
+	// execution.
+	GasConsumed int64
+	// Stack is a copy of the evaluation stack before the instruction
+	// execution (the top item goes first).
+	Stack []stackitem.Item
+	// StackTruncated is true if some stack items were not recorded
+	// because of the limits.
+	StackTruncated bool
+}
+
+// TraceLimits restricts the amount of data recorded by the Tracer. Sizes are
+// approximate and are calculated as the sum of primitive item lengths plus
+// one for every item.
+type TraceLimits struct {
+	// MaxEntries is the maximum number of instructions recorded.
+	MaxEntries int
+	// MaxStackItems is the maximum number of evaluation stack items (from
+	// the top) recorded for every instruction.
+	MaxStackItems int
+	// MaxEntrySize is the maximum size of a single instruction's stack
+	// snapshot.
+	MaxEntrySize int
+	// MaxSize is the maximum size of all stack snapshots, nothing is
+	// recorded after reaching it.
+	MaxSize int
+}
+
+// Tracer records instructions executed by the VM along with the evaluation
+// stack state. It's intended to be used for contract debugging and keeps
+// the amount of data recorded within the limits, everything executed after
+// reaching them is not recorded.
+type Tracer struct {
+	limits    TraceLimits
+	entries   []TraceEntry
+	size      int
+	truncated bool
+}
+
+// NewTracer returns new Tracer with the given limits, defaults are used for
+// non-positive ones.
+func NewTracer(limits TraceLimits) *Tracer {
+	if limits.MaxEntries <= 0 {
+		limits.MaxEntries = DefaultTraceLimit
+	}
+	if limits.MaxStackItems <= 0 {
+		limits.MaxStackItems = DefaultTraceStackItems
+	}
+	if limits.MaxEntrySize <= 0 {
+		limits.MaxEntrySize = DefaultTraceEntrySize
+	}
+	if limits.MaxSize <= 0 {
+		limits.MaxSize = DefaultTraceSize
+	}
+	return &Tracer{limits: limits}
+}
+
+// Entries returns instructions recorded in the order of their execution.
+func (t *Tracer) Entries() []TraceEntry {
+	return t.entries
+}
+
+// Truncated returns true if some of the instructions executed were not
+// recorded because of the limit.
+func (t *Tracer) Truncated() bool {
+	return t.truncated
+}
+
+// record adds an entry for the instruction about to be executed.
+func (t *Tracer) record(v *VM, ctx *Context, op opcode.Opcode) {
+	if t.truncated || len(t.entries) >= t.limits.MaxEntries || t.size >= t.limits.MaxSize {
+		t.truncated = true
+		return
+	}
+	var (
+		budget    = t.limits.MaxEntrySize
+		lastEntry bool
+	)
+	if rest := t.limits.MaxSize - t.size; rest < budget {
+		budget = rest
+		lastEntry = true
+	}
+	var (
+		n         = v.estack.Len()
+		items     = make([]stackitem.Item, 0, n)
+		stackTrnc bool
+		seen      = make(map[stackitem.Item]stackitem.Item)
+		size      = budget
+	)
+	for i := 0; i < n; i++ {
+		if i == t.limits.MaxStackItems {
+			stackTrnc = true
+			break
+		}
+		rest := size
+		item, ok := snapshotItem(v.estack.Peek(i).Item(), &rest, seen)
+		if !ok {
+			if lastEntry {
+				// Total size limit is reached.
+				t.truncated = true
+				return
+			}
+			stackTrnc = true
+			break
+		}
+		size = rest
+		items = append(items, item)
+	}
+	t.size += budget - size
+	t.entries = append(t.entries, TraceEntry{
+		ScriptHash:     ctx.ScriptHash(),
+		IP:             ctx.IP(),
+		Opcode:         op,
+		GasConsumed:    v.gasConsumed,
+		Stack:          items,
+		StackTruncated: stackTrnc,
+	})
+}
+
+// snapshotItem returns a deep copy of the item decreasing budget by its size,
+// it returns false if the budget is exceeded. Items already copied (found in
+// seen) are not accounted for again.
+func snapshotItem(item stackitem.Item, budget *int, seen map[stackitem.Item]stackitem.Item) (stackitem.Item, bool) {
+	if it := seen[item]; it != nil {
+		return it, true
+	}
+	*budget--
+	if *budget < 0 {
+		return nil, false
+	}
+	var res stackitem.Item
+	switch it := item.(type) {
+	case *stackitem.Array, *stackitem.Struct:
+		src := it.Value().([]stackitem.Item)
+		elems := make([]stackitem.Item, len(src))
+		if _, ok := it.(*stackitem.Struct); ok {
+			res = stackitem.NewStruct(elems)
+		} else {
+			res = stackitem.NewArray(elems)
+		}
+		seen[item] = res
+		for i := range src {
+			var ok bool
+			if elems[i], ok = snapshotItem(src[i], budget, seen); !ok {
+				return nil, false
+			}
+		}
+		return res, true
+	case *stackitem.Map:
+		src := it.Value().([]stackitem.MapElement)
+		elems := make([]stackitem.MapElement, len(src))
+		m := stackitem.NewMapWithValue(elems)
+		seen[item] = m
+		for i := range src {
+			var okK, okV bool
+			elems[i].Key, okK = snapshotItem(src[i].Key, budget, seen)
+			if !okK {
+				return nil, false
+			}
+			elems[i].Value, okV = snapshotItem(src[i].Value, budget, seen)
+			if !okV {
+				return nil, false
+			}
+		}
+		return m, true
+	case *stackitem.BigInteger:
+		bi := it.Value().(*big.Int)
+		*budget -= len(bi.Bytes())
+		res = stackitem.NewBigInteger(new(big.Int).Set(bi))
+	case *stackitem.ByteArray:
+		b := it.Value().([]byte)
+		*budget -= len(b)
+		res = stackitem.NewByteArray(append([]byte{}, b...))
+	case *stackitem.Buffer:
+		b := it.Value().([]byte)
+		*budget -= len(b)
+		res = stackitem.NewBuffer(append([]byte{}, b...))
+	default:
+		// Null, Bool, Pointer and Interop are immutable.
+		res = item
+	}
+	if *budget < 0 {
+		return nil, false
+	}
+	seen[item] = res
+	return res, true
+}
+
+// SetTracer makes VM record every instruction executed with the given
+// Tracer, nil disables tracing.
+func (v *VM) SetTracer(t *Tracer) {
+	v.tracer = t
+}
@@ -0,0 +1,89 @@
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_Trace(t *testing.T) {
+	prog := makeProgram(opcode.PUSH2, opcode.PUSH3, opcode.ADD, opcode.RET)
+	t.Run("full", func(t *testing.T) {
+		v := load(prog)
+		tr := NewTracer(TraceLimits{})
+		v.SetTracer(tr)
+		require.NoError(t, v.Run())
+
+		entries := tr.Entries()
+		require.False(t, tr.Truncated())
+		require.Equal(t, 4, len(entries))
+		for i, op := range []opcode.Opcode{opcode.PUSH2, opcode.PUSH3, opcode.ADD, opcode.RET} {
+			require.Equal(t, op, entries[i].Opcode)
+			require.Equal(t, i, entries[i].IP)
+		}
+		require.Equal(t, 0, len(entries[0].Stack))
+		require.Equal(t, []stackitem.Item{
+			stackitem.NewBigInteger(big.NewInt(3)),
+			stackitem.NewBigInteger(big.NewInt(2)),
+		}, entries[2].Stack)
+		require.Equal(t, []stackitem.Item{stackitem.NewBigInteger(big.NewInt(5))}, entries[3].Stack)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		v := load(prog)
+		tr := NewTracer(TraceLimits{MaxEntries: 2})
+		v.SetTracer(tr)
+		require.NoError(t, v.Run())
+		require.True(t, tr.Truncated())
+		require.Equal(t, 2, len(tr.Entries()))
+		require.Equal(t, opcode.PUSH3, tr.Entries()[1].Opcode)
+	})
+	t.Run("snapshot", func(t *testing.T) {
+		v := load(makeProgram(opcode.NEWARRAY0, opcode.DUP, opcode.PUSH1, opcode.APPEND, opcode.RET))
+		tr := NewTracer(TraceLimits{})
+		v.SetTracer(tr)
+		require.NoError(t, v.Run())
+		// Array modified by APPEND is not changed in the earlier entries.
+		require.Equal(t, []stackitem.Item{stackitem.NewArray([]stackitem.Item{})}, tr.Entries()[1].Stack)
+		require.Equal(t, []stackitem.Item{stackitem.NewArray([]stackitem.Item{stackitem.Make(1)})}, tr.Entries()[4].Stack)
+	})
+	t.Run("stack items limit", func(t *testing.T) {
+		v := load(prog)
+		tr := NewTracer(TraceLimits{MaxStackItems: 1})
+		v.SetTracer(tr)
+		require.NoError(t, v.Run())
+		require.False(t, tr.Truncated())
+		e := tr.Entries()[2] // ADD
+		require.True(t, e.StackTruncated)
+		require.Equal(t, []stackitem.Item{stackitem.NewBigInteger(big.NewInt(3))}, e.Stack)
+		require.False(t, tr.Entries()[3].StackTruncated)
+	})
+	bigProg := makeProgram(opcode.PUSH1, opcode.PUSHINT16, 0, 1, opcode.NEWBUFFER,
+		opcode.PUSH2, opcode.DROP, opcode.DROP, opcode.RET)
+	t.Run("entry size limit", func(t *testing.T) {
+		v := load(bigProg)
+		tr := NewTracer(TraceLimits{MaxEntrySize: 100})
+		v.SetTracer(tr)
+		require.NoError(t, v.Run())
+		require.False(t, tr.Truncated())
+		e := tr.Entries()[3] // PUSH2, 256-byte buffer is on top.
+		require.Equal(t, opcode.PUSH2, e.Opcode)
+		require.True(t, e.StackTruncated)
+		require.Equal(t, 0, len(e.Stack))
+		e = tr.Entries()[4] // DROP, buffer is not on top.
+		require.True(t, e.StackTruncated)
+		require.Equal(t, []stackitem.Item{stackitem.Make(2)}, e.Stack)
+	})
+	t.Run("total size limit", func(t *testing.T) {
+		v := load(bigProg)
+		tr := NewTracer(TraceLimits{MaxSize: 600})
+		v.SetTracer(tr)
+		require.NoError(t, v.Run())
+		require.True(t, tr.Truncated())
+		// Two snapshots with 256-byte buffer fit, the third one doesn't.
+		require.Equal(t, 5, len(tr.Entries()))
+		require.False(t, tr.Entries()[4].StackTruncated)
+	})
+}
@@ -11,10 +11,7 @@ import (
 	"math"
 	"math/big"
 	"os"
-	"text/tabwriter"
-	"unicode/utf8"
 
-	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
@@ -52,7 +49,7 @@ const (
 
 	// MaxStackSize is the maximum number of items allowed to be
 	// on all stacks at once.
-	MaxStackSize = 2 * 1024
+	MaxStackSize = stackitem.MaxDeserialized
 
 	maxSHLArg = stackitem.MaxBigIntegerSizeBits
 )
@@ -83,10 +80,18 @@ type VM struct {
 	// LoadToken handles CALLT opcode.
 	LoadToken func(id int32) error
 
+	// StrictBuffers enables MEMCPY destination type check.
+	StrictBuffers bool
+
 	trigger trigger.Type
 
 	// Invocations is a script invocation counter.
 	Invocations map[util.Uint160]int
+
+	// tracer records instructions executed if set.
+	tracer *Tracer
+	// gasStats accounts GAS consumed if set.
+	gasStats *GasStats
 }
 
 // New returns a new VM object ready to load AVM bytecode scripts.
@@ -159,79 +164,8 @@ func (v *VM) PrintOps(out io.Writer) {
 	if out == nil {
 		out = os.Stdout
 	}
-	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
-	fmt.Fprintln(w, "INDEX\tOPCODE\tPARAMETER\t")
-	realctx := v.Context()
-	ctx := realctx.Copy()
-	ctx.ip = 0
-	ctx.nextip = 0
-	for {
-		cursor := ""
-		instr, parameter, err := ctx.Next()
-		if ctx.ip == realctx.ip {
-			cursor = "<<"
-		}
-		if err != nil {
-			fmt.Fprintf(w, "%d\t%s\tERROR: %s\t%s\n", ctx.ip, instr, err, cursor)
-			break
-		}
-		var desc = ""
-		if parameter != nil {
-			switch instr {
-			case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.CALL,
-				opcode.JMPEQ, opcode.JMPNE,
-				opcode.JMPGT, opcode.JMPGE, opcode.JMPLE, opcode.JMPLT,
-				opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.CALLL,
-				opcode.JMPEQL, opcode.JMPNEL,
-				opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLEL, opcode.JMPLTL,
-				opcode.PUSHA, opcode.ENDTRY, opcode.ENDTRYL:
-				desc = getOffsetDesc(ctx, parameter)
-			case opcode.TRY, opcode.TRYL:
-				catchP, finallyP := getTryParams(instr, parameter)
-				desc = fmt.Sprintf("catch %s, finally %s",
-					getOffsetDesc(ctx, catchP), getOffsetDesc(ctx, finallyP))
-			case opcode.INITSSLOT:
-				desc = fmt.Sprint(parameter[0])
-			case opcode.CONVERT, opcode.ISTYPE:
-				typ := stackitem.Type(parameter[0])
-				desc = fmt.Sprintf("%s (%x)", typ, parameter[0])
-			case opcode.INITSLOT:
-				desc = fmt.Sprintf("%d local, %d arg", parameter[0], parameter[1])
-			case opcode.SYSCALL:
-				name, err := interopnames.FromID(GetInteropID(parameter))
-				if err != nil {
-					name = "not found"
-				}
-				desc = fmt.Sprintf("%s (%x)", name, parameter)
-			case opcode.PUSHINT8, opcode.PUSHINT16, opcode.PUSHINT32,
-				opcode.PUSHINT64, opcode.PUSHINT128, opcode.PUSHINT256:
-				val := bigint.FromBytes(parameter)
-				desc = fmt.Sprintf("%d (%x)", val, parameter)
-			case opcode.LDLOC, opcode.STLOC, opcode.LDARG, opcode.STARG, opcode.LDSFLD, opcode.STSFLD:
-				desc = fmt.Sprintf("%d (%x)", parameter[0], parameter)
-			default:
-				if utf8.Valid(parameter) {
-					desc = fmt.Sprintf("%x (%q)", parameter, parameter)
-				} else {
-					desc = fmt.Sprintf("%x", parameter)
-				}
-			}
-		}
-
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", ctx.ip, instr, desc, cursor)
-		if ctx.nextip >= len(ctx.prog) {
-			break
-		}
-	}
-	w.Flush()
-}
-
-func getOffsetDesc(ctx *Context, parameter []byte) string {
-	offset, rOffset, err := calcJumpOffset(ctx, parameter)
-	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
-	}
-	return fmt.Sprintf("%d (%d/%x)", offset, rOffset, parameter)
+	ctx := v.Context()
+	_ = Disassemble(out, ctx.prog, DisasmOptions{Cursor: ctx.ip})
 }
 
 // AddBreakPoint adds a breakpoint to the current context.
@@ -288,9 +222,26 @@ func (v *VM) LoadScriptWithFlags(b []byte, f callflag.CallFlag) {
 	ctx.callFlag = f
 	ctx.static = newSlot(v.refs)
 	ctx.callingScriptHash = v.GetCurrentScriptHash()
+	if cur := v.Context(); cur != nil {
+		ctx.syscalls = cur.syscalls
+	}
 	v.istack.PushVal(ctx)
 }
 
+// RestrictSyscalls allows only syscalls with the given IDs to be used by all
+// currently loaded contexts and contexts created from them (via contract
+// calls), any other syscall faults the VM. It allows to evaluate untrusted
+// scripts with a limited set of syscalls.
+func (v *VM) RestrictSyscalls(ids []uint32) {
+	allowed := make(map[uint32]bool, len(ids))
+	for _, id := range ids {
+		allowed[id] = true
+	}
+	v.istack.Iter(func(e *Element) {
+		e.Value().(*Context).syscalls = allowed
+	})
+}
+
 // LoadScriptWithHash if similar to the LoadScriptWithFlags method, but it also loads
 // given script hash directly into the Context to avoid its recalculations and to make
 // is possible to override it for deployed contracts with special hashes (the function
@@ -527,6 +478,16 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		}
 	}()
 
+	if v.tracer != nil {
+		v.tracer.record(v, ctx, op)
+	}
+	if v.gasStats != nil {
+		gasBefore := v.gasConsumed
+		defer func() {
+			v.gasStats.account(ctx, op, parameter, v.gasConsumed-gasBefore)
+		}()
+	}
+
 	if v.getPrice != nil && ctx.ip < len(ctx.prog) {
 		v.gasConsumed += v.getPrice(op, parameter)
 		if v.GasLimit >= 0 && v.gasConsumed > v.GasLimit {
@@ -672,7 +633,11 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		if di < 0 {
 			panic("invalid destination index")
 		}
-		dst := v.estack.Pop().value.(*stackitem.Buffer).Value().([]byte)
+		dstItem := v.estack.Pop().value
+		if _, ok := dstItem.(*stackitem.Buffer); !ok && v.StrictBuffers {
+			panic("destination is not a buffer")
+		}
+		dst := dstItem.(*stackitem.Buffer).Value().([]byte)
 		if sum := di + n; sum < 0 || sum > len(dst) {
 			panic("size is too big")
 		}
@@ -1112,7 +1077,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 			if index < 0 {
 				panic("invalid key")
 			}
-			v.estack.Push(&Element{value: t.Value().([]stackitem.MapElement)[index].Value.Dup()})
+			v.estack.Push(&Element{value: t.ElementAt(index).Value.Dup()})
 		default:
 			arr := obj.Bytes()
 			if index < 0 || index >= len(arr) {
@@ -1142,7 +1107,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 			v.refs.Add(arr[index])
 		case *stackitem.Map:
 			if i := t.Index(key.value); i >= 0 {
-				v.refs.Remove(t.Value().([]stackitem.MapElement)[i].Value)
+				v.refs.Remove(t.ElementAt(i).Value)
 			} else if t.Len() >= stackitem.MaxArraySize {
 				panic("too big map")
 			}
@@ -1207,7 +1172,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 			index := t.Index(key.Item())
 			// NEO 2.0 doesn't error on missing key.
 			if index >= 0 {
-				v.refs.Remove(t.Value().([]stackitem.MapElement)[index].Value)
+				v.refs.Remove(t.ElementAt(index).Value)
 				t.Drop(index)
 			}
 		default:
@@ -1304,6 +1269,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 
 	case opcode.SYSCALL:
 		interopID := GetInteropID(parameter)
+		if !ctx.isSyscallAllowed(interopID) {
+			panic(fmt.Sprintf("syscall %d is not allowed", interopID))
+		}
 		err := v.SyscallHandler(v, interopID)
 		if err != nil {
 			panic(fmt.Sprintf("failed to invoke syscall %d: %s", interopID, err))
@@ -1347,9 +1315,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 			panic("not a Map")
 		}
 
-		arr := make([]stackitem.Item, 0, m.Len())
-		for k := range m.Value().([]stackitem.MapElement) {
-			arr = append(arr, m.Value().([]stackitem.MapElement)[k].Key.Dup())
+		arr := m.Keys()
+		for k := range arr {
+			arr[k] = arr[k].Dup()
 		}
 		v.estack.PushVal(arr)
 
@@ -1368,9 +1336,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 				arr[i] = cloneIfStruct(src[i])
 			}
 		case *stackitem.Map:
-			arr = make([]stackitem.Item, 0, t.Len())
-			for k := range t.Value().([]stackitem.MapElement) {
-				arr = append(arr, cloneIfStruct(t.Value().([]stackitem.MapElement)[k].Value))
+			arr = t.Values()
+			for k := range arr {
+				arr[k] = cloneIfStruct(arr[k])
 			}
 		default:
 			panic("not a Map, Array or Struct")
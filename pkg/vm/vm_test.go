@@ -493,6 +493,41 @@ func TestCallFlags(t *testing.T) {
 	t.Run("AllFlagsProvided", getTestCallFlagsFunc(readOnly, callflag.ReadOnly, new(int)))
 }
 
+func TestRestrictSyscalls(t *testing.T) {
+	syscall := []byte{0x77, 0x77, 0x77, 0x77}
+	id := binary.LittleEndian.Uint32(syscall)
+	script := append([]byte{byte(opcode.SYSCALL)}, syscall...)
+	newVM := func(allowed ...uint32) *VM {
+		v := newTestVM()
+		v.SyscallHandler = testSyscallHandler
+		v.LoadScriptWithFlags(script, callflag.All)
+		v.RestrictSyscalls(allowed)
+		return v
+	}
+	t.Run("allowed", func(t *testing.T) {
+		v := newVM(id+1, id)
+		runVM(t, v)
+		require.Equal(t, new(int), v.PopResult())
+	})
+	t.Run("not allowed", func(t *testing.T) {
+		checkVMFailed(t, newVM(id+1))
+	})
+	t.Run("empty list", func(t *testing.T) {
+		checkVMFailed(t, newVM())
+	})
+	t.Run("inherited by new context", func(t *testing.T) {
+		v := newVM(id + 1)
+		v.LoadScriptWithFlags(script, callflag.All)
+		checkVMFailed(t, v)
+	})
+	t.Run("not restricted", func(t *testing.T) {
+		v := newTestVM()
+		v.SyscallHandler = testSyscallHandler
+		v.LoadScriptWithFlags(script, callflag.All)
+		runVM(t, v)
+	})
+}
+
 func callNTimes(n uint16) []byte {
 	return makeProgram(
 		opcode.PUSHINT16, opcode.Opcode(n), opcode.Opcode(n>>8), // little-endian
@@ -1042,6 +1077,17 @@ func TestMEMCPY(t *testing.T) {
 	t.Run("NegativeDstIndex", getTestFuncForVM(prog, nil, stackitem.NewBuffer([]byte{0, 1}), -1, []byte{2}, 0, 1))
 	t.Run("BigSizeSrc", getTestFuncForVM(prog, nil, stackitem.NewBuffer([]byte{0, 1}), 0, []byte{2}, 0, 2))
 	t.Run("BigSizeDst", getTestFuncForVM(prog, nil, stackitem.NewBuffer([]byte{0, 1}), 0, []byte{2, 3, 4}, 0, 3))
+	t.Run("NonBufferDst", getTestFuncForVM(prog, nil, []byte{0, 1}, 0, []byte{2}, 0, 1))
+	t.Run("NonBufferDstStrict", func(t *testing.T) {
+		v := load(prog)
+		v.StrictBuffers = true
+		for _, item := range []interface{}{[]byte{0, 1}, 0, []byte{2}, 0, 1} {
+			v.estack.PushVal(item)
+		}
+		err := v.Run()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "destination is not a buffer")
+	})
 }
 
 func TestNEWARRAY0(t *testing.T) {
@@ -12,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 )
 
@@ -27,6 +28,9 @@ type Account struct {
 	// Account import file.
 	wif string
 
+	// Remote signer used instead of the private key if set.
+	remoteSigner *RemoteSigner
+
 	// NEO public address.
 	Address string `json:"address"`
 
@@ -88,10 +92,19 @@ func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
 		t.Scripts = append(t.Scripts, transaction.Witness{})
 		return nil
 	}
-	if a.privateKey == nil {
+	var sign []byte
+	switch {
+	case a.privateKey != nil:
+		sign = a.privateKey.SignHashable(uint32(net), t)
+	case a.remoteSigner != nil:
+		var err error
+		sign, err = a.remoteSigner.SignHashable(uint32(net), t)
+		if err != nil {
+			return err
+		}
+	default:
 		return errors.New("account is not unlocked")
 	}
-	sign := a.privateKey.SignHashable(uint32(net), t)
 
 	verif := a.GetVerificationScript()
 	invoc := append([]byte{byte(opcode.PUSHDATA1), 64}, sign...)
@@ -152,6 +165,50 @@ func (a *Account) PrivateKey() *keys.PrivateKey {
 	return a.privateKey
 }
 
+// Wipe wipes the private key of the Account from memory. The Account can't
+// be used for signing after this call unless it's decrypted again.
+func (a *Account) Wipe() {
+	if a.privateKey == nil {
+		return
+	}
+	a.privateKey.Destroy()
+	a.privateKey = nil
+	a.wif = ""
+}
+
+// SetRemoteSigner makes the Account use the given remote signer when there is
+// no private key available. Signer's public key must be the one used by the
+// Account's contract.
+func (a *Account) SetRemoteSigner(s *RemoteSigner) error {
+	if a.Contract == nil {
+		return errors.New("account has no contract")
+	}
+	pub := s.PublicKey().Bytes()
+	if !bytes.Equal(a.Contract.Script, s.PublicKey().GetVerificationScript()) {
+		_, pubs, ok := vm.ParseMultiSigContract(a.Contract.Script)
+		if !ok {
+			return errors.New("signer key doesn't match account contract")
+		}
+		var found bool
+		for i := range pubs {
+			if bytes.Equal(pubs[i], pub) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("signer key doesn't match account contract")
+		}
+	}
+	a.remoteSigner = s
+	return nil
+}
+
+// RemoteSigner returns remote signer of the Account (nil if not set).
+func (a *Account) RemoteSigner() *RemoteSigner {
+	return a.remoteSigner
+}
+
 // NewAccountFromWIF creates a new Account from the given WIF.
 func NewAccountFromWIF(wif string) (*Account, error) {
 	privKey, err := keys.NewPrivateKeyFromWIF(wif)
@@ -37,6 +37,22 @@ func TestDecryptAccount(t *testing.T) {
 	require.Error(t, acc.Decrypt("qwerty"))
 }
 
+func TestAccountWipe(t *testing.T) {
+	tc := keytestcases.Arr[0]
+	acc := &Account{EncryptedWIF: tc.EncryptedWif}
+	acc.Wipe() // No-op for locked account.
+
+	require.NoError(t, acc.Decrypt(tc.Passphrase))
+	priv := acc.PrivateKey()
+	acc.Wipe()
+	require.Nil(t, acc.PrivateKey())
+	require.Equal(t, 0, priv.D.Sign())
+
+	// Can be decrypted again.
+	require.NoError(t, acc.Decrypt(tc.Passphrase))
+	require.Equal(t, tc.PrivateKey, acc.PrivateKey().String())
+}
+
 func TestNewFromWif(t *testing.T) {
 	for _, testCase := range keytestcases.Arr {
 		acc, err := NewAccountFromWIF(testCase.Wif)
@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// defaultRemoteSignerTimeout is the default timeout for remote signing
+// requests.
+const defaultRemoteSignerTimeout = 5 * time.Second
+
+// RemoteSigner delegates signing to an external service (like a Vault-style
+// key storage), so that the private key doesn't have to be stored locally.
+// The service is expected to accept HTTP POST requests with
+//
+//   {"publicKey": "<hex-encoded compressed public key>", "hash": "<hex-encoded 32-byte hash>"}
+//
+// JSON body and to reply with
+//
+//   {"signature": "<hex-encoded 64-byte signature>"}
+//
+// containing the signature of the hash made with the private key corresponding
+// to the public key. Signatures are checked against the public key before
+// being returned.
+type RemoteSigner struct {
+	url    string
+	pub    *keys.PublicKey
+	client *http.Client
+}
+
+type remoteSignRequest struct {
+	PublicKey string `json:"publicKey"`
+	Hash      string `json:"hash"`
+}
+
+type remoteSignResponse struct {
+	Signature string `json:"signature"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewRemoteSigner creates a signer for the given public key using the service
+// at the specified URL. Default timeout is used if zero timeout is given.
+func NewRemoteSigner(url string, pub *keys.PublicKey, timeout time.Duration) *RemoteSigner {
+	if timeout == 0 {
+		timeout = defaultRemoteSignerTimeout
+	}
+	return &RemoteSigner{
+		url:    url,
+		pub:    pub,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// PublicKey returns the public key of the signer.
+func (s *RemoteSigner) PublicKey() *keys.PublicKey {
+	return s.pub
+}
+
+// Sign signs arbitrary length data using SHA256 to calculate its hash (the
+// same way keys.PrivateKey.Sign does).
+func (s *RemoteSigner) Sign(data []byte) ([]byte, error) {
+	return s.SignHash(sha256.Sum256(data))
+}
+
+// SignHashable signs some Hashable item for the network specified using
+// hash.NetSha256().
+func (s *RemoteSigner) SignHashable(net uint32, hh hash.Hashable) ([]byte, error) {
+	return s.SignHash(hash.NetSha256(net, hh))
+}
+
+// SignHash requests a signature of the given hash from the remote service.
+func (s *RemoteSigner) SignHash(digest util.Uint256) ([]byte, error) {
+	req, err := json.Marshal(remoteSignRequest{
+		PublicKey: hex.EncodeToString(s.pub.Bytes()),
+		Hash:      hex.EncodeToString(digest[:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(req))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var res remoteSignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("invalid remote signer response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if res.Error != "" {
+			return nil, fmt.Errorf("remote signer error (HTTP %d): %s", resp.StatusCode, res.Error)
+		}
+		return nil, fmt.Errorf("remote signer error: HTTP %d", resp.StatusCode)
+	}
+	sig, err := hex.DecodeString(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signature: %w", err)
+	}
+	if !s.pub.Verify(sig, digest[:]) {
+		return nil, errors.New("invalid remote signature")
+	}
+	return sig, nil
+}
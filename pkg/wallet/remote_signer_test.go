@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSignerServer(t *testing.T, priv *keys.PrivateKey) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req remoteSignRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.PublicKey != hex.EncodeToString(priv.PublicKey().Bytes()) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(remoteSignResponse{Error: "unknown key"})
+			return
+		}
+		h, err := hex.DecodeString(req.Hash)
+		require.NoError(t, err)
+		var digest util.Uint256
+		copy(digest[:], h)
+		_ = json.NewEncoder(w).Encode(remoteSignResponse{
+			Signature: hex.EncodeToString(priv.SignHash(digest)),
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRemoteSigner(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	srv := newTestSignerServer(t, priv)
+
+	data := []byte{1, 2, 3}
+	s := NewRemoteSigner(srv.URL, priv.PublicKey(), 0)
+	require.Equal(t, priv.PublicKey(), s.PublicKey())
+	sig, err := s.Sign(data)
+	require.NoError(t, err)
+	require.True(t, priv.PublicKey().Verify(sig, hash.Sha256(data).BytesBE()))
+
+	t.Run("unknown key", func(t *testing.T) {
+		other, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = NewRemoteSigner(srv.URL, other.PublicKey(), 0).Sign(data)
+		require.Error(t, err)
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(remoteSignResponse{
+				Signature: hex.EncodeToString(make([]byte, 64)),
+			})
+		}))
+		defer srv.Close()
+		_, err = NewRemoteSigner(srv.URL, priv.PublicKey(), 0).Sign(data)
+		require.Error(t, err)
+	})
+	t.Run("bad URL", func(t *testing.T) {
+		_, err := NewRemoteSigner("http://127.0.0.1:0", priv.PublicKey(), 0).Sign(data)
+		require.Error(t, err)
+	})
+}
+
+func TestAccount_RemoteSigner(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	srv := newTestSignerServer(t, priv)
+	s := NewRemoteSigner(srv.URL, priv.PublicKey(), 0)
+
+	// Account doesn't have the key locally.
+	accKey, err := keys.NewPrivateKeyFromBytes(priv.Bytes())
+	require.NoError(t, err)
+	acc := NewAccountFromPrivateKey(accKey)
+	acc.Wipe()
+	require.Nil(t, acc.RemoteSigner())
+
+	t.Run("wrong key", func(t *testing.T) {
+		other, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		require.Error(t, acc.SetRemoteSigner(NewRemoteSigner(srv.URL, other.PublicKey(), 0)))
+	})
+	t.Run("multisig", func(t *testing.T) {
+		other, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		multi := NewAccountFromPrivateKey(other)
+		require.NoError(t, multi.ConvertMultisig(1, keys.PublicKeys{other.PublicKey(), priv.PublicKey()}))
+		require.NoError(t, multi.SetRemoteSigner(s))
+	})
+
+	tx := transaction.New([]byte{1, 2, 3}, 0)
+	require.Error(t, acc.SignTx(netmode.UnitTestNet, tx))
+
+	require.NoError(t, acc.SetRemoteSigner(s))
+	require.Equal(t, s, acc.RemoteSigner())
+	require.NoError(t, acc.SignTx(netmode.UnitTestNet, tx))
+	require.Equal(t, 1, len(tx.Scripts))
+	require.True(t, priv.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], uint32(netmode.UnitTestNet), tx))
+}
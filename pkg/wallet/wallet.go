@@ -173,6 +173,14 @@ func (w *Wallet) JSON() ([]byte, error) {
 	return json.MarshalIndent(w, " ", "	")
 }
 
+// Wipe wipes private keys of all wallet accounts from memory (see
+// Account.Wipe).
+func (w *Wallet) Wipe() {
+	for _, acc := range w.Accounts {
+		acc.Wipe()
+	}
+}
+
 // Close closes the internal rw if its an io.ReadCloser.
 func (w *Wallet) Close() {
 	if rc, ok := w.rw.(io.ReadCloser); ok {
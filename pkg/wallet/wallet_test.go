@@ -183,6 +183,20 @@ func TestWallet_GetAccount(t *testing.T) {
 	}
 }
 
+func TestWalletWipe(t *testing.T) {
+	wallet := checkWalletConstructor(t)
+	require.NoError(t, wallet.CreateAccount("testName", "testPass"))
+	acc := wallet.Accounts[0]
+	require.NotNil(t, acc.PrivateKey())
+
+	wallet.Close()
+	require.NotNil(t, acc.PrivateKey())
+
+	wallet.Wipe()
+	require.Nil(t, acc.PrivateKey())
+	require.NoError(t, acc.Decrypt("testPass"))
+}
+
 func TestWalletGetChangeAddress(t *testing.T) {
 	w1, err := NewWalletFromFile("testdata/wallet1.json")
 	require.NoError(t, err)